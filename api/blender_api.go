@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"slices"
 	"strings"
 
 	version "github.com/hashicorp/go-version" // Import version library
@@ -34,10 +35,17 @@ func NewAPI() *API {
 // FetchBuilds fetches the list of Blender builds from the official API,
 // filtering for the current OS/architecture, file extensions, and minimum version.
 func (a *API) FetchBuilds(versionFilter string, buildType string) ([]model.BlenderBuild, error) {
+	builds, _, err := a.FetchBuildsConditional(versionFilter, buildType)
+	return builds, err
+}
+
+// FetchBuildsConditional is FetchBuilds with a conditional request: notModified is true when the
+// builder listing didn't change since the last fetch, the builds are then read from the cached listing.
+func (a *API) FetchBuildsConditional(versionFilter string, buildType string) (builds []model.BlenderBuild, notModified bool, err error) {
 	// Get config
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %w", err)
+		return nil, false, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Determine which API URL to use based on buildType
@@ -54,77 +62,51 @@ func (a *API) FetchBuilds(versionFilter string, buildType string) ([]model.Blend
 		apiURL = dailyBlenderAPIURL
 	}
 
-	// Add UUID to request headers
-	req, err := http.NewRequest("GET", apiURL, nil)
+	// The client identifier is only sent when the user keeps it on
+	uuid := ""
+	if cfg.SendDownloadID {
+		uuid = cfg.UUID
+	}
+	listing, notModified, err := a.fetchListing(apiURL, buildType, uuid)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, false, err
 	}
-	req.Header.Set("X-Client-UUID", cfg.UUID)
 
-	resp, err := a.client.Do(req)
+	builds, err = listingBuilds(listing, versionFilter, buildType, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch data: %w", err)
+		return nil, false, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch data: status code %d", resp.StatusCode)
+	if !notModified {
+		// Without the cached listing the next fetch is just not conditional
+		_ = saveListing(buildType, listing)
 	}
+	return builds, notModified, nil
+}
 
+// listingBuilds decodes a builder listing and keeps the builds FetchBuilds lists, see filterBuilds
+func listingBuilds(listing cachedListing, versionFilter string, buildType string, cfg config.Config) ([]model.BlenderBuild, error) {
 	var allBuildEntries []model.BlenderBuild
-	if err := json.NewDecoder(resp.Body).Decode(&allBuildEntries); err != nil {
+	if err := json.Unmarshal(listing.Body, &allBuildEntries); err != nil {
 		return nil, fmt.Errorf("failed to decode JSON (check API response structure): %w", err)
 	}
+	return filterBuilds(allBuildEntries, versionFilter, buildType, cfg.Rosetta, cfg.ArchivePreference)
+}
+
+// filterBuilds keeps the builds of a listing for the current OS/architecture, file extensions, and minimum version.
+// Of a build published in several archive formats, the one matching archivePreference is listed.
+func filterBuilds(allBuildEntries []model.BlenderBuild, versionFilter string, buildType string, rosetta bool, archivePreference string) ([]model.BlenderBuild, error) {
+	var err error
 
 	// --- Filtering Setup ---
 	currentOS := runtime.GOOS
-	currentArch := runtime.GOARCH
-	// Initialize apiArch explicitly within the switch block below
-	var apiArch string
-
-	// Map architecture names from Go runtime format (GOARCH) to Blender API format.
-	// GOOS values (linux, windows, darwin) match the API 'platform' field directly.
-	switch currentOS {
-	case "linux":
-		switch currentArch {
-		case "amd64":
-			apiArch = "x86_64" // Map Go's amd64 to API's x86_64
-		case "arm64":
-			// Assuming API uses "arm64" for Linux ARM (like other OS).
-			// Verified data did not contain Linux ARM builds from this endpoint.
-			// Adjust if other endpoints use "aarch64" or similar for Linux ARM.
-			apiArch = "arm64"
-		default:
-			// For unknown/unsupported arch, use Go's name; will likely be filtered out later.
-			apiArch = currentArch
-		}
-	case "darwin": // macOS
-		switch currentArch {
-		case "amd64":
-			apiArch = "x86_64" // Map Go's amd64 to API's x86_64
-		case "arm64":
-			apiArch = "arm64" // Go's arm64 matches API's arm64
-		default:
-			apiArch = currentArch
-		}
-	case "windows":
-		switch currentArch {
-		case "amd64":
-			apiArch = "amd64" // Go's amd64 matches API's amd64
-		case "arm64":
-			apiArch = "arm64" // Go's arm64 matches API's arm64
-		default:
-			apiArch = currentArch
-		}
-	default:
-		// For unknown OS, use Go's arch name; OS filter check later will handle it.
-		apiArch = currentArch
-	}
+	apiArchs := AcceptedArchs(currentOS, runtime.GOARCH, rosetta)
 
 	allowedExtensions := map[string]bool{
 		"zip": true, "tar.gz": true, "tar.xz": true, "tar.bz2": true,
 		"xz": true, "dmg": true, "pkg": true,
 	}
+	// Windows installer packages, used when a build has no archive
+	installerExtensions := map[string]bool{"msi": true, "msix": true}
 
 	// Parse the version filter if provided
 	var minVersion *version.Version
@@ -139,18 +121,21 @@ func (a *API) FetchBuilds(versionFilter string, buildType string) ([]model.Blend
 
 	// --- Filtering Loop ---
 	var platformFilteredBuilds []model.BlenderBuild
+	var companions, installers []model.BlenderBuild
 	for _, build := range allBuildEntries {
 		// Check OS
 		if build.OperatingSystem != currentOS {
 			continue
 		}
-		// Check Arch: Use the explicitly mapped apiArch
-		if build.Architecture != apiArch {
+		// Check Arch: Use the explicitly mapped apiArchs
+		if !slices.Contains(apiArchs, build.Architecture) {
 			continue
 		}
-		// Check Extension
+		// Check Extension; other files are companions of a build
 		ext := strings.ToLower(build.FileExtension)
-		if _, ok := allowedExtensions[ext]; !ok {
+		isInstaller := installerExtensions[ext]
+		if _, ok := allowedExtensions[ext]; !ok && !isInstaller {
+			companions = append(companions, build)
 			continue
 		}
 
@@ -168,8 +153,161 @@ func (a *API) FetchBuilds(versionFilter string, buildType string) ([]model.Blend
 
 		// Passed all filters
 		build.Status = model.StateOnline
+		build.Source = buildSource(buildType, build.ReleaseCycle)
+		if isInstaller {
+			installers = append(installers, build)
+			continue
+		}
 		platformFilteredBuilds = append(platformFilteredBuilds, build)
 	}
 
+	platformFilteredBuilds, companions = pickArchives(platformFilteredBuilds, companions, archivePreference)
+	platformFilteredBuilds, companions = addInstallerBuilds(platformFilteredBuilds, installers, companions)
+	attachArtifacts(platformFilteredBuilds, companions)
 	return platformFilteredBuilds, nil
 }
+
+// extractionRank orders archive formats from the quickest to extract to the slowest
+var extractionRank = map[string]int{"zip": 0, "dmg": 1, "pkg": 1, "tar.gz": 2, "tar.xz": 3, "xz": 3, "tar.bz2": 4}
+
+// pickArchives keeps one archive of each build published in several formats: the smallest one, or with
+// fastest_install the one quickest to extract. The other archives are kept as companions.
+func pickArchives(builds, companions []model.BlenderBuild, preference string) ([]model.BlenderBuild, []model.BlenderBuild) {
+	better := func(a, b model.BlenderBuild) bool {
+		aRank, bRank := extractionRank[strings.ToLower(a.FileExtension)], extractionRank[strings.ToLower(b.FileExtension)]
+		if preference == config.ArchiveFastestInstall && aRank != bRank {
+			return aRank < bRank
+		}
+		if a.Size != b.Size {
+			return a.Size < b.Size
+		}
+		return aRank < bRank
+	}
+
+	picked := make(map[string]int) // Build key -> index in kept
+	var kept []model.BlenderBuild
+	for _, b := range builds {
+		key := b.Version + "|" + b.Branch + "|" + b.Hash + "|" + b.Architecture
+		i, seen := picked[key]
+		switch {
+		case !seen:
+			picked[key] = len(kept)
+			kept = append(kept, b)
+		case better(b, kept[i]):
+			companions = append(companions, kept[i])
+			kept[i] = b
+		default:
+			companions = append(companions, b)
+		}
+	}
+	return kept, companions
+}
+
+// addInstallerBuilds lists Windows installer packages as builds when no archive of the same build exists;
+// installers of builds that also have an archive are kept as companions.
+func addInstallerBuilds(builds, installers, companions []model.BlenderBuild) ([]model.BlenderBuild, []model.BlenderBuild) {
+	hasArchive := make(map[string]bool)
+	for _, b := range builds {
+		hasArchive[b.Version+"|"+b.Branch+"|"+b.Hash+"|"+b.Architecture] = true
+	}
+	listed := make(map[string]bool)
+	for _, inst := range installers {
+		key := inst.Version + "|" + inst.Branch + "|" + inst.Hash + "|" + inst.Architecture
+		// One installer per build is enough; the first listed wins
+		if hasArchive[key] || listed[key] {
+			companions = append(companions, inst)
+			continue
+		}
+		listed[key] = true
+		builds = append(builds, inst)
+	}
+	return builds, companions
+}
+
+// attachArtifacts lists the companion files of each build (same version, branch, hash and architecture) as its artifacts
+func attachArtifacts(builds []model.BlenderBuild, companions []model.BlenderBuild) {
+	for i := range builds {
+		for _, c := range companions {
+			if c.Version != builds[i].Version || c.Branch != builds[i].Branch || c.Hash != builds[i].Hash || c.Architecture != builds[i].Architecture {
+				continue
+			}
+			builds[i].Artifacts = append(builds[i].Artifacts, model.Artifact{
+				FileName:      c.FileName,
+				FileExtension: c.FileExtension,
+				URL:           c.DownloadURL,
+				Size:          c.Size,
+			})
+		}
+	}
+}
+
+// PlatformArch maps a Go architecture name (GOARCH) to the name used by the Blender API
+// for the given OS. GOOS values (linux, windows, darwin) match the API 'platform' field directly.
+func PlatformArch(goos, goarch string) string {
+	switch goos {
+	case "linux":
+		switch goarch {
+		case "amd64":
+			return "x86_64" // Map Go's amd64 to API's x86_64
+		case "arm64":
+			// Assuming API uses "arm64" for Linux ARM (like other OS).
+			// Verified data did not contain Linux ARM builds from this endpoint.
+			// Adjust if other endpoints use "aarch64" or similar for Linux ARM.
+			return "arm64"
+		default:
+			// For unknown/unsupported arch, use Go's name; will likely be filtered out later.
+			return goarch
+		}
+	case "darwin": // macOS
+		switch goarch {
+		case "amd64":
+			return "x86_64" // Map Go's amd64 to API's x86_64
+		case "arm64":
+			return "arm64" // Go's arm64 matches API's arm64
+		default:
+			return goarch
+		}
+	case "windows":
+		switch goarch {
+		case "amd64":
+			return "amd64" // Go's amd64 matches API's amd64
+		case "arm64":
+			return "arm64" // Go's arm64 matches API's arm64
+		default:
+			return goarch
+		}
+	default:
+		// For unknown OS, use Go's arch name; OS filter check later will handle it.
+		return goarch
+	}
+}
+
+// NativeArch returns the API architecture name of the running system
+func NativeArch() string {
+	return PlatformArch(runtime.GOOS, runtime.GOARCH)
+}
+
+// AcceptedArchs returns the API architectures listed for the given OS, the native one first.
+// With rosetta set, Apple Silicon Macs also list the Intel builds, which run under Rosetta 2.
+func AcceptedArchs(goos, goarch string, rosetta bool) []string {
+	archs := []string{PlatformArch(goos, goarch)}
+	if rosetta && goos == "darwin" && goarch == "arm64" {
+		archs = append(archs, "x86_64")
+	}
+	return archs
+}
+
+// buildSource returns the provenance recorded for a build fetched from the given build type
+func buildSource(buildType, releaseCycle string) string {
+	switch buildType {
+	case "patch":
+		return model.SourcePatch
+	case "experimental":
+		return model.SourceExperimental
+	}
+	// Daily listings also carry the current release builds
+	if releaseCycle == "stable" {
+		return model.SourceStable
+	}
+	return model.SourceDaily
+}
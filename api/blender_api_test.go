@@ -1,11 +1,16 @@
 package api
 
 import (
+	"TUI-Blender-Launcher/config"
 	"TUI-Blender-Launcher/model"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestFetchBuilds(t *testing.T) {
@@ -258,6 +263,215 @@ func TestFetchBuildsInvalidJSON(t *testing.T) {
 	}
 }
 
+func TestFetchBuildsNotModified(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+
+	// Setup a mock HTTP server answering 304 when the client sends the ETag back
+	var conditional, identified []bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match") != "")
+		identified = append(identified, r.Header.Get("X-Client-UUID") != "")
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprintf(w, `[{
+			"version": "4.2.0",
+			"branch": "main",
+			"hash": "abc123",
+			"file_mtime": 1633046400,
+			"url": "https://example.com/blender-4.2.0.zip",
+			"platform": %q,
+			"architecture": %q,
+			"file_size": 123456789,
+			"file_name": "blender-4.2.0.zip",
+			"file_extension": "zip",
+			"release_cycle": "daily"
+		}]`, runtime.GOOS, PlatformArch(runtime.GOOS, runtime.GOARCH))
+	}))
+	defer server.Close()
+
+	a := &API{client: &http.Client{Transport: &mockTransport{apiURL: dailyBlenderAPIURL, server: server}}}
+
+	first, notModified, err := a.FetchBuildsConditional("", "daily")
+	if err != nil || notModified {
+		t.Fatalf("First fetch: got notModified %v, err %v", notModified, err)
+	}
+	// Turning the identifier off stops sending it with the listing too
+	cfg := config.DefaultConfig()
+	cfg.SendDownloadID = false
+	if err := config.SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	second, notModified, err := a.FetchBuildsConditional("", "daily")
+	if err != nil || !notModified {
+		t.Fatalf("Second fetch: expected notModified, got %v, err %v", notModified, err)
+	}
+
+	if !slices.Equal(conditional, []bool{false, true}) {
+		t.Errorf("Expected only the second request to be conditional, got %v", conditional)
+	}
+	if !slices.Equal(identified, []bool{true, false}) {
+		t.Errorf("Expected the client identifier only while it is on, got %v", identified)
+	}
+	if len(first) != 1 || len(second) != len(first) || second[0].Hash != first[0].Hash {
+		t.Errorf("Expected the cached listing on 304, got %v then %v", first, second)
+	}
+}
+
+func TestLoadCachedBuilds(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+
+	if _, _, err := LoadCachedBuilds("", "daily"); err == nil {
+		t.Fatal("Expected an error before anything was fetched")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{
+			"version": "4.2.0",
+			"branch": "main",
+			"hash": "abc123",
+			"file_mtime": 1633046400,
+			"url": "https://example.com/blender-4.2.0.zip",
+			"platform": %q,
+			"architecture": %q,
+			"file_size": 123456789,
+			"file_name": "blender-4.2.0.zip",
+			"file_extension": "zip",
+			"release_cycle": "daily"
+		}]`, runtime.GOOS, PlatformArch(runtime.GOOS, runtime.GOARCH))
+	}))
+	a := &API{client: &http.Client{Transport: &mockTransport{apiURL: dailyBlenderAPIURL, server: server}}}
+	fetched, err := a.FetchBuilds("", "daily")
+	if err != nil {
+		t.Fatalf("FetchBuilds failed: %v", err)
+	}
+	server.Close()
+
+	// Offline, the builds of the fetched listing are listed as they were fetched
+	cached, fetchedAt, err := LoadCachedBuilds("", "daily")
+	if err != nil {
+		t.Fatalf("LoadCachedBuilds failed: %v", err)
+	}
+	if len(cached) != 1 || cached[0].Hash != fetched[0].Hash || cached[0].Status != model.StateOnline {
+		t.Errorf("Expected the fetched build, got %+v", cached)
+	}
+	if time.Since(fetchedAt) > time.Minute {
+		t.Errorf("Expected the time of the fetch, got %v", fetchedAt)
+	}
+
+	// The version filter applies to the cached listing like to a fetch
+	if filtered, _, err := LoadCachedBuilds("4.3", "daily"); err != nil || len(filtered) != 0 {
+		t.Errorf("Expected the filter to hide 4.2.0, got %+v (%v)", filtered, err)
+	}
+	if _, _, err := LoadCachedBuilds("", "patch"); err == nil {
+		t.Error("Expected an error for a build type that was never fetched")
+	}
+}
+
+func TestAttachArtifacts(t *testing.T) {
+	builds := []model.BlenderBuild{
+		{Version: "4.2.0", Branch: "main", Hash: "abc123", FileName: "blender-4.2.0.zip"},
+		{Version: "4.3.0", Branch: "main", Hash: "def456", FileName: "blender-4.3.0.zip"},
+	}
+	companions := []model.BlenderBuild{
+		{Version: "4.2.0", Branch: "main", Hash: "abc123", FileName: "blender-4.2.0.zip.sha256", FileExtension: "sha256", DownloadURL: "https://example.com/blender-4.2.0.zip.sha256"},
+		{Version: "4.2.0", Branch: "main", Hash: "abc123", FileName: "blender-4.2.0.msix", FileExtension: "msix", Size: 42},
+		{Version: "4.2.0", Branch: "other", Hash: "abc123", FileName: "blender-4.2.0-other.msi", FileExtension: "msi"},
+		{Version: "4.2.0", Branch: "main", Hash: "abc123", Architecture: "x86_64", FileName: "blender-4.2.0-x64.zip.sha256", FileExtension: "sha256"},
+	}
+
+	attachArtifacts(builds, companions)
+
+	if len(builds[0].Artifacts) != 2 {
+		t.Fatalf("Expected 2 artifacts for 4.2.0, got %d", len(builds[0].Artifacts))
+	}
+	if a := builds[0].Artifacts[0]; a.FileName != "blender-4.2.0.zip.sha256" || a.URL != "https://example.com/blender-4.2.0.zip.sha256" {
+		t.Errorf("Unexpected artifact %+v", a)
+	}
+	if builds[0].Artifacts[1].Size != 42 {
+		t.Errorf("Expected artifact size 42, got %d", builds[0].Artifacts[1].Size)
+	}
+	if len(builds[1].Artifacts) != 0 {
+		t.Errorf("Expected no artifacts for 4.3.0, got %d", len(builds[1].Artifacts))
+	}
+}
+
+func TestPickArchives(t *testing.T) {
+	builds := []model.BlenderBuild{
+		{Version: "4.2.0", Hash: "abc123", FileName: "blender-4.2.0.tar.xz", FileExtension: "tar.xz", Size: 300},
+		{Version: "4.2.0", Hash: "abc123", FileName: "blender-4.2.0.zip", FileExtension: "zip", Size: 400},
+		{Version: "4.3.0", Hash: "def456", FileName: "blender-4.3.0.tar.xz", FileExtension: "tar.xz", Size: 300},
+	}
+
+	testCases := []struct {
+		preference string
+		expected   string
+	}{
+		{config.ArchiveSmallestDownload, "blender-4.2.0.tar.xz"},
+		{"", "blender-4.2.0.tar.xz"},
+		{config.ArchiveFastestInstall, "blender-4.2.0.zip"},
+	}
+	for _, tc := range testCases {
+		kept, companions := pickArchives(builds, nil, tc.preference)
+		if len(kept) != 2 || kept[0].FileName != tc.expected || kept[1].FileName != "blender-4.3.0.tar.xz" {
+			t.Errorf("%q: expected %s and the 4.3.0 archive, got %+v", tc.preference, tc.expected, kept)
+		}
+		// The other archive stays available as an additional file
+		if len(companions) != 1 || companions[0].FileName == tc.expected {
+			t.Errorf("%q: expected the other 4.2.0 archive as companion, got %+v", tc.preference, companions)
+		}
+	}
+}
+
+func TestAddInstallerBuilds(t *testing.T) {
+	builds := []model.BlenderBuild{
+		{Version: "4.2.0", Branch: "main", Hash: "abc123", FileName: "blender-4.2.0.zip"},
+	}
+	installers := []model.BlenderBuild{
+		{Version: "4.2.0", Branch: "main", Hash: "abc123", FileName: "blender-4.2.0.msi"},
+		{Version: "4.3.0", Branch: "main", Hash: "def456", FileName: "blender-4.3.0.msix"},
+		{Version: "4.3.0", Branch: "main", Hash: "def456", FileName: "blender-4.3.0.msi"},
+	}
+
+	builds, companions := addInstallerBuilds(builds, installers, nil)
+
+	// The 4.3.0 build only has installers, so the first one is listed
+	if len(builds) != 2 || builds[1].FileName != "blender-4.3.0.msix" {
+		t.Fatalf("Expected the 4.3.0 msix to be listed as a build, got %+v", builds)
+	}
+	if len(companions) != 2 || companions[0].FileName != "blender-4.2.0.msi" || companions[1].FileName != "blender-4.3.0.msi" {
+		t.Errorf("Expected the other installers as companions, got %+v", companions)
+	}
+}
+
+func TestAcceptedArchs(t *testing.T) {
+	testCases := []struct {
+		goos, goarch string
+		rosetta      bool
+		expected     []string
+	}{
+		{"darwin", "arm64", false, []string{"arm64"}},
+		{"darwin", "arm64", true, []string{"arm64", "x86_64"}},
+		{"darwin", "amd64", true, []string{"x86_64"}},
+		{"linux", "amd64", true, []string{"x86_64"}},
+	}
+
+	for _, tc := range testCases {
+		got := AcceptedArchs(tc.goos, tc.goarch, tc.rosetta)
+		if !slices.Equal(got, tc.expected) {
+			t.Errorf("AcceptedArchs(%s, %s, %v) = %v, expected %v", tc.goos, tc.goarch, tc.rosetta, got, tc.expected)
+		}
+	}
+}
+
 // mockTransport is a custom http.RoundTripper that redirects requests
 // from the real API URL to our test server
 type mockTransport struct {
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// blenderCompareAPIURL is the Gitea compare endpoint of the Blender repository on projects.blender.org
+const blenderCompareAPIURL = "https://projects.blender.org/api/v1/repos/blender/blender/compare/%s...%s"
+
+// compareResponse is the subset of the Gitea compare response we use
+type compareResponse struct {
+	Commits []struct {
+		SHA    string `json:"sha"`
+		Commit struct {
+			Message string `json:"message"`
+			Author  struct {
+				Name string    `json:"name"`
+				Date time.Time `json:"date"`
+			} `json:"author"`
+		} `json:"commit"`
+	} `json:"commits"`
+}
+
+// FetchCommitsBetween fetches the commits that are in head but not in base, newest first.
+func (a *API) FetchCommitsBetween(base, head string) ([]model.Commit, error) {
+	if base == "" || head == "" {
+		return nil, fmt.Errorf("both build hashes are needed to list commits")
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf(blenderCompareAPIURL, base, head), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch commits: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch commits: status code %d", resp.StatusCode)
+	}
+
+	var compare compareResponse
+	if err := json.NewDecoder(resp.Body).Decode(&compare); err != nil {
+		return nil, fmt.Errorf("failed to decode commit list: %w", err)
+	}
+
+	// The API lists commits oldest first; show the newest first like git log
+	commits := make([]model.Commit, 0, len(compare.Commits))
+	for i := len(compare.Commits) - 1; i >= 0; i-- {
+		c := compare.Commits[i]
+		title, _, _ := strings.Cut(c.Commit.Message, "\n")
+		commits = append(commits, model.Commit{
+			Hash:   c.SHA,
+			Title:  title,
+			Author: c.Commit.Author.Name,
+			Date:   c.Commit.Author.Date,
+		})
+	}
+	return commits, nil
+}
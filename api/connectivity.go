@@ -0,0 +1,56 @@
+package api
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// connectivityCheckURL is requested to find out whether the builder is reachable
+const connectivityCheckURL = "https://builder.blender.org/"
+
+// connectivityTimeout bounds the connectivity check so startup never hangs on a dead network
+const connectivityTimeout = 5 * time.Second
+
+// CheckConnectivity reports whether the Blender builder can be reached.
+// The request goes through the same transport as fetches, so HTTP(S)_PROXY settings are honoured.
+func (a *API) CheckConnectivity() error {
+	client := &http.Client{
+		Transport: a.client.Transport,
+		Timeout:   connectivityTimeout,
+	}
+
+	req, err := http.NewRequest("HEAD", connectivityCheckURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("builder.blender.org is unreachable: %w", err)
+	}
+	resp.Body.Close()
+
+	// Any HTTP answer means the network path (and proxy) works
+	return nil
+}
+
+// LoadCachedBuilds returns the builds of the last fetched listing of a build type, filtered like FetchBuilds,
+// and when the listing was fetched. It lists the builds while the builder is unreachable.
+func LoadCachedBuilds(versionFilter string, buildType string) ([]model.BlenderBuild, time.Time, error) {
+	listing := loadListing(buildType)
+	if listing == nil {
+		return nil, time.Time{}, fmt.Errorf("no cached %s build list available", buildType)
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to load config: %w", err)
+	}
+	builds, err := listingBuilds(*listing, versionFilter, buildType, cfg)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return builds, listing.FetchedAt, nil
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"TUI-Blender-Launcher/config"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// cachedListing is the last builder response for a build type with its validators.
+// They are sent back in conditional requests so an unchanged listing isn't downloaded again.
+// The listing also holds the builds shown while offline, see LoadCachedBuilds.
+type cachedListing struct {
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"last_modified,omitempty"`
+	FetchedAt    time.Time       `json:"fetched_at"`
+	Body         json.RawMessage `json:"body"`
+}
+
+// getListingPath returns the cache file for the builder response of a build type.
+func getListingPath(buildType string) (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "listing-"+buildType+".json"), nil
+}
+
+// loadListing returns the cached builder response of a build type, nil when there is none
+func loadListing(buildType string) *cachedListing {
+	path, err := getListingPath(buildType)
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var listing cachedListing
+	if err := json.Unmarshal(data, &listing); err != nil || len(listing.Body) == 0 {
+		return nil
+	}
+	return &listing
+}
+
+// saveListing stores a builder response with its validators for the next conditional request.
+func saveListing(buildType string, listing cachedListing) error {
+	path, err := getListingPath(buildType)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("could not create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(listing)
+	if err != nil {
+		return fmt.Errorf("failed to marshal build listing: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// fetchListing downloads the build listing of a build type from apiURL.
+// When a response was cached, the request carries its ETag and date; a 304 answer returns
+// the cached listing with notModified set. Other listings are returned for the caller to
+// save once they decoded fine. An empty uuid sends no X-Client-UUID header.
+func (a *API) fetchListing(apiURL, buildType, uuid string) (listing cachedListing, notModified bool, err error) {
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return cachedListing{}, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	if uuid != "" {
+		req.Header.Set("X-Client-UUID", uuid)
+	}
+
+	cached := loadListing(buildType)
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return cachedListing{}, false, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return *cached, true, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return cachedListing{}, false, fmt.Errorf("failed to fetch data: status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return cachedListing{}, false, fmt.Errorf("failed to fetch data: %w", err)
+	}
+	return cachedListing{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    time.Now(),
+		Body:         body,
+	}, false, nil
+}
@@ -0,0 +1,9 @@
+package config
+
+// CustomColumn is an extra column of the build list whose values are printed by a command.
+// The command runs through the shell with the listed builds as a JSON array on stdin and prints
+// the value of each build on its own line, in the same order.
+type CustomColumn struct {
+	Name    string `toml:"name"`    // Column header
+	Command string `toml:"command"` // e.g. "python3 ~/studio/approval.py"
+}
@@ -1,28 +1,100 @@
 package config
 
 import (
+	"TUI-Blender-Launcher/model"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/google/uuid"
 )
 
-// AppName is used for the config directory
+// AppName is used for the config, cache and state directories
 const AppName = "tui-blender-launcher" // Use lowercase app name
 
 // Config holds the application settings.
 type Config struct {
-	DownloadDir   string `toml:"download_dir"`
-	VersionFilter string `toml:"version_filter"` // e.g., "4.0", "3.6", or empty for no filter
-	BuildType     string `toml:"build_type"`     // "daily", "patch", or "experimental"
-	UUID          string `toml:"uuid"`           // Unique identifier for this instance
+	DownloadDir    string `toml:"download_dir"`
+	SharedDir      string `toml:"shared_dir"`       // Read-only builds shared by an admin, e.g. on a network path
+	VersionFilter  string `toml:"version_filter"`   // e.g., "4.0", "3.6", or empty for no filter
+	TagFilter      string `toml:"tag_filter"`       // Only show builds with this tag, empty for no filter
+	BuildType      string `toml:"build_type"`       // "daily", "patch", or "experimental"
+	UUID           string `toml:"uuid"`             // Unique identifier for this instance
+	SendDownloadID bool   `toml:"send_download_id"` // Send the UUID with downloads and build list fetches
+	GPUProbe       bool   `toml:"gpu_probe"`        // Probe the Cycles GPU devices of a build after installing it
+	SmokeTest      bool   `toml:"smoke_test"`       // Run blender --version after installing a build and mark it Broken if it fails
+	AutoRepair     bool   `toml:"auto_repair"`      // Re-download builds that are Broken or fail verification without asking
+	KeepArchives   bool   `toml:"keep_archives"`    // Keep downloaded archives in the archive cache after installing them
+	BlendHandler   string `toml:"blend_handler"`    // Version of the build registered as .blend file handler
+	Metered        bool   `toml:"metered"`          // Metered connection: no automatic downloads, confirm manual ones
+	PromotionAdmin bool   `toml:"promotion_admin"`  // Allow changing the promotion state of builds
+	ApprovedOnly   bool   `toml:"approved_only"`    // Only launch builds promoted to approved
+	Unquarantine   bool   `toml:"unquarantine"`     // macOS: clear the quarantine attribute of builds without asking
+	Rosetta        bool   `toml:"rosetta"`          // macOS: also list Intel builds on Apple Silicon, installed next to the native ones
+	Density        string `toml:"density"`          // "comfortable" or "compact" build list layout
+	ProgressStyle  string `toml:"progress_style"`   // "bar", "percentage", "blocks" or "braille"
+	ReduceMotion   bool   `toml:"reduce_motion"`    // Don't blink rows whose status just changed, only underline them
+	Proxy          string `toml:"proxy"`            // Proxy URL for all requests, e.g. http://proxy:3128
+	ProxyUser      string `toml:"proxy_user"`       // Proxy user name, empty for no authentication
+	IPVersion      string `toml:"ip_version"`       // "auto", "ipv4" or "ipv6"
+	DNSServer      string `toml:"dns_server"`       // Resolver used instead of the system one, e.g. 1.1.1.1 or 1.1.1.1:53
+	DownloadSource string `toml:"download_source"`  // Mirror tried before the builder, one of mirrors; empty to start with the builder
+	// Redraw progress less often and as plain text: "auto" (over SSH and in tmux or screen), "on" or "off"
+	LowBandwidthMode string `toml:"low_bandwidth"`
+	// Progress redraw interval in low bandwidth mode in milliseconds; 0 for the default of one second
+	RefreshIntervalMS int `toml:"refresh_interval_ms"`
+	// Name of installed build directories, e.g. "{version}-{cycle}-{hash}"; empty for the name in the archive
+	DirTemplate string `toml:"dir_template"`
+	// Directory a build extracts to that wasn't installed by the launcher: "ask", "overwrite", "keep_both" or "abort"
+	ConflictPolicy string `toml:"conflict_policy"`
+	// Directory of the archive cache, see keep_archives; empty for "archives" in the cache directory
+	ArchiveCacheDir string `toml:"archive_cache_dir"`
+	// Version filter per build type, e.g. patch = "" to list all patch builds; other types use version_filter
+	VersionFilters map[string]string `toml:"version_filters"`
+	// Fixed IP addresses for host names, e.g. builder.blender.org = "1.2.3.4"
+	HostOverrides map[string]string `toml:"host_overrides"`
+	// Sandbox tool per build source on Linux, e.g. experimental = "firejail"
+	Sandbox map[string]string `toml:"sandbox"`
+	// Profile builds are launched with unless they have their own; empty for none
+	LaunchProfile string `toml:"launch_profile"`
+	// Named sets of Blender arguments and environment variables, picked per launch with p
+	LaunchProfiles map[string]LaunchProfile `toml:"launch_profiles"`
+	// Base URLs of download mirrors, tried in order when the builder fails
+	Mirrors []string `toml:"mirrors"`
+	// Terminal emulators tried first when launching a build on Linux, a name or a command line
+	Terminals []string `toml:"terminals"`
+	// Extra build list columns whose values a command prints, e.g. the approval status kept by a studio API
+	CustomColumns []CustomColumn `toml:"custom_columns"`
+	// Paths inside build archives to extract, relative to the build directory, e.g. "4.2/scripts"; all when empty
+	ExtractInclude []string `toml:"extract_include"`
+	// Paths inside build archives to skip when extracting, e.g. "*/python/lib/*/test" or "*/datafiles/locale"
+	ExtractExclude []string `toml:"extract_exclude"`
+	// Archive format picked for builds published in several: "smallest_download" or "fastest_install"
+	ArchivePreference string `toml:"archive_preference"`
+	// When an online build is an update of the installed one: "either" (hash, then build date), "hash" or "build_date"
+	UpdatePolicy string `toml:"update_policy"`
+	// Monthly download budget in GB; automatic downloads stop and manual ones are confirmed past it, 0 for no budget
+	DownloadBudgetGB float64 `toml:"download_budget_gb"`
+	// Size limit of .downloading in GB, the least recently written leftovers are deleted past it; 0 for no limit
+	DownloadingQuotaGB float64 `toml:"downloading_quota_gb"`
+	// Downloads running at the same time, later ones are queued; 0 for no limit
+	MaxDownloads int `toml:"max_downloads"`
+	// Old builds archived longer ago are purged at startup; 0 keeps them until cleaned manually
+	OldBuildsRetentionDays int `toml:"oldbuilds_retention_days"`
+	// Files written in parallel when extracting a build; 0 to tune to the CPU count
+	ExtractWorkers int `toml:"extract_workers"`
+	// Read and write buffer of the extraction in MB; 0 to tune to the available memory
+	ExtractBufferMB int `toml:"extract_buffer_mb"`
+	// Secret: kept in the system keyring when available, in plain text otherwise
+	ProxyPassword string `toml:"proxy_password,omitempty"`
 }
 
 var (
-	instance *Config
+	instance atomic.Pointer[Config] // Replaced while downloads read it
 	once     sync.Once
 )
 
@@ -35,10 +107,16 @@ func GetConfigInstance() *Config {
 			// Log error but continue with default config
 			fmt.Printf("Warning: Failed to load config: %v\n", err)
 		}
-		instance = &cfg
+		instance.Store(&cfg)
 	})
 
-	return instance
+	return instance.Load()
+}
+
+// SetConfigInstance replaces the singleton config instance after settings changed at runtime
+func SetConfigInstance(cfg Config) {
+	once.Do(func() {})
+	instance.Store(&cfg)
 }
 
 // DefaultConfig returns a Config struct with default values.
@@ -47,26 +125,66 @@ func DefaultConfig() Config {
 	// We will expand the ~ later
 	homeDir, _ := os.UserHomeDir() // Use UserHomeDir for safety
 	defaultDownloadPath := filepath.Join(homeDir, "blender/blender-build")
+	if portableRoot != "" {
+		defaultDownloadPath = filepath.Join(portableRoot, "builds")
+	}
 
 	return Config{
-		DownloadDir:   defaultDownloadPath,
-		VersionFilter: "",                  // No filter by default
-		BuildType:     "daily",             // Default to patch builds
-		UUID:          uuid.New().String(), // Generate a new UUID
+		DownloadDir:    defaultDownloadPath,
+		VersionFilter:  "",                  // No filter by default
+		BuildType:      "daily",             // Default to patch builds
+		UUID:           uuid.New().String(), // Generate a new UUID
+		SendDownloadID: true,
+		IPVersion:      "auto",
+		Density:        DensityComfortable,
+		ProgressStyle:  "bar",
+		ConflictPolicy: ConflictAsk,
+		// Remote sessions are detected, see RemoteSession
+		LowBandwidthMode: LowBandwidthAuto,
+		// Downloads usually take longer than extraction
+		ArchivePreference: ArchiveSmallestDownload,
+		UpdatePolicy:      model.UpdatePolicyEither,
+	}
+}
+
+// DownloadBudget returns the monthly download budget in bytes, 0 for no budget
+func (c Config) DownloadBudget() int64 {
+	return int64(c.DownloadBudgetGB * (1 << 30))
+}
+
+// DownloadingQuota returns the size limit of .downloading in bytes, 0 for no limit
+func (c Config) DownloadingQuota() int64 {
+	return int64(c.DownloadingQuotaGB * (1 << 30))
+}
+
+// VersionFilterFor returns the version filter applied to builds of a build type
+func (c Config) VersionFilterFor(buildType string) string {
+	if filter, ok := c.VersionFilters[buildType]; ok {
+		return filter
+	}
+	return c.VersionFilter
+}
+
+// SetVersionFilterFor changes the version filter of a build type.
+// A filter equal to version_filter removes the entry, so the type follows version_filter again.
+func (c *Config) SetVersionFilterFor(buildType, filter string) {
+	if filter == c.VersionFilter {
+		delete(c.VersionFilters, buildType)
+		return
+	}
+	if c.VersionFilters == nil {
+		c.VersionFilters = make(map[string]string)
 	}
+	c.VersionFilters[buildType] = filter
 }
 
 // GetConfigPath returns the full path to the config file.
 func GetConfigPath() (string, error) {
-	configDir, err := os.UserConfigDir() // Gets ~/.config on Linux, appropriate paths on other OS
+	appConfigDir, err := ConfigDir()
 	if err != nil {
-		return "", fmt.Errorf("could not get user config directory: %w", err)
+		return "", err
 	}
-
-	appConfigDir := filepath.Join(configDir, AppName)
-	configFilePath := filepath.Join(appConfigDir, "config.toml")
-
-	return configFilePath, nil
+	return filepath.Join(appConfigDir, "config.toml"), nil
 }
 
 // LoadConfig loads the configuration from the default path.
@@ -90,10 +208,40 @@ func LoadConfig() (Config, error) {
 	}
 
 	// File exists, try to load it
-	if _, err := toml.DecodeFile(cfgPath, &cfg); err != nil {
-		return Config{}, fmt.Errorf("could not decode config file %s: %w", cfgPath, err)
+	data, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return Config{}, fmt.Errorf("could not read config file %s: %w", cfgPath, err)
+	}
+	md, err := toml.Decode(string(data), &cfg)
+	if err != nil {
+		return Config{}, decodeError(cfgPath, err)
+	}
+
+	// An empty build type means the default one
+	if cfg.BuildType == "" {
+		cfg.BuildType = DefaultConfig().BuildType
+	}
+
+	// Reject unknown keys and bad values instead of silently ignoring them
+	if errs := checkFile(cfgPath, string(data), md, cfg); len(errs) > 0 {
+		return Config{}, errs
+	}
+
+	// Secrets are normally not in the file but in the keyring
+	if hasSecrets(cfg) {
+		plaintext := cfg.ProxyPassword != ""
+		loadSecrets(&cfg)
+		// Move secrets written into the file by hand into the keyring
+		if plaintext && storeSecrets(cfg).ProxyPassword == "" {
+			if err := SaveConfig(cfg); err != nil {
+				return Config{}, err
+			}
+		}
 	}
 
+	// In portable mode download_dir is relative to the portable directory
+	cfg.DownloadDir = resolvePortablePath(cfg.DownloadDir)
+
 	// Expand ~ in DownloadDir if present
 	if cfg.DownloadDir != "" && cfg.DownloadDir[0] == '~' {
 		homeDir, err := os.UserHomeDir()
@@ -106,6 +254,22 @@ func LoadConfig() (Config, error) {
 	return cfg, nil
 }
 
+// ModTime returns the modification time of the config file.
+// A missing file yields the zero time without error.
+func ModTime() (time.Time, error) {
+	cfgPath, err := GetConfigPath()
+	if err != nil {
+		return time.Time{}, err
+	}
+	info, err := os.Stat(cfgPath)
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, fmt.Errorf("could not stat config file %s: %w", cfgPath, err)
+	}
+	return info.ModTime(), nil
+}
+
 // SaveConfig saves the configuration to the default path.
 // It creates the config directory if it doesn't exist.
 func SaveConfig(cfg Config) error {
@@ -128,11 +292,32 @@ func SaveConfig(cfg Config) error {
 	}
 	defer file.Close()
 
-	// Encode the config to the file
+	// Encode the config to the file, keeping secrets out of it when possible
+	cfg.DownloadDir = portablePath(cfg.DownloadDir)
 	encoder := toml.NewEncoder(file)
-	if err := encoder.Encode(cfg); err != nil {
+	if err := encoder.Encode(storeSecrets(cfg)); err != nil {
 		return fmt.Errorf("could not encode config to file %s: %w", cfgPath, err)
 	}
 
 	return nil
 }
+
+// ResetConfig replaces the config file with the default settings.
+// The previous file is kept next to it as config.toml.bak.
+func ResetConfig() (Config, error) {
+	cfgPath, err := GetConfigPath()
+	if err != nil {
+		return Config{}, err
+	}
+	if _, err := os.Stat(cfgPath); err == nil {
+		if err := os.Rename(cfgPath, cfgPath+".bak"); err != nil {
+			return Config{}, fmt.Errorf("could not back up config file %s: %w", cfgPath, err)
+		}
+	}
+
+	cfg := DefaultConfig()
+	if err := SaveConfig(cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
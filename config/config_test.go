@@ -1,10 +1,13 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -95,6 +98,18 @@ func TestLoadConfig(t *testing.T) {
 			expectError:   true,
 			checkConfig:   nil, // Not needed for error case
 		},
+		{
+			name:          "unknown key",
+			configContent: "download_dir = \"/custom/path\"\ndownload_dri = \"/other\"\n",
+			expectError:   true,
+			checkConfig:   nil,
+		},
+		{
+			name:          "invalid build type",
+			configContent: "download_dir = \"/custom/path\"\nbuild_type = \"nightly\"\n",
+			expectError:   true,
+			checkConfig:   nil,
+		},
 		{
 			name:          "missing config file",
 			configContent: "", // No content, file will be deleted
@@ -231,3 +246,378 @@ func TestSaveConfig(t *testing.T) {
 func containsStr(s, substr string) bool {
 	return strings.HasPrefix(s, substr) || strings.Contains(s, "\n"+substr) || strings.Contains(s, substr+"\n")
 }
+
+func TestValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	if err := Validate(cfg); err != nil {
+		t.Errorf("Default config should be valid, got: %v", err)
+	}
+
+	cfg.BuildType = "nightly"
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for invalid build_type")
+	}
+
+	cfg = DefaultConfig()
+	cfg.DownloadDir = ""
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for empty download_dir")
+	}
+
+	cfg = DefaultConfig()
+	cfg.Mirrors = []string{"https://mirror.example.org", "mirror.example.org"}
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for mirror without scheme")
+	}
+
+	cfg = DefaultConfig()
+	cfg.Mirrors = []string{"https://mirror.example.org"}
+	cfg.DownloadSource = "https://other.example.org"
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for a download_source that isn't a mirror")
+	}
+
+	cfg = DefaultConfig()
+	cfg.SharedDir = cfg.DownloadDir
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for shared_dir equal to download_dir")
+	}
+
+	cfg = DefaultConfig()
+	cfg.Sandbox = map[string]string{"experimental": "firejail", "patch": "docker", "nightly": "bwrap"}
+	if errs, ok := Validate(cfg).(ValidationErrors); !ok || len(errs) != 2 {
+		t.Errorf("Expected errors for the sandbox tool and source, got: %v", errs)
+	}
+
+	cfg = DefaultConfig()
+	cfg.VersionFilters = map[string]string{"daily": "4.2", "nightly": "4.0"}
+	if errs, ok := Validate(cfg).(ValidationErrors); !ok || len(errs) != 1 {
+		t.Errorf("Expected an error for the unknown build type, got: %v", errs)
+	}
+
+	cfg = DefaultConfig()
+	cfg.ExtractInclude = []string{"blender", "4.2/scripts"}
+	cfg.ExtractExclude = []string{"*/python/lib/*/test", "[a-", "/usr/share"}
+	if errs, ok := Validate(cfg).(ValidationErrors); !ok || len(errs) != 2 {
+		t.Errorf("Expected errors for the malformed and the absolute pattern, got: %v", errs)
+	}
+
+	cfg = DefaultConfig()
+	cfg.Terminals = []string{"kitty", " "}
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for empty terminal")
+	}
+
+	cfg = DefaultConfig()
+	cfg.IPVersion = "ipv5"
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for invalid ip_version")
+	}
+
+	cfg = DefaultConfig()
+	cfg.Density = "cozy"
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for invalid density")
+	}
+
+	cfg = DefaultConfig()
+	cfg.DirTemplate = "{version}-{commit}"
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for unknown dir_template placeholder")
+	}
+
+	cfg = DefaultConfig()
+	cfg.ConflictPolicy = "merge"
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for invalid conflict_policy")
+	}
+
+	cfg = DefaultConfig()
+	cfg.LowBandwidthMode = "sometimes"
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for invalid low_bandwidth")
+	}
+
+	cfg = DefaultConfig()
+	cfg.RefreshIntervalMS = -100
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for negative refresh_interval_ms")
+	}
+
+	cfg = DefaultConfig()
+	cfg.DownloadBudgetGB = -5
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for negative download_budget_gb")
+	}
+
+	cfg = DefaultConfig()
+	cfg.DownloadingQuotaGB = -1
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for negative downloading_quota_gb")
+	}
+
+	cfg = DefaultConfig()
+	cfg.MaxDownloads = -1
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for negative max_downloads")
+	}
+
+	cfg = DefaultConfig()
+	cfg.ExtractWorkers = -1
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for negative extract_workers")
+	}
+
+	cfg = DefaultConfig()
+	cfg.ExtractBufferMB = MaxExtractBufferMB + 1
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for extract_buffer_mb past the limit")
+	}
+
+	cfg = DefaultConfig()
+	cfg.CustomColumns = []CustomColumn{{Name: "Approval", Command: "approval.sh"}, {Name: "Approval", Command: "other.sh"}}
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for custom columns of the same name")
+	}
+
+	cfg = DefaultConfig()
+	cfg.CustomColumns = []CustomColumn{{Name: "Approval"}}
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for a custom column without command")
+	}
+
+	cfg = DefaultConfig()
+	cfg.UpdatePolicy = "newest"
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for invalid update_policy")
+	}
+
+	cfg = DefaultConfig()
+	cfg.ProgressStyle = "dots"
+	if err := Validate(cfg); err == nil {
+		t.Error("Expected error for invalid progress_style")
+	}
+
+	cfg = DefaultConfig()
+	cfg.DNSServer = "dns.example.org"
+	cfg.HostOverrides = map[string]string{"builder.blender.org": "not-an-ip"}
+	if errs, ok := Validate(cfg).(ValidationErrors); !ok || len(errs) != 2 {
+		t.Errorf("Expected errors for dns_server and host_overrides, got: %v", errs)
+	}
+}
+
+func TestVersionFilterFor(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.VersionFilter = "4.0"
+	cfg.SetVersionFilterFor("daily", "4.2")
+	cfg.SetVersionFilterFor("patch", "")
+
+	expected := map[string]string{"daily": "4.2", "experimental": "4.0", "patch": ""}
+	for buildType, filter := range expected {
+		if got := cfg.VersionFilterFor(buildType); got != filter {
+			t.Errorf("VersionFilterFor(%q) = %q, expected %q", buildType, got, filter)
+		}
+	}
+
+	// Setting the shared filter again drops the entry
+	cfg.SetVersionFilterFor("daily", "4.0")
+	if _, ok := cfg.VersionFilters["daily"]; ok {
+		t.Errorf("Expected no daily entry left, got %v", cfg.VersionFilters)
+	}
+}
+
+func TestLoadConfigValidationErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	oldConfigHome := os.Getenv("XDG_CONFIG_HOME")
+	defer os.Setenv("XDG_CONFIG_HOME", oldConfigHome)
+	os.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	configDir := filepath.Join(tempDir, AppName)
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	content := "download_dir = \"/custom/path\"\nbuild_type = \"nightly\"\ncolour = \"blue\"\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	_, err := LoadConfig()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 problems, got %d: %v", len(errs), errs)
+	}
+
+	lines := map[string]int{}
+	for _, e := range errs {
+		lines[e.Key] = e.Line
+	}
+	if lines["colour"] != 3 {
+		t.Errorf("Expected unknown key colour on line 3, got %d", lines["colour"])
+	}
+	if lines["build_type"] != 2 {
+		t.Errorf("Expected build_type on line 2, got %d", lines["build_type"])
+	}
+}
+
+func TestDirectoryOverrides(t *testing.T) {
+	t.Cleanup(func() {
+		configDirOverride = ""
+		dataDirOverride = ""
+	})
+	tempDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", filepath.Join(tempDir, "state-home"))
+
+	if runtime.GOOS == "linux" {
+		stateDir, err := StateDir()
+		if err != nil {
+			t.Fatalf("StateDir failed: %v", err)
+		}
+		if expected := filepath.Join(tempDir, "state-home", AppName); stateDir != expected {
+			t.Errorf("Expected state directory %s, got %s", expected, stateDir)
+		}
+	}
+
+	if err := SetConfigDir(filepath.Join(tempDir, "portable")); err != nil {
+		t.Fatalf("SetConfigDir failed: %v", err)
+	}
+	if err := SetDataDir(filepath.Join(tempDir, "portable", "data")); err != nil {
+		t.Fatalf("SetDataDir failed: %v", err)
+	}
+
+	cfgPath, _ := GetConfigPath()
+	if expected := filepath.Join(tempDir, "portable", "config.toml"); cfgPath != expected {
+		t.Errorf("Expected config path %s, got %s", expected, cfgPath)
+	}
+	cacheDir, _ := CacheDir()
+	if expected := filepath.Join(tempDir, "portable", "data", "cache"); cacheDir != expected {
+		t.Errorf("Expected cache directory %s, got %s", expected, cacheDir)
+	}
+	stateDir, _ := StateDir()
+	if expected := filepath.Join(tempDir, "portable", "data", "state"); stateDir != expected {
+		t.Errorf("Expected state directory %s, got %s", expected, stateDir)
+	}
+}
+
+func TestPortableMode(t *testing.T) {
+	t.Cleanup(func() {
+		portableRoot = ""
+		configDirOverride = ""
+		dataDirOverride = ""
+	})
+	root := t.TempDir()
+	if err := EnablePortable(root); err != nil {
+		t.Fatalf("EnablePortable failed: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	if expected := filepath.Join(root, "builds"); cfg.DownloadDir != expected {
+		t.Errorf("Expected download directory %s, got %s", expected, cfg.DownloadDir)
+	}
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+
+	// The saved path is relative so the directory can be moved
+	data, err := os.ReadFile(filepath.Join(root, "config", "config.toml"))
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if !strings.Contains(string(data), `download_dir = "builds"`) {
+		t.Errorf("Expected a relative download_dir, got:\n%s", data)
+	}
+
+	loaded, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if loaded.DownloadDir != cfg.DownloadDir {
+		t.Errorf("Expected download directory %s after loading, got %s", cfg.DownloadDir, loaded.DownloadDir)
+	}
+}
+
+func TestLowBandwidth(t *testing.T) {
+	for _, name := range []string{"SSH_CONNECTION", "SSH_CLIENT", "SSH_TTY"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("TERM", "xterm-256color")
+
+	cfg := DefaultConfig()
+	if cfg.LowBandwidth() {
+		t.Error("Expected low bandwidth mode to be off in a local terminal")
+	}
+	t.Setenv("TERM", "tmux-256color")
+	if !cfg.LowBandwidth() {
+		t.Error("Expected low bandwidth mode inside tmux")
+	}
+	t.Setenv("TERM", "xterm")
+	t.Setenv("SSH_CONNECTION", "10.0.0.2 50000 10.0.0.1 22")
+	if !cfg.LowBandwidth() {
+		t.Error("Expected low bandwidth mode over SSH")
+	}
+	cfg.LowBandwidthMode = LowBandwidthOff
+	if cfg.LowBandwidth() {
+		t.Error("Expected low_bandwidth = \"off\" to win over detection")
+	}
+
+	if got := cfg.RefreshInterval(); got != DefaultRefreshInterval {
+		t.Errorf("Expected the default refresh interval, got %v", got)
+	}
+	cfg.RefreshIntervalMS = 2500
+	if got := cfg.RefreshInterval(); got != 2500*time.Millisecond {
+		t.Errorf("Expected 2.5s refresh interval, got %v", got)
+	}
+}
+
+func TestLaunchProfiles(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+	configDir := filepath.Join(tempDir, AppName)
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	content := `download_dir = "/custom/path"
+launch_profile = "CUDA headless"
+
+[launch_profiles."CUDA headless"]
+args = ["--background"]
+env = { CYCLES_DEVICE = "CUDA", BLENDER_DEBUG = "1" }
+
+[launch_profiles.viewport-debug]
+args = ["--debug-gpu"]
+`
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if names := cfg.LaunchProfileNames(); len(names) != 2 || names[0] != "CUDA headless" {
+		t.Fatalf("Expected two launch profiles, got %v", names)
+	}
+
+	// Builds without their own profile use launch_profile, NoLaunchProfile turns it off
+	name, profile, ok := cfg.ResolveLaunchProfile("")
+	if !ok || name != "CUDA headless" || profile.Args[0] != "--background" {
+		t.Errorf("Expected the global profile, got %q %+v", name, profile)
+	}
+	if env := profile.Environ(); len(env) != 2 || env[0] != "BLENDER_DEBUG=1" {
+		t.Errorf("Expected sorted environment variables, got %v", env)
+	}
+	if name, _, _ := cfg.ResolveLaunchProfile("viewport-debug"); name != "viewport-debug" {
+		t.Errorf("Expected the build's own profile, got %q", name)
+	}
+	if _, _, ok := cfg.ResolveLaunchProfile(NoLaunchProfile); ok {
+		t.Error("Expected no profile for none")
+	}
+
+	cfg.LaunchProfile = "CPU"
+	cfg.LaunchProfiles[NoLaunchProfile] = LaunchProfile{}
+	cfg.LaunchProfiles["broken"] = LaunchProfile{Env: map[string]string{"A=B": "1"}}
+	if errs, ok := Validate(cfg).(ValidationErrors); !ok || len(errs) != 3 {
+		t.Errorf("Expected errors for the reserved name, the variable name and the unknown launch_profile, got: %v", errs)
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// IPVersions lists the accepted values of ip_version
+var IPVersions = []string{"auto", "ipv4", "ipv6"}
+
+// dnsPort is used when dns_server has no port
+const dnsPort = "53"
+
+// ApplyNetwork applies the IP version, DNS server and host overrides to the default HTTP transport,
+// which the API, download and checksum clients all go through.
+func ApplyNetwork(cfg Config) error {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("unexpected default transport %T", http.DefaultTransport)
+	}
+	transport.DialContext = cfg.DialContext()
+	return nil
+}
+
+// DialContext returns a dial function honouring the network settings of the configuration
+func (cfg Config) DialContext() func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if cfg.DNSServer != "" {
+		server := dnsServerAddr(cfg.DNSServer)
+		dialer.Resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, server)
+			},
+		}
+	}
+
+	overrides := make(map[string]string, len(cfg.HostOverrides))
+	for host, ip := range cfg.HostOverrides {
+		overrides[strings.ToLower(host)] = ip
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if network == "tcp" {
+			switch cfg.IPVersion {
+			case "ipv4":
+				network = "tcp4"
+			case "ipv6":
+				network = "tcp6"
+			}
+		}
+		if host, port, err := net.SplitHostPort(addr); err == nil {
+			if ip, ok := overrides[strings.ToLower(host)]; ok {
+				addr = net.JoinHostPort(ip, port)
+			}
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
+}
+
+// dnsServerAddr adds the default DNS port to a server without one
+func dnsServerAddr(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(strings.Trim(server, "[]"), dnsPort)
+}
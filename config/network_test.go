@@ -0,0 +1,45 @@
+package config
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+func TestDialContextHostOverride(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			conn.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	cfg := DefaultConfig()
+	cfg.IPVersion = "ipv4"
+	cfg.HostOverrides = map[string]string{"Builder.Example.Invalid": "127.0.0.1"}
+
+	conn, err := cfg.DialContext()(context.Background(), "tcp", net.JoinHostPort("builder.example.invalid", port))
+	if err != nil {
+		t.Fatalf("Dial with host override failed: %v", err)
+	}
+	conn.Close()
+}
+
+func TestDNSServerAddr(t *testing.T) {
+	tests := map[string]string{
+		"1.1.1.1":              "1.1.1.1:53",
+		"1.1.1.1:5353":         "1.1.1.1:5353",
+		"2606:4700:4700::1111": "[2606:4700:4700::1111]:53",
+		"[::1]:5353":           "[::1]:5353",
+	}
+	for in, want := range tests {
+		if got := dnsServerAddr(in); got != want {
+			t.Errorf("dnsServerAddr(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Directory overrides from the command line, empty to use the user directories
+var (
+	configDirOverride string
+	dataDirOverride   string
+)
+
+// SetConfigDir keeps config.toml in dir instead of the user config directory
+func SetConfigDir(dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("invalid config directory %s: %w", dir, err)
+	}
+	configDirOverride = abs
+	return nil
+}
+
+// SetDataDir keeps the cache and state files in dir, e.g. next to a portable install
+func SetDataDir(dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("invalid data directory %s: %w", dir, err)
+	}
+	dataDirOverride = abs
+	return nil
+}
+
+// ConfigDir returns the directory holding config.toml ($XDG_CONFIG_HOME on Linux)
+func ConfigDir() (string, error) {
+	if configDirOverride != "" {
+		return configDirOverride, nil
+	}
+	configDir, err := os.UserConfigDir() // Gets ~/.config on Linux, appropriate paths on other OS
+	if err != nil {
+		return "", fmt.Errorf("could not get user config directory: %w", err)
+	}
+	return filepath.Join(configDir, AppName), nil
+}
+
+// CacheDir returns the directory for files that can be fetched again, like the build lists ($XDG_CACHE_HOME on Linux)
+func CacheDir() (string, error) {
+	if dataDirOverride != "" {
+		return filepath.Join(dataDirOverride, "cache"), nil
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get user cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, AppName), nil
+}
+
+// ArchiveCachePath returns the directory kept archives are moved to, see keep_archives
+func (c Config) ArchiveCachePath() (string, error) {
+	if c.ArchiveCacheDir != "" {
+		return c.ArchiveCacheDir, nil
+	}
+	cacheDir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "archives"), nil
+}
+
+// StateDir returns the directory for state kept between runs, like the download schedule ($XDG_STATE_HOME on Linux).
+// Platforms without a state directory use the config directory.
+func StateDir() (string, error) {
+	if dataDirOverride != "" {
+		return filepath.Join(dataDirOverride, "state"), nil
+	}
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Join(dir, AppName), nil
+	}
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		return ConfigDir()
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get user state directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", AppName), nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PortableMarker next to the executable turns on portable mode
+const PortableMarker = "portable.txt"
+
+// portableRoot is the directory everything is stored in, empty when not portable
+var portableRoot string
+
+// EnablePortable keeps the config, cache, state and builds in root instead of the user directories
+func EnablePortable(root string) error {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("invalid portable directory %s: %w", root, err)
+	}
+	portableRoot = abs
+	configDirOverride = filepath.Join(abs, "config")
+	dataDirOverride = filepath.Join(abs, "data")
+	return nil
+}
+
+// PortableRoot returns the portable directory, or an empty string when not running portable
+func PortableRoot() string {
+	return portableRoot
+}
+
+// DetectPortable returns the directory of the executable when it holds the portable marker
+func DetectPortable() (string, bool) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", false
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	dir := filepath.Dir(exe)
+	if _, err := os.Stat(filepath.Join(dir, PortableMarker)); err != nil {
+		return "", false
+	}
+	return dir, true
+}
+
+// portablePath makes a path inside the portable directory relative, so it survives a changed drive letter or mount point
+func portablePath(path string) string {
+	if portableRoot == "" || !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(portableRoot, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
+
+// resolvePortablePath turns a path relative to the portable directory into an absolute one
+func resolvePortablePath(path string) string {
+	if portableRoot == "" || path == "" || filepath.IsAbs(path) || path[0] == '~' {
+		return path
+	}
+	return filepath.Join(portableRoot, path)
+}
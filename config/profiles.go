@@ -0,0 +1,48 @@
+package config
+
+import (
+	"slices"
+	"sort"
+)
+
+// NoLaunchProfile launches a build without a profile, overriding launch_profile for a build
+const NoLaunchProfile = "none"
+
+// LaunchProfile is a named set of Blender arguments and environment variables applied when launching a build
+type LaunchProfile struct {
+	Args []string          `toml:"args"` // Passed before the .blend file, e.g. ["--gpu-backend", "vulkan"]
+	Env  map[string]string `toml:"env"`  // e.g. CYCLES_DEVICE = "CUDA"
+}
+
+// Environ returns the environment variables of the profile as sorted KEY=value pairs
+func (p LaunchProfile) Environ() []string {
+	env := make([]string, 0, len(p.Env))
+	for key, value := range p.Env {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// LaunchProfileNames returns the names of the configured launch profiles, sorted
+func (c Config) LaunchProfileNames() []string {
+	names := make([]string, 0, len(c.LaunchProfiles))
+	for name := range c.LaunchProfiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ResolveLaunchProfile returns the profile a build is launched with: name if set, launch_profile otherwise.
+// NoLaunchProfile and unknown names resolve to no profile.
+func (c Config) ResolveLaunchProfile(name string) (string, LaunchProfile, bool) {
+	if name == "" {
+		name = c.LaunchProfile
+	}
+	profile, ok := c.LaunchProfiles[name]
+	if !ok {
+		return "", LaunchProfile{}, false
+	}
+	return name, profile, true
+}
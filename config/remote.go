@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// Low bandwidth modes, see low_bandwidth
+const (
+	LowBandwidthAuto = "auto" // On in remote sessions, see RemoteSession
+	LowBandwidthOn   = "on"
+	LowBandwidthOff  = "off"
+)
+
+// LowBandwidthModes lists the accepted values of low_bandwidth
+var LowBandwidthModes = []string{LowBandwidthAuto, LowBandwidthOn, LowBandwidthOff}
+
+// DefaultRefreshInterval is how often progress is redrawn in low bandwidth mode unless refresh_interval_ms is set
+const DefaultRefreshInterval = time.Second
+
+// RemoteSession reports whether the launcher seems to run over SSH or inside tmux or screen,
+// where every redraw is sent over a possibly slow connection
+func RemoteSession() bool {
+	for _, name := range []string{"SSH_CONNECTION", "SSH_CLIENT", "SSH_TTY"} {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	term := os.Getenv("TERM")
+	return strings.HasPrefix(term, "screen") || strings.HasPrefix(term, "tmux")
+}
+
+// LowBandwidth reports whether progress is redrawn less often and as plain text
+func (c Config) LowBandwidth() bool {
+	switch c.LowBandwidthMode {
+	case LowBandwidthOn:
+		return true
+	case LowBandwidthOff:
+		return false
+	}
+	return RemoteSession()
+}
+
+// RefreshInterval returns how often progress is redrawn in low bandwidth mode
+func (c Config) RefreshInterval() time.Duration {
+	if c.RefreshIntervalMS > 0 {
+		return time.Duration(c.RefreshIntervalMS) * time.Millisecond
+	}
+	return DefaultRefreshInterval
+}
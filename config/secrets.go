@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/BurntSushi/toml"
+	"github.com/zalando/go-keyring"
+)
+
+// keyringProxyPassword is the keyring entry holding proxy_password
+const keyringProxyPassword = "proxy_password"
+
+// hasSecrets reports whether the config uses any secret value
+func hasSecrets(cfg Config) bool {
+	return cfg.ProxyUser != "" || cfg.ProxyPassword != ""
+}
+
+// loadSecrets fills secret fields that are not in the file from the system keyring.
+// Without a keyring they simply stay empty.
+func loadSecrets(cfg *Config) {
+	if cfg.ProxyPassword != "" || cfg.ProxyUser == "" {
+		return
+	}
+	if password, err := keyring.Get(AppName, keyringProxyPassword); err == nil {
+		cfg.ProxyPassword = password
+	}
+}
+
+// storeSecrets moves secret fields into the system keyring and returns the config to write to disk.
+// When no keyring is available the secrets stay in the returned config, i.e. in plain text.
+func storeSecrets(cfg Config) Config {
+	if cfg.ProxyPassword == "" {
+		return cfg
+	}
+	if err := keyring.Set(AppName, keyringProxyPassword, cfg.ProxyPassword); err != nil {
+		return cfg
+	}
+	cfg.ProxyPassword = ""
+	return cfg
+}
+
+// PlaintextSecrets reports whether the config file holds secrets in plain text,
+// which happens when no system keyring is available.
+func PlaintextSecrets() bool {
+	cfgPath, err := GetConfigPath()
+	if err != nil {
+		return false
+	}
+	var secrets struct {
+		ProxyPassword string `toml:"proxy_password"`
+	}
+	if _, err := toml.DecodeFile(cfgPath, &secrets); err != nil {
+		return false
+	}
+	return secrets.ProxyPassword != ""
+}
+
+// ApplyProxy routes all HTTP requests through the configured proxy by setting it on the default transport,
+// which the API, download and checksum clients all go through. Without a proxy HTTP_PROXY/HTTPS_PROXY apply.
+// The environment is left alone, so Blender and other child processes never see the proxy password.
+func ApplyProxy(cfg Config) error {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("unexpected default transport %T", http.DefaultTransport)
+	}
+	if cfg.Proxy == "" {
+		transport.Proxy = http.ProxyFromEnvironment
+		transport.CloseIdleConnections()
+		return nil
+	}
+	proxyURL, err := url.Parse(cfg.Proxy)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL %q: %w", cfg.Proxy, err)
+	}
+	if cfg.ProxyUser != "" {
+		proxyURL.User = url.UserPassword(cfg.ProxyUser, cfg.ProxyPassword)
+	}
+	transport.Proxy = http.ProxyURL(proxyURL)
+	// Connections opened through the previous proxy aren't reused
+	transport.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestSecretsStoredInKeyring(t *testing.T) {
+	keyring.MockInit()
+	oldConfigHome := os.Getenv("XDG_CONFIG_HOME")
+	defer os.Setenv("XDG_CONFIG_HOME", oldConfigHome)
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg := DefaultConfig()
+	cfg.Proxy = "http://proxy:3128"
+	cfg.ProxyUser = "artist"
+	cfg.ProxyPassword = "hunter2"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig returned an error: %v", err)
+	}
+
+	configPath, _ := GetConfigPath()
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config file: %v", err)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Error("Password was written to the config file despite an available keyring")
+	}
+	if PlaintextSecrets() {
+		t.Error("Expected no plain-text secrets")
+	}
+
+	loaded, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig returned an error: %v", err)
+	}
+	if loaded.ProxyPassword != "hunter2" {
+		t.Errorf("Expected password from keyring, got %q", loaded.ProxyPassword)
+	}
+}
+
+func TestSecretsPlaintextFallback(t *testing.T) {
+	keyring.MockInitWithError(errors.New("no keyring"))
+	defer keyring.MockInit()
+	oldConfigHome := os.Getenv("XDG_CONFIG_HOME")
+	defer os.Setenv("XDG_CONFIG_HOME", oldConfigHome)
+	os.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg := DefaultConfig()
+	cfg.ProxyUser = "artist"
+	cfg.ProxyPassword = "hunter2"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig returned an error: %v", err)
+	}
+	if !PlaintextSecrets() {
+		t.Error("Expected the password to be stored in plain text without a keyring")
+	}
+
+	loaded, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig returned an error: %v", err)
+	}
+	if loaded.ProxyPassword != "hunter2" {
+		t.Errorf("Expected password from the config file, got %q", loaded.ProxyPassword)
+	}
+}
+
+func TestApplyProxy(t *testing.T) {
+	transport := http.DefaultTransport.(*http.Transport)
+	oldProxy := transport.Proxy
+	defer func() { transport.Proxy = oldProxy }()
+	oldEnv, hadEnv := os.LookupEnv("HTTP_PROXY")
+	os.Unsetenv("HTTP_PROXY")
+	defer func() {
+		if hadEnv {
+			os.Setenv("HTTP_PROXY", oldEnv)
+		}
+	}()
+
+	cfg := Config{Proxy: "http://proxy:3128", ProxyUser: "artist", ProxyPassword: "hunter2"}
+	if err := ApplyProxy(cfg); err != nil {
+		t.Fatalf("ApplyProxy failed: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://builder.blender.org", nil)
+	proxyURL, err := transport.Proxy(req)
+	if err != nil || proxyURL == nil || proxyURL.Host != "proxy:3128" {
+		t.Fatalf("Expected the configured proxy, got %v (%v)", proxyURL, err)
+	}
+	if password, _ := proxyURL.User.Password(); password != "hunter2" {
+		t.Errorf("Expected the proxy credentials, got %q", proxyURL.User)
+	}
+	// Launched builds inherit the environment, the password must not end up there
+	if value, ok := os.LookupEnv("HTTP_PROXY"); ok {
+		t.Errorf("Expected HTTP_PROXY to stay unset, got %q", value)
+	}
+
+	if err := ApplyProxy(Config{}); err != nil {
+		t.Fatalf("ApplyProxy failed: %v", err)
+	}
+	if proxyURL, _ := transport.Proxy(req); proxyURL != nil {
+		t.Errorf("Expected no proxy once removed, got %v", proxyURL)
+	}
+}
@@ -0,0 +1,516 @@
+package config
+
+import (
+	"TUI-Blender-Launcher/model"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// BuildTypes lists the accepted values of build_type
+var BuildTypes = []string{"daily", "experimental", "patch"}
+
+// Layout densities of the build list
+const (
+	DensityComfortable = "comfortable" // Title, separator and all columns
+	DensityCompact     = "compact"     // More rows and only the most important columns
+)
+
+// Densities lists the accepted values of density
+var Densities = []string{DensityComfortable, DensityCompact}
+
+// Archive formats picked for builds published in several, see archive_preference
+const (
+	ArchiveSmallestDownload = "smallest_download" // The smallest file, usually tar.xz
+	ArchiveFastestInstall   = "fastest_install"   // The format quickest to extract, usually zip
+)
+
+// ArchivePreferences lists the accepted values of archive_preference
+var ArchivePreferences = []string{ArchiveSmallestDownload, ArchiveFastestInstall}
+
+// What to do when the directory a build extracts to exists but wasn't installed by the launcher, see conflict_policy
+const (
+	ConflictAsk       = "ask"       // Ask in the TUI; installs nobody can answer for, like farm-sync, abort
+	ConflictOverwrite = "overwrite" // Move the directory to .oldbuilds like a replaced build
+	ConflictKeepBoth  = "keep_both" // Install next to it under a numbered name
+	ConflictAbort     = "abort"     // Don't install the build
+)
+
+// ConflictPolicies lists the accepted values of conflict_policy
+var ConflictPolicies = []string{ConflictAsk, ConflictOverwrite, ConflictKeepBoth, ConflictAbort}
+
+// Upper limits of the extraction overrides, see extract_workers and extract_buffer_mb
+const (
+	MaxExtractWorkers  = 64
+	MaxExtractBufferMB = 256
+)
+
+// ProgressStyles lists the accepted values of progress_style
+var ProgressStyles = []string{"bar", "percentage", "blocks", "braille"}
+
+// Sandbox tools builds can be launched through on Linux
+const (
+	SandboxNone     = "none"
+	SandboxFirejail = "firejail"
+	SandboxBwrap    = "bwrap"
+)
+
+// SandboxTools lists the accepted values of the sandbox table
+var SandboxTools = []string{SandboxNone, SandboxFirejail, SandboxBwrap}
+
+// SandboxSources lists the accepted keys of the sandbox table, the sources of builds
+var SandboxSources = []string{"daily", "patch", "experimental", "stable", "external"}
+
+// ValidationError describes a problem with a single key of the config file
+type ValidationError struct {
+	File     string   // Path of the config file, empty when validating a Config value
+	Line     int      // Line of the offending key, 0 when unknown
+	Key      string   // Offending key, empty for syntax errors outside a key
+	Value    string   // Offending value as written
+	Accepted []string // Accepted values or keys, if there is a fixed set
+	Reason   string   // What is wrong
+}
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	if e.File != "" {
+		sb.WriteString(e.File)
+		if e.Line > 0 {
+			fmt.Fprintf(&sb, ":%d", e.Line)
+		}
+		sb.WriteString(": ")
+	}
+	if e.Key != "" {
+		sb.WriteString(e.Key)
+		if e.Value != "" {
+			fmt.Fprintf(&sb, " = %q", e.Value)
+		}
+		sb.WriteString(": ")
+	}
+	sb.WriteString(e.Reason)
+	if len(e.Accepted) > 0 {
+		fmt.Fprintf(&sb, " (accepted: %s)", strings.Join(e.Accepted, ", "))
+	}
+	return sb.String()
+}
+
+// ValidationErrors collects every problem found in a config file
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// knownKeys returns the TOML keys of the Config fields
+func knownKeys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag.Get("toml"); tag != "" {
+			keys = append(keys, tag)
+		}
+	}
+	return keys
+}
+
+// Validate checks that the configuration values are usable.
+// The returned error is a ValidationErrors listing every problem.
+func Validate(cfg Config) error {
+	var errs ValidationErrors
+	if cfg.DownloadDir == "" {
+		errs = append(errs, &ValidationError{Key: "download_dir", Reason: "cannot be empty"})
+	}
+
+	validType := false
+	for _, t := range BuildTypes {
+		if cfg.BuildType == t {
+			validType = true
+		}
+	}
+	if !validType {
+		errs = append(errs, &ValidationError{
+			Key:      "build_type",
+			Value:    cfg.BuildType,
+			Accepted: BuildTypes,
+			Reason:   "invalid value",
+		})
+	}
+
+	if cfg.SharedDir != "" && filepath.Clean(cfg.SharedDir) == filepath.Clean(cfg.DownloadDir) {
+		errs = append(errs, &ValidationError{
+			Key:    "shared_dir",
+			Value:  cfg.SharedDir,
+			Reason: "must differ from download_dir",
+		})
+	}
+
+	if cfg.DownloadBudgetGB < 0 {
+		errs = append(errs, &ValidationError{
+			Key:    "download_budget_gb",
+			Value:  fmt.Sprint(cfg.DownloadBudgetGB),
+			Reason: "cannot be negative, use 0 for no budget",
+		})
+	}
+
+	if cfg.DownloadingQuotaGB < 0 {
+		errs = append(errs, &ValidationError{
+			Key:    "downloading_quota_gb",
+			Value:  fmt.Sprint(cfg.DownloadingQuotaGB),
+			Reason: "cannot be negative, use 0 for no limit",
+		})
+	}
+
+	if cfg.MaxDownloads < 0 {
+		errs = append(errs, &ValidationError{
+			Key:    "max_downloads",
+			Value:  fmt.Sprint(cfg.MaxDownloads),
+			Reason: "cannot be negative, use 0 for no limit",
+		})
+	}
+
+	if cfg.ExtractWorkers < 0 || cfg.ExtractWorkers > MaxExtractWorkers {
+		errs = append(errs, &ValidationError{
+			Key:    "extract_workers",
+			Value:  fmt.Sprint(cfg.ExtractWorkers),
+			Reason: fmt.Sprintf("must be between 1 and %d, or 0 to tune to the CPU count", MaxExtractWorkers),
+		})
+	}
+
+	if cfg.ExtractBufferMB < 0 || cfg.ExtractBufferMB > MaxExtractBufferMB {
+		errs = append(errs, &ValidationError{
+			Key:    "extract_buffer_mb",
+			Value:  fmt.Sprint(cfg.ExtractBufferMB),
+			Reason: fmt.Sprintf("must be between 1 and %d, or 0 to tune to the available memory", MaxExtractBufferMB),
+		})
+	}
+
+	if cfg.OldBuildsRetentionDays < 0 {
+		errs = append(errs, &ValidationError{
+			Key:    "oldbuilds_retention_days",
+			Value:  fmt.Sprint(cfg.OldBuildsRetentionDays),
+			Reason: "cannot be negative, use 0 to keep old builds",
+		})
+	}
+
+	if cfg.Proxy != "" {
+		if proxyURL, err := url.Parse(cfg.Proxy); err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			errs = append(errs, &ValidationError{
+				Key:    "proxy",
+				Value:  cfg.Proxy,
+				Reason: "not a valid proxy URL, expected e.g. http://proxy:3128",
+			})
+		}
+	}
+
+	validIPVersion := cfg.IPVersion == ""
+	for _, v := range IPVersions {
+		if cfg.IPVersion == v {
+			validIPVersion = true
+		}
+	}
+	if !validIPVersion {
+		errs = append(errs, &ValidationError{
+			Key:      "ip_version",
+			Value:    cfg.IPVersion,
+			Accepted: IPVersions,
+			Reason:   "invalid value",
+		})
+	}
+
+	validDensity := cfg.Density == ""
+	for _, d := range Densities {
+		if cfg.Density == d {
+			validDensity = true
+		}
+	}
+	if !validDensity {
+		errs = append(errs, &ValidationError{
+			Key:      "density",
+			Value:    cfg.Density,
+			Accepted: Densities,
+			Reason:   "invalid value",
+		})
+	}
+
+	if cfg.DirTemplate != "" {
+		if err := model.CheckDirTemplate(cfg.DirTemplate); err != nil {
+			errs = append(errs, &ValidationError{
+				Key:    "dir_template",
+				Value:  cfg.DirTemplate,
+				Reason: err.Error(),
+			})
+		}
+	}
+
+	if cfg.ConflictPolicy != "" && !slices.Contains(ConflictPolicies, cfg.ConflictPolicy) {
+		errs = append(errs, &ValidationError{
+			Key:      "conflict_policy",
+			Value:    cfg.ConflictPolicy,
+			Accepted: ConflictPolicies,
+			Reason:   "invalid value",
+		})
+	}
+
+	if cfg.ArchivePreference != "" && !slices.Contains(ArchivePreferences, cfg.ArchivePreference) {
+		errs = append(errs, &ValidationError{
+			Key:      "archive_preference",
+			Value:    cfg.ArchivePreference,
+			Accepted: ArchivePreferences,
+			Reason:   "invalid value",
+		})
+	}
+
+	if cfg.UpdatePolicy != "" && !slices.Contains(model.UpdatePolicies, cfg.UpdatePolicy) {
+		errs = append(errs, &ValidationError{
+			Key:      "update_policy",
+			Value:    cfg.UpdatePolicy,
+			Accepted: model.UpdatePolicies,
+			Reason:   "invalid value",
+		})
+	}
+
+	validProgressStyle := cfg.ProgressStyle == ""
+	for _, style := range ProgressStyles {
+		if cfg.ProgressStyle == style {
+			validProgressStyle = true
+		}
+	}
+	if !validProgressStyle {
+		errs = append(errs, &ValidationError{
+			Key:      "progress_style",
+			Value:    cfg.ProgressStyle,
+			Accepted: ProgressStyles,
+			Reason:   "invalid value",
+		})
+	}
+
+	if cfg.LowBandwidthMode != "" && !slices.Contains(LowBandwidthModes, cfg.LowBandwidthMode) {
+		errs = append(errs, &ValidationError{
+			Key:      "low_bandwidth",
+			Value:    cfg.LowBandwidthMode,
+			Accepted: LowBandwidthModes,
+			Reason:   "invalid value",
+		})
+	}
+
+	if cfg.RefreshIntervalMS < 0 {
+		errs = append(errs, &ValidationError{
+			Key:    "refresh_interval_ms",
+			Value:  fmt.Sprint(cfg.RefreshIntervalMS),
+			Reason: "cannot be negative, use 0 for the default",
+		})
+	}
+
+	if cfg.DNSServer != "" {
+		host, _, err := net.SplitHostPort(dnsServerAddr(cfg.DNSServer))
+		if err != nil || net.ParseIP(host) == nil {
+			errs = append(errs, &ValidationError{
+				Key:    "dns_server",
+				Value:  cfg.DNSServer,
+				Reason: "not a valid DNS server, expected an IP address with optional port, e.g. 1.1.1.1:53",
+			})
+		}
+	}
+
+	for buildType := range cfg.VersionFilters {
+		if !slices.Contains(BuildTypes, buildType) {
+			errs = append(errs, &ValidationError{
+				Key:      "version_filters." + buildType,
+				Accepted: BuildTypes,
+				Reason:   "unknown build type",
+			})
+		}
+	}
+
+	for host, ip := range cfg.HostOverrides {
+		if net.ParseIP(ip) == nil {
+			errs = append(errs, &ValidationError{
+				Key:    "host_overrides",
+				Value:  host + " = " + ip,
+				Reason: "not a valid IP address",
+			})
+		}
+	}
+
+	for source, tool := range cfg.Sandbox {
+		if !slices.Contains(SandboxSources, source) {
+			errs = append(errs, &ValidationError{
+				Key:      "sandbox." + source,
+				Accepted: SandboxSources,
+				Reason:   "unknown build source",
+			})
+		} else if !slices.Contains(SandboxTools, tool) {
+			errs = append(errs, &ValidationError{
+				Key:      "sandbox." + source,
+				Value:    tool,
+				Accepted: SandboxTools,
+				Reason:   "invalid value",
+			})
+		}
+	}
+
+	profiles := cfg.LaunchProfileNames()
+	for _, name := range profiles {
+		if name == "" || name == NoLaunchProfile {
+			errs = append(errs, &ValidationError{
+				Key:    "launch_profiles",
+				Value:  name,
+				Reason: "reserved profile name",
+			})
+		}
+		for _, key := range slices.Sorted(maps.Keys(cfg.LaunchProfiles[name].Env)) {
+			if key == "" || strings.ContainsAny(key, "= ") {
+				errs = append(errs, &ValidationError{
+					Key:    "launch_profiles." + name + ".env",
+					Value:  key,
+					Reason: "not a valid environment variable name",
+				})
+			}
+		}
+	}
+	if cfg.LaunchProfile != "" && !slices.Contains(profiles, cfg.LaunchProfile) {
+		errs = append(errs, &ValidationError{
+			Key:      "launch_profile",
+			Value:    cfg.LaunchProfile,
+			Accepted: profiles,
+			Reason:   "not one of the launch profiles",
+		})
+	}
+
+	columnNames := make(map[string]bool)
+	for i, column := range cfg.CustomColumns {
+		key := fmt.Sprintf("custom_columns[%d]", i)
+		switch {
+		case strings.TrimSpace(column.Name) == "":
+			errs = append(errs, &ValidationError{
+				Key:    key + ".name",
+				Reason: "cannot be empty",
+			})
+		case columnNames[column.Name]:
+			errs = append(errs, &ValidationError{
+				Key:    key + ".name",
+				Value:  column.Name,
+				Reason: "another custom column has this name",
+			})
+		}
+		columnNames[column.Name] = true
+		if strings.TrimSpace(column.Command) == "" {
+			errs = append(errs, &ValidationError{
+				Key:    key + ".command",
+				Reason: "cannot be empty, the command prints the values of the column",
+			})
+		}
+	}
+
+	for _, mirror := range cfg.Mirrors {
+		if mirrorURL, err := url.Parse(mirror); err != nil || (mirrorURL.Scheme != "http" && mirrorURL.Scheme != "https") || mirrorURL.Host == "" {
+			errs = append(errs, &ValidationError{
+				Key:    "mirrors",
+				Value:  mirror,
+				Reason: "not a valid mirror URL, expected e.g. https://mirror.example.org",
+			})
+		}
+	}
+
+	if cfg.DownloadSource != "" && !slices.Contains(cfg.Mirrors, cfg.DownloadSource) {
+		errs = append(errs, &ValidationError{
+			Key:      "download_source",
+			Value:    cfg.DownloadSource,
+			Accepted: cfg.Mirrors,
+			Reason:   "not one of the mirrors",
+		})
+	}
+
+	extractPatterns := []struct {
+		key      string
+		patterns []string
+	}{{"extract_include", cfg.ExtractInclude}, {"extract_exclude", cfg.ExtractExclude}}
+	for _, list := range extractPatterns {
+		for _, pattern := range list.patterns {
+			if _, err := path.Match(pattern, ""); err != nil || pattern == "" || path.IsAbs(pattern) || strings.HasPrefix(pattern, "..") {
+				errs = append(errs, &ValidationError{
+					Key:    list.key,
+					Value:  pattern,
+					Reason: "not a valid path pattern, expected a relative glob like */python/lib/*/test",
+				})
+			}
+		}
+	}
+
+	for _, term := range cfg.Terminals {
+		if strings.TrimSpace(term) == "" {
+			errs = append(errs, &ValidationError{
+				Key:    "terminals",
+				Value:  term,
+				Reason: "empty terminal, expected a name like kitty or a command line like \"wezterm start --\"",
+			})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// keyLine returns the line on which a top-level key is assigned, or 0 if it isn't found
+func keyLine(data, key string) int {
+	re := regexp.MustCompile(`^\s*"?` + regexp.QuoteMeta(key) + `"?\s*=`)
+	for i, line := range strings.Split(data, "\n") {
+		if re.MatchString(line) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// checkFile validates a decoded config file, locating each problem in the file
+func checkFile(path, data string, md toml.MetaData, cfg Config) ValidationErrors {
+	var errs ValidationErrors
+	for _, key := range md.Undecoded() {
+		errs = append(errs, &ValidationError{
+			Key:      key.String(),
+			Accepted: knownKeys(),
+			Reason:   "unknown key",
+		})
+	}
+
+	var valueErrs ValidationErrors
+	if errors.As(Validate(cfg), &valueErrs) {
+		errs = append(errs, valueErrs...)
+	}
+
+	for _, e := range errs {
+		e.File = path
+		e.Line = keyLine(data, e.Key)
+	}
+	return errs
+}
+
+// decodeError turns a TOML decoding error into a ValidationErrors
+func decodeError(path string, err error) error {
+	var parseErr toml.ParseError
+	if errors.As(err, &parseErr) {
+		return ValidationErrors{{
+			File:   path,
+			Line:   parseErr.Position.Line,
+			Key:    parseErr.LastKey,
+			Reason: parseErr.Message,
+		}}
+	}
+	return ValidationErrors{{File: path, Reason: err.Error()}}
+}
@@ -0,0 +1,107 @@
+package digest
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// sessionFilename is the file in the state directory holding when the launcher last ran
+const sessionFilename = "session.json"
+
+// Interval is how long the launcher has to be unused before the digest is shown at startup
+const Interval = 24 * time.Hour
+
+// Session records when the launcher last ran
+type Session struct {
+	LastRun time.Time `json:"last_run"`
+}
+
+// Due reports whether the digest of the builds published since the last session is shown at now.
+// There is nothing to catch up on before the first session.
+func (s *Session) Due(now time.Time) bool {
+	return !s.LastRun.IsZero() && now.Sub(s.LastRun) > Interval
+}
+
+// GetSessionPath returns the full path to the session file in the state directory.
+func GetSessionPath() (string, error) {
+	stateDir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, sessionFilename), nil
+}
+
+// LoadSession reads the last session from disk. A missing file yields a session that never ran.
+func LoadSession() (*Session, error) {
+	path, err := GetSessionPath()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Session{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the session to disk.
+func (s *Session) Save() error {
+	path, err := GetSessionPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("could not create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Group is the builds of a series published since the last session
+type Group struct {
+	BuildType string
+	Series    string
+	Builds    []model.BlenderBuild // Newest version first
+}
+
+// Build lists the builds of a build type published after since, grouped by series, newest series first
+func Build(buildType string, builds []model.BlenderBuild, since time.Time) []Group {
+	var published []model.BlenderBuild
+	for _, b := range builds {
+		if b.BuildDate.Time().After(since) {
+			published = append(published, b)
+		}
+	}
+	published = model.GroupBuildsBySeries(model.SortBuilds(published, 0, true))
+
+	var groups []Group
+	for _, b := range published {
+		series := model.BuildSeries(b.Version)
+		if len(groups) == 0 || groups[len(groups)-1].Series != series {
+			groups = append(groups, Group{BuildType: buildType, Series: series})
+		}
+		last := &groups[len(groups)-1]
+		last.Builds = append(last.Builds, b)
+	}
+	return groups
+}
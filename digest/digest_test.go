@@ -0,0 +1,94 @@
+package digest
+
+import (
+	"TUI-Blender-Launcher/model"
+	"testing"
+	"time"
+)
+
+func TestDue(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		lastRun  time.Time
+		expected bool
+	}{
+		{"first session", time.Time{}, false},
+		{"earlier today", now.Add(-3 * time.Hour), false},
+		{"back from a holiday", now.AddDate(0, 0, -12), true},
+	}
+	for _, tc := range testCases {
+		s := &Session{LastRun: tc.lastRun}
+		if got := s.Due(now); got != tc.expected {
+			t.Errorf("%s: expected due %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	since := time.Date(2026, 10, 4, 18, 0, 0, 0, time.UTC)
+	build := func(version, branch string, publishedAfter time.Duration) model.BlenderBuild {
+		return model.BlenderBuild{Version: version, Branch: branch, BuildDate: model.Timestamp(since.Add(publishedAfter))}
+	}
+	builds := []model.BlenderBuild{
+		build("4.2.3", "v42", 2*time.Hour),
+		build("4.4.0", "main", 24*time.Hour),
+		build("4.3.1", "v43", -time.Hour), // Published before the last session
+		build("4.2.4", "v42", 48*time.Hour),
+		build("4.3.2", "v43", 72*time.Hour),
+	}
+
+	groups := Build("daily", builds, since)
+	expected := []struct {
+		series   string
+		versions []string
+	}{
+		{"4.4", []string{"4.4.0"}},
+		{"4.3", []string{"4.3.2"}},
+		{"4.2", []string{"4.2.4", "4.2.3"}},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("Expected %d groups, got %+v", len(expected), groups)
+	}
+	for i, want := range expected {
+		group := groups[i]
+		if group.Series != want.series || group.BuildType != "daily" || len(group.Builds) != len(want.versions) {
+			t.Errorf("Group %d: expected daily %s with %v, got %s %s with %d builds", i, want.series, want.versions, group.BuildType, group.Series, len(group.Builds))
+			continue
+		}
+		for j, version := range want.versions {
+			if group.Builds[j].Version != version {
+				t.Errorf("Group %s: expected %s at %d, got %s", want.series, version, j, group.Builds[j].Version)
+			}
+		}
+	}
+
+	if groups := Build("daily", builds, since.AddDate(0, 0, 5)); len(groups) != 0 {
+		t.Errorf("Expected no groups without new builds, got %+v", groups)
+	}
+}
+
+func TestLoadSaveSession(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	s, err := LoadSession()
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, got: %v", err)
+	}
+	if !s.LastRun.IsZero() {
+		t.Fatalf("Expected a session that never ran, got %v", s.LastRun)
+	}
+
+	s.LastRun = time.Date(2026, 10, 4, 18, 0, 0, 0, time.UTC)
+	if err := s.Save(); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	loaded, err := LoadSession()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if !loaded.LastRun.Equal(s.LastRun) {
+		t.Errorf("Expected last run %v, got %v", s.LastRun, loaded.LastRun)
+	}
+}
@@ -0,0 +1,270 @@
+// Package doctor runs headless diagnostics of the launcher setup and prints a report
+// that can be attached to bug reports.
+package doctor
+
+import (
+	"TUI-Blender-Launcher/api"
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/launch"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// minFreeSpace is the free space below which installing a build is likely to fail
+const minFreeSpace = 2 << 30
+
+// platformArchives maps an OS to the archive format the builder publishes for it
+var platformArchives = map[string]string{
+	"linux":   ".tar.xz",
+	"windows": ".zip",
+	"darwin":  ".dmg",
+}
+
+// Status is the outcome of a single check
+type Status int
+
+const (
+	StatusPass Status = iota
+	StatusWarn
+	StatusFail
+)
+
+// String returns the label printed in the report
+func (s Status) String() string {
+	switch s {
+	case StatusPass:
+		return "PASS"
+	case StatusWarn:
+		return "WARN"
+	default:
+		return "FAIL"
+	}
+}
+
+// Result is the outcome of a single check with a one-line explanation
+type Result struct {
+	Name   string
+	Status Status
+	Detail string
+}
+
+// Run performs all checks. Checks that need a usable config fall back to the defaults
+// when the config file is invalid, so the report is as complete as possible.
+func Run() []Result {
+	cfgResult, cfg := checkConfig()
+	results := []Result{cfgResult}
+
+	// Requests should go out the same way as in the TUI
+	if err := config.ApplyProxy(cfg); err != nil {
+		results = append(results, Result{"Proxy", StatusFail, err.Error()})
+	}
+	if err := config.ApplyNetwork(cfg); err != nil {
+		results = append(results, Result{"Network settings", StatusFail, err.Error()})
+	}
+
+	results = append(results,
+		checkDownloadDir(cfg.DownloadDir),
+		checkFreeSpace(cfg.DownloadDir),
+		checkFilesystem(cfg.DownloadDir),
+		checkNetwork(),
+		checkExtraction(runtime.GOOS),
+		checkLeftovers(cfg.DownloadDir),
+		checkJournal(cfg.DownloadDir),
+	)
+	if runtime.GOOS == "linux" {
+		results = append(results, checkOpener(), checkSandbox(launch.DetectSandbox()))
+	}
+	return results
+}
+
+// Report prints the results and a summary. It returns false when any check failed.
+func Report(w io.Writer, results []Result) bool {
+	fmt.Fprintf(w, "TUI Blender Launcher doctor (%s/%s, %s)\n\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+
+	counts := make(map[Status]int)
+	for _, r := range results {
+		counts[r.Status]++
+		fmt.Fprintf(w, "[%s] %s: %s\n", r.Status, r.Name, r.Detail)
+	}
+	fmt.Fprintf(w, "\n%d passed, %d warnings, %d failed\n", counts[StatusPass], counts[StatusWarn], counts[StatusFail])
+	return counts[StatusFail] == 0
+}
+
+// checkConfig loads and validates the config file, returning the config to use for the other checks
+func checkConfig() (Result, config.Config) {
+	const name = "Config"
+	cfgPath, err := config.GetConfigPath()
+	if err != nil {
+		return Result{name, StatusFail, err.Error()}, config.DefaultConfig()
+	}
+	if _, err := os.Stat(cfgPath); os.IsNotExist(err) {
+		return Result{name, StatusWarn, fmt.Sprintf("%s does not exist, defaults are used until the first start", cfgPath)}, config.DefaultConfig()
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		var errs config.ValidationErrors
+		if errors.As(err, &errs) {
+			problems := make([]string, len(errs))
+			for i, e := range errs {
+				problems[i] = e.Error()
+			}
+			return Result{name, StatusFail, strings.Join(problems, "; ")}, config.DefaultConfig()
+		}
+		return Result{name, StatusFail, err.Error()}, config.DefaultConfig()
+	}
+	return Result{name, StatusPass, cfgPath + " is valid"}, cfg
+}
+
+// checkDownloadDir checks that builds can be written to the download directory
+func checkDownloadDir(dir string) Result {
+	const name = "Download directory"
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return Result{name, StatusWarn, dir + " does not exist yet, it is created on the first download"}
+	}
+	if err != nil {
+		return Result{name, StatusFail, err.Error()}
+	}
+	if !info.IsDir() {
+		return Result{name, StatusFail, dir + " is not a directory"}
+	}
+
+	probe, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return Result{name, StatusFail, fmt.Sprintf("%s is not writable: %v", dir, err)}
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return Result{name, StatusPass, dir + " is writable"}
+}
+
+// checkFreeSpace checks that the download directory's filesystem has room for a build
+func checkFreeSpace(dir string) Result {
+	const name = "Free space"
+	// The directory may not exist yet; check the closest existing parent
+	for {
+		if _, err := os.Stat(dir); err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	free, err := local.FreeSpace(dir)
+	if err != nil {
+		return Result{name, StatusWarn, err.Error()}
+	}
+	detail := fmt.Sprintf("%s available in %s", model.FormatByteSize(int64(free)), dir)
+	if free < minFreeSpace {
+		return Result{name, StatusWarn, detail + ", a build needs about " + model.FormatByteSize(minFreeSpace)}
+	}
+	return Result{name, StatusPass, detail}
+}
+
+// checkFilesystem warns when the download directory is on a network share
+func checkFilesystem(dir string) Result {
+	const name = "Filesystem"
+	if warning := download.DetectFilesystem(dir).Warning(dir); warning != "" {
+		return Result{name, StatusWarn, warning}
+	}
+	return Result{name, StatusPass, "local filesystem"}
+}
+
+// checkNetwork checks that the builder API can be reached
+func checkNetwork() Result {
+	const name = "Builder API"
+	if err := api.NewAPI().CheckConnectivity(); err != nil {
+		return Result{name, StatusFail, err.Error()}
+	}
+	return Result{name, StatusPass, "builder.blender.org is reachable"}
+}
+
+// checkExtraction checks that the archives published for goos can be extracted
+func checkExtraction(goos string) Result {
+	const name = "Extraction"
+	format, ok := platformArchives[goos]
+	if !ok {
+		return Result{name, StatusFail, "no builds are published for " + goos}
+	}
+	if !download.SupportedArchive("blender" + format) {
+		return Result{name, StatusFail, fmt.Sprintf("%s archives used on %s can't be extracted, supported: %s",
+			format, goos, strings.Join(download.ArchiveFormats, ", "))}
+	}
+	return Result{name, StatusPass, format + " archives are extracted without external tools"}
+}
+
+// checkLeftovers looks for partial downloads left behind by interrupted sessions
+func checkLeftovers(dir string) Result {
+	const name = "Partial downloads"
+	downloadingDir := filepath.Join(dir, download.DownloadingDir)
+	entries, err := os.ReadDir(downloadingDir)
+	if err != nil || len(entries) == 0 {
+		return Result{name, StatusPass, "none"}
+	}
+
+	var size int64
+	for _, entry := range entries {
+		if entry.IsDir() {
+			if s, err := download.DirSize(filepath.Join(downloadingDir, entry.Name())); err == nil {
+				size += s
+			}
+		} else if info, err := entry.Info(); err == nil {
+			size += info.Size()
+		}
+	}
+	return Result{name, StatusWarn, fmt.Sprintf("%d stale item(s) using %s in %s, safe to delete unless a download is running",
+		len(entries), model.FormatByteSize(size), downloadingDir)}
+}
+
+// checkJournal looks for installs interrupted by a crash
+func checkJournal(dir string) Result {
+	const name = "Interrupted installs"
+	ops, err := download.ReadJournal(dir)
+	if err != nil {
+		return Result{name, StatusFail, err.Error()}
+	}
+	if len(ops) == 0 {
+		return Result{name, StatusPass, "none"}
+	}
+	versions := make([]string, len(ops))
+	for i, op := range ops {
+		versions[i] = op.Version
+	}
+	return Result{name, StatusWarn, fmt.Sprintf("%s, resolved at the next start of the launcher", strings.Join(versions, ", "))}
+}
+
+// checkSandbox reports the Flatpak or Snap sandbox and whether builds can be launched on the host
+func checkSandbox(sandbox launch.Sandbox) Result {
+	const name = "Sandbox"
+	switch sandbox {
+	case launch.SandboxFlatpak:
+		if exec.Command("flatpak-spawn", "--host", "true").Run() != nil {
+			return Result{name, StatusFail, "Flatpak without host access, grant --talk-name=org.freedesktop.Flatpak to launch builds"}
+		}
+		return Result{name, StatusPass, "Flatpak, builds are launched on the host with flatpak-spawn"}
+	case launch.SandboxSnap:
+		return Result{name, StatusWarn, "Snap, launching builds needs classic confinement"}
+	default:
+		return Result{name, StatusPass, "none"}
+	}
+}
+
+// checkOpener checks for the tool used to open directories and web pages on Linux
+func checkOpener() Result {
+	const name = "Desktop integration"
+	for _, tool := range []string{"xdg-open", "gnome-open", "kde-open"} {
+		if path, err := exec.LookPath(tool); err == nil {
+			return Result{name, StatusPass, "found " + path}
+		}
+	}
+	return Result{name, StatusWarn, "xdg-open not found, opening directories and web pages won't work"}
+}
@@ -0,0 +1,82 @@
+package doctor
+
+import (
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/launch"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckDownloadDir(t *testing.T) {
+	dir := t.TempDir()
+	if r := checkDownloadDir(dir); r.Status != StatusPass {
+		t.Errorf("Expected writable directory to pass, got %s: %s", r.Status, r.Detail)
+	}
+	if r := checkDownloadDir(filepath.Join(dir, "missing")); r.Status != StatusWarn {
+		t.Errorf("Expected missing directory to warn, got %s: %s", r.Status, r.Detail)
+	}
+
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if r := checkDownloadDir(file); r.Status != StatusFail {
+		t.Errorf("Expected a file to fail, got %s: %s", r.Status, r.Detail)
+	}
+}
+
+func TestCheckSandbox(t *testing.T) {
+	if r := checkSandbox(launch.SandboxNone); r.Status != StatusPass {
+		t.Errorf("Expected no sandbox to pass, got %s: %s", r.Status, r.Detail)
+	}
+	if r := checkSandbox(launch.SandboxSnap); r.Status != StatusWarn {
+		t.Errorf("Expected Snap to warn, got %s: %s", r.Status, r.Detail)
+	}
+}
+
+func TestCheckLeftovers(t *testing.T) {
+	dir := t.TempDir()
+	if r := checkLeftovers(dir); r.Status != StatusPass {
+		t.Errorf("Expected no leftovers to pass, got %s: %s", r.Status, r.Detail)
+	}
+
+	downloadingDir := filepath.Join(dir, download.DownloadingDir)
+	if err := os.MkdirAll(downloadingDir, 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", downloadingDir, err)
+	}
+	if err := os.WriteFile(filepath.Join(downloadingDir, "blender.tar.xz"), make([]byte, 2048), 0644); err != nil {
+		t.Fatalf("Failed to write partial download: %v", err)
+	}
+	r := checkLeftovers(dir)
+	if r.Status != StatusWarn || !strings.Contains(r.Detail, "1 stale item(s) using 2.0KB") {
+		t.Errorf("Expected a warning about 1 item of 2.0KB, got %s: %s", r.Status, r.Detail)
+	}
+}
+
+func TestCheckExtraction(t *testing.T) {
+	if r := checkExtraction("linux"); r.Status != StatusPass {
+		t.Errorf("Expected tar.xz to be supported, got %s: %s", r.Status, r.Detail)
+	}
+	if r := checkExtraction("darwin"); r.Status != StatusFail {
+		t.Errorf("Expected dmg to be unsupported, got %s: %s", r.Status, r.Detail)
+	}
+}
+
+func TestReport(t *testing.T) {
+	var out bytes.Buffer
+	ok := Report(&out, []Result{
+		{"Config", StatusPass, "valid"},
+		{"Builder API", StatusFail, "unreachable"},
+	})
+	if ok {
+		t.Error("Report should return false when a check failed")
+	}
+	for _, want := range []string{"[PASS] Config: valid", "[FAIL] Builder API: unreachable", "1 passed, 0 warnings, 1 failed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Report output is missing %q:\n%s", want, out.String())
+		}
+	}
+}
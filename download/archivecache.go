@@ -0,0 +1,159 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// archiveMetaSuffix is appended to the name of a cached archive for the file holding its build metadata
+const archiveMetaSuffix = ".json"
+
+// CachedArchive is a build archive kept in the archive cache after it was installed, see keep_archives
+type CachedArchive struct {
+	Build  model.BlenderBuild // Build the archive was downloaded for, with its checksum
+	Path   string
+	Size   int64
+	Cached time.Time
+}
+
+// CacheArchive moves a downloaded archive into cacheDir, next to a file with the metadata of its build
+func CacheArchive(build model.BlenderBuild, archivePath, cacheDir string) (CachedArchive, error) {
+	if err := mkdirAll(cacheDir, 0750); err != nil {
+		return CachedArchive{}, fmt.Errorf("failed to create archive cache: %w", err)
+	}
+	cachedPath := filepath.Join(cacheDir, filepath.Base(archivePath))
+	// The cache may be on another filesystem than the download directory
+	if err := rename(archivePath, cachedPath); err != nil {
+		if err := copyFile(archivePath, cachedPath, 0644); err != nil {
+			os.Remove(cachedPath)
+			return CachedArchive{}, fmt.Errorf("failed to move archive to the cache: %w", err)
+		}
+		os.Remove(archivePath)
+	}
+
+	data, err := json.MarshalIndent(build, "", "  ")
+	if err != nil {
+		return CachedArchive{}, fmt.Errorf("failed to marshal build metadata: %w", err)
+	}
+	if err := writeFile(cachedPath+archiveMetaSuffix, data, 0644); err != nil {
+		return CachedArchive{}, fmt.Errorf("failed to write archive metadata: %w", err)
+	}
+	info, err := os.Stat(cachedPath)
+	if err != nil {
+		return CachedArchive{}, err
+	}
+	return CachedArchive{Build: build, Path: cachedPath, Size: info.Size(), Cached: info.ModTime()}, nil
+}
+
+// CachedArchives lists the archives in cacheDir, the most recently cached first.
+// Archives without metadata, e.g. copied there by hand, are left out.
+func CachedArchives(cacheDir string) ([]CachedArchive, error) {
+	entries, err := os.ReadDir(cacheDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read archive cache: %w", err)
+	}
+
+	var archives []CachedArchive
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, archiveMetaSuffix) {
+			continue
+		}
+		path := filepath.Join(cacheDir, strings.TrimSuffix(name, archiveMetaSuffix))
+		info, err := os.Stat(path)
+		if err != nil || !SupportedArchive(path) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cacheDir, name))
+		if err != nil {
+			continue
+		}
+		var build model.BlenderBuild
+		if err := json.Unmarshal(data, &build); err != nil {
+			continue
+		}
+		archives = append(archives, CachedArchive{Build: build, Path: path, Size: info.Size(), Cached: info.ModTime()})
+	}
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].Cached.After(archives[j].Cached)
+	})
+	return archives, nil
+}
+
+// FindCachedArchive returns the cached archive of a build: the one of the same hash when the hash of the build is known,
+// otherwise the most recently cached archive of its version and architecture. ok is false when the cache holds none.
+func FindCachedArchive(cacheDir string, build model.BlenderBuild) (archive CachedArchive, ok bool, err error) {
+	archives, err := CachedArchives(cacheDir)
+	if err != nil {
+		return CachedArchive{}, false, err
+	}
+	for _, cached := range archives {
+		if cached.Build.Version != build.Version || cached.Build.Architecture != build.Architecture {
+			continue
+		}
+		if build.Hash == "" || cached.Build.Hash == build.Hash {
+			return cached, true, nil
+		}
+	}
+	return CachedArchive{}, false, nil
+}
+
+// RemoveCachedArchive deletes an archive and its metadata from the archive cache
+func RemoveCachedArchive(archive CachedArchive) error {
+	if err := os.Remove(archive.Path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete %s: %w", filepath.Base(archive.Path), err)
+	}
+	if err := os.Remove(archive.Path + archiveMetaSuffix); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete metadata of %s: %w", filepath.Base(archive.Path), err)
+	}
+	return nil
+}
+
+// ExportArchive copies a cached archive to destDir, e.g. a USB stick for another machine, and returns the copy.
+// A .sha256 file is written next to it when the checksum is known.
+func ExportArchive(archive CachedArchive, destDir string) (string, error) {
+	name := filepath.Base(archive.Path)
+	dest := filepath.Join(destDir, name)
+	if _, err := os.Stat(dest); err == nil {
+		return "", fmt.Errorf("%s already exists in %s", name, destDir)
+	}
+	if err := copyFile(archive.Path, dest, 0644); err != nil {
+		os.Remove(dest)
+		return "", fmt.Errorf("failed to export %s: %w", name, err)
+	}
+	if archive.Build.SHA256 != "" {
+		sum := fmt.Sprintf("%s  %s\n", archive.Build.SHA256, name)
+		if err := writeFile(dest+".sha256", []byte(sum), 0644); err != nil {
+			return dest, fmt.Errorf("failed to write checksum of %s: %w", name, err)
+		}
+	}
+	return dest, nil
+}
+
+// ErrArchiveDamaged is returned when a cached archive no longer matches the checksum recorded when it was downloaded
+var ErrArchiveDamaged = errors.New("cached archive is damaged")
+
+// InstallFromArchive installs a build from the archive cache like a download would, without the network.
+// The archive is checked against its recorded checksum first and stays in the cache.
+func InstallFromArchive(archive CachedArchive, downloadBaseDir string, progressCb ProgressCallback, phaseCb PhaseFunc, resolve ConflictFunc, cancelCh <-chan struct{}) (string, error) {
+	enterPhase(phaseCb, model.PhaseVerify)
+	if archive.Build.SHA256 != "" {
+		sum, err := fileChecksumProgress(archive.Path, progressCb)
+		if err != nil {
+			return "", err
+		}
+		if sum != archive.Build.SHA256 {
+			return "", fmt.Errorf("%w: %s", ErrArchiveDamaged, filepath.Base(archive.Path))
+		}
+	}
+	return installArchive(archive.Build, archive.Path, downloadBaseDir, progressCb, phaseCb, resolve, cancelCh)
+}
@@ -0,0 +1,54 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/model"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFindCachedArchive(t *testing.T) {
+	cacheDir := t.TempDir()
+	cache := func(name string, build model.BlenderBuild, age time.Duration) {
+		t.Helper()
+		archivePath := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(archivePath, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		archive, err := CacheArchive(build, archivePath, cacheDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		modTime := time.Now().Add(-age)
+		if err := os.Chtimes(archive.Path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cache("blender-4.3.0-old.tar.xz", model.BlenderBuild{Version: "4.3.0", Architecture: "x64", Hash: "aaa"}, 48*time.Hour)
+	cache("blender-4.3.0-new.tar.xz", model.BlenderBuild{Version: "4.3.0", Architecture: "x64", Hash: "bbb"}, time.Hour)
+	cache("blender-4.3.0-arm.tar.xz", model.BlenderBuild{Version: "4.3.0", Architecture: "arm64", Hash: "ccc"}, 0)
+
+	tests := []struct {
+		name  string
+		build model.BlenderBuild
+		want  string
+	}{
+		{"same hash", model.BlenderBuild{Version: "4.3.0", Architecture: "x64", Hash: "aaa"}, "blender-4.3.0-old.tar.xz"},
+		{"other hash", model.BlenderBuild{Version: "4.3.0", Architecture: "x64", Hash: "ddd"}, ""},
+		{"no hash, most recent", model.BlenderBuild{Version: "4.3.0", Architecture: "x64"}, "blender-4.3.0-new.tar.xz"},
+		{"architecture", model.BlenderBuild{Version: "4.3.0", Architecture: "arm64"}, "blender-4.3.0-arm.tar.xz"},
+		{"not cached", model.BlenderBuild{Version: "4.4.0", Architecture: "x64"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive, ok, err := FindCachedArchive(cacheDir, tt.build)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := filepath.Base(archive.Path); ok != (tt.want != "") || (ok && got != tt.want) {
+				t.Errorf("FindCachedArchive = %s, %v; expected %q", got, ok, tt.want)
+			}
+		})
+	}
+}
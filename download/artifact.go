@@ -0,0 +1,32 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DownloadArtifact downloads a companion file of a build into its install directory
+// and returns the path of the saved file. Mirrors are tried like for the build itself.
+func DownloadArtifact(artifact model.Artifact, installDir string, cancelCh <-chan struct{}) (string, error) {
+	name := filepath.Base(artifact.FileName)
+	if name == "." || name == string(filepath.Separator) {
+		return "", fmt.Errorf("invalid artifact file name %q", artifact.FileName)
+	}
+	destPath := filepath.Join(installDir, name)
+
+	// Download next to the target so an interrupted download never looks complete
+	partPath := destPath + ".part"
+	cfg := config.GetConfigInstance()
+	if _, err := downloadFromMirrors(artifact.URL, cfg.Mirrors, cfg.DownloadSource, partPath, nil, cancelCh); err != nil {
+		os.Remove(partPath)
+		return "", err
+	}
+	if err := os.Rename(partPath, destPath); err != nil {
+		os.Remove(partPath)
+		return "", fmt.Errorf("failed to save %s: %w", name, err)
+	}
+	return destPath, nil
+}
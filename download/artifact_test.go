@@ -0,0 +1,45 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/model"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDownloadArtifact(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/blender-4.2.0-debug.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("symbols"))
+	}))
+	defer server.Close()
+
+	installDir := t.TempDir()
+	artifact := model.Artifact{FileName: "blender-4.2.0-debug.zip", URL: server.URL + "/blender-4.2.0-debug.zip"}
+	path, err := DownloadArtifact(artifact, installDir, nil)
+	if err != nil {
+		t.Fatalf("DownloadArtifact failed: %v", err)
+	}
+	if path != filepath.Join(installDir, "blender-4.2.0-debug.zip") {
+		t.Errorf("Unexpected artifact path %s", path)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "symbols" {
+		t.Errorf("Unexpected artifact content %q (%v)", data, err)
+	}
+
+	// A failed download leaves nothing behind
+	artifact.URL = server.URL + "/missing.zip"
+	artifact.FileName = "missing.zip"
+	if _, err := DownloadArtifact(artifact, installDir, nil); err == nil {
+		t.Error("Expected an error for a missing artifact")
+	}
+	entries, _ := os.ReadDir(installDir)
+	if len(entries) != 1 {
+		t.Errorf("Expected only the downloaded artifact in the install directory, got %d entries", len(entries))
+	}
+}
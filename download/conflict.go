@@ -0,0 +1,58 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/config"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrTargetExists is returned when an install is aborted because its directory already exists
+var ErrTargetExists = errors.New("directory already exists")
+
+// Resolution is what to do with a directory in the way of an install
+type Resolution int
+
+const (
+	ResolveOverwrite Resolution = iota // Move the directory to .oldbuilds, like a replaced build
+	ResolveKeepBoth                    // Install next to it under a numbered name
+	ResolveAbort                       // Don't install the build
+)
+
+// ConflictFunc decides what to do when dir, the directory a build extracts to, exists but holds no
+// build installed by the launcher, e.g. a manual copy. It may block, e.g. to ask the user.
+type ConflictFunc func(dir string) Resolution
+
+// ConflictPolicy returns the ConflictFunc applying a conflict_policy without asking.
+// "ask" aborts, it is for installs nobody can answer for.
+func ConflictPolicy(policy string) ConflictFunc {
+	return func(string) Resolution {
+		switch policy {
+		case config.ConflictOverwrite:
+			return ResolveOverwrite
+		case config.ConflictKeepBoth:
+			return ResolveKeepBoth
+		}
+		return ResolveAbort
+	}
+}
+
+// unmanagedDir reports whether dir exists without a build installed by the launcher, which saves version.json
+func unmanagedDir(dir string) bool {
+	if _, err := os.Stat(dir); err != nil {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, versionMetaFilename))
+	return os.IsNotExist(err)
+}
+
+// freeDirName returns dir with the first numbered suffix (-2, -3, ...) that doesn't exist yet
+func freeDirName(dir string) string {
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d", dir, n)
+		if _, err := os.Lstat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/config"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUnmanagedDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "blender-4.3.0")
+	if unmanagedDir(dir) {
+		t.Error("A missing directory is no conflict")
+	}
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !unmanagedDir(dir) {
+		t.Error("Expected a directory without version.json to be unmanaged")
+	}
+	if err := os.WriteFile(filepath.Join(dir, versionMetaFilename), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if unmanagedDir(dir) {
+		t.Error("Expected a directory with version.json to be installed by the launcher")
+	}
+}
+
+func TestFreeDirName(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "blender-4.3.0")
+	if got := freeDirName(dir); got != dir+"-2" {
+		t.Errorf("Expected %s-2, got %s", dir, got)
+	}
+	if err := os.Mkdir(dir+"-2", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got := freeDirName(dir); got != dir+"-3" {
+		t.Errorf("Expected %s-3, got %s", dir, got)
+	}
+}
+
+func TestConflictPolicy(t *testing.T) {
+	for policy, want := range map[string]Resolution{
+		config.ConflictOverwrite: ResolveOverwrite,
+		config.ConflictKeepBoth:  ResolveKeepBoth,
+		config.ConflictAbort:     ResolveAbort,
+		config.ConflictAsk:       ResolveAbort,
+	} {
+		if got := ConflictPolicy(policy)("dir"); got != want {
+			t.Errorf("Policy %q: expected %d, got %d", policy, want, got)
+		}
+	}
+}
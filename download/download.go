@@ -23,6 +23,23 @@ import (
 
 const DownloadingDir = ".downloading"
 const OldBuildsDir = ".oldbuilds"
+const TrashDir = ".trash" // Deleted directories kept until the launcher exits, so deleting them can be undone
+
+// ArchiveFormats lists the archive file suffixes that can be extracted
+var ArchiveFormats = []string{".tar.xz", ".zip", ".msix"}
+
+// SupportedArchive reports whether an archive file can be extracted
+func SupportedArchive(fileName string) bool {
+	if strings.HasSuffix(fileName, ".msi") {
+		return msiSupported
+	}
+	for _, suffix := range ArchiveFormats {
+		if strings.HasSuffix(fileName, suffix) {
+			return true
+		}
+	}
+	return false
+}
 
 // Error constants
 var ErrCancelled = errors.New("operation cancelled")
@@ -35,6 +52,16 @@ const versionMetaFilename = "version.json"
 // It receives bytes downloaded and total file size.
 type ProgressCallback func(downloadedBytes, totalBytes int64)
 
+// PhaseFunc is told when an install enters its next phase; the progress callback then reports the progress of that phase
+type PhaseFunc func(phase model.InstallPhase)
+
+// enterPhase reports the next phase of an install to phaseCb, which may be nil
+func enterPhase(phaseCb PhaseFunc, phase model.InstallPhase) {
+	if phaseCb != nil {
+		phaseCb(phase)
+	}
+}
+
 // ExtractionProgressCallback represents a callback used to report extraction progress.
 // Since we can't know the total size up front, we use a percentage (0.0-1.0) estimate.
 type ExtractionProgressCallback func(estimatedProgress float64)
@@ -61,7 +88,9 @@ func downloadFile(url string, destFilePath string, progressCb ProgressCallback,
 	}
 
 	// Set headers
-	req.HTTPRequest.Header.Set("X-Download-ID", config.GetConfigInstance().UUID)
+	if cfg := config.GetConfigInstance(); cfg.SendDownloadID && cfg.UUID != "" {
+		req.HTTPRequest.Header.Set("X-Download-ID", cfg.UUID)
+	}
 	req.HTTPRequest.Header.Set("User-Agent", "TUI-Blender-Launcher")
 
 	// Start download
@@ -96,8 +125,10 @@ func (r *CancelableReader) Read(p []byte) (n int, err error) {
 	}
 }
 
-// extractTarXz extracts a .tar.xz archive with progress updates.
-func extractTarXz(archivePath, destDir string, progressCb ExtractionProgressCallback, cancelCh <-chan struct{}) error {
+// extractTarXz extracts a .tar.xz archive with progress updates, leaving out the entries filter skips.
+// Symlinks the filesystem can't hold are collected in links to be materialized afterwards.
+// Files are written by tuning.Workers in parallel through buffers of tuning.BufferSize.
+func extractTarXz(archivePath, destDir string, filter extractFilter, tuning ExtractTuning, links *linkFallback, progressCb ExtractionProgressCallback, cancelCh <-chan struct{}) error {
 	// Get file info to calculate rough progress based on archive size
 	fileInfo, err := os.Stat(archivePath)
 	if err != nil {
@@ -111,8 +142,7 @@ func extractTarXz(archivePath, destDir string, progressCb ExtractionProgressCall
 	}
 	defer file.Close()
 
-	// Increase buffer size for better performance
-	const bufferSize = 4 * 1024 * 1024 // 4MB buffer for better throughput
+	bufferSize := tuning.BufferSize
 	bufferedFile := bufio.NewReaderSize(file, bufferSize)
 
 	// Create a reader that will track read progress
@@ -143,10 +173,10 @@ func extractTarXz(archivePath, destDir string, progressCb ExtractionProgressCall
 		progressCb(0.0)
 	}
 
-	const maxWorkers = 4
-	sem := make(chan struct{}, maxWorkers)
+	// Workers and the contents read ahead for them are bounded, so archives of many small files don't pile up in memory
+	sem := make(chan struct{}, tuning.Workers)
+	inFlight := newByteBudget(tuning.MaxInFlight)
 	var wg sync.WaitGroup
-	errChan := make(chan error, maxWorkers)
 	var firstErr error
 	var errLock sync.Mutex
 
@@ -192,21 +222,34 @@ extractLoop:
 			break extractLoop
 		}
 		entryCount++
+		if filter.skip(header.Name) {
+			continue
+		}
 
 		// Use header.Name as is without modifying the path
 		targetPath := filepath.Join(destDir, header.Name)
 
 		switch header.Typeflag {
 		case tar.TypeDir:
-			if err := os.MkdirAll(targetPath, os.FileMode(header.Mode)); err != nil {
+			if err := mkdirAll(targetPath, os.FileMode(header.Mode)); err != nil {
 				setFirstError(fmt.Errorf("failed to create dir %s: %w", targetPath, err))
 				break extractLoop
 			}
 		case tar.TypeReg:
 			if header.Size > 0 {
 				if header.Size <= int64(bufferSize) {
+					// Wait for a free worker and room in the in-flight budget before reading the file
+					select {
+					case sem <- struct{}{}: // Acquire semaphore
+					case <-cancelCh:
+						setFirstError(ErrCancelled)
+						break extractLoop
+					}
+					inFlight.acquire(header.Size)
 					fileContents := make([]byte, header.Size)
 					if _, err := io.ReadFull(tarReader, fileContents); err != nil {
+						inFlight.release(header.Size)
+						<-sem
 						if errors.Is(err, ErrCancelled) {
 							setFirstError(ErrCancelled)
 						} else {
@@ -218,31 +261,28 @@ extractLoop:
 					wg.Add(1)
 					go func(targetPath string, fileMode int64, contents []byte) {
 						defer wg.Done()
-						select {
-						case sem <- struct{}{}: // Acquire semaphore
-							defer func() { <-sem }() // Release semaphore
-						case <-cancelCh:
-							errChan <- ErrCancelled
-							return
-						}
+						defer func() {
+							inFlight.release(int64(len(contents)))
+							<-sem // Release semaphore
+						}()
 
-						if err := os.MkdirAll(filepath.Dir(targetPath), 0750); err != nil {
-							errChan <- fmt.Errorf("failed to create parent dir for file %s: %w", targetPath, err)
+						if err := mkdirAll(filepath.Dir(targetPath), 0750); err != nil {
+							setFirstError(fmt.Errorf("failed to create parent dir for file %s: %w", targetPath, err))
 							return
 						}
 
-						if err := os.WriteFile(targetPath, contents, os.FileMode(fileMode)); err != nil {
-							errChan <- fmt.Errorf("failed to write file %s: %w", targetPath, err)
+						if err := writeFile(targetPath, contents, os.FileMode(fileMode)); err != nil {
+							setFirstError(fmt.Errorf("failed to write file %s: %w", targetPath, err))
 							return
 						}
 					}(targetPath, header.Mode, fileContents)
 				} else {
-					if err := os.MkdirAll(filepath.Dir(targetPath), 0750); err != nil {
+					if err := mkdirAll(filepath.Dir(targetPath), 0750); err != nil {
 						setFirstError(fmt.Errorf("failed to create parent dir for file %s: %w", targetPath, err))
 						break extractLoop
 					}
 
-					outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY, os.FileMode(header.Mode))
+					outFile, err := createFile(targetPath, os.FileMode(header.Mode))
 					if err != nil {
 						setFirstError(fmt.Errorf("failed to create file %s: %w", targetPath, err))
 						break extractLoop
@@ -274,18 +314,18 @@ extractLoop:
 					}
 				}
 			} else {
-				if err := os.MkdirAll(filepath.Dir(targetPath), 0750); err != nil {
+				if err := mkdirAll(filepath.Dir(targetPath), 0750); err != nil {
 					setFirstError(fmt.Errorf("failed to create parent dir for empty file %s: %w", targetPath, err))
 					break extractLoop
 				}
 
-				if err := os.WriteFile(targetPath, []byte{}, os.FileMode(header.Mode)); err != nil {
+				if err := writeFile(targetPath, []byte{}, os.FileMode(header.Mode)); err != nil {
 					setFirstError(fmt.Errorf("failed to create empty file %s: %w", targetPath, err))
 					break extractLoop
 				}
 			}
 		case tar.TypeSymlink:
-			if err := os.MkdirAll(filepath.Dir(targetPath), 0750); err != nil {
+			if err := mkdirAll(filepath.Dir(targetPath), 0750); err != nil {
 				setFirstError(fmt.Errorf("failed to create parent dir for symlink %s: %w", targetPath, err))
 				break extractLoop
 			}
@@ -296,6 +336,10 @@ extractLoop:
 				}
 			}
 			if err := os.Symlink(header.Linkname, targetPath); err != nil {
+				if links != nil {
+					links.add(targetPath, header.Linkname)
+					continue
+				}
 				setFirstError(fmt.Errorf("failed to create symlink %s -> %s: %w", targetPath, header.Linkname, err))
 				break extractLoop
 			}
@@ -304,10 +348,6 @@ extractLoop:
 
 	// Remove the cleanup label and just have the cleanup code
 	wg.Wait()
-	close(errChan)
-	for err := range errChan {
-		setFirstError(err)
-	}
 
 	if progressCb != nil {
 		progressCb(1.0)
@@ -340,6 +380,28 @@ func (pt *progressTracker) Read(p []byte) (n int, err error) {
 	return
 }
 
+// DirSize returns the total size of the regular files below dir.
+func DirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure %s: %w", dir, err)
+	}
+	return size, nil
+}
+
 // saveVersionMetadata saves the build info as version.json inside the extracted directory.
 func saveVersionMetadata(build model.BlenderBuild, extractedDir string) error {
 	metaPath := filepath.Join(extractedDir, versionMetaFilename)
@@ -353,28 +415,45 @@ func saveVersionMetadata(build model.BlenderBuild, extractedDir string) error {
 		return fmt.Errorf("failed to marshal build metadata: %w", err)
 	}
 
-	if err := os.WriteFile(metaPath, jsonData, 0644); err != nil {
+	if err := writeFile(metaPath, jsonData, 0644); err != nil {
 		return fmt.Errorf("failed to write %s: %w", versionMetaFilename, err)
 	}
 	return nil
 }
 
-// extractZip extracts a .zip archive with progress updates.
-func extractZip(archivePath, destDir string, progressCb ExtractionProgressCallback, cancelCh <-chan struct{}) error {
+// otherArchitecture reports whether dir holds a build of another architecture than arch,
+// e.g. the Intel build kept next to the Apple Silicon one of the same version.
+func otherArchitecture(dir, arch string) bool {
+	data, err := os.ReadFile(filepath.Join(dir, versionMetaFilename))
+	if err != nil {
+		return false
+	}
+	var installed model.BlenderBuild
+	if err := json.Unmarshal(data, &installed); err != nil {
+		return false
+	}
+	return arch != "" && installed.Architecture != "" && installed.Architecture != arch
+}
+
+// extractZip extracts a .zip archive with progress updates, leaving out the entries filter skips.
+// Files are written by tuning.Workers in parallel through buffers of tuning.BufferSize.
+func extractZip(archivePath, destDir string, filter extractFilter, tuning ExtractTuning, progressCb ExtractionProgressCallback, cancelCh <-chan struct{}) error {
 	zipReader, err := zip.OpenReader(archivePath)
 	if err != nil {
 		return fmt.Errorf("failed to open zip archive: %w", err)
 	}
 	defer zipReader.Close()
 
-	// Get total uncompressed size for progress tracking
+	// Get total uncompressed size of the extracted files for progress tracking
 	var totalSize uint64
 	for _, file := range zipReader.File {
-		totalSize += file.UncompressedSize64
+		if !filter.skip(file.Name) {
+			totalSize += file.UncompressedSize64
+		}
 	}
 
 	// Create a buffer for copying file contents
-	const bufferSize = 4 * 1024 * 1024 // 4MB buffer
+	bufferSize := tuning.BufferSize
 	copyBuffer := make([]byte, bufferSize)
 
 	if progressCb != nil {
@@ -384,10 +463,10 @@ func extractZip(archivePath, destDir string, progressCb ExtractionProgressCallba
 	var processedSize uint64
 	var processedSizeLock sync.Mutex
 
-	const maxWorkers = 4
-	sem := make(chan struct{}, maxWorkers)
+	// Workers and the contents read ahead for them are bounded, so archives of many small files don't pile up in memory
+	sem := make(chan struct{}, tuning.Workers)
+	inFlight := newByteBudget(tuning.MaxInFlight)
 	var wg sync.WaitGroup
-	errChan := make(chan error, maxWorkers)
 	var firstErr error
 	var errLock sync.Mutex
 
@@ -417,6 +496,10 @@ func extractZip(archivePath, destDir string, progressCb ExtractionProgressCallba
 			break
 		}
 
+		if filter.skip(file.Name) {
+			continue
+		}
+
 		// Get proper file path ensuring no path traversal
 		targetPath := filepath.Join(destDir, file.Name)
 
@@ -425,7 +508,7 @@ func extractZip(archivePath, destDir string, progressCb ExtractionProgressCallba
 
 		if file.FileInfo().IsDir() {
 			// Create directory
-			if err := os.MkdirAll(targetPath, 0750); err != nil {
+			if err := mkdirAll(targetPath, 0750); err != nil {
 				setFirstError(fmt.Errorf("failed to create directory %s: %w", targetPath, err))
 				break
 			}
@@ -433,39 +516,45 @@ func extractZip(archivePath, destDir string, progressCb ExtractionProgressCallba
 		}
 
 		// Make sure parent directory exists
-		if err := os.MkdirAll(filepath.Dir(targetPath), 0750); err != nil {
+		if err := mkdirAll(filepath.Dir(targetPath), 0750); err != nil {
 			setFirstError(fmt.Errorf("failed to create parent directory for %s: %w", targetPath, err))
 			break
 		}
 
 		// Small files can be read entirely into memory
 		if file.UncompressedSize64 <= uint64(bufferSize) {
+			// Wait for a free worker and room in the in-flight budget before starting one
+			select {
+			case sem <- struct{}{}: // Acquire semaphore
+			case <-cancelCh:
+				setFirstError(ErrCancelled)
+				goto cleanup
+			}
+			size := int64(file.UncompressedSize64)
+			inFlight.acquire(size)
 			wg.Add(1)
 			go func(file *zip.File, targetPath string) {
 				defer wg.Done()
-				select {
-				case sem <- struct{}{}: // Acquire semaphore
-					defer func() { <-sem }() // Release semaphore
-				case <-cancelCh:
-					errChan <- ErrCancelled
-					return
-				}
+				defer func() {
+					inFlight.release(size)
+					<-sem // Release semaphore
+				}()
 
 				rc, err := file.Open()
 				if err != nil {
-					errChan <- fmt.Errorf("failed to open zip file entry %s: %w", file.Name, err)
+					setFirstError(fmt.Errorf("failed to open zip file entry %s: %w", file.Name, err))
 					return
 				}
 				defer rc.Close()
 
 				fileContents := make([]byte, file.UncompressedSize64)
 				if _, err := io.ReadFull(rc, fileContents); err != nil {
-					errChan <- fmt.Errorf("failed to read zip file entry %s: %w", file.Name, err)
+					setFirstError(fmt.Errorf("failed to read zip file entry %s: %w", file.Name, err))
 					return
 				}
 
-				if err := os.WriteFile(targetPath, fileContents, file.Mode()); err != nil {
-					errChan <- fmt.Errorf("failed to write file %s: %w", targetPath, err)
+				if err := writeFile(targetPath, fileContents, file.Mode()); err != nil {
+					setFirstError(fmt.Errorf("failed to write file %s: %w", targetPath, err))
 					return
 				}
 
@@ -487,7 +576,7 @@ func extractZip(archivePath, destDir string, progressCb ExtractionProgressCallba
 				break
 			}
 
-			outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY, file.Mode())
+			outFile, err := createFile(targetPath, file.Mode())
 			if err != nil {
 				rc.Close()
 				setFirstError(fmt.Errorf("failed to create file %s: %w", targetPath, err))
@@ -532,10 +621,6 @@ func extractZip(archivePath, destDir string, progressCb ExtractionProgressCallba
 
 cleanup:
 	wg.Wait()
-	close(errChan)
-	for err := range errChan {
-		setFirstError(err)
-	}
 
 	if progressCb != nil {
 		progressCb(1.0)
@@ -544,6 +629,28 @@ cleanup:
 	return firstErr
 }
 
+// archiveRootDir returns the name of the directory an archive extracts to. Installer packages have
+// no root directory and unpack into one named after the package.
+func archiveRootDir(fileName, archivePath string) (string, error) {
+	switch {
+	case strings.HasSuffix(fileName, ".tar.xz"):
+		rootDir, err := findRootDirInTarXz(archivePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to find root directory in archive: %w", err)
+		}
+		return rootDir, nil
+	case strings.HasSuffix(fileName, ".zip"):
+		rootDir, err := findRootDirInZip(archivePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to find root directory in zip archive: %w", err)
+		}
+		return rootDir, nil
+	case strings.HasSuffix(fileName, ".msix") || strings.HasSuffix(fileName, ".msi"):
+		return installerRootDir(fileName), nil
+	}
+	return "", fmt.Errorf("unsupported archive format: %s", fileName)
+}
+
 // findRootDirInZip peeks into the ZIP archive to find the root directory name
 func findRootDirInZip(archivePath string) (string, error) {
 	zipReader, err := zip.OpenReader(archivePath)
@@ -601,8 +708,12 @@ func findRootDirInTarXz(archivePath string) (string, error) {
 }
 
 // DownloadAndExtractBuild downloads and extracts a build, handling cancellation.
-func DownloadAndExtractBuild(build model.BlenderBuild, downloadBaseDir string, progressCb ProgressCallback, cancelCh <-chan struct{}) (string, error) {
+// The archive is checked against the published checksum and build.SHA256 if set; on a mismatch it is deleted unextracted.
+// resolve decides what happens to a directory in the way that the launcher didn't install, nil aborts.
+// phaseCb, if not nil, is told about each phase of the install as it starts.
+func DownloadAndExtractBuild(build model.BlenderBuild, downloadBaseDir string, progressCb ProgressCallback, phaseCb PhaseFunc, resolve ConflictFunc, cancelCh <-chan struct{}) (string, error) {
 	// 1. Download
+	enterPhase(phaseCb, model.PhaseDownload)
 	downloadFileName := filepath.Base(build.DownloadURL)
 	downloadTempDir := filepath.Join(downloadBaseDir, DownloadingDir)
 	if err := os.MkdirAll(downloadTempDir, 0750); err != nil {
@@ -616,12 +727,34 @@ func DownloadAndExtractBuild(build model.BlenderBuild, downloadBaseDir string, p
 		}
 	}()
 
-	if err := downloadFile(build.DownloadURL, downloadPath, progressCb, cancelCh); err != nil {
+	cfg := config.GetConfigInstance()
+	servedBy, err := downloadFromMirrors(build.DownloadURL, cfg.Mirrors, cfg.DownloadSource, downloadPath, progressCb, cancelCh)
+	if err != nil {
 		if errors.Is(err, ErrCancelled) {
 			return "", ErrCancelled // Propagate cancellation error
 		}
 		return "", fmt.Errorf("download failed: %w", err)
 	}
+	build.DownloadedFrom = hostOf(servedBy)
+
+	// Check the archive against the checksum published by the builder, or by the mirror if the builder is unreachable
+	enterPhase(phaseCb, model.PhaseVerify)
+	sum, verified, err := verifyArchive([]string{build.DownloadURL, servedBy}, downloadPath, progressCb)
+	if err != nil {
+		return "", err
+	}
+	// A checksum the build already carries, e.g. from a farm manifest, has to match before anything is extracted
+	if build.SHA256 != "" {
+		if build.SHA256 != sum {
+			return "", fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, build.SHA256, sum)
+		}
+		verified = true
+	}
+	build.SHA256 = sum
+	build.Verification = model.VerificationNoChecksum
+	if verified {
+		build.Verification = model.VerificationVerified
+	}
 
 	// Check for cancellation after download, before extraction
 	select {
@@ -631,18 +764,88 @@ func DownloadAndExtractBuild(build model.BlenderBuild, downloadBaseDir string, p
 		// Continue
 	}
 
+	extractedRootDir, err := installArchive(build, downloadPath, downloadBaseDir, progressCb, phaseCb, resolve, cancelCh)
+	if err != nil || !cfg.KeepArchives {
+		return extractedRootDir, err
+	}
+
+	// Keep the archive for reinstalls; the build is installed even if that fails
+	if cacheDir, err := cfg.ArchiveCachePath(); err == nil {
+		_, _ = CacheArchive(build, downloadPath, cacheDir)
+	}
+	return extractedRootDir, nil
+}
+
+// installArchive extracts a downloaded build archive into downloadBaseDir and saves its version.json.
+// An existing install of the build is moved to .oldbuilds; the archive itself is left alone.
+func installArchive(build model.BlenderBuild, archivePath, downloadBaseDir string, progressCb ProgressCallback, phaseCb PhaseFunc, resolve ConflictFunc, cancelCh <-chan struct{}) (_ string, err error) {
+	cfg := config.GetConfigInstance()
+	downloadFileName := filepath.Base(archivePath)
+	downloadPath := archivePath
+	downloadTempDir := filepath.Join(downloadBaseDir, DownloadingDir)
+
+	// Record the install in the journal so a crash during the backup or extraction can be
+	// rolled back at the next start. A failed install is rolled back right away; the entry is
+	// cleared on success or once the rollback worked, otherwise the next start retries it.
+	op := Operation{
+		ID:      fmt.Sprintf("%s-%d", build.Version, time.Now().UnixNano()),
+		Version: build.Version,
+		Started: time.Now(),
+	}
+	defer func() {
+		if err != nil {
+			if rollErr := rollBack(op); rollErr != nil {
+				err = fmt.Errorf("%w (rollback failed: %v)", err, rollErr)
+				return
+			}
+		}
+		_ = finishOperation(downloadBaseDir, op.ID)
+	}()
+
 	// 2. The archive contains a root directory, we'll extract directly to downloadBaseDir
+	rootDir, err := archiveRootDir(downloadFileName, downloadPath)
+	if err != nil {
+		return "", err
+	}
+	installName := rootDir
+	if cfg.DirTemplate != "" {
+		if name := build.DirName(cfg.DirTemplate); name != "" && name != rootDir {
+			installName = name
+			build.ArchiveDir = rootDir
+		}
+	}
+	extractedRootDir := filepath.Join(downloadBaseDir, installName)
+
+	// A directory in the way that the launcher didn't install, e.g. a manual copy, is only replaced when resolve says so
+	var existingBuildDir, keptDir string
+	if unmanagedDir(extractedRootDir) {
+		resolution := ResolveAbort
+		if resolve != nil {
+			resolution = resolve(extractedRootDir)
+		}
+		switch resolution {
+		case ResolveOverwrite:
+			existingBuildDir = extractedRootDir
+		case ResolveKeepBoth:
+			keptDir = extractedRootDir
+			extractedRootDir = freeDirName(extractedRootDir)
+		default:
+			return "", fmt.Errorf("install of %s aborted: %s: %w", build.Version, extractedRootDir, ErrTargetExists)
+		}
+	}
+
 	// Look for any existing directory with this build version
-	var existingBuildDir string
+	enterPhase(phaseCb, model.PhaseBackup)
 	entries, err := os.ReadDir(downloadBaseDir)
-	if err == nil {
+	if err == nil && existingBuildDir == "" {
 		// Find any directories that might contain this version
 		version := build.Version
 		for _, entry := range entries {
-			if entry.IsDir() && entry.Name() != DownloadingDir && entry.Name() != OldBuildsDir {
+			if entry.IsDir() && entry.Name() != DownloadingDir && entry.Name() != OldBuildsDir && entry.Name() != TrashDir {
+				dir := filepath.Join(downloadBaseDir, entry.Name())
 				// Check if this directory contains the version we're downloading
-				if strings.Contains(entry.Name(), version) {
-					existingBuildDir = filepath.Join(downloadBaseDir, entry.Name())
+				if dir != keptDir && strings.Contains(entry.Name(), version) && !otherArchitecture(dir, build.Architecture) {
+					existingBuildDir = dir
 					break
 				}
 			}
@@ -650,6 +853,7 @@ func DownloadAndExtractBuild(build model.BlenderBuild, downloadBaseDir string, p
 	}
 
 	// If we found an existing build directory, back it up
+	var previousBuildDir string
 	if existingBuildDir != "" {
 		oldBuildsDir := filepath.Join(downloadBaseDir, OldBuildsDir)
 		if err := os.MkdirAll(oldBuildsDir, 0750); err != nil {
@@ -658,14 +862,21 @@ func DownloadAndExtractBuild(build model.BlenderBuild, downloadBaseDir string, p
 		timestamp := time.Now().Format("20060102_150405")
 		oldBuildName := fmt.Sprintf("%s_%s", filepath.Base(existingBuildDir), timestamp)
 		oldBuildPath := filepath.Join(oldBuildsDir, oldBuildName)
-		if err := os.Rename(existingBuildDir, oldBuildPath); err != nil {
+		op.BackupFrom, op.BackupTo = existingBuildDir, oldBuildPath
+		if err := recordOperation(downloadBaseDir, op); err != nil {
+			return "", fmt.Errorf("failed to record install: %w", err)
+		}
+		if err := rename(existingBuildDir, oldBuildPath); err != nil {
 			if errRem := os.RemoveAll(existingBuildDir); errRem != nil {
 				return "", fmt.Errorf("failed to replace old build dir: %w", err)
 			}
+		} else {
+			previousBuildDir = oldBuildPath
 		}
 	}
 
 	// 3. Extract based on archive type
+	enterPhase(phaseCb, model.PhaseExtract)
 	extractionCb := func(progress float64) {
 		if progressCb != nil {
 			// Use a large virtual size to indicate extraction phase to the UI
@@ -675,50 +886,88 @@ func DownloadAndExtractBuild(build model.BlenderBuild, downloadBaseDir string, p
 		}
 	}
 
-	var extractedRootDir string
 	var extractErr error
-
-	// Handle different archive formats
-	if strings.HasSuffix(downloadFileName, ".tar.xz") {
-		// Peek into the archive to find the root directory
-		rootDir, err := findRootDirInTarXz(downloadPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to find root directory in archive: %w", err)
+	filter := newExtractFilter(cfg)
+	tuning := NewExtractTuning(cfg)
+	var links linkFallback
+
+	// Extracting thousands of small files onto a network share is slow and may fail on symlinks,
+	// so extract on the local disk and move the finished build over. A build installed under another
+	// name than its archive root, renamed by dir_template or kept next to a directory of the same name,
+	// is extracted aside as well and renamed into place.
+	extractBaseDir := downloadBaseDir
+	fs := DetectFilesystem(downloadBaseDir)
+	if fs.Network || filepath.Base(extractedRootDir) != rootDir {
+		tempParent := downloadTempDir
+		if fs.Network {
+			tempParent = ""
+		} else if err := os.MkdirAll(tempParent, 0750); err != nil {
+			return "", fmt.Errorf("failed to create download temp dir: %w", err)
 		}
-		extractedRootDir = filepath.Join(downloadBaseDir, rootDir)
-
-		// Extract the archive
-		extractErr = extractTarXz(downloadPath, downloadBaseDir, extractionCb, cancelCh)
-	} else if strings.HasSuffix(downloadFileName, ".zip") {
-		// Peek into the archive to find the root directory
-		rootDir, err := findRootDirInZip(downloadPath)
+		localDir, err := os.MkdirTemp(tempParent, "blender-extract-*")
 		if err != nil {
-			return "", fmt.Errorf("failed to find root directory in zip archive: %w", err)
+			return "", fmt.Errorf("failed to create local extraction dir: %w", err)
 		}
-		extractedRootDir = filepath.Join(downloadBaseDir, rootDir)
+		defer os.RemoveAll(localDir)
+		extractBaseDir = localDir
+	}
 
-		// Extract the zip archive
-		extractErr = extractZip(downloadPath, downloadBaseDir, extractionCb, cancelCh)
-	} else {
-		return "", fmt.Errorf("unsupported archive format: %s", downloadFileName)
+	op.ExtractDir = extractedRootDir
+	if err := recordOperation(downloadBaseDir, op); err != nil {
+		return "", fmt.Errorf("failed to record install: %w", err)
 	}
 
-	// Handle extraction error
+	// Handle different archive formats
+	localRootDir := filepath.Join(extractBaseDir, rootDir)
+	extractStart := time.Now()
+	switch {
+	case strings.HasSuffix(downloadFileName, ".tar.xz"):
+		extractErr = extractTarXz(downloadPath, extractBaseDir, filter, tuning, &links, extractionCb, cancelCh)
+	case strings.HasSuffix(downloadFileName, ".zip"):
+		extractErr = extractZip(downloadPath, extractBaseDir, filter, tuning, extractionCb, cancelCh)
+	case strings.HasSuffix(downloadFileName, ".msix"):
+		extractErr = extractMsix(downloadPath, localRootDir, tuning, extractionCb, cancelCh)
+	default:
+		extractErr = extractMsi(downloadPath, localRootDir, extractionCb, cancelCh)
+	}
+
+	// Handle extraction error, the partially extracted directory is removed by the rollback
 	if extractErr != nil {
-		// Attempt to clean up partially extracted directory
-		if extractedRootDir != "" {
-			if remErr := os.RemoveAll(extractedRootDir); remErr != nil {
-			}
-		}
 		if errors.Is(extractErr, ErrCancelled) {
 			return "", ErrCancelled // Propagate cancellation
 		}
 		return "", fmt.Errorf("extraction failed: %w", extractErr)
 	}
+	extraction := &model.ExtractionStats{Duration: time.Since(extractStart), Workers: tuning.Workers, BufferSize: tuning.BufferSize}
+	enterPhase(phaseCb, model.PhaseFinalize)
+	build.ExtractWarnings = links.materialize(localRootDir)
+	if extractBaseDir != downloadBaseDir {
+		if err := moveTree(localRootDir, extractedRootDir, fs.Symlinks); err != nil {
+			return "", err
+		}
+	}
 
-	// 4. Save Metadata
+	// 4. Reinstalling the same build keeps the user's metadata and config
+	if previousBuildDir != "" {
+		if err := restoreUserFiles(&build, previousBuildDir, extractedRootDir); err != nil {
+			return "", err
+		}
+	}
+
+	// 5. Save Metadata, recording the size on disk for later size estimates
+	if size, err := DirSize(extractedRootDir); err == nil {
+		build.InstalledSize = size
+		extraction.Bytes = size
+	}
+	build.Extraction = extraction
+	if tree, err := TreeChecksum(extractedRootDir); err == nil {
+		build.TreeSHA256 = tree
+	}
+	if backends, err := GPUBackends(extractedRootDir); err == nil {
+		build.GPUBackends = backends
+	}
 	if err := saveVersionMetadata(build, extractedRootDir); err != nil {
-		return extractedRootDir, fmt.Errorf("metadata save failed: %w", err)
+		return "", fmt.Errorf("metadata save failed: %w", err)
 	}
 
 	return extractedRootDir, nil
@@ -0,0 +1,56 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/config"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// extractFilter decides which archive entries are extracted, from the extract_include and extract_exclude settings
+type extractFilter struct {
+	include []string // Extract only matching paths, everything when empty
+	exclude []string // Skip matching paths, even when included
+}
+
+// newExtractFilter returns the extraction filter configured in cfg
+func newExtractFilter(cfg *config.Config) extractFilter {
+	if cfg == nil {
+		return extractFilter{}
+	}
+	return extractFilter{include: cfg.ExtractInclude, exclude: cfg.ExtractExclude}
+}
+
+// skip reports whether an archive entry is left out. Patterns are matched against the entry path below
+// the root directory of the archive and each of its parent directories, so excluding a directory skips its contents.
+func (f extractFilter) skip(name string) bool {
+	name = strings.Trim(strings.TrimPrefix(filepath.ToSlash(name), "./"), "/")
+	_, rel, found := strings.Cut(name, "/")
+	if !found || rel == "" {
+		// The root directory itself
+		return false
+	}
+	if matchesAny(f.exclude, rel) {
+		return true
+	}
+	return len(f.include) > 0 && !matchesAny(f.include, rel)
+}
+
+// matchesAny reports whether a slash-separated path or one of its parent directories matches any of the patterns
+func matchesAny(patterns []string, rel string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	for p := rel; ; {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+		i := strings.LastIndex(p, "/")
+		if i < 0 {
+			return false
+		}
+		p = p[:i]
+	}
+}
@@ -0,0 +1,82 @@
+package download
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractFilterSkip(t *testing.T) {
+	filter := extractFilter{
+		include: []string{"blender", "4.2"},
+		exclude: []string{"*/python/lib/*/test", "*/datafiles/locale"},
+	}
+
+	testCases := []struct {
+		name     string
+		expected bool
+	}{
+		{"blender-4.2.0-linux/", false},
+		{"blender-4.2.0-linux/blender", false},
+		{"blender-4.2.0-linux/4.2/scripts/startup/a.py", false},
+		{"blender-4.2.0-linux/4.2/python/lib/python3.11/test/", true},
+		{"blender-4.2.0-linux/4.2/python/lib/python3.11/test/test_os.py", true},
+		{"blender-4.2.0-linux/4.2/datafiles/locale/fr/blender.mo", true},
+		{"blender-4.2.0-linux/readme.html", true},
+		{"./blender-4.2.0-linux/lib/libcycles.so", true},
+	}
+
+	for _, tc := range testCases {
+		if got := filter.skip(tc.name); got != tc.expected {
+			t.Errorf("skip(%q) = %v, expected %v", tc.name, got, tc.expected)
+		}
+	}
+
+	if (extractFilter{}).skip("blender-4.2.0-linux/readme.html") {
+		t.Error("Expected an empty filter to extract everything")
+	}
+}
+
+func TestExtractZipFiltered(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "blender-4.2.0-windows-x64.zip")
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range map[string]string{
+		"blender-4.2.0-windows-x64/blender.exe":                          "exe",
+		"blender-4.2.0-windows-x64/4.2/scripts/a.py":                     "print()",
+		"blender-4.2.0-windows-x64/4.2/python/lib/test/test_os.py":       "test",
+		"blender-4.2.0-windows-x64/4.2/datafiles/locale/fr/blender.mo":   "mo",
+		"blender-4.2.0-windows-x64/4.2/datafiles/colormanagement/a.ocio": "ocio",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to add %s: %v", name, err)
+		}
+		w.Write([]byte(content))
+	}
+	zw.Close()
+	f.Close()
+
+	destDir := filepath.Join(dir, "builds")
+	filter := extractFilter{exclude: []string{"*/python/lib/test", "*/datafiles/locale"}}
+	if err := extractZip(archivePath, destDir, filter, NewExtractTuning(nil), nil, nil); err != nil {
+		t.Fatalf("extractZip failed: %v", err)
+	}
+
+	root := filepath.Join(destDir, "blender-4.2.0-windows-x64")
+	for _, kept := range []string{"blender.exe", "4.2/scripts/a.py", "4.2/datafiles/colormanagement/a.ocio"} {
+		if _, err := os.Stat(filepath.Join(root, kept)); err != nil {
+			t.Errorf("Expected %s to be extracted: %v", kept, err)
+		}
+	}
+	for _, skipped := range []string{"4.2/python/lib/test", "4.2/datafiles/locale"} {
+		if _, err := os.Stat(filepath.Join(root, skipped)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be skipped, got %v", skipped, err)
+		}
+	}
+}
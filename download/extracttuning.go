@@ -0,0 +1,127 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/config"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Bounds of the autotuned extraction; past maxAutoWorkers the disk rather than the CPU is the limit
+const (
+	minAutoWorkers   = 2
+	maxAutoWorkers   = 16
+	minAutoBuffer    = 1 << 20
+	maxAutoBuffer    = 16 << 20
+	defaultBuffer    = 4 << 20
+	bufferMemoryPart = 32 // Share of the available memory the extraction buffers may take, 1/32
+
+	// Below this much available memory extractions run in the low-memory mode
+	lowMemoryThreshold = 1 << 30
+	lowMemoryWorkers   = 2
+)
+
+// availableMemory returns the memory available to new allocations and whether it is known. Replaced in tests.
+var availableMemory = readAvailableMemory
+
+// ExtractTuning is the parallelism and buffer size an extraction runs with
+type ExtractTuning struct {
+	Workers     int   // Files written in parallel
+	BufferSize  int   // Bytes of the read and write buffers, files up to this size are written by the workers
+	MaxInFlight int64 // Bytes of file contents read ahead for the workers at most
+	Auto        bool  // Neither was set in config.toml
+	LowMemory   bool  // Little memory was available, files are streamed one at a time
+}
+
+// NewExtractTuning returns the extraction tuning of cfg: extract_workers and extract_buffer_mb when set,
+// otherwise a worker per CPU and buffers sized to the available memory.
+// With little memory available the autotuned workers are cut and the contents read ahead
+// for them are capped at a single buffer, whatever config.toml sets.
+func NewExtractTuning(cfg *config.Config) ExtractTuning {
+	available, known := availableMemory()
+	t := ExtractTuning{Auto: true, LowMemory: known && available < lowMemoryThreshold}
+	if cfg != nil && cfg.ExtractWorkers > 0 {
+		t.Workers, t.Auto = cfg.ExtractWorkers, false
+	} else if t.LowMemory {
+		t.Workers = lowMemoryWorkers
+	} else {
+		t.Workers = min(max(runtime.NumCPU(), minAutoWorkers), maxAutoWorkers)
+	}
+	if cfg != nil && cfg.ExtractBufferMB > 0 {
+		t.BufferSize, t.Auto = cfg.ExtractBufferMB<<20, false
+	} else if known {
+		t.BufferSize = autoBufferSize(available, t.Workers)
+	} else {
+		t.BufferSize = defaultBuffer
+	}
+	t.MaxInFlight = int64(t.Workers) * int64(t.BufferSize)
+	if t.LowMemory {
+		t.MaxInFlight = int64(t.BufferSize)
+	}
+	return t
+}
+
+// autoBufferSize returns the largest power of two buffer size that keeps a buffer per worker,
+// plus the read and write buffers of the extraction itself, within a share of the available memory
+func autoBufferSize(available uint64, workers int) int {
+	budget := available / bufferMemoryPart / uint64(workers+2)
+	size := minAutoBuffer
+	for size < maxAutoBuffer && uint64(size*2) <= budget {
+		size *= 2
+	}
+	return size
+}
+
+// String describes the tuning, e.g. "8 workers, 4MB buffer (auto)"
+func (t ExtractTuning) String() string {
+	s := fmt.Sprintf("%d workers, %dMB buffer", t.Workers, t.BufferSize>>20)
+	switch {
+	case t.Auto && t.LowMemory:
+		s += " (auto, low memory)"
+	case t.Auto:
+		s += " (auto)"
+	case t.LowMemory:
+		s += " (low memory)"
+	}
+	return s
+}
+
+// byteBudget caps the bytes held at once, e.g. file contents waiting for an extraction worker
+type byteBudget struct {
+	mu    sync.Mutex
+	cond  *sync.Cond
+	limit int64
+	used  int64
+}
+
+// newByteBudget returns a budget of limit bytes, unlimited when limit is 0
+func newByteBudget(limit int64) *byteBudget {
+	b := &byteBudget{limit: limit}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// acquire waits until n more bytes fit in the budget. More than the whole budget
+// is granted once nothing else is held, so a large file can't wait forever.
+func (b *byteBudget) acquire(n int64) {
+	if b.limit <= 0 {
+		return
+	}
+	b.mu.Lock()
+	for b.used > 0 && b.used+n > b.limit {
+		b.cond.Wait()
+	}
+	b.used += n
+	b.mu.Unlock()
+}
+
+// release returns n bytes to the budget
+func (b *byteBudget) release(n int64) {
+	if b.limit <= 0 {
+		return
+	}
+	b.mu.Lock()
+	b.used -= n
+	b.mu.Unlock()
+	b.cond.Broadcast()
+}
@@ -0,0 +1,132 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/config"
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewExtractTuning(t *testing.T) {
+	defer func(orig func() (uint64, bool)) { availableMemory = orig }(availableMemory)
+	availableMemory = func() (uint64, bool) { return 16 << 30, true }
+
+	auto := NewExtractTuning(nil)
+	if !auto.Auto || auto.Workers < minAutoWorkers || auto.Workers > maxAutoWorkers {
+		t.Errorf("Expected autotuned workers, got %+v", auto)
+	}
+	if auto.BufferSize < minAutoBuffer || auto.BufferSize > maxAutoBuffer {
+		t.Errorf("Expected an autotuned buffer, got %+v", auto)
+	}
+
+	if auto.MaxInFlight < int64(auto.BufferSize) {
+		t.Errorf("Expected at least a buffer in flight, got %+v", auto)
+	}
+
+	cfg := config.Config{ExtractWorkers: 3, ExtractBufferMB: 2}
+	set := NewExtractTuning(&cfg)
+	if set.Auto || set.Workers != 3 || set.BufferSize != 2<<20 {
+		t.Errorf("Expected the config overrides, got %+v", set)
+	}
+	if set.String() != "3 workers, 2MB buffer" {
+		t.Errorf("Unexpected description %q", set.String())
+	}
+
+	// With little memory the workers are cut and a single buffer is read ahead
+	availableMemory = func() (uint64, bool) { return 512 << 20, true }
+	low := NewExtractTuning(nil)
+	if !low.LowMemory || low.Workers != lowMemoryWorkers || low.MaxInFlight != int64(low.BufferSize) {
+		t.Errorf("Expected the low-memory tuning, got %+v", low)
+	}
+	if low.String() != "2 workers, 4MB buffer (auto, low memory)" {
+		t.Errorf("Unexpected description %q", low.String())
+	}
+	if set := NewExtractTuning(&cfg); !set.LowMemory || set.Workers != 3 || set.MaxInFlight != 2<<20 {
+		t.Errorf("Expected the config workers with a single buffer in flight, got %+v", set)
+	}
+
+	// Unknown memory is never treated as low
+	availableMemory = func() (uint64, bool) { return 0, false }
+	if unknown := NewExtractTuning(nil); unknown.LowMemory || unknown.BufferSize != defaultBuffer {
+		t.Errorf("Expected the default buffer without memory information, got %+v", unknown)
+	}
+}
+
+func TestByteBudget(t *testing.T) {
+	budget := newByteBudget(10)
+	budget.acquire(8)
+
+	acquired := make(chan struct{})
+	go func() {
+		budget.acquire(5)
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("Expected the acquire past the budget to wait")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	budget.release(8)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the acquire to go through once bytes were released")
+	}
+
+	// More than the whole budget is granted once it is empty
+	budget.release(5)
+	budget.acquire(50)
+}
+
+func TestAutoBufferSize(t *testing.T) {
+	tests := []struct {
+		available uint64
+		workers   int
+		want      int
+	}{
+		{512 << 20, 4, 2 << 20},  // 512MB / 32 / 6 = 2.7MB
+		{2 << 30, 4, 8 << 20},    // 2GB / 32 / 6 = 10.7MB
+		{64 << 30, 16, 16 << 20}, // Capped
+		{64 << 20, 8, 1 << 20},   // Never below 1MB
+	}
+	for _, tt := range tests {
+		if got := autoBufferSize(tt.available, tt.workers); got != tt.want {
+			t.Errorf("autoBufferSize(%d, %d) = %d, want %d", tt.available, tt.workers, got, tt.want)
+		}
+	}
+}
+
+func TestExtractZipLowMemory(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "blender-4.2.0-windows-x64.zip")
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for i := 0; i < 200; i++ {
+		w, err := zw.Create(fmt.Sprintf("blender-4.2.0-windows-x64/scripts/%03d.py", i))
+		if err != nil {
+			t.Fatalf("Failed to add file %d: %v", i, err)
+		}
+		w.Write([]byte(strings.Repeat("x", 100+i)))
+	}
+	zw.Close()
+	f.Close()
+
+	// The in-flight budget holds less than two files, they are written one after the other
+	tuning := ExtractTuning{Workers: 2, BufferSize: 1 << 20, MaxInFlight: 200, LowMemory: true}
+	destDir := filepath.Join(dir, "builds")
+	if err := extractZip(archivePath, destDir, extractFilter{}, tuning, nil, nil); err != nil {
+		t.Fatalf("extractZip failed: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(destDir, "blender-4.2.0-windows-x64", "scripts"))
+	if err != nil || len(entries) != 200 {
+		t.Fatalf("Expected 200 extracted files, got %d (%v)", len(entries), err)
+	}
+}
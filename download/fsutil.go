@@ -0,0 +1,64 @@
+package download
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// fileRetryAttempts is how often a file operation is tried while another program holds the file open
+const fileRetryAttempts = 6
+
+// fileRetryDelay is the wait before the first retry, doubled after each attempt. Replaced in tests.
+var fileRetryDelay = 100 * time.Millisecond
+
+// lockedFile reports whether an error means the file is briefly held open by another program,
+// typically an antivirus scanner inspecting a freshly written file. Replaced in tests.
+var lockedFile = isLockedFileError
+
+// retryFileOp runs a file operation, retrying it with growing delays while the file is locked
+// by another program. A file that stays locked gets an error explaining the likely cause.
+func retryFileOp(path string, op func() error) error {
+	delay := fileRetryDelay
+	var err error
+	for attempt := 1; attempt <= fileRetryAttempts; attempt++ {
+		if err = op(); err == nil || !lockedFile(err) {
+			return err
+		}
+		if attempt < fileRetryAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+	return fmt.Errorf("%w: %s stayed locked by another program; antivirus software scanning new files "+
+		"is a common cause, try excluding the download directory from real-time scanning", err, path)
+}
+
+// writeFile writes a file like os.WriteFile, handling long paths and locked files
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	path = longPath(path)
+	return retryFileOp(path, func() error { return os.WriteFile(path, data, perm) })
+}
+
+// createFile opens a file for writing like os.OpenFile, handling long paths and locked files
+func createFile(path string, perm os.FileMode) (*os.File, error) {
+	path = longPath(path)
+	var f *os.File
+	err := retryFileOp(path, func() (err error) {
+		f, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY, perm)
+		return err
+	})
+	return f, err
+}
+
+// mkdirAll creates a directory like os.MkdirAll, handling long paths and locked parents
+func mkdirAll(path string, perm os.FileMode) error {
+	path = longPath(path)
+	return retryFileOp(path, func() error { return os.MkdirAll(path, perm) })
+}
+
+// rename moves a file or directory like os.Rename, retrying while a file inside is locked
+func rename(oldPath, newPath string) error {
+	oldPath, newPath = longPath(oldPath), longPath(newPath)
+	return retryFileOp(oldPath, func() error { return os.Rename(oldPath, newPath) })
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package download
+
+// longPath returns path unchanged; only Windows limits path length
+func longPath(path string) string {
+	return path
+}
+
+// isLockedFileError reports whether err means another program holds the file; files are
+// not locked against writes outside Windows
+func isLockedFileError(err error) bool {
+	return false
+}
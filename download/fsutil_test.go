@@ -0,0 +1,46 @@
+package download
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryFileOp(t *testing.T) {
+	errLocked := errors.New("sharing violation")
+	defer func(orig func(error) bool) { lockedFile = orig }(lockedFile)
+	lockedFile = func(err error) bool { return errors.Is(err, errLocked) }
+	defer func(orig time.Duration) { fileRetryDelay = orig }(fileRetryDelay)
+	fileRetryDelay = time.Millisecond
+
+	// A file released after a few attempts is written
+	calls := 0
+	err := retryFileOp("blender.exe", func() error {
+		calls++
+		if calls < 3 {
+			return errLocked
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("Expected success on the third attempt, got %v after %d calls", err, calls)
+	}
+
+	// Other errors are returned right away
+	calls = 0
+	errOther := errors.New("disk full")
+	if err := retryFileOp("blender.exe", func() error { calls++; return errOther }); err != errOther || calls != 1 {
+		t.Errorf("Expected the error without retries, got %v after %d calls", err, calls)
+	}
+
+	// A file that stays locked gets an explanation
+	calls = 0
+	err = retryFileOp("blender.exe", func() error { calls++; return errLocked })
+	if !errors.Is(err, errLocked) || calls != fileRetryAttempts {
+		t.Errorf("Expected %d attempts and the locked error, got %v after %d calls", fileRetryAttempts, err, calls)
+	}
+	if err != nil && !strings.Contains(err.Error(), "antivirus") {
+		t.Errorf("Expected the error to mention antivirus software, got %v", err)
+	}
+}
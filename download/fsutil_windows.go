@@ -0,0 +1,41 @@
+//go:build windows
+// +build windows
+
+package download
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Windows error codes of files held open by another process
+const (
+	errorAccessDenied     syscall.Errno = 5
+	errorSharingViolation syscall.Errno = 32
+	errorLockViolation    syscall.Errno = 33
+)
+
+// longPath returns path with the \\?\ prefix, lifting the 260 character MAX_PATH limit
+// that deep paths in Blender archives exceed
+func longPath(path string) string {
+	if strings.HasPrefix(path, `\\?\`) || !filepath.IsAbs(path) {
+		return path
+	}
+	path = filepath.Clean(path)
+	if strings.HasPrefix(path, `\\`) {
+		return `\\?\UNC\` + path[2:] // Network share
+	}
+	return `\\?\` + path
+}
+
+// isLockedFileError reports whether err is a sharing, lock or access violation; antivirus
+// scanners and the search indexer briefly lock new files, which makes these transient
+func isLockedFileError(err error) bool {
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+	return errno == errorSharingViolation || errno == errorLockViolation || errno == errorAccessDenied
+}
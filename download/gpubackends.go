@@ -0,0 +1,56 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gpuKernelPrefixes maps the file name prefixes of the Cycles kernels a build ships to their GPU backend
+var gpuKernelPrefixes = []struct{ prefix, backend string }{
+	{"kernel_optix", model.GPUBackendOptiX},
+	{"kernel_sm_", model.GPUBackendCUDA},
+	{"kernel_compute_", model.GPUBackendCUDA}, // PTX for GPUs newer than the compiled ones
+	{"kernel_gfx", model.GPUBackendHIP},
+	{"libcycles_kernel_oneapi", model.GPUBackendOneAPI},
+	{"cycles_kernel_oneapi", model.GPUBackendOneAPI},
+	{"kernel.metal", model.GPUBackendMetal}, // Compiled when first rendering
+}
+
+// GPUBackends lists the Cycles GPU backends an installed build ships kernels for, in model.GPUBackendOrder order
+func GPUBackends(dir string) ([]string, error) {
+	found := make(map[string]bool)
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && treeChecksumSkip[d.Name()] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		for _, k := range gpuKernelPrefixes {
+			if strings.HasPrefix(d.Name(), k.prefix) {
+				found[k.backend] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of %s: %w", dir, err)
+	}
+
+	backends := []string{}
+	for _, backend := range model.GPUBackendOrder {
+		if found[backend] {
+			backends = append(backends, backend)
+		}
+	}
+	return backends, nil
+}
@@ -0,0 +1,35 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/model"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestGPUBackends(t *testing.T) {
+	dir := t.TempDir()
+	if backends, err := GPUBackends(dir); err != nil || backends == nil || len(backends) != 0 {
+		t.Errorf("Expected no backends for a build without kernels, got %v, %v", backends, err)
+	}
+
+	kernelDir := filepath.Join(dir, "4.2", "scripts", "addons_core", "cycles", "lib")
+	if err := os.MkdirAll(kernelDir, 0750); err != nil {
+		t.Fatalf("Failed to create %s: %v", kernelDir, err)
+	}
+	for _, name := range []string{"kernel_sm_86.cubin.zst", "kernel_gfx1030.fatbin.zst", "kernel_optix_shader_raytrace.ptx.zst"} {
+		if err := os.WriteFile(filepath.Join(kernelDir, name), nil, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	backends, err := GPUBackends(dir)
+	if err != nil {
+		t.Fatalf("GPUBackends failed: %v", err)
+	}
+	expected := []string{model.GPUBackendCUDA, model.GPUBackendOptiX, model.GPUBackendHIP}
+	if !slices.Equal(backends, expected) {
+		t.Errorf("Expected %v, got %v", expected, backends)
+	}
+}
@@ -0,0 +1,72 @@
+package download
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// installerExecutables are the files marking the Blender directory inside an unpacked installer
+var installerExecutables = []string{"blender-launcher.exe", "blender.exe"}
+
+// installerRootDir returns the directory an installer package is unpacked into, named after the package
+func installerRootDir(fileName string) string {
+	return strings.TrimSuffix(fileName, filepath.Ext(fileName))
+}
+
+// extractMsix unpacks an MSIX package (a zip archive with package metadata) into destDir
+// and moves the Blender files to its top level.
+func extractMsix(packagePath, destDir string, tuning ExtractTuning, progressCb ExtractionProgressCallback, cancelCh <-chan struct{}) error {
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", destDir, err)
+	}
+	// The package has no root directory the extraction filter could match below
+	if err := extractZip(packagePath, destDir, extractFilter{}, tuning, progressCb, cancelCh); err != nil {
+		return err
+	}
+	return flattenInstall(destDir)
+}
+
+// flattenInstall moves the directory holding the Blender executable to the top of root,
+// dropping installer metadata around it. Installers nest the files, e.g. under
+// "Blender Foundation/Blender 4.2", while launches look for the executable in root.
+func flattenInstall(root string) error {
+	var exeDir string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || exeDir != "" {
+			return err
+		}
+		if !info.IsDir() {
+			for _, name := range installerExecutables {
+				if strings.EqualFold(info.Name(), name) {
+					exeDir = filepath.Dir(path)
+					return filepath.SkipDir
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to search unpacked installer: %w", err)
+	}
+	if exeDir == "" {
+		return fmt.Errorf("no Blender executable found in the installer package")
+	}
+	if exeDir == root {
+		return nil
+	}
+
+	// Move the Blender directory next to root, then put it in root's place
+	moved := root + ".flatten"
+	if err := os.Rename(exeDir, moved); err != nil {
+		return fmt.Errorf("failed to move unpacked files: %w", err)
+	}
+	if err := os.RemoveAll(root); err != nil {
+		return fmt.Errorf("failed to remove installer metadata: %w", err)
+	}
+	if err := os.Rename(moved, root); err != nil {
+		return fmt.Errorf("failed to move unpacked files: %w", err)
+	}
+	return nil
+}
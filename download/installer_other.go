@@ -0,0 +1,14 @@
+//go:build !windows
+// +build !windows
+
+package download
+
+import "errors"
+
+// msiSupported reports whether MSI packages can be unpacked on this platform
+const msiSupported = false
+
+// extractMsi is only available on Windows, where msiexec unpacks the package
+func extractMsi(packagePath, destDir string, progressCb ExtractionProgressCallback, cancelCh <-chan struct{}) error {
+	return errors.New("MSI packages can only be installed on Windows")
+}
@@ -0,0 +1,58 @@
+package download
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractMsix(t *testing.T) {
+	dir := t.TempDir()
+	packagePath := filepath.Join(dir, "blender-4.2.0-windows.amd64-release.msix")
+	f, err := os.Create(packagePath)
+	if err != nil {
+		t.Fatalf("Failed to create package: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range map[string]string{
+		"AppxManifest.xml":                             "<Package/>",
+		"[Content_Types].xml":                          "<Types/>",
+		"VFS/ProgramFilesX64/Blender/blender.exe":      "exe",
+		"VFS/ProgramFilesX64/Blender/4.2/scripts/a.py": "print()",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to add %s: %v", name, err)
+		}
+		w.Write([]byte(content))
+	}
+	zw.Close()
+	f.Close()
+
+	root := filepath.Join(dir, installerRootDir(filepath.Base(packagePath)))
+	if filepath.Base(root) != "blender-4.2.0-windows.amd64-release" {
+		t.Errorf("Unexpected root directory %s", root)
+	}
+	if err := extractMsix(packagePath, root, NewExtractTuning(nil), nil, nil); err != nil {
+		t.Fatalf("extractMsix failed: %v", err)
+	}
+
+	// The Blender directory replaces the package layout
+	for _, name := range []string{"blender.exe", filepath.Join("4.2", "scripts", "a.py")} {
+		if _, err := os.Stat(filepath.Join(root, name)); err != nil {
+			t.Errorf("Expected %s in the install directory: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "AppxManifest.xml")); !os.IsNotExist(err) {
+		t.Error("Package metadata should be removed")
+	}
+}
+
+func TestFlattenInstallWithoutExecutable(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "readme.txt"), []byte("no blender here"), 0644)
+	if err := flattenInstall(root); err == nil {
+		t.Error("Expected an error when the package has no Blender executable")
+	}
+}
@@ -0,0 +1,49 @@
+//go:build windows
+// +build windows
+
+package download
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// msiSupported reports whether MSI packages can be unpacked on this platform
+const msiSupported = true
+
+// extractMsi unpacks an MSI package into destDir with an administrative install,
+// which copies the files without registering Blender with Windows.
+func extractMsi(packagePath, destDir string, progressCb ExtractionProgressCallback, cancelCh <-chan struct{}) error {
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", destDir, err)
+	}
+	if progressCb != nil {
+		progressCb(0.0)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-cancelCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	cmd := exec.CommandContext(ctx, "msiexec", "/a", packagePath, "/qn", "TARGETDIR="+destDir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			return ErrCancelled
+		}
+		return fmt.Errorf("msiexec failed: %w: %s", err, out)
+	}
+	if progressCb != nil {
+		progressCb(1.0)
+	}
+
+	// The administrative install also leaves a copy of the package behind
+	return flattenInstall(destDir)
+}
@@ -0,0 +1,178 @@
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// JournalFilename is the file in the download directory recording installs in progress.
+// An entry left behind means the launcher was killed in the middle of an install.
+const JournalFilename = ".journal.json"
+
+// journalMu serializes journal updates from concurrent downloads
+var journalMu sync.Mutex
+
+// Operation is an install in progress: an existing build moved aside and a new one being extracted
+type Operation struct {
+	ID         string    `json:"id"`
+	Version    string    `json:"version"`
+	BackupFrom string    `json:"backup_from,omitempty"` // Existing build directory being replaced
+	BackupTo   string    `json:"backup_to,omitempty"`   // Where it is kept in .oldbuilds
+	ExtractDir string    `json:"extract_dir,omitempty"` // Root directory of the new build
+	Started    time.Time `json:"started"`
+}
+
+// Recovery describes what was done with an interrupted operation
+type Recovery struct {
+	Operation  Operation
+	RolledBack bool // The new build was removed and the replaced one restored; false if it was complete
+}
+
+// ReadJournal returns the operations recorded in the journal of downloadDir
+func ReadJournal(downloadDir string) ([]Operation, error) {
+	data, err := os.ReadFile(filepath.Join(downloadDir, JournalFilename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", JournalFilename, err)
+	}
+	var ops []Operation
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", JournalFilename, err)
+	}
+	return ops, nil
+}
+
+// writeJournal replaces the journal, removing it when no operation is left.
+// The file is written next to the journal and renamed over it so a crash never leaves it truncated.
+func writeJournal(downloadDir string, ops []Operation) error {
+	path := filepath.Join(downloadDir, JournalFilename)
+	if len(ops) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", JournalFilename, err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(ops, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", JournalFilename, err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", JournalFilename, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", JournalFilename, err)
+	}
+	return nil
+}
+
+// recordOperation adds op to the journal, or updates it if it is already recorded
+func recordOperation(downloadDir string, op Operation) error {
+	journalMu.Lock()
+	defer journalMu.Unlock()
+
+	ops, err := ReadJournal(downloadDir)
+	if err != nil {
+		return err
+	}
+	for i := range ops {
+		if ops[i].ID == op.ID {
+			ops[i] = op
+			return writeJournal(downloadDir, ops)
+		}
+	}
+	return writeJournal(downloadDir, append(ops, op))
+}
+
+// finishOperation removes an operation from the journal
+func finishOperation(downloadDir, id string) error {
+	journalMu.Lock()
+	defer journalMu.Unlock()
+
+	ops, err := ReadJournal(downloadDir)
+	if err != nil {
+		return err
+	}
+	kept := ops[:0]
+	for _, op := range ops {
+		if op.ID != id {
+			kept = append(kept, op)
+		}
+	}
+	return writeJournal(downloadDir, kept)
+}
+
+// rollBack removes the partially installed build and moves the replaced build back in place
+func rollBack(op Operation) error {
+	if op.ExtractDir != "" {
+		if err := os.RemoveAll(op.ExtractDir); err != nil {
+			return fmt.Errorf("failed to remove partial install %s: %w", op.ExtractDir, err)
+		}
+	}
+	if op.BackupFrom == "" || op.BackupTo == "" {
+		return nil
+	}
+	if _, err := os.Stat(op.BackupTo); err != nil {
+		// The backup rename never happened
+		return nil
+	}
+	if _, err := os.Stat(op.BackupFrom); err == nil {
+		return fmt.Errorf("cannot restore %s: the directory exists", op.BackupFrom)
+	}
+	if err := os.Rename(op.BackupTo, op.BackupFrom); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", op.BackupFrom, err)
+	}
+	return nil
+}
+
+// complete reports whether the build of an operation was fully installed, i.e. its metadata was saved
+func (op Operation) complete() bool {
+	if op.ExtractDir == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(op.ExtractDir, versionMetaFilename))
+	return err == nil
+}
+
+// RecoverJournal resolves operations interrupted by a crash. Installs that got as far as saving
+// their metadata are kept; all others are rolled back. Operations that can't be rolled back stay
+// in the journal and are reported in the error.
+func RecoverJournal(downloadDir string) ([]Recovery, error) {
+	journalMu.Lock()
+	defer journalMu.Unlock()
+
+	ops, err := ReadJournal(downloadDir)
+	if err != nil || len(ops) == 0 {
+		return nil, err
+	}
+
+	var recovered []Recovery
+	var failed []Operation
+	var firstErr error
+	for _, op := range ops {
+		if op.complete() {
+			recovered = append(recovered, Recovery{Operation: op})
+			continue
+		}
+		if err := rollBack(op); err != nil {
+			failed = append(failed, op)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to recover install of %s: %w", op.Version, err)
+			}
+			continue
+		}
+		recovered = append(recovered, Recovery{Operation: op, RolledBack: true})
+	}
+
+	if err := writeJournal(downloadDir, failed); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return recovered, firstErr
+}
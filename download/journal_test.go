@@ -0,0 +1,116 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/model"
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecoverJournalRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	installed := filepath.Join(dir, "blender-4.2.0-abc")
+	backup := filepath.Join(dir, OldBuildsDir, "blender-4.2.0-abc_20250101_120000")
+	partial := filepath.Join(dir, "blender-4.2.0-def")
+
+	// Killed during extraction: the old build is in .oldbuilds, the new one half extracted
+	if err := os.MkdirAll(backup, 0755); err != nil {
+		t.Fatalf("Failed to create backup: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(partial, "4.2"), 0755); err != nil {
+		t.Fatalf("Failed to create partial install: %v", err)
+	}
+	op := Operation{ID: "4.2.0-1", Version: "4.2.0", BackupFrom: installed, BackupTo: backup, ExtractDir: partial}
+	if err := recordOperation(dir, op); err != nil {
+		t.Fatalf("recordOperation failed: %v", err)
+	}
+
+	recovered, err := RecoverJournal(dir)
+	if err != nil {
+		t.Fatalf("RecoverJournal failed: %v", err)
+	}
+	if len(recovered) != 1 || !recovered[0].RolledBack {
+		t.Fatalf("Expected one rolled back operation, got %+v", recovered)
+	}
+	if _, err := os.Stat(partial); !os.IsNotExist(err) {
+		t.Error("Partial install should be removed")
+	}
+	if _, err := os.Stat(installed); err != nil {
+		t.Errorf("Replaced build should be restored: %v", err)
+	}
+	if ops, _ := ReadJournal(dir); len(ops) != 0 {
+		t.Errorf("Journal should be empty, got %d operations", len(ops))
+	}
+}
+
+func TestRecoverJournalKeepsCompleteInstall(t *testing.T) {
+	dir := t.TempDir()
+	extracted := filepath.Join(dir, "blender-4.3.0-abc")
+	if err := os.MkdirAll(extracted, 0755); err != nil {
+		t.Fatalf("Failed to create install: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(extracted, versionMetaFilename), []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+	if err := recordOperation(dir, Operation{ID: "4.3.0-1", Version: "4.3.0", ExtractDir: extracted}); err != nil {
+		t.Fatalf("recordOperation failed: %v", err)
+	}
+
+	recovered, err := RecoverJournal(dir)
+	if err != nil {
+		t.Fatalf("RecoverJournal failed: %v", err)
+	}
+	if len(recovered) != 1 || recovered[0].RolledBack {
+		t.Fatalf("Expected one completed operation, got %+v", recovered)
+	}
+	if _, err := os.Stat(extracted); err != nil {
+		t.Errorf("Complete install should be kept: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, JournalFilename)); !os.IsNotExist(err) {
+		t.Error("Journal file should be removed once empty")
+	}
+}
+
+func TestInstallArchiveRollsBackOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(t.TempDir(), "blender-4.2.0-windows-x64.zip")
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("blender-4.2.0-windows-x64/blender.exe")
+	if err != nil {
+		t.Fatalf("Failed to add blender.exe: %v", err)
+	}
+	w.Write([]byte("exe"))
+	zw.Close()
+	f.Close()
+
+	installed := filepath.Join(dir, "blender-4.2.0-windows-x64")
+	if err := os.MkdirAll(installed, 0755); err != nil {
+		t.Fatalf("Failed to create install: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(installed, versionMetaFilename), []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+
+	// Cancelled during the extraction, after the installed build was moved to .oldbuilds
+	cancelCh := make(chan struct{})
+	close(cancelCh)
+	build := model.BlenderBuild{Version: "4.2.0"}
+	if _, err := installArchive(build, archivePath, dir, nil, nil, nil, cancelCh); !errors.Is(err, ErrCancelled) {
+		t.Fatalf("Expected the install to be cancelled, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(installed, versionMetaFilename)); err != nil {
+		t.Errorf("Replaced build should be restored: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(installed, "blender.exe")); !os.IsNotExist(err) {
+		t.Error("Partial install should be removed")
+	}
+	if ops, _ := ReadJournal(dir); len(ops) != 0 {
+		t.Errorf("Journal should be empty after the rollback, got %d operations", len(ops))
+	}
+}
@@ -0,0 +1,32 @@
+package download
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readAvailableMemory returns the memory available to new allocations without swapping, read from /proc/meminfo
+func readAvailableMemory() (uint64, bool) {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// MemAvailable:    8123456 kB
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemAvailable:" {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return kb * 1024, true
+	}
+	return 0, false
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package download
+
+// readAvailableMemory reports the available memory as unknown, it is only read on Linux
+func readAvailableMemory() (uint64, bool) {
+	return 0, false
+}
@@ -0,0 +1,75 @@
+package download
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+// mirrorURLs returns the primary download URL followed by the same file on each mirror.
+// A mirror is a base URL replacing the scheme and host of the primary URL; the path is kept,
+// so mirrors must follow the builder's directory layout.
+func mirrorURLs(primary string, mirrors []string) []string {
+	urls := []string{primary}
+	parsed, err := url.Parse(primary)
+	if err != nil {
+		return urls
+	}
+	for _, mirror := range mirrors {
+		mirror = strings.TrimRight(strings.TrimSpace(mirror), "/")
+		if mirror == "" {
+			continue
+		}
+		urls = append(urls, mirror+parsed.EscapedPath())
+	}
+	return urls
+}
+
+// sourceURLs returns the URLs to try for a file in order: the preferred mirror first when set,
+// then the primary URL and the other mirrors.
+func sourceURLs(primary string, mirrors []string, preferred string) []string {
+	urls := mirrorURLs(primary, mirrors)
+	preferredURLs := mirrorURLs(primary, []string{preferred})
+	if len(preferredURLs) < 2 {
+		return urls
+	}
+	i := slices.Index(urls, preferredURLs[1])
+	if i < 0 {
+		return urls
+	}
+	first := urls[i]
+	return append([]string{first}, slices.Delete(urls, i, i+1)...)
+}
+
+// downloadFromMirrors downloads a file from the preferred mirror or the primary URL, falling back
+// to the other sources in order. It returns the URL that served the file.
+func downloadFromMirrors(primary string, mirrors []string, preferred string, destFilePath string, progressCb ProgressCallback, cancelCh <-chan struct{}) (string, error) {
+	var errs []string
+	for _, u := range sourceURLs(primary, mirrors, preferred) {
+		err := downloadFile(u, destFilePath, progressCb, cancelCh)
+		if err == nil {
+			return u, nil
+		}
+		if errors.Is(err, ErrCancelled) {
+			return "", ErrCancelled
+		}
+		// Start the next source from scratch
+		os.Remove(destFilePath)
+		errs = append(errs, fmt.Sprintf("%s: %v", hostOf(u), err))
+	}
+	if len(errs) == 1 {
+		return "", errors.New(errs[0])
+	}
+	return "", fmt.Errorf("all download sources failed: %s", strings.Join(errs, "; "))
+}
+
+// hostOf returns the host of a URL, or the URL itself if it can't be parsed
+func hostOf(rawURL string) string {
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	return rawURL
+}
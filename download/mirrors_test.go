@@ -0,0 +1,40 @@
+package download
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMirrorURLs(t *testing.T) {
+	primary := "https://builder.blender.org/download/daily/blender-4.2.0-linux-x64.tar.xz"
+	got := mirrorURLs(primary, []string{"https://mirror.example.org/", " ", "http://10.0.0.1:8080"})
+	want := []string{
+		primary,
+		"https://mirror.example.org/download/daily/blender-4.2.0-linux-x64.tar.xz",
+		"http://10.0.0.1:8080/download/daily/blender-4.2.0-linux-x64.tar.xz",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mirrorURLs() = %v, want %v", got, want)
+	}
+}
+
+func TestSourceURLs(t *testing.T) {
+	primary := "https://builder.blender.org/download/daily/blender-4.2.0-linux-x64.tar.xz"
+	mirrors := []string{"https://a.example.org", "https://b.example.org/"}
+	got := sourceURLs(primary, mirrors, "https://b.example.org/")
+	want := []string{
+		"https://b.example.org/download/daily/blender-4.2.0-linux-x64.tar.xz",
+		primary,
+		"https://a.example.org/download/daily/blender-4.2.0-linux-x64.tar.xz",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sourceURLs() = %v, want %v", got, want)
+	}
+
+	// Without a preferred mirror, or one that isn't configured, the builder comes first
+	for _, preferred := range []string{"", "https://c.example.org"} {
+		if got := sourceURLs(primary, mirrors, preferred); !reflect.DeepEqual(got, mirrorURLs(primary, mirrors)) {
+			t.Errorf("sourceURLs() with preferred %q = %v, want the builder first", preferred, got)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package download
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Filesystem describes the filesystem holding a download directory
+type Filesystem struct {
+	Network  bool   // NFS, SMB and the like, where extracting thousands of small files is slow
+	Type     string // Name of the network filesystem, e.g. "nfs"
+	Symlinks bool   // Whether symbolic links can be created on it
+}
+
+// DetectFilesystem inspects the filesystem holding dir, or its closest existing parent
+func DetectFilesystem(dir string) Filesystem {
+	for {
+		if _, err := os.Stat(dir); err == nil || filepath.Dir(dir) == dir {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	fsType, network := networkFilesystem(dir)
+	return Filesystem{Network: network, Type: fsType, Symlinks: symlinksSupported(dir)}
+}
+
+// Warning describes the consequences of installing builds onto a network filesystem, empty for a local one
+func (fs Filesystem) Warning(dir string) string {
+	switch {
+	case fs.Network && !fs.Symlinks:
+		return fmt.Sprintf("%s is on a network filesystem (%s) without symlink support; builds are extracted locally first and their symlinks copied as files", dir, fs.Type)
+	case fs.Network:
+		return fmt.Sprintf("%s is on a network filesystem (%s); builds are extracted locally first, installs take longer", dir, fs.Type)
+	}
+	return ""
+}
+
+// symlinksSupported reports whether a symbolic link can be created in dir.
+// A directory that can't be written to is assumed to support them, writing fails anyway.
+func symlinksSupported(dir string) bool {
+	probe, err := os.MkdirTemp(dir, ".symlink-probe-*")
+	if err != nil {
+		return true
+	}
+	defer os.RemoveAll(probe)
+	return os.Symlink("target", filepath.Join(probe, "link")) == nil
+}
+
+// moveTree moves the extracted directory src to dst, which may be on another filesystem.
+// When dst can't hold symlinks, they are replaced by copies of the files they point to.
+func moveTree(src, dst string, symlinks bool) error {
+	if symlinks {
+		if err := rename(src, dst); err == nil {
+			return nil
+		}
+	}
+	if err := copyTree(src, dst, symlinks); err != nil {
+		os.RemoveAll(dst)
+		return fmt.Errorf("failed to move %s to %s: %w", filepath.Base(src), filepath.Dir(dst), err)
+	}
+	return os.RemoveAll(src)
+}
+
+// copyTree copies the directory src to dst, keeping symlinks as links when symlinks is set
+func copyTree(src, dst string, symlinks bool) error {
+	return filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return mkdirAll(target, info.Mode().Perm()|0700)
+		case d.Type()&os.ModeSymlink != 0:
+			if symlinks {
+				link, err := os.Readlink(path)
+				if err != nil {
+					return err
+				}
+				return os.Symlink(link, target)
+			}
+			// Copy what the link points to; links pointing nowhere are dropped
+			resolved, err := os.Stat(path)
+			if err != nil {
+				return nil
+			}
+			if resolved.IsDir() {
+				real, err := filepath.EvalSymlinks(path)
+				if err != nil {
+					return err
+				}
+				return copyTree(real, target, false)
+			}
+			return copyFile(path, target, resolved.Mode())
+		case d.Type().IsRegular():
+			return copyFile(path, target, info.Mode())
+		}
+		return nil
+	})
+}
+
+// copyFile copies the contents of src, following symlinks, to a new file dst
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := createFile(dst, mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
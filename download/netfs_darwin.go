@@ -0,0 +1,22 @@
+package download
+
+import "syscall"
+
+// networkTypes lists the macOS filesystem types of network shares
+var networkTypes = map[string]bool{"nfs": true, "smbfs": true, "afpfs": true, "webdav": true, "cifs": true}
+
+// networkFilesystem returns the name of the network filesystem holding path, if it is on one
+func networkFilesystem(path string) (string, bool) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return "", false
+	}
+	var name []byte
+	for _, c := range stat.Fstypename {
+		if c == 0 {
+			break
+		}
+		name = append(name, byte(c))
+	}
+	return string(name), networkTypes[string(name)]
+}
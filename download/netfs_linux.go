@@ -0,0 +1,26 @@
+package download
+
+import "syscall"
+
+// networkMagics maps the statfs magic numbers of network filesystems to their names
+var networkMagics = map[int64]string{
+	0x6969:     "nfs",
+	0x517b:     "smb",
+	0xff534d42: "cifs",
+	0xfe534d42: "smb2",
+	0x5346414f: "afs",
+	0x00c36400: "ceph",
+	0x01021997: "9p",
+	0x73757245: "coda",
+	0x564c:     "ncp",
+}
+
+// networkFilesystem returns the name of the network filesystem holding path, if it is on one
+func networkFilesystem(path string) (string, bool) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return "", false
+	}
+	name, ok := networkMagics[int64(stat.Type)]
+	return name, ok
+}
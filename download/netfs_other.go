@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
+
+package download
+
+// networkFilesystem reports no network filesystem, there is no detection on this platform
+func networkFilesystem(path string) (string, bool) {
+	return "", false
+}
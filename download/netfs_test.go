@@ -0,0 +1,58 @@
+package download
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMoveTreeWithoutSymlinks(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "blender-4.3.0")
+	if err := os.MkdirAll(filepath.Join(src, "lib"), 0755); err != nil {
+		t.Fatalf("Failed to create build: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "lib", "libfoo.so.1"), []byte("foo"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.Symlink("libfoo.so.1", filepath.Join(src, "lib", "libfoo.so")); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+	if err := os.Symlink("lib", filepath.Join(src, "libs")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	os.Symlink("missing", filepath.Join(src, "dangling"))
+
+	dst := filepath.Join(t.TempDir(), "blender-4.3.0")
+	if err := moveTree(src, dst, false); err != nil {
+		t.Fatalf("moveTree failed: %v", err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Errorf("Expected the source to be removed, got %v", err)
+	}
+
+	for _, name := range []string{"lib/libfoo.so", "libs/libfoo.so.1"} {
+		info, err := os.Lstat(filepath.Join(dst, name))
+		if err != nil || info.Mode()&os.ModeSymlink != 0 {
+			t.Errorf("Expected %s to be copied as a regular file, got %v (%v)", name, info, err)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, "lib", "libfoo.so")); string(data) != "foo" {
+		t.Errorf("Expected the link target's contents, got %q", data)
+	}
+	if _, err := os.Lstat(filepath.Join(dst, "dangling")); !os.IsNotExist(err) {
+		t.Errorf("Expected the dangling link to be dropped, got %v", err)
+	}
+}
+
+func TestFilesystemWarning(t *testing.T) {
+	if w := (Filesystem{Symlinks: true}).Warning("/builds"); w != "" {
+		t.Errorf("Expected no warning for a local filesystem, got %q", w)
+	}
+	if w := (Filesystem{Network: true, Type: "nfs", Symlinks: true}).Warning("/builds"); !strings.Contains(w, "network filesystem (nfs)") {
+		t.Errorf("Expected a network filesystem warning, got %q", w)
+	}
+	if fs := DetectFilesystem(filepath.Join(t.TempDir(), "not", "created")); fs.Network {
+		t.Errorf("Expected the temp directory to be local, got %+v", fs)
+	}
+}
@@ -0,0 +1,30 @@
+package download
+
+import (
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// driveRemote is the GetDriveType result of a mapped network drive
+const driveRemote = 4
+
+var procGetDriveType = syscall.NewLazyDLL("kernel32.dll").NewProc("GetDriveTypeW")
+
+// networkFilesystem reports whether path is on a network share, either a UNC path or a mapped drive
+func networkFilesystem(path string) (string, bool) {
+	volume := filepath.VolumeName(path)
+	if strings.HasPrefix(volume, `\\`) {
+		return "smb", true
+	}
+	rootPtr, err := syscall.UTF16PtrFromString(volume + `\`)
+	if err != nil {
+		return "", false
+	}
+	ret, _, _ := procGetDriveType.Call(uintptr(unsafe.Pointer(rootPtr)))
+	if ret == driveRemote {
+		return "smb", true
+	}
+	return "", false
+}
@@ -0,0 +1,94 @@
+package download
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// speedTestBytes is how much of the sample file is requested from each source
+const speedTestBytes = 4 << 20
+
+// speedTestTimeout bounds the test of a single source, a slow source is measured on what arrived until then
+const speedTestTimeout = 15 * time.Second
+
+// SourceSpeed is the outcome of the speed test of a download source
+type SourceSpeed struct {
+	Mirror     string        // Base URL of the mirror, empty for the builder
+	Host       string        // Host that was tested
+	Latency    time.Duration // Time until the response headers arrived
+	Throughput float64       // Bytes per second while receiving the body
+	Err        error         // Why the source failed, nil if it answered
+}
+
+// SpeedTest requests the start of sampleURL from the builder and each mirror in turn with a ranged
+// request and measures how fast they answer. Sources are tested one after the other so they don't
+// share the bandwidth. The results are ranked fastest first, failed sources last.
+func SpeedTest(sampleURL string, mirrors []string) []SourceSpeed {
+	var results []SourceSpeed
+	results = append(results, testSource(sampleURL))
+	for _, mirror := range mirrors {
+		if urls := mirrorURLs(sampleURL, []string{mirror}); len(urls) == 2 {
+			result := testSource(urls[1])
+			result.Mirror = mirror
+			results = append(results, result)
+		}
+	}
+	RankSources(results)
+	return results
+}
+
+// RankSources sorts speed test results fastest first, failed sources last
+func RankSources(results []SourceSpeed) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if (a.Err == nil) != (b.Err == nil) {
+			return a.Err == nil
+		}
+		return a.Throughput > b.Throughput
+	})
+}
+
+// testSource measures the latency and throughput of a single URL
+func testSource(u string) SourceSpeed {
+	result := SourceSpeed{Host: hostOf(u)}
+	client := &http.Client{Timeout: speedTestTimeout}
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to create request: %w", err)
+		return result
+	}
+	req.Header.Set("User-Agent", "TUI-Blender-Launcher")
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", speedTestBytes-1))
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+	result.Latency = time.Since(start)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		result.Err = fmt.Errorf("unexpected response: %s", resp.Status)
+		return result
+	}
+
+	// A server ignoring the range sends the whole file, only the sample size is read
+	bodyStart := time.Now()
+	n, err := io.CopyN(io.Discard, resp.Body, speedTestBytes)
+	elapsed := time.Since(bodyStart)
+	if err != nil && !errors.Is(err, io.EOF) && n == 0 {
+		result.Err = err
+		return result
+	}
+	if n == 0 {
+		result.Err = errors.New("no data received")
+		return result
+	}
+	result.Throughput = float64(n) / max(elapsed.Seconds(), 0.001)
+	return result
+}
@@ -0,0 +1,56 @@
+package download
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSpeedTest(t *testing.T) {
+	data := bytes.Repeat([]byte("x"), 1<<20)
+	builder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		http.ServeContent(w, r, "blender.tar.xz", time.Time{}, bytes.NewReader(data))
+	}))
+	defer builder.Close()
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "blender.tar.xz", time.Time{}, bytes.NewReader(data))
+	}))
+	defer mirror.Close()
+	broken := httptest.NewServer(http.NotFoundHandler())
+	defer broken.Close()
+
+	results := SpeedTest(builder.URL+"/download/daily/blender.tar.xz", []string{broken.URL, mirror.URL + "/"})
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for i, result := range results[:2] {
+		if result.Err != nil || result.Throughput <= 0 {
+			t.Errorf("Result %d: expected a measured source, got %+v", i, result)
+		}
+	}
+	for _, result := range results[:2] {
+		if result.Mirror == "" && result.Latency < 50*time.Millisecond {
+			t.Errorf("Expected the latency of the builder to include its delay, got %v", result.Latency)
+		}
+	}
+	if last := results[2]; last.Err == nil || last.Mirror != broken.URL {
+		t.Errorf("Expected the broken mirror last with an error, got %+v", last)
+	}
+}
+
+func TestRankSources(t *testing.T) {
+	results := []SourceSpeed{
+		{Host: "failed", Err: http.ErrHandlerTimeout},
+		{Host: "slow", Throughput: 1 << 20},
+		{Host: "fast", Throughput: 10 << 20},
+	}
+	RankSources(results)
+	for i, host := range []string{"fast", "slow", "failed"} {
+		if results[i].Host != host {
+			t.Errorf("Position %d: expected %s, got %s", i, host, results[i].Host)
+		}
+	}
+}
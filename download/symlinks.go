@@ -0,0 +1,98 @@
+package download
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// linkFallback collects the symlinks of an archive that couldn't be created during extraction,
+// on filesystems without symlink support such as exFAT, FAT32 or Windows without developer mode
+type linkFallback struct {
+	pending []pendingLink
+}
+
+// pendingLink is a symlink at path pointing to linkname, as stored in the archive
+type pendingLink struct {
+	path     string
+	linkname string
+}
+
+// add records a symlink to materialize once the archive is extracted
+func (f *linkFallback) add(path, linkname string) {
+	f.pending = append(f.pending, pendingLink{path: path, linkname: linkname})
+}
+
+// materialize replaces the collected symlinks with copies of the files or directories they point to.
+// Links are resolved once the whole archive is extracted, as their targets may come later in it.
+// Links pointing outside root, to a missing file, or to another link that can't be resolved are skipped.
+// Returns the warnings to record with the build, nil when there were no links to replace.
+func (f *linkFallback) materialize(root string) []string {
+	if len(f.pending) == 0 {
+		return nil
+	}
+
+	var warnings []string
+	skip := func(link pendingLink, reason string) {
+		rel, _ := filepath.Rel(root, link.path)
+		warnings = append(warnings, fmt.Sprintf("symlink %s -> %s skipped: %s", filepath.ToSlash(rel), link.linkname, reason))
+	}
+
+	// Links pointing to other links are copied once their target exists; repeat while that makes progress
+	copied := 0
+	pending := f.pending
+	for len(pending) > 0 {
+		waiting := make(map[string]bool, len(pending))
+		for _, link := range pending {
+			waiting[link.path] = true
+		}
+
+		var next []pendingLink
+		for _, link := range pending {
+			if filepath.IsAbs(link.linkname) {
+				skip(link, "absolute target")
+				continue
+			}
+			target := filepath.Join(filepath.Dir(link.path), filepath.FromSlash(link.linkname))
+			if rel, err := filepath.Rel(root, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				skip(link, "target outside the build")
+				continue
+			}
+			if waiting[target] {
+				next = append(next, link)
+				continue
+			}
+
+			info, err := os.Stat(target)
+			if err != nil {
+				skip(link, "target missing")
+				continue
+			}
+			if info.IsDir() {
+				err = copyTree(target, link.path, false)
+			} else {
+				err = copyFile(target, link.path, info.Mode())
+			}
+			if err != nil {
+				skip(link, err.Error())
+				continue
+			}
+			copied++
+		}
+
+		if len(next) == len(pending) {
+			for _, link := range next {
+				skip(link, "circular link")
+			}
+			break
+		}
+		pending = next
+	}
+
+	f.pending = nil
+	if copied > 0 {
+		warnings = append([]string{fmt.Sprintf("%d symlink(s) copied as files, the filesystem doesn't support symlinks", copied)}, warnings...)
+	}
+	return warnings
+}
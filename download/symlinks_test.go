@@ -0,0 +1,55 @@
+package download
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLinkFallbackMaterialize(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "blender-4.3.0-linux-x64")
+	lib := filepath.Join(root, "lib")
+	if err := os.MkdirAll(filepath.Join(lib, "python"), 0755); err != nil {
+		t.Fatalf("Failed to create build: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(lib, "libfoo.so.1.2"), []byte("foo"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(lib, "python", "site.py"), []byte("site"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	var links linkFallback
+	links.add(filepath.Join(lib, "libfoo.so"), "libfoo.so.1") // Points to a link listed after it
+	links.add(filepath.Join(lib, "libfoo.so.1"), "libfoo.so.1.2")
+	links.add(filepath.Join(root, "python"), "lib/python")
+	links.add(filepath.Join(lib, "missing.so"), "libmissing.so.3")
+	links.add(filepath.Join(lib, "escape"), "../../outside")
+
+	warnings := links.materialize(root)
+	for _, name := range []string{"lib/libfoo.so", "lib/libfoo.so.1"} {
+		if data, err := os.ReadFile(filepath.Join(root, name)); err != nil || string(data) != "foo" {
+			t.Errorf("Expected %s to be a copy of libfoo.so.1.2, got %q (%v)", name, data, err)
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "python", "site.py")); err != nil || string(data) != "site" {
+		t.Errorf("Expected the linked directory to be copied, got %q (%v)", data, err)
+	}
+
+	all := strings.Join(warnings, "\n")
+	for _, want := range []string{
+		"3 symlink(s) copied as files",
+		"symlink lib/missing.so -> libmissing.so.3 skipped: target missing",
+		"symlink lib/escape -> ../../outside skipped: target outside the build",
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("Expected warning %q, got:\n%s", want, all)
+		}
+	}
+
+	var none linkFallback
+	if warnings := none.materialize(root); warnings != nil {
+		t.Errorf("Expected no warnings without links, got %v", warnings)
+	}
+}
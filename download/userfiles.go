@@ -0,0 +1,57 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// isolatedConfigDir is the Blender user config kept inside a build directory, see local.IsolatedConfigDir
+const isolatedConfigDir = "isolated-config"
+
+// restoreUserFiles carries what the user set up for a build over from the previous install of the same build,
+// e.g. when a broken build is downloaded again: the label, notes, tags, promotion and launch profile from its version.json,
+// unless build has its own, and its isolated user config. Previous installs of another build are left alone.
+func restoreUserFiles(build *model.BlenderBuild, previousDir, installDir string) error {
+	data, err := os.ReadFile(filepath.Join(previousDir, versionMetaFilename))
+	if err != nil {
+		return nil
+	}
+	var previous model.BlenderBuild
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil
+	}
+	if previous.Version != build.Version || previous.Hash != build.Hash || previous.Architecture != build.Architecture {
+		return nil
+	}
+
+	if build.Label == "" {
+		build.Label = previous.Label
+	}
+	if build.Notes == "" {
+		build.Notes = previous.Notes
+	}
+	if len(build.Tags) == 0 {
+		build.Tags = previous.Tags
+	}
+	if build.Promotion == "" {
+		build.Promotion = previous.Promotion
+	}
+	if build.LaunchProfile == "" {
+		build.LaunchProfile = previous.LaunchProfile
+	}
+
+	configDir := filepath.Join(previousDir, isolatedConfigDir)
+	if _, err := os.Stat(configDir); err != nil {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(installDir, isolatedConfigDir)); err == nil {
+		return nil
+	}
+	if err := rename(configDir, filepath.Join(installDir, isolatedConfigDir)); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", isolatedConfigDir, err)
+	}
+	return nil
+}
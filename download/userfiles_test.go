@@ -0,0 +1,64 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRestoreUserFiles(t *testing.T) {
+	dir := t.TempDir()
+	previousDir := filepath.Join(dir, OldBuildsDir, "blender-4.2.0-linux_20260101_120000")
+	installDir := filepath.Join(dir, "blender-4.2.0-linux")
+	for _, d := range []string{filepath.Join(previousDir, isolatedConfigDir, "4.2", "config"), installDir} {
+		if err := os.MkdirAll(d, 0750); err != nil {
+			t.Fatalf("Failed to create %s: %v", d, err)
+		}
+	}
+	userPref := filepath.Join(isolatedConfigDir, "4.2", "config", "userpref.blend")
+	if err := os.WriteFile(filepath.Join(previousDir, userPref), []byte("prefs"), 0644); err != nil {
+		t.Fatalf("Failed to write preferences: %v", err)
+	}
+
+	previous := model.BlenderBuild{
+		Version:       "4.2.0",
+		Hash:          "abc123",
+		Label:         "Lighting",
+		Notes:         "Use for shot 10",
+		Tags:          []string{"lighting"},
+		Promotion:     model.PromotionApproved,
+		LaunchProfile: "CUDA headless",
+	}
+	data, _ := json.Marshal(previous)
+	if err := os.WriteFile(filepath.Join(previousDir, versionMetaFilename), data, 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+
+	// Another build of the same version is a different install, nothing is carried over
+	other := model.BlenderBuild{Version: "4.2.0", Hash: "def456"}
+	if err := restoreUserFiles(&other, previousDir, installDir); err != nil {
+		t.Fatalf("restoreUserFiles failed: %v", err)
+	}
+	if other.Label != "" {
+		t.Errorf("Expected no label for another build, got %q", other.Label)
+	}
+	if _, err := os.Stat(filepath.Join(installDir, userPref)); !os.IsNotExist(err) {
+		t.Errorf("Expected the user config to stay with the previous install, got %v", err)
+	}
+
+	build := model.BlenderBuild{Version: "4.2.0", Hash: "abc123", Notes: "Reinstalled"}
+	if err := restoreUserFiles(&build, previousDir, installDir); err != nil {
+		t.Fatalf("restoreUserFiles failed: %v", err)
+	}
+	if build.Label != "Lighting" || build.Promotion != model.PromotionApproved || len(build.Tags) != 1 || build.LaunchProfile != "CUDA headless" {
+		t.Errorf("Expected the user metadata to be restored, got %+v", build)
+	}
+	if build.Notes != "Reinstalled" {
+		t.Errorf("Expected the build's own notes to be kept, got %q", build.Notes)
+	}
+	if data, err := os.ReadFile(filepath.Join(installDir, userPref)); err != nil || string(data) != "prefs" {
+		t.Errorf("Expected the user config to be restored, got %q, %v", data, err)
+	}
+}
@@ -0,0 +1,150 @@
+package download
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrChecksumMismatch is returned when a downloaded archive doesn't match the published checksum
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// checksumTimeout bounds the request for the published checksum
+const checksumTimeout = 30 * time.Second
+
+// treeChecksumSkip lists files and directories that change while a build is used
+// and are therefore left out of the installed tree checksum.
+var treeChecksumSkip = map[string]bool{
+	versionMetaFilename: true,
+	"__pycache__":       true,
+	isolatedConfigDir:   true,
+}
+
+// fetchChecksum downloads the SHA-256 checksum the builder publishes next to an archive.
+// The file holds "<hash>  <file name>".
+func fetchChecksum(archiveURL string) (string, error) {
+	client := &http.Client{Timeout: checksumTimeout}
+	resp, err := client.Get(archiveURL + ".sha256")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch checksum: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch checksum: status code %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum: %w", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty checksum file")
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+// fileChecksum returns the SHA-256 checksum of a file.
+func fileChecksum(path string) (string, error) {
+	return fileChecksumProgress(path, nil)
+}
+
+// fileChecksumProgress returns the SHA-256 checksum of a file, reporting the bytes hashed to progressCb if not nil
+func fileChecksumProgress(path string, progressCb ProgressCallback) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var total int64
+	if info, err := file.Stat(); err == nil {
+		total = info.Size()
+	}
+	hash := sha256.New()
+	buf := make([]byte, 1<<20)
+	var hashed int64
+	for {
+		n, err := file.Read(buf)
+		hash.Write(buf[:n])
+		hashed += int64(n)
+		if progressCb != nil && n > 0 {
+			progressCb(hashed, total)
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return "", fmt.Errorf("failed to hash %s: %w", path, err)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// verifyArchive checks a downloaded archive against the checksum published next to the first
+// of archiveURLs that has one. verified is false when none does. progressCb, if not nil, follows the hashing.
+func verifyArchive(archiveURLs []string, archivePath string, progressCb ProgressCallback) (sum string, verified bool, err error) {
+	sum, err = fileChecksumProgress(archivePath, progressCb)
+	if err != nil {
+		return "", false, err
+	}
+	var expected string
+	for _, archiveURL := range archiveURLs {
+		if expected, err = fetchChecksum(archiveURL); err == nil {
+			break
+		}
+	}
+	if expected == "" {
+		return sum, false, nil
+	}
+	if expected != sum {
+		return sum, false, fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, sum)
+	}
+	return sum, true, nil
+}
+
+// TreeChecksum returns a SHA-256 checksum over the paths and contents of the files of an
+// installed build, leaving out files that change while the build is used.
+func TreeChecksum(dir string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && treeChecksumSkip[d.Name()] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() && !strings.HasSuffix(d.Name(), ".pyc") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list files of %s: %w", dir, err)
+	}
+	sort.Strings(files)
+
+	tree := sha256.New()
+	for _, path := range files {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return "", err
+		}
+		sum, err := fileChecksum(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(tree, "%s %s\n", sum, filepath.ToSlash(rel))
+	}
+	return hex.EncodeToString(tree.Sum(nil)), nil
+}
@@ -0,0 +1,85 @@
+package download
+
+import (
+	"TUI-Blender-Launcher/model"
+	"archive/zip"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTreeChecksum(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	write("blender", "binary")
+	write("4.2/scripts/startup.py", "print()")
+
+	before, err := TreeChecksum(dir)
+	if err != nil {
+		t.Fatalf("TreeChecksum returned an error: %v", err)
+	}
+
+	// Files that change while a build is used don't affect the checksum
+	write(versionMetaFilename, "{}")
+	write("4.2/scripts/__pycache__/startup.cpython-311.pyc", "bytecode")
+	if after, _ := TreeChecksum(dir); after != before {
+		t.Error("Checksum changed after writing metadata and bytecode caches")
+	}
+
+	// Changed build files do
+	write("blender", "tampered")
+	if after, _ := TreeChecksum(dir); after == before {
+		t.Error("Checksum did not change after modifying a build file")
+	}
+}
+
+func TestDownloadChecksKnownChecksum(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "blender-4.2.0-windows-x64.zip")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	w, _ := zw.Create("blender-4.2.0-windows-x64/blender.exe")
+	w.Write([]byte("exe"))
+	zw.Close()
+	f.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/blender-4.2.0-windows-x64.zip" {
+			http.NotFound(w, r) // No published checksum
+			return
+		}
+		http.ServeFile(w, r, archive)
+	}))
+	defer server.Close()
+
+	// Not the archive the checksum was recorded from: nothing is extracted and the archive is deleted
+	dir := t.TempDir()
+	build := model.BlenderBuild{Version: "4.2.0", DownloadURL: server.URL + "/blender-4.2.0-windows-x64.zip", SHA256: "aaa"}
+	if _, err := DownloadAndExtractBuild(build, dir, nil, nil, nil, nil); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Expected a checksum mismatch, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "blender-4.2.0-windows-x64")); !os.IsNotExist(err) {
+		t.Error("Expected nothing to be extracted")
+	}
+	if entries, _ := os.ReadDir(filepath.Join(dir, DownloadingDir)); len(entries) != 0 {
+		t.Errorf("Expected the archive to be deleted, got %d entries", len(entries))
+	}
+
+	// The right checksum installs it
+	build.SHA256, _ = fileChecksum(archive)
+	if _, err := DownloadAndExtractBuild(build, dir, nil, nil, nil, nil); err != nil {
+		t.Fatalf("Expected the install to succeed, got %v", err)
+	}
+}
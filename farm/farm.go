@@ -0,0 +1,246 @@
+// Package farm exports the installed builds as a provisioning manifest for render farm nodes,
+// and installs the builds of a manifest on a node without the TUI.
+package farm
+
+import (
+	"TUI-Blender-Launcher/api"
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	// ManifestFilename is the manifest written by Export
+	ManifestFilename = "farm-manifest.json"
+	// The bootstrap scripts written next to it, for Linux and macOS nodes and for Windows nodes
+	shellScriptFilename      = "farm-bootstrap.sh"
+	powershellScriptFilename = "farm-bootstrap.ps1"
+)
+
+// Entry is a build a farm node installs, pinned by its commit hash
+type Entry struct {
+	Version      string `json:"version"`
+	Branch       string `json:"branch"`
+	Hash         string `json:"hash"`
+	Platform     string `json:"platform"`
+	Architecture string `json:"architecture"`
+	URL          string `json:"url"`
+	Size         int64  `json:"file_size"`
+	SHA256       string `json:"sha256,omitempty"` // Archive checksum recorded when the launcher installed it
+	Label        string `json:"label,omitempty"`
+}
+
+// Manifest lists the builds every farm node should have installed
+type Manifest struct {
+	Generated time.Time `json:"generated"`
+	Builds    []Entry   `json:"builds"`
+}
+
+// NewManifest lists the builds that can be downloaded again: those with a download URL and a hash
+func NewManifest(builds []model.BlenderBuild, now time.Time) Manifest {
+	manifest := Manifest{Generated: now, Builds: []Entry{}}
+	for _, build := range builds {
+		if build.DownloadURL == "" || build.Hash == "" {
+			continue
+		}
+		manifest.Builds = append(manifest.Builds, Entry{
+			Version:      build.Version,
+			Branch:       build.Branch,
+			Hash:         build.Hash,
+			Platform:     build.OperatingSystem,
+			Architecture: build.Architecture,
+			URL:          build.DownloadURL,
+			Size:         build.Size,
+			SHA256:       build.SHA256,
+			Label:        build.Label,
+		})
+	}
+	return manifest
+}
+
+// Build returns the build to download for an entry
+func (e Entry) Build() model.BlenderBuild {
+	return model.BlenderBuild{
+		Version:         e.Version,
+		Branch:          e.Branch,
+		Hash:            e.Hash,
+		OperatingSystem: e.Platform,
+		Architecture:    e.Architecture,
+		DownloadURL:     e.URL,
+		Size:            e.Size,
+		FileName:        filepath.Base(e.URL),
+		SHA256:          e.SHA256,
+		Label:           e.Label,
+	}
+}
+
+// Load reads a manifest written by Export
+func Load(path string) (Manifest, error) {
+	var manifest Manifest
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return manifest, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// Export writes the manifest and the bootstrap scripts nodes run to install its builds into dir.
+// Returns the path of the manifest.
+func Export(dir string, manifest Manifest) (string, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", fmt.Errorf("could not create export directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	manifestPath := filepath.Join(dir, ManifestFilename)
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", manifestPath, err)
+	}
+
+	scripts := map[string]string{
+		shellScriptFilename:      shellScript,
+		powershellScriptFilename: powershellScript,
+	}
+	for name, script := range scripts {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return manifestPath, nil
+}
+
+// Missing returns the entries of the manifest for the platform of a node that aren't installed yet.
+// Entries for other platforms are returned as skipped, a node can't run them.
+func Missing(manifest Manifest, installed []model.BlenderBuild, goos, arch string) (missing, skipped []Entry) {
+	for _, entry := range manifest.Builds {
+		if !strings.EqualFold(entry.Platform, goos) || (entry.Architecture != "" && entry.Architecture != arch) {
+			skipped = append(skipped, entry)
+			continue
+		}
+		found := false
+		for _, build := range installed {
+			if build.Version == entry.Version && build.Hash == entry.Hash && (build.Architecture == "" || build.Architecture == entry.Architecture) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, entry)
+		}
+	}
+	return missing, skipped
+}
+
+// Sync installs the missing builds of a manifest with install, printing a line per build to w.
+// install downloads a build, checks its archive against the SHA256 of the build before extracting it
+// and returns its installed metadata. Returns false when a build failed.
+func Sync(w io.Writer, manifest Manifest, installed []model.BlenderBuild, goos, arch string, install func(model.BlenderBuild) (*model.BlenderBuild, error)) bool {
+	missing, skipped := Missing(manifest, installed, goos, arch)
+	for _, entry := range skipped {
+		fmt.Fprintf(w, "[SKIP] %s (%s): built for %s %s\n", entry.Version, entry.Hash, entry.Platform, entry.Architecture)
+	}
+
+	failed := 0
+	for _, entry := range missing {
+		fmt.Fprintf(w, "[....] %s (%s): downloading %s\n", entry.Version, entry.Hash, model.FormatByteSize(entry.Size))
+		if _, err := install(entry.Build()); err != nil {
+			failed++
+			fmt.Fprintf(w, "[FAIL] %s (%s): %v\n", entry.Version, entry.Hash, err)
+			continue
+		}
+		fmt.Fprintf(w, "[DONE] %s (%s)\n", entry.Version, entry.Hash)
+	}
+
+	fmt.Fprintf(w, "\n%d installed, %d already present, %d skipped, %d failed\n",
+		len(missing)-failed, len(manifest.Builds)-len(missing)-len(skipped), len(skipped), failed)
+	return failed == 0
+}
+
+// SyncFile installs the missing builds of the manifest at path into the download directory of cfg,
+// the same way the TUI downloads them. Returns false when the manifest can't be read or a build failed.
+func SyncFile(w io.Writer, cfg config.Config, path string) bool {
+	manifest, err := Load(path)
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return false
+	}
+	installed, err := local.ScanLocalBuilds(cfg.DownloadDir)
+	if err != nil {
+		fmt.Fprintf(w, "Error: failed to scan %s: %v\n", cfg.DownloadDir, err)
+		return false
+	}
+
+	// The downloads read the mirrors and the download ID from the config instance
+	config.SetConfigInstance(cfg)
+	fmt.Fprintf(w, "Provisioning %d builds of %s into %s\n\n", len(manifest.Builds), path, cfg.DownloadDir)
+	return Sync(w, manifest, installed, runtime.GOOS, api.NativeArch(), func(build model.BlenderBuild) (*model.BlenderBuild, error) {
+		dir, err := download.DownloadAndExtractBuild(build, cfg.DownloadDir, func(int64, int64) {}, nil, download.ConflictPolicy(cfg.ConflictPolicy), nil)
+		if err != nil {
+			return nil, err
+		}
+		return local.ReadBuildInfo(dir)
+	})
+}
+
+// ExportDir writes the manifest of the builds installed in the download directory of cfg into dir
+func ExportDir(w io.Writer, cfg config.Config, dir string) bool {
+	installed, err := local.ScanLocalBuilds(cfg.DownloadDir)
+	if err != nil {
+		fmt.Fprintf(w, "Error: failed to scan %s: %v\n", cfg.DownloadDir, err)
+		return false
+	}
+	manifest := NewManifest(installed, time.Now())
+	path, err := Export(dir, manifest)
+	if err != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return false
+	}
+	fmt.Fprintf(w, "Wrote %d builds to %s\n", len(manifest.Builds), path)
+	return true
+}
+
+// shellScript installs the builds of the manifest next to it on Linux and macOS nodes.
+// The launcher is taken from $BLENDER_LAUNCHER, next to the script, or the PATH.
+const shellScript = `#!/bin/sh
+# Installs the Blender builds listed in farm-manifest.json, written by TUI Blender Launcher.
+set -e
+dir="$(cd "$(dirname "$0")" && pwd)"
+launcher="${BLENDER_LAUNCHER:-}"
+if [ -z "$launcher" ]; then
+	if [ -x "$dir/tui-blender-launcher" ]; then
+		launcher="$dir/tui-blender-launcher"
+	else
+		launcher="tui-blender-launcher"
+	fi
+fi
+exec "$launcher" "$@" farm-sync "$dir/farm-manifest.json"
+`
+
+// powershellScript does the same on Windows nodes
+const powershellScript = `# Installs the Blender builds listed in farm-manifest.json, written by TUI Blender Launcher.
+$ErrorActionPreference = "Stop"
+$dir = Split-Path -Parent $MyInvocation.MyCommand.Path
+$launcher = $env:BLENDER_LAUNCHER
+if (-not $launcher) {
+	$bundled = Join-Path $dir "tui-blender-launcher.exe"
+	if (Test-Path $bundled) { $launcher = $bundled } else { $launcher = "tui-blender-launcher.exe" }
+}
+& $launcher @args farm-sync (Join-Path $dir "farm-manifest.json")
+exit $LASTEXITCODE
+`
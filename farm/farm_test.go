@@ -0,0 +1,106 @@
+package farm
+
+import (
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/model"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testBuilds() []model.BlenderBuild {
+	return []model.BlenderBuild{
+		{Version: "4.2.3", Hash: "423a1b2c3d4", OperatingSystem: "linux", Architecture: "x86_64", DownloadURL: "https://example.org/blender-4.2.3-linux.tar.xz", SHA256: "aaa"},
+		{Version: "4.3.0", Hash: "430a1b2c3d4", OperatingSystem: "linux", Architecture: "x86_64", DownloadURL: "https://example.org/blender-4.3.0-linux.tar.xz"},
+		{Version: "4.4.0", Hash: "440a1b2c3d4", OperatingSystem: "windows", Architecture: "amd64", DownloadURL: "https://example.org/blender-4.4.0-windows.zip"},
+		{Version: "4.1.0", OperatingSystem: "linux", Architecture: "x86_64"}, // Imported, can't be downloaded again
+	}
+}
+
+func TestNewManifest(t *testing.T) {
+	manifest := NewManifest(testBuilds(), time.Now())
+	if len(manifest.Builds) != 3 {
+		t.Fatalf("Expected 3 builds with a download URL and hash, got %d", len(manifest.Builds))
+	}
+	if manifest.Builds[0].SHA256 != "aaa" || manifest.Builds[0].Platform != "linux" {
+		t.Errorf("Expected the checksum and platform to be kept, got %+v", manifest.Builds[0])
+	}
+}
+
+func TestMissing(t *testing.T) {
+	manifest := NewManifest(testBuilds(), time.Now())
+	installed := []model.BlenderBuild{{Version: "4.3.0", Hash: "430a1b2c3d4", Architecture: "x86_64"}}
+
+	missing, skipped := Missing(manifest, installed, "linux", "x86_64")
+	if len(missing) != 1 || missing[0].Version != "4.2.3" {
+		t.Errorf("Expected only 4.2.3 to be missing, got %+v", missing)
+	}
+	if len(skipped) != 1 || skipped[0].Version != "4.4.0" {
+		t.Errorf("Expected the Windows build to be skipped, got %+v", skipped)
+	}
+
+	// Another build of the same version doesn't count
+	installed[0].Hash = "430ffffffff"
+	if missing, _ := Missing(manifest, installed, "linux", "x86_64"); len(missing) != 2 {
+		t.Errorf("Expected 2 missing builds, got %d", len(missing))
+	}
+}
+
+func TestSync(t *testing.T) {
+	manifest := NewManifest(testBuilds(), time.Now())
+	var out strings.Builder
+	var installed []string
+	ok := Sync(&out, manifest, nil, "linux", "x86_64", func(build model.BlenderBuild) (*model.BlenderBuild, error) {
+		if build.Version == "4.2.3" {
+			// Not the archive the manifest was written from, the download refuses to extract it
+			if build.SHA256 != "aaa" {
+				t.Errorf("Expected the checksum of the manifest, got %q", build.SHA256)
+			}
+			return nil, fmt.Errorf("%w: expected %s, got bbb", download.ErrChecksumMismatch, build.SHA256)
+		}
+		installed = append(installed, build.Version)
+		return &build, nil
+	})
+	if ok {
+		t.Error("Expected a checksum mismatch to fail the sync")
+	}
+	if strings.Join(installed, ",") != "4.3.0" {
+		t.Errorf("Expected only 4.3.0 to be installed, got %v", installed)
+	}
+	if !strings.Contains(out.String(), "1 installed, 0 already present, 1 skipped, 1 failed") {
+		t.Errorf("Unexpected summary:\n%s", out.String())
+	}
+
+	out.Reset()
+	ok = Sync(&out, manifest, nil, "linux", "x86_64", func(build model.BlenderBuild) (*model.BlenderBuild, error) {
+		return nil, errors.New("404 Not Found")
+	})
+	if ok || !strings.Contains(out.String(), "[FAIL] 4.3.0 (430a1b2c3d4): 404 Not Found") {
+		t.Errorf("Expected failed downloads to be reported:\n%s", out.String())
+	}
+}
+
+func TestExportAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "farm")
+	path, err := Export(dir, NewManifest(testBuilds(), time.Now()))
+	if err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+	for _, name := range []string{shellScriptFilename, powershellScriptFilename} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected bootstrap script %s: %v", name, err)
+		}
+	}
+
+	manifest, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if len(manifest.Builds) != 3 || manifest.Builds[1].Build().DownloadURL != "https://example.org/blender-4.3.0-linux.tar.xz" {
+		t.Errorf("Expected the exported builds back, got %+v", manifest.Builds)
+	}
+}
@@ -0,0 +1,339 @@
+//go:build integration
+// +build integration
+
+// Package integration runs the launcher end to end against a fake builder server.
+// It is only built with the integration tag: go test -tags integration ./integration/
+package integration
+
+import (
+	"TUI-Blender-Launcher/api"
+	"TUI-Blender-Launcher/model"
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ulikunitz/xz"
+)
+
+// builderHost is the host whose requests are redirected to the fake server
+const builderHost = "builder.blender.org"
+
+// FakeBuilder serves synthetic build listings and archives in place of builder.blender.org
+type FakeBuilder struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	listings map[string][]model.BlenderBuild // Build type -> listed builds
+	files    map[string][]byte               // URL path -> content
+	requests []string                        // URL paths requested, in order
+	stall    bool                            // Archive downloads stop halfway until the client gives up
+}
+
+// NewFakeBuilder starts a fake builder server with empty listings
+func NewFakeBuilder() *FakeBuilder {
+	f := &FakeBuilder{
+		listings: make(map[string][]model.BlenderBuild),
+		files:    make(map[string][]byte),
+	}
+	f.server = httptest.NewTLSServer(http.HandlerFunc(f.serve))
+	return f
+}
+
+// Close stops the server
+func (f *FakeBuilder) Close() {
+	f.server.Close()
+}
+
+// Install redirects all connections to builder.blender.org made through the default transport,
+// which the API and download clients use or clone, to the fake server. The returned function restores it.
+func (f *FakeBuilder) Install() (restore func()) {
+	transport := http.DefaultTransport.(*http.Transport)
+	originalDial, originalTLS := transport.DialContext, transport.TLSClientConfig
+
+	serverAddr := f.server.Listener.Addr().String()
+	var dialer net.Dialer
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if host, _, err := net.SplitHostPort(addr); err == nil && host == builderHost {
+			addr = serverAddr
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
+	// The test server certificate isn't issued for the builder host
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	transport.CloseIdleConnections()
+
+	return func() {
+		transport.DialContext, transport.TLSClientConfig = originalDial, originalTLS
+		transport.CloseIdleConnections()
+	}
+}
+
+// StallDownloads makes archive downloads stop halfway and wait until the client gives up,
+// leaving time to cancel them
+func (f *FakeBuilder) StallDownloads(stall bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stall = stall
+}
+
+// Requests returns the URL paths requested so far
+func (f *FakeBuilder) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+// AddBuild lists a build for the current platform under buildType and serves a small archive for it,
+// with the checksum file the real builder publishes next to each archive.
+// The archive holds a Blender executable stub and a scripts directory.
+func (f *FakeBuilder) AddBuild(buildType, version, branch, hash string) (model.BlenderBuild, error) {
+	ext := "tar.xz"
+	if runtime.GOOS == "windows" {
+		ext = "zip"
+	}
+	arch := api.PlatformArch(runtime.GOOS, runtime.GOARCH)
+	rootDir := fmt.Sprintf("blender-%s-%s-%s-%s.%s-release", version, branch, hash, runtime.GOOS, arch)
+	fileName := rootDir + "." + ext
+
+	archive, err := buildArchive(ext, rootDir, version, false)
+	if err != nil {
+		return model.BlenderBuild{}, err
+	}
+	sum := sha256.Sum256(archive)
+	filePath := path.Join("/download", buildType, fileName)
+
+	build := model.BlenderBuild{
+		Version:         version,
+		Branch:          branch,
+		Hash:            hash,
+		BuildDate:       model.Timestamp(time.Now().Truncate(time.Second)),
+		DownloadURL:     "https://" + builderHost + filePath,
+		OperatingSystem: runtime.GOOS,
+		Architecture:    arch,
+		Size:            int64(len(archive)),
+		FileName:        fileName,
+		FileExtension:   ext,
+		ReleaseCycle:    "alpha",
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listings[buildType] = append(f.listings[buildType], build)
+	f.files[filePath] = archive
+	f.files[filePath+".sha256"] = []byte(hex.EncodeToString(sum[:]) + "  " + fileName + "\n")
+	return build, nil
+}
+
+// Archive returns the archive served for a build
+func (f *FakeBuilder) Archive(build model.BlenderBuild) []byte {
+	u, _ := url.Parse(build.DownloadURL)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.files[u.Path]
+}
+
+// BreakBuild serves an archive whose Blender executable fails to start in place of the archive of a build
+func (f *FakeBuilder) BreakBuild(build model.BlenderBuild) error {
+	return f.replaceArchive(build, true)
+}
+
+// FixBuild serves the working archive of a build again after BreakBuild
+func (f *FakeBuilder) FixBuild(build model.BlenderBuild) error {
+	return f.replaceArchive(build, false)
+}
+
+// replaceArchive rebuilds the archive of a build, updating its listed size and published checksum
+func (f *FakeBuilder) replaceArchive(build model.BlenderBuild, broken bool) error {
+	rootDir := strings.TrimSuffix(build.FileName, "."+build.FileExtension)
+	archive, err := buildArchive(build.FileExtension, rootDir, build.Version, broken)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(archive)
+	u, _ := url.Parse(build.DownloadURL)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for buildType, listed := range f.listings {
+		for j := range listed {
+			if listed[j].DownloadURL == build.DownloadURL {
+				f.listings[buildType][j].Size = int64(len(archive))
+			}
+		}
+	}
+	f.files[u.Path] = archive
+	f.files[u.Path+".sha256"] = []byte(hex.EncodeToString(sum[:]) + "  " + build.FileName + "\n")
+	return nil
+}
+
+// CorruptChecksum replaces the published checksum of a build so verification fails
+func (f *FakeBuilder) CorruptChecksum(build model.BlenderBuild) {
+	u, _ := url.Parse(build.DownloadURL)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.files[u.Path+".sha256"] = []byte(strings.Repeat("0", 64) + "  " + build.FileName + "\n")
+}
+
+// serve answers listing requests (/download/<type>/?format=json), conditional on their ETag, and file requests
+func (f *FakeBuilder) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests = append(f.requests, r.URL.Path)
+	buildType := strings.Trim(strings.TrimPrefix(r.URL.Path, "/download/"), "/")
+	listing := f.listings[buildType]
+	data, ok := f.files[r.URL.Path]
+	stall := f.stall
+	f.mu.Unlock()
+
+	if r.URL.Query().Get("format") == "json" {
+		if listing == nil {
+			listing = []model.BlenderBuild{}
+		}
+		data, _ := json.Marshal(listing)
+		sum := sha256.Sum256(data)
+		etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+		return
+	}
+
+	if r.URL.Path == "/" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if stall && r.Method == http.MethodGet && path.Ext(r.URL.Path) != ".sha256" {
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(data[:len(data)/2])
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		<-r.Context().Done()
+		return
+	}
+	http.ServeContent(w, r, path.Base(r.URL.Path), time.Time{}, bytes.NewReader(data))
+}
+
+// archiveFile is a file placed in a generated archive
+type archiveFile struct {
+	name    string
+	content string
+	mode    int64
+}
+
+// archiveFiles returns the files of a synthetic build below rootDir.
+// The executable of a broken build exits with an error.
+func archiveFiles(rootDir, version string, broken bool) []archiveFile {
+	exe := "blender"
+	if runtime.GOOS == "windows" {
+		exe = "blender-launcher.exe"
+	}
+	series := version
+	if parts := strings.SplitN(version, ".", 3); len(parts) >= 2 {
+		series = parts[0] + "." + parts[1]
+	}
+	script := "#!/bin/sh\necho \"Blender " + version + "\"\n"
+	if broken {
+		script = "#!/bin/sh\necho \"error while loading shared libraries\" >&2\nexit 127\n"
+	}
+	return []archiveFile{
+		{path.Join(rootDir, exe), script, 0755},
+		{path.Join(rootDir, series, "scripts", "startup", "bl_ui.py"), "# synthetic\n", 0644},
+		{path.Join(rootDir, "readme.html"), "<p>Synthetic Blender " + version + "</p>\n", 0644},
+	}
+}
+
+// buildArchive generates a tar.xz or zip archive of a synthetic build, see archiveFiles
+func buildArchive(ext, rootDir, version string, broken bool) ([]byte, error) {
+	var buf bytes.Buffer
+	files := archiveFiles(rootDir, version, broken)
+
+	switch ext {
+	case "zip":
+		zw := zip.NewWriter(&buf)
+		for _, file := range files {
+			header := &zip.FileHeader{Name: file.name, Method: zip.Deflate}
+			header.SetMode(0644)
+			w, err := zw.CreateHeader(header)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add %s: %w", file.name, err)
+			}
+			if _, err := w.Write([]byte(file.content)); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", file.name, err)
+			}
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close zip archive: %w", err)
+		}
+	case "tar.xz":
+		xw, err := xz.NewWriter(&buf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create xz writer: %w", err)
+		}
+		tw := tar.NewWriter(xw)
+		dirs := map[string]bool{}
+		for _, file := range files {
+			// Directory entries first, like real archives
+			for dir := path.Dir(file.name); dir != "." && !dirs[dir]; dir = path.Dir(dir) {
+				dirs[dir] = true
+			}
+		}
+		for _, dir := range sortedKeys(dirs) {
+			if err := tw.WriteHeader(&tar.Header{Name: dir + "/", Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+				return nil, fmt.Errorf("failed to add %s: %w", dir, err)
+			}
+		}
+		for _, file := range files {
+			header := &tar.Header{Name: file.name, Typeflag: tar.TypeReg, Mode: file.mode, Size: int64(len(file.content))}
+			if err := tw.WriteHeader(header); err != nil {
+				return nil, fmt.Errorf("failed to add %s: %w", file.name, err)
+			}
+			if _, err := tw.Write([]byte(file.content)); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", file.name, err)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close tar archive: %w", err)
+		}
+		if err := xw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to close xz stream: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported archive format: %s", ext)
+	}
+	return buf.Bytes(), nil
+}
+
+// sortedKeys returns the keys of a set, parents before children
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
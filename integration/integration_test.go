@@ -0,0 +1,354 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"TUI-Blender-Launcher/api"
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/farm"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// setup starts a fake builder and points the config and download directory at temporary directories
+func setup(t *testing.T) (*FakeBuilder, string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, ".local", "state"))
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+
+	downloadDir := filepath.Join(home, "blender-builds")
+	cfg := config.DefaultConfig()
+	cfg.DownloadDir = downloadDir
+	if err := config.SaveConfig(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	config.SetConfigInstance(cfg)
+
+	builder := NewFakeBuilder()
+	t.Cleanup(builder.Close)
+	t.Cleanup(builder.Install())
+	return builder, downloadDir
+}
+
+// fetchBuild fetches the listing of buildType and returns the build with the given version
+func fetchBuild(t *testing.T, buildType, version string) model.BlenderBuild {
+	t.Helper()
+	builds, err := api.NewAPI().FetchBuilds("", buildType)
+	if err != nil {
+		t.Fatalf("FetchBuilds(%s) failed: %v", buildType, err)
+	}
+	for _, build := range builds {
+		if build.Version == version {
+			return build
+		}
+	}
+	t.Fatalf("Build %s not found in %s listing of %d builds", version, buildType, len(builds))
+	return model.BlenderBuild{}
+}
+
+func TestFetchDownloadExtractLaunch(t *testing.T) {
+	builder, downloadDir := setup(t)
+	if _, err := builder.AddBuild("daily", "4.3.0", "main", "a1b2c3d4e5f6"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	if _, err := builder.AddBuild("experimental", "4.4.0", "npr-prototype", "0f1e2d3c4b5a"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+
+	if err := api.NewAPI().CheckConnectivity(); err != nil {
+		t.Fatalf("Fake builder should be reachable: %v", err)
+	}
+
+	for _, tc := range []struct {
+		buildType, version, source string
+	}{
+		{"daily", "4.3.0", model.SourceDaily},
+		{"experimental", "4.4.0", model.SourceExperimental},
+	} {
+		build := fetchBuild(t, tc.buildType, tc.version)
+		if build.Source != tc.source {
+			t.Errorf("Build %s: expected source %s, got %s", tc.version, tc.source, build.Source)
+		}
+
+		var lastBytes int64
+		progress := func(current, total int64) { lastBytes = current }
+		var phases []model.InstallPhase
+		phase := func(p model.InstallPhase) { phases = append(phases, p) }
+		installDir, err := download.DownloadAndExtractBuild(build, downloadDir, progress, phase, nil, make(chan struct{}))
+		if err != nil {
+			t.Fatalf("DownloadAndExtractBuild(%s) failed: %v", tc.version, err)
+		}
+		if lastBytes == 0 {
+			t.Errorf("Build %s: no progress reported", tc.version)
+		}
+		want := []model.InstallPhase{model.PhaseDownload, model.PhaseVerify, model.PhaseBackup, model.PhaseExtract, model.PhaseFinalize}
+		if !slices.Equal(phases, want) {
+			t.Errorf("Build %s: expected phases %v, got %v", tc.version, want, phases)
+		}
+
+		info, err := local.ReadBuildInfo(installDir)
+		if err != nil || info == nil {
+			t.Fatalf("Build %s: failed to read version.json: %v", tc.version, err)
+		}
+		if info.Verification != model.VerificationVerified {
+			t.Errorf("Build %s: expected verification %q, got %q", tc.version, model.VerificationVerified, info.Verification)
+		}
+		if info.DownloadedFrom != builderHost {
+			t.Errorf("Build %s: expected download from %s, got %q", tc.version, builderHost, info.DownloadedFrom)
+		}
+		if info.InstalledSize == 0 || info.TreeSHA256 == "" {
+			t.Errorf("Build %s: installed size and tree checksum should be recorded", tc.version)
+		}
+	}
+
+	// Each archive was checked against its published checksum
+	checksums := 0
+	for _, path := range builder.Requests() {
+		if filepath.Ext(path) == ".sha256" {
+			checksums++
+		}
+	}
+	if checksums != 2 {
+		t.Errorf("Expected 2 checksum requests, got %d", checksums)
+	}
+
+	// Both builds are found by a scan of the download directory
+	builds, err := local.ScanLocalBuilds(downloadDir)
+	if err != nil {
+		t.Fatalf("ScanLocalBuilds failed: %v", err)
+	}
+	if len(builds) != 2 {
+		t.Fatalf("Expected 2 local builds, got %d", len(builds))
+	}
+
+	// Launching resolves the executable of the installed build
+	msg := local.LaunchBlenderCmd(downloadDir, "4.3.0", "")()
+	execMsg, ok := msg.(model.BlenderExecMsg)
+	if !ok {
+		t.Fatalf("Expected BlenderExecMsg, got %T: %v", msg, msg)
+	}
+	if _, err := os.Stat(execMsg.Executable); err != nil {
+		t.Errorf("Launch executable %s does not exist: %v", execMsg.Executable, err)
+	}
+
+	// The installed files still match the checksum recorded at install time
+	if _, result, err := local.VerifyAndSaveBuild(execMsg.InstallDir); err != nil || result != local.VerifyUnchanged {
+		t.Errorf("Expected unchanged build on re-verification, got %v (%v)", result, err)
+	}
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	builder, downloadDir := setup(t)
+	added, err := builder.AddBuild("daily", "4.3.1", "main", "b2c3d4e5f6a1")
+	if err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	builder.CorruptChecksum(added)
+
+	build := fetchBuild(t, "daily", "4.3.1")
+	_, err = download.DownloadAndExtractBuild(build, downloadDir, nil, nil, nil, make(chan struct{}))
+	if !errors.Is(err, download.ErrChecksumMismatch) {
+		t.Fatalf("Expected checksum mismatch, got: %v", err)
+	}
+
+	builds, err := local.ScanLocalBuilds(downloadDir)
+	if err != nil {
+		t.Fatalf("ScanLocalBuilds failed: %v", err)
+	}
+	if len(builds) != 0 {
+		t.Errorf("A build failing verification must not be installed, found %d", len(builds))
+	}
+}
+
+func TestTargetDirectoryConflict(t *testing.T) {
+	builder, downloadDir := setup(t)
+	if _, err := builder.AddBuild("daily", "4.3.0", "main", "a1b2c3d4e5f6"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	build := fetchBuild(t, "daily", "4.3.0")
+	installDir, err := download.DownloadAndExtractBuild(build, downloadDir, nil, nil, nil, make(chan struct{}))
+	if err != nil {
+		t.Fatalf("DownloadAndExtractBuild failed: %v", err)
+	}
+	// Without version.json the directory looks like a manual copy
+	if err := os.Remove(filepath.Join(installDir, "version.json")); err != nil {
+		t.Fatalf("Failed to remove version.json: %v", err)
+	}
+
+	_, err = download.DownloadAndExtractBuild(build, downloadDir, nil, nil, download.ConflictPolicy(config.ConflictAbort), make(chan struct{}))
+	if !errors.Is(err, download.ErrTargetExists) {
+		t.Fatalf("Expected the install to abort, got: %v", err)
+	}
+
+	kept, err := download.DownloadAndExtractBuild(build, downloadDir, nil, nil, download.ConflictPolicy(config.ConflictKeepBoth), make(chan struct{}))
+	if err != nil {
+		t.Fatalf("Keeping both failed: %v", err)
+	}
+	if kept != installDir+"-2" {
+		t.Errorf("Expected the build in %s-2, got %s", installDir, kept)
+	}
+	if _, err := os.Stat(installDir); err != nil {
+		t.Errorf("Expected the manual copy to be kept: %v", err)
+	}
+
+	replaced, err := download.DownloadAndExtractBuild(build, downloadDir, nil, nil, download.ConflictPolicy(config.ConflictOverwrite), make(chan struct{}))
+	if err != nil || replaced != installDir {
+		t.Fatalf("Expected the build in %s, got %s (%v)", installDir, replaced, err)
+	}
+	if info, err := local.ReadBuildInfo(installDir); err != nil || info == nil {
+		t.Errorf("Expected the overwritten directory to hold the build: %v", err)
+	}
+	if old, err := local.ListOldBuilds(downloadDir); err != nil || len(old) != 1 {
+		t.Errorf("Expected the manual copy in .oldbuilds, got %+v (%v)", old, err)
+	}
+}
+
+func TestDirTemplate(t *testing.T) {
+	builder, downloadDir := setup(t)
+	if _, err := builder.AddBuild("daily", "4.3.0", "main", "a1b2c3d4e5f6"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	cfg := *config.GetConfigInstance()
+	cfg.DirTemplate = "{version}-{cycle}-{hash}"
+	config.SetConfigInstance(cfg)
+
+	build := fetchBuild(t, "daily", "4.3.0")
+	installDir, err := download.DownloadAndExtractBuild(build, downloadDir, nil, nil, nil, make(chan struct{}))
+	if err != nil {
+		t.Fatalf("DownloadAndExtractBuild failed: %v", err)
+	}
+	if want := filepath.Join(downloadDir, build.DirName(cfg.DirTemplate)); installDir != want {
+		t.Errorf("Expected the build in %s, got %s", want, installDir)
+	}
+
+	// version.json keeps where the build came from
+	info, err := local.ReadBuildInfo(installDir)
+	if err != nil || info == nil {
+		t.Fatalf("Failed to read version.json: %v", err)
+	}
+	if info.ArchiveDir == "" || info.ArchiveDir == filepath.Base(installDir) {
+		t.Errorf("Expected the original directory name, got %q", info.ArchiveDir)
+	}
+
+	// Installing it again replaces the renamed build
+	if _, err := download.DownloadAndExtractBuild(build, downloadDir, nil, nil, nil, make(chan struct{})); err != nil {
+		t.Fatalf("Reinstall failed: %v", err)
+	}
+	if old, err := local.ListOldBuilds(downloadDir); err != nil || len(old) != 1 {
+		t.Errorf("Expected the previous install in .oldbuilds, got %+v (%v)", old, err)
+	}
+}
+
+func TestArchiveCache(t *testing.T) {
+	builder, downloadDir := setup(t)
+	if _, err := builder.AddBuild("daily", "4.3.0", "main", "a1b2c3d4e5f6"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	cfg := *config.GetConfigInstance()
+	cfg.KeepArchives = true
+	config.SetConfigInstance(cfg)
+	cacheDir, err := cfg.ArchiveCachePath()
+	if err != nil {
+		t.Fatalf("ArchiveCachePath failed: %v", err)
+	}
+
+	build := fetchBuild(t, "daily", "4.3.0")
+	installDir, err := download.DownloadAndExtractBuild(build, downloadDir, nil, nil, nil, make(chan struct{}))
+	if err != nil {
+		t.Fatalf("DownloadAndExtractBuild failed: %v", err)
+	}
+	archives, err := download.CachedArchives(cacheDir)
+	if err != nil || len(archives) != 1 {
+		t.Fatalf("Expected the archive in the cache, got %+v (%v)", archives, err)
+	}
+	if archives[0].Build.Version != "4.3.0" || archives[0].Build.SHA256 == "" {
+		t.Errorf("Expected the build and its checksum with the archive, got %+v", archives[0].Build)
+	}
+
+	// Reinstalling from the cache makes no request to the builder
+	if err := os.RemoveAll(installDir); err != nil {
+		t.Fatal(err)
+	}
+	requests := len(builder.Requests())
+	reinstalled, err := download.InstallFromArchive(archives[0], downloadDir, nil, nil, nil, make(chan struct{}))
+	if err != nil || reinstalled != installDir {
+		t.Fatalf("InstallFromArchive = %s, %v; expected %s", reinstalled, err, installDir)
+	}
+	if got := len(builder.Requests()); got != requests {
+		t.Errorf("Expected no requests for a reinstall from the cache, got %d", got-requests)
+	}
+	if info, err := local.ReadBuildInfo(installDir); err != nil || info == nil || info.Hash != build.Hash {
+		t.Errorf("Expected version.json of the reinstalled build, got %+v (%v)", info, err)
+	}
+
+	// Exports come with their checksum, a damaged archive is refused
+	exportDir := t.TempDir()
+	exported, err := download.ExportArchive(archives[0], exportDir)
+	if err != nil {
+		t.Fatalf("ExportArchive failed: %v", err)
+	}
+	if _, err := os.Stat(exported + ".sha256"); err != nil {
+		t.Errorf("Expected a checksum file next to the export: %v", err)
+	}
+	if err := os.WriteFile(archives[0].Path, []byte("damaged"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := download.InstallFromArchive(archives[0], downloadDir, nil, nil, nil, make(chan struct{})); !errors.Is(err, download.ErrArchiveDamaged) {
+		t.Errorf("Expected ErrArchiveDamaged, got %v", err)
+	}
+	if err := download.RemoveCachedArchive(archives[0]); err != nil {
+		t.Fatalf("RemoveCachedArchive failed: %v", err)
+	}
+	if archives, _ := download.CachedArchives(cacheDir); len(archives) != 0 {
+		t.Errorf("Expected an empty cache, got %+v", archives)
+	}
+}
+
+func TestFarmProvisioning(t *testing.T) {
+	builder, downloadDir := setup(t)
+	if _, err := builder.AddBuild("daily", "4.3.0", "main", "a1b2c3d4e5f6"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	build := fetchBuild(t, "daily", "4.3.0")
+	if _, err := download.DownloadAndExtractBuild(build, downloadDir, nil, nil, nil, make(chan struct{})); err != nil {
+		t.Fatalf("DownloadAndExtractBuild failed: %v", err)
+	}
+
+	// The workstation exports its builds, a node with an empty download directory installs them
+	cfg := config.DefaultConfig()
+	cfg.DownloadDir = downloadDir
+	exportDir := filepath.Join(t.TempDir(), "farm")
+	var out strings.Builder
+	if !farm.ExportDir(&out, cfg, exportDir) {
+		t.Fatalf("Export failed:\n%s", out.String())
+	}
+
+	node := config.DefaultConfig()
+	node.DownloadDir = filepath.Join(t.TempDir(), "node-builds")
+	manifestPath := filepath.Join(exportDir, farm.ManifestFilename)
+	out.Reset()
+	if !farm.SyncFile(&out, node, manifestPath) {
+		t.Fatalf("Sync failed:\n%s", out.String())
+	}
+	builds, err := local.ScanLocalBuilds(node.DownloadDir)
+	if err != nil || len(builds) != 1 || builds[0].Hash != build.Hash {
+		t.Fatalf("Expected the node to have build %s, got %+v (%v)", build.Hash, builds, err)
+	}
+
+	// A second run has nothing to do
+	out.Reset()
+	if !farm.SyncFile(&out, node, manifestPath) || !strings.Contains(out.String(), "0 installed, 1 already present") {
+		t.Errorf("Expected the build to be present already:\n%s", out.String())
+	}
+}
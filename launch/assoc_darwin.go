@@ -0,0 +1,42 @@
+//go:build darwin
+// +build darwin
+
+package launch
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// lsregister is the LaunchServices registration tool shipped with macOS
+const lsregister = "/System/Library/Frameworks/CoreServices.framework/Frameworks/LaunchServices.framework/Support/lsregister"
+
+// blenderBundleID is the bundle identifier shared by all Blender.app builds
+const blenderBundleID = "org.blenderfoundation.blender"
+
+// AssociateBlendFiles registers the app bundle containing blenderExe as the default handler
+// for .blend files (macOS-specific). The bundle is re-registered with LaunchServices and, when
+// duti is installed, set as the LSHandlers entry for the .blend extension.
+func AssociateBlendFiles(blenderExe string, version string) error {
+	appPath := blenderExe
+	for appPath != "/" && appPath != "." && !strings.HasSuffix(appPath, ".app") {
+		appPath = filepath.Dir(appPath)
+	}
+	if !strings.HasSuffix(appPath, ".app") {
+		return fmt.Errorf("could not find the Blender %s app bundle for %s", version, blenderExe)
+	}
+
+	// Registering last makes LaunchServices prefer this copy among bundles sharing the same ID
+	if out, err := exec.Command(lsregister, "-f", appPath).CombinedOutput(); err != nil {
+		return fmt.Errorf("lsregister failed: %w: %s", err, out)
+	}
+
+	if _, err := exec.LookPath("duti"); err == nil {
+		if out, err := exec.Command("duti", "-s", blenderBundleID, ".blend", "all").CombinedOutput(); err != nil {
+			return fmt.Errorf("duti failed: %w: %s", err, out)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+//go:build linux
+// +build linux
+
+package launch
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// blendMimeType is the MIME type registered for .blend files by Blender's own desktop file
+const blendMimeType = "application/x-blender"
+
+// AssociateBlendFiles registers blenderExe as the default handler for .blend files (Linux-specific).
+// It writes a user desktop entry and makes it the default application for the .blend MIME type.
+func AssociateBlendFiles(blenderExe string, version string) error {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("could not get home directory: %w", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+
+	appsDir := filepath.Join(dataHome, "applications")
+	if err := os.MkdirAll(appsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", appsDir, err)
+	}
+
+	// A single desktop entry is rewritten on every change so switching builds never leaves stale handlers
+	desktopPath := filepath.Join(appsDir, BlendHandlerID+".desktop")
+	entry := fmt.Sprintf(`[Desktop Entry]
+Type=Application
+Name=Blender %s
+Comment=Blender %s managed by TUI Blender Launcher
+Exec="%s" %%f
+Icon=blender
+Terminal=false
+NoDisplay=true
+MimeType=%s;
+`, version, version, blenderExe, blendMimeType)
+	if err := os.WriteFile(desktopPath, []byte(entry), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", desktopPath, err)
+	}
+
+	if out, err := exec.Command("xdg-mime", "default", BlendHandlerID+".desktop", blendMimeType).CombinedOutput(); err != nil {
+		return fmt.Errorf("xdg-mime failed: %w: %s", err, out)
+	}
+
+	// Refreshing the desktop database is best effort; not every desktop ships the tool
+	_ = exec.Command("update-desktop-database", appsDir).Run()
+	return nil
+}
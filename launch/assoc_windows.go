@@ -0,0 +1,29 @@
+//go:build windows
+// +build windows
+
+package launch
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// AssociateBlendFiles registers blenderExe as the default handler for .blend files (Windows-specific).
+// It writes per-user registry keys under HKCU\Software\Classes, so no elevation is needed.
+func AssociateBlendFiles(blenderExe string, version string) error {
+	progID := BlendHandlerID + ".blend"
+	keys := [][]string{
+		{`HKCU\Software\Classes\.blend`, progID},
+		{`HKCU\Software\Classes\` + progID, "Blender " + version},
+		{`HKCU\Software\Classes\` + progID + `\DefaultIcon`, `"` + blenderExe + `",1`},
+		{`HKCU\Software\Classes\` + progID + `\shell\open\command`, `"` + blenderExe + `" "%1"`},
+	}
+
+	for _, k := range keys {
+		cmd := exec.Command("reg", "add", k[0], "/ve", "/d", k[1], "/f")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to set registry key %s: %w: %s", k[0], err, out)
+		}
+	}
+	return nil
+}
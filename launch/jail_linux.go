@@ -0,0 +1,69 @@
+//go:build linux
+// +build linux
+
+package launch
+
+import (
+	"TUI-Blender-Launcher/config"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// SandboxArgs returns the command that runs a build from installDir inside the given sandbox tool.
+// The sandbox has no network and no access to the home directory besides the read-only build
+// and the directories of files, e.g. the .blend file it opens, which are read-only as well.
+// An empty tool or "none" returns no command.
+func SandboxArgs(tool, installDir string, files ...string) ([]string, error) {
+	switch tool {
+	case "", config.SandboxNone:
+		return nil, nil
+	case config.SandboxFirejail, config.SandboxBwrap:
+		if !HostHasCommand(tool) {
+			return nil, fmt.Errorf("%s is not installed, needed to sandbox this build", tool)
+		}
+	default:
+		return nil, fmt.Errorf("unknown sandbox tool %q", tool)
+	}
+
+	readOnly := []string{installDir}
+	for _, file := range files {
+		if dir, err := filepath.Abs(filepath.Dir(file)); err == nil && !slices.Contains(readOnly, dir) {
+			readOnly = append(readOnly, dir)
+		}
+	}
+
+	if tool == config.SandboxFirejail {
+		args := []string{"firejail", "--quiet", "--noprofile", "--net=none", "--private-tmp"}
+		for _, dir := range readOnly {
+			args = append(args, "--whitelist="+dir, "--read-only="+dir)
+		}
+		return append(args, "--"), nil
+	}
+
+	args := []string{
+		"bwrap",
+		"--ro-bind", "/", "/",
+		"--dev", "/dev",
+		"--dev-bind-try", "/dev/dri", "/dev/dri",
+		"--proc", "/proc",
+		"--tmpfs", "/tmp",
+		"--ro-bind-try", "/tmp/.X11-unix", "/tmp/.X11-unix",
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		args = append(args, "--tmpfs", home)
+	}
+	if xauth := os.Getenv("XAUTHORITY"); xauth != "" {
+		args = append(args, "--ro-bind-try", xauth, xauth)
+	}
+	// Bound after the empty home, which would hide them otherwise
+	for _, dir := range readOnly {
+		args = append(args, "--ro-bind", dir, dir)
+	}
+	return append(args,
+		"--unshare-all",
+		"--new-session",
+		"--",
+	), nil
+}
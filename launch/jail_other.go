@@ -0,0 +1,18 @@
+//go:build !linux
+// +build !linux
+
+package launch
+
+import (
+	"TUI-Blender-Launcher/config"
+	"fmt"
+)
+
+// SandboxArgs returns the command that runs a build inside the given sandbox tool.
+// Sandboxing needs firejail or bubblewrap and is only available on Linux.
+func SandboxArgs(tool, installDir string, files ...string) ([]string, error) {
+	if tool == "" || tool == config.SandboxNone {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("sandboxing with %s is only supported on Linux", tool)
+}
@@ -1,7 +1,7 @@
-//go:build !linux
-// +build !linux
-
 package launch
 
-// This file is intentionally left blank for non-Linux platforms.
-// The actual implementation is provided in platform-specific files.
+// This file holds declarations shared by all platforms.
+// The actual implementations are provided in platform-specific files.
+
+// BlendHandlerID identifies the .blend file handler registered by AssociateBlendFiles
+const BlendHandlerID = "tui-blender-launcher"
@@ -5,12 +5,32 @@ package launch
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 )
 
-// BlenderInNewTerminal launches Blender in a new terminal window (macOS-specific)
-func BlenderInNewTerminal(blenderExe string) error {
-	cmd := exec.Command("open", "-a", "Terminal", blenderExe)
+// BlenderInNewTerminal launches Blender in a new terminal window (macOS-specific).
+// command is the Blender executable followed by its arguments; sandboxes are not supported on macOS.
+// Terminal can't pass arguments on, so Blender is started without a terminal when there are any.
+func BlenderInNewTerminal(command []string, env []string) error {
+	blenderExe := command[0]
+	if len(command) > 1 {
+		cmd := exec.Command(blenderExe, command[1:]...)
+		cmd.Env = append(os.Environ(), env...)
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to launch Blender: %w", err)
+		}
+		cmd.Process.Release()
+		return nil
+	}
+	// Apps started through LaunchServices don't inherit our environment, so pass it explicitly
+	args := []string{"-a", "Terminal"}
+	for _, e := range env {
+		args = append(args, "--env", e)
+	}
+	args = append(args, blenderExe)
+
+	cmd := exec.Command("open", args...)
 	err := cmd.Start()
 	if err != nil {
 		return fmt.Errorf("failed to launch Blender in new terminal: %w", err)
@@ -4,35 +4,104 @@
 package launch
 
 import (
+	"TUI-Blender-Launcher/config"
 	"fmt"
-	"os/exec"
+	"os"
+	"path/filepath"
+	"strings"
 	"syscall"
 )
 
-// BlenderInNewTerminal launches Blender in a new terminal window (Linux-specific)
-func BlenderInNewTerminal(blenderExe string) error {
-	terminals := []struct {
-		name string
-		args []string
-	}{
-		{"x-terminal-emulator", []string{"-e", "nohup", blenderExe, "&"}},
-		{"gnome-terminal", []string{"--", "bash", "-c", "exec " + blenderExe}},
-		{"alacritty", []string{"-e", "bash", "-c", "exec " + blenderExe}},
-		{"xterm", []string{"-e", "bash", "-c", "exec " + blenderExe}},
-		{"konsole", []string{"-e", "bash", "-c", "exec " + blenderExe}},
-	}
+// terminalArgs holds the arguments known terminal emulators need before the command to run
+var terminalArgs = map[string][]string{
+	"x-terminal-emulator": {"-e"},
+	"gnome-terminal":      {"--"},
+	"kgx":                 {"--"},
+	"konsole":             {"-e"},
+	"xfce4-terminal":      {"-x"},
+	"alacritty":           {"-e"},
+	"ghostty":             {"-e"},
+	"kitty":               {},
+	"foot":                {},
+	"wezterm":             {"start", "--"},
+	"xterm":               {"-e"},
+}
+
+// waylandTerminals are tried first in Wayland sessions, they run without XWayland
+var waylandTerminals = []string{"foot", "kitty", "wezterm", "alacritty", "ghostty", "kgx"}
 
-	for _, term := range terminals {
-		cmd := exec.Command(term.name, term.args...)
+// defaultTerminals are tried after the configured ones
+var defaultTerminals = []string{"x-terminal-emulator", "gnome-terminal", "konsole", "xfce4-terminal", "kitty", "alacritty", "wezterm", "xterm"}
+
+// BlenderInNewTerminal launches Blender in a new terminal window (Linux-specific).
+// command is the Blender executable and its arguments, optionally preceded by a sandbox from SandboxArgs.
+// The terminals from the config and $TERMINAL are tried before the detected ones, and
+// Blender is started detached without a terminal when none is installed.
+// Inside Flatpak or Snap the terminal is started on the host, outside of the sandbox.
+func BlenderInNewTerminal(command []string, env []string) error {
+	for _, term := range terminalCandidates(config.GetConfigInstance().Terminals) {
+		fields := strings.Fields(term)
+		if !HostHasCommand(fields[0]) {
+			continue
+		}
+		args := fields[1:]
+		if len(fields) == 1 {
+			args = knownTerminalArgs(filepath.Base(fields[0]))
+		}
+		cmd := HostCommand(fields[0], append(args, command...), env)
 		cmd.SysProcAttr = &syscall.SysProcAttr{
 			Setpgid: true,
 		}
-		err := cmd.Start()
-		if err == nil {
+		if err := cmd.Start(); err == nil {
 			cmd.Process.Release()
 			return nil
 		}
 	}
 
-	return fmt.Errorf("failed to launch Blender: no terminal emulator worked")
+	// No terminal emulator: run Blender on its own, it keeps running when the launcher quits
+	cmd := HostCommand(command[0], command[1:], env)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid: true,
+	}
+	if err := cmd.Start(); err != nil {
+		if DetectSandbox() == SandboxFlatpak {
+			return fmt.Errorf("failed to launch Blender on the host, the Flatpak needs --talk-name=org.freedesktop.Flatpak: %w", err)
+		}
+		return fmt.Errorf("failed to launch Blender: %w", err)
+	}
+	cmd.Process.Release()
+	return nil
+}
+
+// terminalCandidates returns the terminal commands to try in order, without duplicates.
+// Entries are a terminal name or a full command line that the Blender executable is appended to.
+func terminalCandidates(configured []string) []string {
+	candidates := append([]string{}, configured...)
+	if term := os.Getenv("TERMINAL"); term != "" {
+		candidates = append(candidates, term)
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append(candidates, waylandTerminals...)
+	}
+	candidates = append(candidates, defaultTerminals...)
+
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		c = strings.TrimSpace(c)
+		if c == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		result = append(result, c)
+	}
+	return result
+}
+
+// knownTerminalArgs returns the arguments for a terminal, -e for unknown ones
+func knownTerminalArgs(name string) []string {
+	if args, ok := terminalArgs[name]; ok {
+		return append([]string{}, args...)
+	}
+	return []string{"-e"}
 }
@@ -5,12 +5,15 @@ package launch
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 )
 
-// BlenderInNewTerminal launches Blender in a new terminal window (Windows-specific)
-func BlenderInNewTerminal(blenderExe string) error {
-	cmd := exec.Command("cmd", "/C", "start", "", blenderExe, "-con")
+// BlenderInNewTerminal launches Blender in a new terminal window (Windows-specific).
+// command is the Blender executable followed by its arguments; sandboxes are not supported on Windows.
+func BlenderInNewTerminal(command []string, env []string) error {
+	cmd := exec.Command("cmd", append([]string{"/C", "start", "", command[0], "-con"}, command[1:]...)...)
+	cmd.Env = append(os.Environ(), env...)
 	err := cmd.Start()
 	if err != nil {
 		return fmt.Errorf("failed to launch Blender in new terminal: %w", err)
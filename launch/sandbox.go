@@ -0,0 +1,95 @@
+package launch
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// Sandbox identifies the application sandbox the launcher runs in
+type Sandbox string
+
+const (
+	SandboxNone    Sandbox = ""
+	SandboxFlatpak Sandbox = "flatpak"
+	SandboxSnap    Sandbox = "snap"
+)
+
+// flatpakInfoPath is present in every Flatpak sandbox
+const flatpakInfoPath = "/.flatpak-info"
+
+// snapEnvVars are set up by snapd for the confined app and break host programs that inherit them
+var snapEnvVars = []string{
+	"LD_LIBRARY_PATH",
+	"LD_PRELOAD",
+	"GTK_PATH",
+	"GTK_EXE_PREFIX",
+	"GIO_MODULE_DIR",
+	"GDK_PIXBUF_MODULE_FILE",
+	"LOCPATH",
+	"PYTHONHOME",
+	"PYTHONPATH",
+}
+
+// DetectSandbox reports whether the launcher runs inside Flatpak or Snap
+func DetectSandbox() Sandbox {
+	if runtime.GOOS != "linux" {
+		return SandboxNone
+	}
+	if os.Getenv("FLATPAK_ID") != "" {
+		return SandboxFlatpak
+	}
+	if _, err := os.Stat(flatpakInfoPath); err == nil {
+		return SandboxFlatpak
+	}
+	if os.Getenv("SNAP") != "" && os.Getenv("SNAP_NAME") != "" {
+		return SandboxSnap
+	}
+	return SandboxNone
+}
+
+// HostCommand builds a command that runs name with the extra env outside of the sandbox.
+// Inside Flatpak it goes through flatpak-spawn, inside Snap the snap runtime variables are dropped.
+func HostCommand(name string, args []string, env []string) *exec.Cmd {
+	switch DetectSandbox() {
+	case SandboxFlatpak:
+		spawnArgs := []string{"--host"}
+		for _, kv := range env {
+			spawnArgs = append(spawnArgs, "--env="+kv)
+		}
+		spawnArgs = append(spawnArgs, name)
+		return exec.Command("flatpak-spawn", append(spawnArgs, args...)...)
+	case SandboxSnap:
+		cmd := exec.Command(name, args...)
+		cmd.Env = append(hostEnv(os.Environ()), env...)
+		return cmd
+	default:
+		cmd := exec.Command(name, args...)
+		cmd.Env = append(os.Environ(), env...)
+		return cmd
+	}
+}
+
+// HostHasCommand reports whether name can be run on the host
+func HostHasCommand(name string) bool {
+	if DetectSandbox() == SandboxFlatpak {
+		return exec.Command("flatpak-spawn", "--host", "sh", "-c", `command -v "$0"`, name).Run() == nil
+	}
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// hostEnv removes the variables snapd injects into the confined environment
+func hostEnv(environ []string) []string {
+	result := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		key, _, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(key, "SNAP") || slices.Contains(snapEnvVars, key) {
+			continue
+		}
+		result = append(result, kv)
+	}
+	return result
+}
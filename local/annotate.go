@@ -0,0 +1,71 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strings"
+)
+
+// SetBuildLabel saves a custom label for an installed build in its version.json.
+// An empty label removes it; the build directory is left as it is.
+func SetBuildLabel(installDir, label string) (*model.BlenderBuild, error) {
+	return editBuildInfo(installDir, func(build *model.BlenderBuild) {
+		build.Label = strings.TrimSpace(label)
+	})
+}
+
+// SetBuildNotes saves free-form notes for an installed build in its version.json.
+// Empty notes remove them.
+func SetBuildNotes(installDir, notes string) (*model.BlenderBuild, error) {
+	return editBuildInfo(installDir, func(build *model.BlenderBuild) {
+		build.Notes = strings.TrimSpace(notes)
+	})
+}
+
+// SetBuildTags saves the tags of an installed build in its version.json.
+// No tags remove them.
+func SetBuildTags(installDir string, tags []string) (*model.BlenderBuild, error) {
+	return editBuildInfo(installDir, func(build *model.BlenderBuild) {
+		build.Tags = tags
+	})
+}
+
+// SetBuildPromotion saves the promotion state of an installed build in its version.json
+func SetBuildPromotion(installDir, promotion string) (*model.BlenderBuild, error) {
+	return editBuildInfo(installDir, func(build *model.BlenderBuild) {
+		build.Promotion = promotion
+	})
+}
+
+// SetBuildSnooze saves until when updates of an installed build are snoozed in its version.json.
+// A nil time ends the snooze.
+func SetBuildSnooze(installDir string, until *model.Timestamp) (*model.BlenderBuild, error) {
+	return editBuildInfo(installDir, func(build *model.BlenderBuild) {
+		build.SnoozedUntil = until
+	})
+}
+
+// SetBuildLaunchProfile saves the launch profile of an installed build in its version.json.
+// An empty name removes it, so the build uses the global launch_profile again.
+func SetBuildLaunchProfile(installDir, profile string) (*model.BlenderBuild, error) {
+	return editBuildInfo(installDir, func(build *model.BlenderBuild) {
+		build.LaunchProfile = profile
+	})
+}
+
+// editBuildInfo applies edit to the version.json of an installed build and saves it
+func editBuildInfo(installDir string, edit func(*model.BlenderBuild)) (*model.BlenderBuild, error) {
+	build, err := ReadBuildInfo(installDir)
+	if err != nil {
+		return nil, err
+	}
+	if build == nil {
+		return nil, fmt.Errorf("no %s found in %s", versionMetaFilename, installDir)
+	}
+
+	edit(build)
+	if err := WriteBuildInfo(installDir, *build); err != nil {
+		return nil, err
+	}
+	return build, nil
+}
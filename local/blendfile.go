@@ -0,0 +1,84 @@
+package local
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Magic bytes at the start of .blend files
+var (
+	blendMagic = []byte("BLENDER")
+	gzipMagic  = []byte{0x1f, 0x8b}
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// ReadBlendVersion returns the major.minor series of the Blender version that saved a .blend file,
+// read from the file header. Files compressed with gzip (before Blender 3.0) are supported;
+// Zstandard compressed files (File › Compress in Blender 3.0 and later) are not.
+func ReadBlendVersion(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	start, err := r.Peek(len(zstdMagic))
+	if err != nil {
+		return "", fmt.Errorf("%s is not a .blend file", path)
+	}
+	var header io.Reader = r
+	switch {
+	case bytes.HasPrefix(start, gzipMagic):
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return "", fmt.Errorf("failed to decompress %s: %w", path, err)
+		}
+		defer gz.Close()
+		header = gz
+	case bytes.HasPrefix(start, zstdMagic):
+		return "", fmt.Errorf("%s is compressed, its version can't be read; save it without File › Compress", path)
+	}
+
+	buf := make([]byte, 17)
+	n, err := io.ReadFull(header, buf)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	series, ok := parseBlendHeader(buf[:n])
+	if !ok {
+		return "", fmt.Errorf("%s is not a .blend file", path)
+	}
+	return series, nil
+}
+
+// parseBlendHeader reads the version from a .blend file header.
+// Up to Blender 4.x the header is "BLENDER", the pointer size ('_' or '-'), the endianness ('v' or 'V')
+// and three digits, e.g. "BLENDER-v402" for 4.2. Blender 5.0 and later write the header size, a format
+// version and four digits instead, e.g. "BLENDER17-01v0500" for 5.0.
+func parseBlendHeader(header []byte) (string, bool) {
+	if !bytes.HasPrefix(header, blendMagic) || len(header) < 12 {
+		return "", false
+	}
+	rest := header[len(blendMagic):]
+
+	var digits []byte
+	switch {
+	case (rest[0] == '_' || rest[0] == '-') && (rest[1] == 'v' || rest[1] == 'V'):
+		digits = rest[2:5]
+	case len(rest) >= 10 && rest[2] == '-' && (rest[5] == 'v' || rest[5] == 'V'):
+		digits = rest[6:10]
+	default:
+		return "", false
+	}
+	version, err := strconv.Atoi(string(digits))
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("%d.%d", version/100, version%100), true
+}
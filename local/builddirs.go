@@ -0,0 +1,56 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// BuildDir is a directory belonging to an installed build, as offered by the open directory menu
+type BuildDir struct {
+	Name   string // e.g. "User config"
+	Path   string
+	Exists bool // Blender creates its user directories at the first start that needs them
+}
+
+// extensionsSeries is the first series keeping add-ons as extensions in the user directory
+var extensionsSeries = version.Must(version.NewVersion("4.2"))
+
+// BuildDirs returns the directories of the build installed in installDir: the installation, the user
+// config directory Blender uses for its version (or the isolated one inside the installation), the
+// add-ons folder, extensions from 4.2 on, and the temporary directory where Blender writes crash logs
+func BuildDirs(installDir, buildVersion string) ([]BuildDir, error) {
+	userDir := filepath.Join(installDir, IsolatedConfigDir)
+	if !UsesIsolatedConfig(installDir) {
+		series, err := SeriesFromVersion(buildVersion)
+		if err != nil {
+			return nil, err
+		}
+		root, err := BlenderUserConfigRoot()
+		if err != nil {
+			return nil, err
+		}
+		userDir = filepath.Join(root, series)
+	}
+
+	dirs := []BuildDir{
+		{Name: "Installation", Path: installDir},
+		{Name: "User config", Path: userDir},
+	}
+	if v, err := version.NewVersion(buildVersion); err == nil && !v.LessThan(extensionsSeries) {
+		dirs = append(dirs,
+			BuildDir{Name: "Extensions", Path: filepath.Join(userDir, "extensions", "user_default")},
+			BuildDir{Name: "Legacy add-ons", Path: filepath.Join(userDir, "scripts", "addons")},
+		)
+	} else {
+		dirs = append(dirs, BuildDir{Name: "Add-ons", Path: filepath.Join(userDir, "scripts", "addons")})
+	}
+	dirs = append(dirs, BuildDir{Name: "Temp and crash logs", Path: os.TempDir()})
+
+	for i := range dirs {
+		info, err := os.Stat(dirs[i].Path)
+		dirs[i].Exists = err == nil && info.IsDir()
+	}
+	return dirs, nil
+}
@@ -0,0 +1,58 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// columnCommandTimeout bounds how long the command of a custom column may take for the whole build list.
+const columnCommandTimeout = 30 * time.Second
+
+// CustomColumnValues runs the command of a custom column through the shell with builds as a JSON array on stdin
+// and returns the line it printed for each build, in order. Builds it printed no line for get an empty value.
+func CustomColumnValues(column config.CustomColumn, builds []model.BlenderBuild) ([]string, error) {
+	input, err := json.Marshal(builds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal builds: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), columnCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", column.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", column.Command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("no answer within %s", columnCommandTimeout)
+		}
+		if message, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); message != "" {
+			return nil, fmt.Errorf("%w: %s", err, message)
+		}
+		return nil, err
+	}
+
+	values := make([]string, len(builds))
+	lines := strings.Split(strings.ReplaceAll(stdout.String(), "\r\n", "\n"), "\n")
+	for i := range values {
+		if i < len(lines) {
+			values[i] = strings.TrimSpace(lines[i])
+		}
+	}
+	return values, nil
+}
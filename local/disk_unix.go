@@ -0,0 +1,24 @@
+//go:build !windows
+// +build !windows
+
+package local
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// FreeSpace returns the number of bytes available to the user on the filesystem holding path.
+func FreeSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, fmt.Errorf("failed to get free space of %s: %w", path, err)
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
+
+// ListDrives returns the filesystem roots to offer besides the current directory.
+// Unix systems have a single root, so there is nothing to list.
+func ListDrives() []string {
+	return nil
+}
@@ -0,0 +1,39 @@
+//go:build windows
+// +build windows
+
+package local
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var procGetDiskFreeSpaceEx = syscall.NewLazyDLL("kernel32.dll").NewProc("GetDiskFreeSpaceExW")
+
+// FreeSpace returns the number of bytes available to the user on the volume holding path.
+func FreeSpace(path string) (uint64, error) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get free space of %s: %w", path, err)
+	}
+	var freeBytes uint64
+	ret, _, callErr := procGetDiskFreeSpaceEx.Call(uintptr(unsafe.Pointer(pathPtr)), uintptr(unsafe.Pointer(&freeBytes)), 0, 0)
+	if ret == 0 {
+		return 0, fmt.Errorf("failed to get free space of %s: %w", path, callErr)
+	}
+	return freeBytes, nil
+}
+
+// ListDrives returns the drive roots that exist, e.g., C:\ and D:\.
+func ListDrives() []string {
+	var drives []string
+	for letter := 'A'; letter <= 'Z'; letter++ {
+		root := string(letter) + `:\`
+		if _, err := os.Stat(root); err == nil {
+			drives = append(drives, root)
+		}
+	}
+	return drives
+}
@@ -0,0 +1,21 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/model"
+	"os/exec"
+	"strings"
+)
+
+// SystemGlibc returns the major.minor glibc version of this machine, empty if it has none, e.g. with musl
+func SystemGlibc() string {
+	out, err := exec.Command("getconf", "GNU_LIBC_VERSION").Output()
+	if err != nil {
+		return ""
+	}
+	// e.g. "glibc 2.35"
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 || fields[0] != "glibc" {
+		return ""
+	}
+	return model.BuildSeries(fields[1])
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package local
+
+// SystemGlibc returns the glibc version of this machine, Blender builds for other platforms don't use it
+func SystemGlibc() string {
+	return ""
+}
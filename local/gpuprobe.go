@@ -0,0 +1,100 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/model"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// gpuProbeScript reports which Cycles device types have a GPU device and the name of the first one.
+// Background mode creates no GPU context, so the devices come from the Cycles preferences instead.
+const gpuProbeScript = `import bpy
+prefs = bpy.context.preferences.addons["cycles"].preferences
+for device_type in ("CUDA", "OPTIX", "HIP", "ONEAPI", "METAL"):
+    try:
+        devices = [d for d in prefs.get_devices_for_type(device_type) if d.type == device_type]
+    except Exception:
+        continue
+    print("TBL:" + device_type + "=" + str(len(devices) > 0))
+    if devices:
+        print("TBL:renderer=" + devices[0].name)
+`
+
+// computeDeviceNames maps the Cycles device types of gpuProbeScript to their display names
+var computeDeviceNames = map[string]string{
+	"CUDA":   "CUDA",
+	"OPTIX":  "OptiX",
+	"HIP":    "HIP",
+	"ONEAPI": "oneAPI",
+	"METAL":  "Metal",
+}
+
+// ProbeGPU starts the build once in background mode and records which Cycles devices are available,
+// or that Blender crashed looking for them. Display backends (OpenGL, Vulkan) aren't probed: a background
+// run never creates their context, so it can't tell whether they work.
+// A sandbox command from launch.SandboxArgs runs the probe inside the sandbox.
+func ProbeGPU(installDir string, sandbox []string) (*model.GPUProbeResult, error) {
+	blenderExe := findProbeExecutable(installDir)
+	if blenderExe == "" {
+		return nil, fmt.Errorf("could not find Blender executable in %s", installDir)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	cmd := probeCommand(ctx, sandbox, blenderExe,
+		"--background", "--factory-startup",
+		"--python-expr", gpuProbeScript)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+
+	result := &model.GPUProbeResult{
+		Compute:  make(map[string]bool),
+		ProbedAt: model.Timestamp(time.Now()),
+	}
+	// A run killed by a signal (segfault, abort) is a crash, typically in a GPU driver;
+	// other failures are reported as such
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && !exitErr.Exited() {
+		result.Crashed = true
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GPU probe failed: %w", err)
+	}
+
+	values := parseProbeValues(stdout.String())
+	for deviceType, deviceName := range computeDeviceNames {
+		if available, ok := values[deviceType]; ok {
+			result.Compute[deviceName] = available == "True"
+		}
+	}
+	result.Renderer = values["renderer"]
+	return result, nil
+}
+
+// ProbeAndSaveGPU runs the GPU probe for the build in installDir and caches the result in its version.json.
+func ProbeAndSaveGPU(installDir string, sandbox []string) (*model.BlenderBuild, error) {
+	build, err := ReadBuildInfo(installDir)
+	if err != nil {
+		return nil, err
+	}
+	if build == nil {
+		return nil, fmt.Errorf("no %s found in %s", versionMetaFilename, installDir)
+	}
+
+	result, err := ProbeGPU(installDir, sandbox)
+	if err != nil {
+		return nil, err
+	}
+	build.GPUProbe = result
+
+	if err := WriteBuildInfo(installDir, *build); err != nil {
+		return nil, err
+	}
+	return build, nil
+}
@@ -0,0 +1,86 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/download"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// archivedAtPattern matches the timestamp appended to a build directory when it is moved to .oldbuilds
+var archivedAtPattern = regexp.MustCompile(`_(\d{8}_\d{6})$`)
+
+// OldBuild is a build directory archived in .oldbuilds after an update.
+type OldBuild struct {
+	Name       string
+	Path       string
+	ArchivedAt time.Time
+	Size       int64
+}
+
+// archivedAt returns when an old build was archived, from its name or else its modification time
+func archivedAt(entry os.DirEntry) time.Time {
+	if match := archivedAtPattern.FindStringSubmatch(entry.Name()); match != nil {
+		if t, err := time.ParseInLocation("20060102_150405", match[1], time.Local); err == nil {
+			return t
+		}
+	}
+	if info, err := entry.Info(); err == nil {
+		return info.ModTime()
+	}
+	return time.Time{}
+}
+
+// ListOldBuilds returns the old builds archived in the .oldbuilds directory, without their size.
+func ListOldBuilds(downloadDir string) ([]OldBuild, error) {
+	oldBuildsDir := filepath.Join(downloadDir, download.OldBuildsDir)
+	entries, err := os.ReadDir(oldBuildsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read %s directory: %w", download.OldBuildsDir, err)
+	}
+
+	var builds []OldBuild
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		builds = append(builds, OldBuild{Name: entry.Name(), Path: filepath.Join(oldBuildsDir, entry.Name()), ArchivedAt: archivedAt(entry)})
+	}
+	return builds, nil
+}
+
+// ExpiredOldBuilds returns the old builds archived longer than retention ago.
+func ExpiredOldBuilds(downloadDir string, retention time.Duration, now time.Time) ([]OldBuild, error) {
+	builds, err := ListOldBuilds(downloadDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []OldBuild
+	for _, build := range builds {
+		if build.ArchivedAt.IsZero() || now.Sub(build.ArchivedAt) < retention {
+			continue
+		}
+		build.Size, _ = download.DirSize(build.Path)
+		expired = append(expired, build)
+	}
+	return expired, nil
+}
+
+// TrashOldBuilds moves the given old builds to the trash, see MoveToTrash.
+// Returns the trashed builds, up to the one that failed on error.
+func TrashOldBuilds(downloadDir string, builds []OldBuild) ([]TrashedDir, error) {
+	var trashed []TrashedDir
+	for _, build := range builds {
+		t, err := MoveToTrash(downloadDir, build.Path)
+		if err != nil {
+			return trashed, fmt.Errorf("failed to delete old build %s: %w", build.Name, err)
+		}
+		trashed = append(trashed, t)
+	}
+	return trashed, nil
+}
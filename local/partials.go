@@ -0,0 +1,75 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/download"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// partialMinAge is how long a partial download must be untouched to count as orphaned.
+// Running downloads write to their file continuously.
+const partialMinAge = 5 * time.Minute
+
+// PartialDownload is a file left in .downloading by a download that never finished.
+type PartialDownload struct {
+	Name    string
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// OrphanedPartials returns the partial downloads in .downloading that no running download is writing to.
+func OrphanedPartials(downloadDir string, now time.Time) ([]PartialDownload, error) {
+	entries, err := downloadingEntries(downloadDir)
+	if err != nil {
+		return nil, err
+	}
+	var partials []PartialDownload
+	for _, entry := range entries {
+		if now.Sub(entry.ModTime) >= partialMinAge {
+			partials = append(partials, entry)
+		}
+	}
+	return partials, nil
+}
+
+// downloadingEntries lists everything in .downloading with its size: partial downloads,
+// archives left behind and extraction directories
+func downloadingEntries(downloadDir string) ([]PartialDownload, error) {
+	downloadingDir := filepath.Join(downloadDir, download.DownloadingDir)
+	entries, err := os.ReadDir(downloadingDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read %s directory: %w", download.DownloadingDir, err)
+	}
+
+	var items []PartialDownload
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(downloadingDir, entry.Name())
+		size := info.Size()
+		if entry.IsDir() {
+			size, _ = download.DirSize(path)
+		}
+		items = append(items, PartialDownload{Name: entry.Name(), Path: path, Size: size, ModTime: info.ModTime()})
+	}
+	return items, nil
+}
+
+// DeletePartials deletes the given partial downloads and returns how many were removed.
+func DeletePartials(partials []PartialDownload) (int, error) {
+	deleted := 0
+	for _, partial := range partials {
+		if err := os.RemoveAll(partial.Path); err != nil {
+			return deleted, fmt.Errorf("failed to delete partial download %s: %w", partial.Name, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
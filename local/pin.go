@@ -0,0 +1,61 @@
+package local
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PinFileName is the file pinning the Blender version of a project directory, like .nvmrc for Node.js
+const PinFileName = ".blender-version"
+
+// pinPattern matches a pinned major.minor series or full version
+var pinPattern = regexp.MustCompile(`^\d+\.\d+(\.\d+)?$`)
+
+// FindPinFile looks for a .blender-version file in dir and its parents and returns its path and
+// the pinned version, e.g. "4.2" or "4.2.3". The path is empty when no project pins a version.
+// Empty lines and lines starting with # are skipped, a leading "v" is allowed.
+func FindPinFile(dir string) (string, string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid directory %s: %w", dir, err)
+	}
+	for {
+		path := filepath.Join(dir, PinFileName)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			version, err := parsePinFile(string(data))
+			if err != nil {
+				return path, "", fmt.Errorf("invalid %s: %w", path, err)
+			}
+			return path, version, nil
+		}
+		if !os.IsNotExist(err) {
+			return path, "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", nil
+		}
+		dir = parent
+	}
+}
+
+// parsePinFile returns the version in the content of a .blender-version file
+func parsePinFile(content string) (string, error) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		version := strings.TrimPrefix(line, "v")
+		if !pinPattern.MatchString(version) {
+			return "", fmt.Errorf("%q is not a Blender version like 4.2 or 4.2.3", line)
+		}
+		return version, nil
+	}
+	return "", fmt.Errorf("no version found")
+}
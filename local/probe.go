@@ -0,0 +1,143 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/model"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// probeTimeout bounds how long a build may take to answer the introspection probe.
+const probeTimeout = 60 * time.Second
+
+// probePrefix marks the lines printed by the probe script so Blender's own output can be ignored.
+const probePrefix = "TBL:"
+
+// probeScript is run inside Blender to report the bundled Python and library versions.
+const probeScript = `import sys, bpy
+def out(k, v): print("TBL:" + k + "=" + str(v))
+out("Blender", bpy.app.version_string)
+out("Python", sys.version.split()[0])
+for name, label in (("usd", "USD"), ("ocio", "OpenColorIO"), ("oiio", "OpenImageIO"), ("openvdb", "OpenVDB"), ("alembic", "Alembic"), ("opensubdiv", "OpenSubdiv")):
+    lib = getattr(bpy.app, name, None)
+    if lib is not None and getattr(lib, "supported", False):
+        out(label, lib.version_string)
+opts = bpy.app.build_options
+if getattr(opts, "cycles", False):
+    import _cycles
+    version = getattr(_cycles, "version", None)
+    if isinstance(version, tuple):
+        version = ".".join(str(v) for v in version)
+    if version:
+        out("Cycles", version)
+    if getattr(opts, "cycles_osl", False):
+        out("OSL", getattr(_cycles, "osl_version_string", "enabled"))
+`
+
+// findProbeExecutable locates the Blender binary that can be run headless.
+// On Windows this is blender.exe rather than the detaching blender-launcher.exe.
+func findProbeExecutable(installDir string) string {
+	candidate := filepath.Join(installDir, "blender")
+	if runtime.GOOS == "windows" {
+		candidate = filepath.Join(installDir, "blender.exe")
+	}
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate
+	}
+	return ""
+}
+
+// probeCommand runs blenderExe with args, inside the sandbox when a command from launch.SandboxArgs is given
+func probeCommand(ctx context.Context, sandbox []string, blenderExe string, args ...string) *exec.Cmd {
+	command := append(append(slices.Clone(sandbox), blenderExe), args...)
+	return exec.CommandContext(ctx, command[0], command[1:]...)
+}
+
+// ProbeBuild runs the build in the given directory in background mode, inside the sandbox if given,
+// and collects its bundled Python and library versions.
+func ProbeBuild(installDir string, sandbox []string) (*model.BuildIntrospection, error) {
+	blenderExe := findProbeExecutable(installDir)
+	if blenderExe == "" {
+		return nil, fmt.Errorf("could not find Blender executable in %s", installDir)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+
+	cmd := probeCommand(ctx, sandbox, blenderExe, "--background", "--factory-startup", "--python-expr", probeScript)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("probe of %s failed: %w", blenderExe, err)
+	}
+
+	info := parseProbeOutput(stdout.String())
+	if info.BlenderVersion == "" {
+		return nil, fmt.Errorf("probe of %s returned no version information", blenderExe)
+	}
+	info.ProbedAt = model.Timestamp(time.Now())
+	return info, nil
+}
+
+// parseProbeValues extracts the key=value pairs printed by a probe script.
+func parseProbeValues(output string) map[string]string {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, probePrefix) {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, probePrefix), "=")
+		if ok {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// parseProbeOutput converts the output of probeScript into introspection data.
+func parseProbeOutput(output string) *model.BuildIntrospection {
+	info := &model.BuildIntrospection{Libraries: make(map[string]string)}
+	for key, value := range parseProbeValues(output) {
+		switch key {
+		case "Blender":
+			info.BlenderVersion = value
+		case "Python":
+			info.PythonVersion = value
+		default:
+			info.Libraries[key] = value
+		}
+	}
+	return info
+}
+
+// ProbeAndSaveBuild probes the build in installDir and caches the result in its version.json.
+func ProbeAndSaveBuild(installDir string, sandbox []string) (*model.BlenderBuild, error) {
+	build, err := ReadBuildInfo(installDir)
+	if err != nil {
+		return nil, err
+	}
+	if build == nil {
+		return nil, fmt.Errorf("no %s found in %s", versionMetaFilename, installDir)
+	}
+
+	info, err := ProbeBuild(installDir, sandbox)
+	if err != nil {
+		return nil, err
+	}
+	build.Introspection = info
+
+	if err := WriteBuildInfo(installDir, *build); err != nil {
+		return nil, err
+	}
+	return build, nil
+}
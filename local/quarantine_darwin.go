@@ -0,0 +1,34 @@
+//go:build darwin
+// +build darwin
+
+package local
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// quarantineAttr is set by macOS on files from the internet; Gatekeeper blocks their first launch
+const quarantineAttr = "com.apple.quarantine"
+
+// Quarantined reports whether the Blender executable of a build carries the quarantine attribute
+func Quarantined(installDir string) bool {
+	target := FindBlenderExecutable(installDir)
+	if target == "" {
+		target = installDir
+	}
+	return exec.Command("xattr", "-p", quarantineAttr, target).Run() == nil
+}
+
+// ClearQuarantine removes the quarantine attribute from every file of a build
+func ClearQuarantine(installDir string) error {
+	if out, err := exec.Command("xattr", "-dr", quarantineAttr, installDir).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to clear the quarantine attribute of %s: %w: %s", installDir, err, out)
+	}
+	return nil
+}
+
+// OpenSecuritySettings opens the Privacy & Security settings where blocked apps can be allowed
+func OpenSecuritySettings() error {
+	return exec.Command("open", "x-apple.systempreferences:com.apple.preference.security?General").Start()
+}
@@ -0,0 +1,19 @@
+//go:build !darwin
+// +build !darwin
+
+package local
+
+// Quarantined reports whether a build carries the macOS quarantine attribute, never outside macOS
+func Quarantined(installDir string) bool {
+	return false
+}
+
+// ClearQuarantine removes the macOS quarantine attribute; nothing to do outside macOS
+func ClearQuarantine(installDir string) error {
+	return nil
+}
+
+// OpenSecuritySettings opens the macOS Privacy & Security settings; nothing to do outside macOS
+func OpenSecuritySettings() error {
+	return nil
+}
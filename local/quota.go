@@ -0,0 +1,54 @@
+package local
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// DownloadingSize returns the bytes used by .downloading
+func DownloadingSize(downloadDir string) (int64, error) {
+	entries, err := downloadingEntries(downloadDir)
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, entry := range entries {
+		size += entry.Size
+	}
+	return size, nil
+}
+
+// EvictDownloading deletes the least recently written items in .downloading until it uses at most quota bytes.
+// Items in keep, e.g. paused downloads, and items written to recently are never deleted, so .downloading
+// may stay above the quota. It returns the deleted items and the bytes used afterwards.
+func EvictDownloading(downloadDir string, quota int64, keep map[string]bool, now time.Time) ([]PartialDownload, int64, error) {
+	entries, err := downloadingEntries(downloadDir)
+	if err != nil {
+		return nil, 0, err
+	}
+	var size int64
+	for _, entry := range entries {
+		size += entry.Size
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime.Before(entries[j].ModTime)
+	})
+	var evicted []PartialDownload
+	for _, entry := range entries {
+		if size <= quota {
+			break
+		}
+		if keep[entry.Path] || now.Sub(entry.ModTime) < partialMinAge {
+			continue
+		}
+		if err := os.RemoveAll(entry.Path); err != nil {
+			return evicted, size, fmt.Errorf("failed to delete %s: %w", entry.Name, err)
+		}
+		size -= entry.Size
+		evicted = append(evicted, entry)
+	}
+	return evicted, size, nil
+}
@@ -0,0 +1,184 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// scanWorkers is the number of build directories read concurrently during a scan
+const scanWorkers = 8
+
+// scanCacheEntry is the build read from a directory, valid while the directory and its
+// version.json keep the recorded modification times and size
+type scanCacheEntry struct {
+	DirModTime  time.Time          `json:"dir_mod_time"`
+	MetaModTime time.Time          `json:"meta_mod_time"`
+	MetaSize    int64              `json:"meta_size"`
+	Build       model.BlenderBuild `json:"build"`
+}
+
+// scanCache holds the builds read by previous scans, keyed by directory path.
+// It is loaded from the cache directory on first use and saved by SaveScanCache.
+var scanCache = struct {
+	sync.Mutex
+	entries map[string]scanCacheEntry
+	loaded  bool // The saved entries were read
+	changed bool // Entries changed since they were loaded or saved
+}{entries: make(map[string]scanCacheEntry)}
+
+// getScanCachePath returns the file the scan cache is saved to between sessions
+func getScanCachePath() (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "scancache.json"), nil
+}
+
+// loadScanCache reads the saved scan cache once; the scanCache lock must be held.
+// A missing or unreadable file leaves the cache empty, the scan reads every directory then.
+func loadScanCache() {
+	if scanCache.loaded {
+		return
+	}
+	scanCache.loaded = true
+	path, err := getScanCachePath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var entries map[string]scanCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return
+	}
+	for dirPath, entry := range entries {
+		// Entries of this session are newer
+		if _, ok := scanCache.entries[dirPath]; !ok {
+			scanCache.entries[dirPath] = entry
+		}
+	}
+}
+
+// SaveScanCache saves the scan cache so the next session can list the local builds before
+// its first scan, see CachedLocalBuilds, and skip reading unchanged directories.
+func SaveScanCache() error {
+	scanCache.Lock()
+	defer scanCache.Unlock()
+	if !scanCache.changed {
+		return nil
+	}
+	path, err := getScanCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("could not create cache directory: %w", err)
+	}
+	data, err := json.Marshal(scanCache.entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scan cache: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	scanCache.changed = false
+	return nil
+}
+
+// CachedLocalBuilds returns the builds in downloadDir as the scan cache last saw them, without
+// checking the directories. They are listed at startup until the first scan finished.
+func CachedLocalBuilds(downloadDir string) []model.BlenderBuild {
+	scanCache.Lock()
+	defer scanCache.Unlock()
+	loadScanCache()
+
+	var builds []model.BlenderBuild
+	for dirPath, entry := range scanCache.entries {
+		if filepath.Dir(dirPath) == filepath.Clean(downloadDir) {
+			builds = append(builds, entry.Build)
+		}
+	}
+	sort.Slice(builds, func(i, j int) bool {
+		return builds[i].Version > builds[j].Version
+	})
+	return builds
+}
+
+// readBuildInfoCached reads a build directory like ReadBuildInfo, reusing the result of an earlier
+// scan when neither the directory nor its version.json changed since. version.json is checked too
+// because rewriting it in place (after a probe or verification) doesn't touch the directory.
+func readBuildInfoCached(dirPath string) (*model.BlenderBuild, error) {
+	dirInfo, err := os.Stat(dirPath)
+	if err != nil {
+		forgetCachedBuild(dirPath)
+		return ReadBuildInfo(dirPath)
+	}
+	metaInfo, err := os.Stat(filepath.Join(dirPath, versionMetaFilename))
+	if err != nil {
+		forgetCachedBuild(dirPath)
+		return ReadBuildInfo(dirPath)
+	}
+
+	scanCache.Lock()
+	loadScanCache()
+	entry, ok := scanCache.entries[dirPath]
+	scanCache.Unlock()
+	if ok && entry.DirModTime.Equal(dirInfo.ModTime()) && entry.MetaModTime.Equal(metaInfo.ModTime()) && entry.MetaSize == metaInfo.Size() {
+		build := entry.Build
+		return &build, nil
+	}
+
+	build, err := ReadBuildInfo(dirPath)
+	if err != nil || build == nil {
+		forgetCachedBuild(dirPath)
+		return build, err
+	}
+	scanCache.Lock()
+	scanCache.entries[dirPath] = scanCacheEntry{
+		DirModTime:  dirInfo.ModTime(),
+		MetaModTime: metaInfo.ModTime(),
+		MetaSize:    metaInfo.Size(),
+		Build:       *build,
+	}
+	scanCache.changed = true
+	scanCache.Unlock()
+	return build, nil
+}
+
+// forgetCachedBuild drops the cached build of a directory
+func forgetCachedBuild(dirPath string) {
+	scanCache.Lock()
+	loadScanCache()
+	if _, ok := scanCache.entries[dirPath]; ok {
+		delete(scanCache.entries, dirPath)
+		scanCache.changed = true
+	}
+	scanCache.Unlock()
+}
+
+// pruneScanCache drops the cached builds of directories in downloadDir a scan no longer found
+func pruneScanCache(downloadDir string, dirs []string) {
+	found := make(map[string]bool, len(dirs))
+	for _, dirPath := range dirs {
+		found[dirPath] = true
+	}
+	scanCache.Lock()
+	defer scanCache.Unlock()
+	loadScanCache()
+	for dirPath := range scanCache.entries {
+		if filepath.Dir(dirPath) == filepath.Clean(downloadDir) && !found[dirPath] {
+			delete(scanCache.entries, dirPath)
+			scanCache.changed = true
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// scanHashes scans downloadDir and returns the hash of each build found, by version
+func scanHashes(t *testing.T, downloadDir string) map[string]string {
+	t.Helper()
+	builds, err := ScanLocalBuilds(downloadDir)
+	if err != nil {
+		t.Fatalf("ScanLocalBuilds failed: %v", err)
+	}
+	hashes := make(map[string]string, len(builds))
+	for _, build := range builds {
+		hashes[build.Version] = build.Hash
+	}
+	return hashes
+}
+
+func TestScanCache(t *testing.T) {
+	downloadDir := t.TempDir()
+	dirPath := filepath.Join(downloadDir, "blender-4.3.0")
+	metaPath := filepath.Join(dirPath, versionMetaFilename)
+	if err := os.Mkdir(dirPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	written := time.Now().Add(-time.Hour).Truncate(time.Second)
+	write := func(hash string, modTime time.Time) {
+		t.Helper()
+		if err := WriteBuildInfo(dirPath, model.BlenderBuild{Version: "4.3.0", Hash: hash}); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(metaPath, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(dirPath, written, written); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("aaaaaaaaaaaa", written)
+	if got := scanHashes(t, downloadDir)["4.3.0"]; got != "aaaaaaaaaaaa" {
+		t.Fatalf("Expected the build read from version.json, got hash %q", got)
+	}
+
+	// Same size and modification time: the cached build is served without reading the file
+	write("bbbbbbbbbbbb", written)
+	if got := scanHashes(t, downloadDir)["4.3.0"]; got != "aaaaaaaaaaaa" {
+		t.Errorf("Expected the cached build, got hash %q", got)
+	}
+
+	// A newer version.json is read again
+	write("bbbbbbbbbbbb", written.Add(time.Minute))
+	if got := scanHashes(t, downloadDir)["4.3.0"]; got != "bbbbbbbbbbbb" {
+		t.Errorf("Expected the rewritten version.json to be read, got hash %q", got)
+	}
+
+	// A corrupt version.json drops the build and its cache entry
+	if err := os.WriteFile(metaPath, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if hashes := scanHashes(t, downloadDir); len(hashes) != 0 {
+		t.Errorf("Expected no build for a corrupt version.json, got %v", hashes)
+	}
+	scanCache.Lock()
+	_, cached := scanCache.entries[dirPath]
+	scanCache.Unlock()
+	if cached {
+		t.Error("Expected the corrupt version.json to drop the cached build")
+	}
+
+	// Once fixed, and again once removed
+	write("cccccccccccc", written.Add(2*time.Minute))
+	if got := scanHashes(t, downloadDir)["4.3.0"]; got != "cccccccccccc" {
+		t.Errorf("Expected the fixed version.json to be read, got hash %q", got)
+	}
+	if err := os.Remove(metaPath); err != nil {
+		t.Fatal(err)
+	}
+	if hashes := scanHashes(t, downloadDir); len(hashes) != 0 {
+		t.Errorf("Expected no build without version.json, got %v", hashes)
+	}
+}
+
+func TestScanLocalBuildsConcurrent(t *testing.T) {
+	downloadDir := t.TempDir()
+	for i := 0; i < 3*scanWorkers; i++ {
+		dirPath := filepath.Join(downloadDir, fmt.Sprintf("blender-4.%d.0", i))
+		if err := os.Mkdir(dirPath, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteBuildInfo(dirPath, model.BlenderBuild{Version: fmt.Sprintf("4.%d.0", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(downloadDir, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var scanned int
+	builds, err := ScanLocalBuildsFunc(downloadDir, func(p ScanProgress) {
+		scanned = p.Scanned
+		if p.Total != 3*scanWorkers+1 {
+			t.Errorf("Expected %d directories in total, got %d", 3*scanWorkers+1, p.Total)
+		}
+	})
+	if err != nil {
+		t.Fatalf("ScanLocalBuildsFunc failed: %v", err)
+	}
+	if len(builds) != 3*scanWorkers || scanned != 3*scanWorkers+1 {
+		t.Errorf("Expected %d builds from %d directories, got %d after %d", 3*scanWorkers, 3*scanWorkers+1, len(builds), scanned)
+	}
+	for _, build := range builds {
+		if build.Status != model.StateLocal {
+			t.Errorf("Expected %s to be local, got %v", build.Version, build.Status)
+		}
+	}
+}
+
+func TestSaveScanCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	downloadDir := t.TempDir()
+	for _, version := range []string{"4.2.0", "4.3.0"} {
+		dirPath := filepath.Join(downloadDir, "blender-"+version)
+		if err := os.Mkdir(dirPath, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteBuildInfo(dirPath, model.BlenderBuild{Version: version}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := ScanLocalBuilds(downloadDir); err != nil {
+		t.Fatalf("ScanLocalBuilds failed: %v", err)
+	}
+	// A build deleted since is dropped by the next scan
+	if err := os.RemoveAll(filepath.Join(downloadDir, "blender-4.2.0")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ScanLocalBuilds(downloadDir); err != nil {
+		t.Fatalf("ScanLocalBuilds failed: %v", err)
+	}
+	if err := SaveScanCache(); err != nil {
+		t.Fatalf("SaveScanCache failed: %v", err)
+	}
+
+	// The next session lists the saved builds before scanning
+	scanCache.Lock()
+	scanCache.entries = make(map[string]scanCacheEntry)
+	scanCache.loaded = false
+	scanCache.Unlock()
+	builds := CachedLocalBuilds(downloadDir)
+	if len(builds) != 1 || builds[0].Version != "4.3.0" || builds[0].Status != model.StateLocal {
+		t.Errorf("Expected the cached 4.3.0 build, got %+v", builds)
+	}
+	if other := CachedLocalBuilds(t.TempDir()); len(other) != 0 {
+		t.Errorf("Expected no builds for another download directory, got %+v", other)
+	}
+}
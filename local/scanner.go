@@ -2,6 +2,7 @@ package local
 
 import (
 	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/launch"
 	"TUI-Blender-Launcher/model"
 	"encoding/json"
 	"fmt"
@@ -37,8 +38,75 @@ func ReadBuildInfo(dirPath string) (*model.BlenderBuild, error) {
 	return &build, nil
 }
 
+// WriteBuildInfo writes build information to version.json in the given directory.
+func WriteBuildInfo(dirPath string, build model.BlenderBuild) error {
+	metaPath := filepath.Join(dirPath, versionMetaFilename)
+	jsonData, err := json.MarshalIndent(build, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal build metadata: %w", err)
+	}
+	if err := os.WriteFile(metaPath, jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", metaPath, err)
+	}
+	return nil
+}
+
+// FindBuildDir returns the installation directory of the local build with the given version and architecture,
+// see BlenderBuild.Matches. Returns an empty string if no such build exists.
+func FindBuildDir(downloadDir string, version string, arch string) (string, error) {
+	return findBuildDir(downloadDir, func(b *model.BlenderBuild) bool {
+		return b.Matches(version, arch)
+	})
+}
+
+// FindBuildDirOf returns the installation directory of build, like FindBuildDir but also matching its hash
+// so builds of the same version installed side by side are told apart.
+func FindBuildDirOf(downloadDir string, build model.BlenderBuild) (string, error) {
+	return findBuildDir(downloadDir, func(b *model.BlenderBuild) bool {
+		return b.Matches(build.Version, build.Architecture) && b.Hash == build.Hash
+	})
+}
+
+// findBuildDir returns the first installation directory whose build info satisfies match
+func findBuildDir(downloadDir string, match func(*model.BlenderBuild) bool) (string, error) {
+	entries, err := os.ReadDir(downloadDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read download directory %s: %w", downloadDir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != download.DownloadingDir && entry.Name() != download.OldBuildsDir && entry.Name() != download.TrashDir {
+			dirPath := filepath.Join(downloadDir, entry.Name())
+			buildInfo, err := ReadBuildInfo(dirPath)
+			if err != nil {
+				continue
+			}
+			if buildInfo != nil && match(buildInfo) {
+				return dirPath, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// ScanProgress reports the progress of a local build scan after each directory.
+type ScanProgress struct {
+	Build   *model.BlenderBuild // Build found in the directory, nil if it holds none
+	Scanned int                 // Directories scanned so far
+	Total   int                 // Directories to scan
+}
+
 // ScanLocalBuilds scans the download directory for local Blender builds using version.json.
 func ScanLocalBuilds(downloadDir string) ([]model.BlenderBuild, error) {
+	return ScanLocalBuildsFunc(downloadDir, nil)
+}
+
+// ScanLocalBuildsFunc scans like ScanLocalBuilds, calling progress after each directory
+// so callers can show builds as they are found on slow filesystems.
+// Directories are read concurrently and unchanged ones are served from a cache;
+// progress is called from the calling goroutine only.
+func ScanLocalBuildsFunc(downloadDir string, progress func(ScanProgress)) ([]model.BlenderBuild, error) {
 	var localBuilds []model.BlenderBuild
 	entries, err := os.ReadDir(downloadDir)
 	if err != nil {
@@ -48,20 +116,49 @@ func ScanLocalBuilds(downloadDir string) ([]model.BlenderBuild, error) {
 		return nil, fmt.Errorf("failed to read download directory %s: %w", downloadDir, err)
 	}
 
+	var dirs []string
 	for _, entry := range entries {
-		if entry.IsDir() && entry.Name() != download.OldBuildsDir {
-			dirPath := filepath.Join(downloadDir, entry.Name())
-			buildInfo, err := ReadBuildInfo(dirPath)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error processing directory %s: %v\n", dirPath, err)
-				continue
-			}
-			if buildInfo != nil {
-				localBuilds = append(localBuilds, *buildInfo)
+		if entry.IsDir() && entry.Name() != download.OldBuildsDir && entry.Name() != download.TrashDir {
+			dirs = append(dirs, filepath.Join(downloadDir, entry.Name()))
+		}
+	}
+
+	type scanResult struct {
+		dirPath string
+		build   *model.BlenderBuild
+		err     error
+	}
+	jobs := make(chan string)
+	results := make(chan scanResult)
+	for w := 0; w < scanWorkers && w < len(dirs); w++ {
+		go func() {
+			for dirPath := range jobs {
+				build, err := readBuildInfoCached(dirPath)
+				results <- scanResult{dirPath: dirPath, build: build, err: err}
 			}
+		}()
+	}
+	go func() {
+		for _, dirPath := range dirs {
+			jobs <- dirPath
+		}
+		close(jobs)
+	}()
+
+	for i := range dirs {
+		result := <-results
+		if result.err != nil {
+			fmt.Fprintf(os.Stderr, "Error processing directory %s: %v\n", result.dirPath, result.err)
+		} else if result.build != nil {
+			localBuilds = append(localBuilds, *result.build)
+		}
+		if progress != nil {
+			progress(ScanProgress{Build: result.build, Scanned: i + 1, Total: len(dirs)})
 		}
 	}
 
+	pruneScanCache(downloadDir, dirs)
+
 	sort.Slice(localBuilds, func(i, j int) bool {
 		return localBuilds[i].Version > localBuilds[j].Version
 	})
@@ -81,7 +178,7 @@ func BuildLocalLookupMap(downloadDir string) (map[string]bool, error) {
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() && entry.Name() != download.OldBuildsDir {
+		if entry.IsDir() && entry.Name() != download.OldBuildsDir && entry.Name() != download.TrashDir {
 			dirPath := filepath.Join(downloadDir, entry.Name())
 			buildInfo, err := ReadBuildInfo(dirPath)
 			if err != nil {
@@ -97,34 +194,18 @@ func BuildLocalLookupMap(downloadDir string) (map[string]bool, error) {
 	return lookupMap, nil
 }
 
-// DeleteBuild finds and deletes a local build by version. Returns true if deletion was successful.
-func DeleteBuild(downloadDir string, version string) (bool, error) {
-	entries, err := os.ReadDir(downloadDir)
-	if err != nil {
-		return false, fmt.Errorf("failed to read download directory %s: %w", downloadDir, err)
-	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			dirPath := filepath.Join(downloadDir, entry.Name())
-			buildInfo, err := ReadBuildInfo(dirPath)
-			if err != nil {
-				continue
-			}
-			if buildInfo != nil && buildInfo.Version == version {
-				if err := os.RemoveAll(dirPath); err != nil {
-					return false, fmt.Errorf("failed to delete build directory %s: %w", dirPath, err)
-				}
-				return true, nil
-			}
-		}
-	}
+// LaunchBlenderCmd creates a command to launch Blender for a specific version and architecture.
+func LaunchBlenderCmd(downloadDir string, version string, arch string) tea.Cmd {
+	return launchBlenderCmd(downloadDir, version, arch, false)
+}
 
-	return false, nil
+// LaunchSharedBlenderCmd creates a command to launch a build from the read-only shared directory.
+// Its isolated user config, if any, is kept in the user's state directory.
+func LaunchSharedBlenderCmd(sharedDir string, version string, arch string) tea.Cmd {
+	return launchBlenderCmd(sharedDir, version, arch, true)
 }
 
-// LaunchBlenderCmd creates a command to launch Blender for a specific version.
-func LaunchBlenderCmd(downloadDir string, version string) tea.Cmd {
+func launchBlenderCmd(downloadDir string, version string, arch string, shared bool) tea.Cmd {
 	return func() tea.Msg {
 		entries, err := os.ReadDir(downloadDir)
 		if err != nil {
@@ -138,15 +219,31 @@ func LaunchBlenderCmd(downloadDir string, version string) tea.Cmd {
 				if err != nil {
 					continue
 				}
-				if buildInfo != nil && buildInfo.Version == version {
-					blenderExe := findBlenderExecutable(dirPath)
+				if buildInfo != nil && buildInfo.Matches(version, arch) {
+					blenderExe := FindBlenderExecutable(dirPath)
 					if blenderExe == "" {
 						return fmt.Errorf("could not find Blender executable in %s", dirPath)
 					}
-					return model.BlenderExecMsg{
+					execMsg := model.BlenderExecMsg{
 						Version:    version,
 						Executable: blenderExe,
+						InstallDir: dirPath,
+						ConfigRoot: dirPath,
+						Source:     buildInfo.SourceLabel(),
+						Profile:    buildInfo.LaunchProfile,
 					}
+					if shared {
+						configRoot, err := SharedConfigDir(dirPath)
+						if err != nil {
+							return err
+						}
+						execMsg.ConfigRoot = configRoot
+					}
+					execMsg.Quarantine = Quarantined(dirPath)
+					if UsesIsolatedConfig(execMsg.ConfigRoot) {
+						execMsg.Env = IsolatedConfigEnv(execMsg.ConfigRoot)
+					}
+					return execMsg
 				}
 			}
 		}
@@ -189,8 +286,8 @@ func OpenDirCmd(dir string) tea.Cmd {
 	}
 }
 
-// findBlenderExecutable locates the Blender executable in the installation directory.
-func findBlenderExecutable(installDir string) string {
+// FindBlenderExecutable locates the Blender executable in the installation directory.
+func FindBlenderExecutable(installDir string) string {
 	var candidate string
 	switch runtime.GOOS {
 	case "windows":
@@ -212,6 +309,9 @@ func OpenFileExplorer(dir string) error {
 	var cmd *exec.Cmd
 	if runtime.GOOS == "windows" {
 		cmd = exec.Command("explorer", dir)
+	} else if launch.DetectSandbox() == launch.SandboxFlatpak {
+		// The document portal can't open directories, so ask the host's file manager
+		cmd = launch.HostCommand("xdg-open", []string{dir}, nil)
 	} else {
 		if _, err := exec.LookPath("xdg-open"); err == nil {
 			cmd = exec.Command("xdg-open", dir)
@@ -233,40 +333,27 @@ func OpenFileExplorer(dir string) error {
 	return cmd.Start()
 }
 
-// openFileExplorer is a simple wrapper for OpenFileExplorer.
-func openFileExplorer(dir string) error {
-	return OpenFileExplorer(dir)
-}
-
-// CleanOldBuilds removes all builds from the .oldbuilds directory.
-// Returns the number of cleaned builds and any error encountered.
-func CleanOldBuilds(downloadDir string) (int, error) {
-	oldBuildsDir := filepath.Join(downloadDir, download.OldBuildsDir)
-
-	// Check if the old builds directory exists
-	if _, err := os.Stat(oldBuildsDir); os.IsNotExist(err) {
-		// If it doesn't exist, there's nothing to clean
-		return 0, nil
-	}
-
-	// Read the contents of the old builds directory
-	entries, err := os.ReadDir(oldBuildsDir)
-	if err != nil {
-		return 0, fmt.Errorf("failed to read %s directory: %w", download.OldBuildsDir, err)
+// OpenURL opens a web page in the default browser.
+// Inside Flatpak and Snap xdg-open forwards to the desktop portal.
+func OpenURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	case "darwin":
+		cmd = exec.Command("open", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
 	}
 
-	cleanedCount := 0
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	detachProcess(cmd)
 
-	// Delete each old build
-	for _, entry := range entries {
-		if entry.IsDir() {
-			dirPath := filepath.Join(oldBuildsDir, entry.Name())
-			if err := os.RemoveAll(dirPath); err != nil {
-				return cleanedCount, fmt.Errorf("failed to delete old build %s: %w", entry.Name(), err)
-			}
-			cleanedCount++
-		}
-	}
+	return cmd.Start()
+}
 
-	return cleanedCount, nil
+// openFileExplorer is a simple wrapper for OpenFileExplorer.
+func openFileExplorer(dir string) error {
+	return OpenFileExplorer(dir)
 }
@@ -0,0 +1,50 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"path/filepath"
+	"sort"
+)
+
+// sharedStateDir is the directory in the state directory holding per-user files of shared builds
+const sharedStateDir = "shared"
+
+// MergeSharedBuilds adds the builds of the read-only shared directory to the user's own builds.
+// A build installed by the user takes precedence over a shared build of the same version and architecture.
+func MergeSharedBuilds(builds []model.BlenderBuild, sharedDir string) ([]model.BlenderBuild, error) {
+	if sharedDir == "" {
+		return builds, nil
+	}
+	sharedBuilds, err := ScanLocalBuilds(sharedDir)
+	if err != nil {
+		return builds, err
+	}
+
+	own := make(map[string]bool, len(builds))
+	for _, build := range builds {
+		own[build.Version+"|"+build.Architecture] = true
+	}
+	for _, build := range sharedBuilds {
+		if own[build.Version+"|"+build.Architecture] {
+			continue
+		}
+		build.Shared = true
+		builds = append(builds, build)
+	}
+
+	sort.Slice(builds, func(i, j int) bool {
+		return builds[i].Version > builds[j].Version
+	})
+	return builds, nil
+}
+
+// SharedConfigDir returns the per-user directory that stands in for the installation directory
+// of a shared build when keeping its isolated user config, since the shared one is read-only.
+func SharedConfigDir(installDir string) (string, error) {
+	stateDir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, sharedStateDir, filepath.Base(installDir)), nil
+}
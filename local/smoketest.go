@@ -0,0 +1,70 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/model"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// smokeTestTimeout bounds how long a build may take to print its version.
+const smokeTestTimeout = 30 * time.Second
+
+// SmokeTest runs `blender --version --background` for the build in installDir and records
+// whether it exits cleanly and the version it prints. A sandbox command from launch.SandboxArgs runs it inside the sandbox.
+func SmokeTest(installDir string, sandbox []string) *model.SmokeTestResult {
+	result := &model.SmokeTestResult{TestedAt: model.Timestamp(time.Now())}
+
+	blenderExe := findProbeExecutable(installDir)
+	if blenderExe == "" {
+		result.Error = fmt.Sprintf("could not find Blender executable in %s", installDir)
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), smokeTestTimeout)
+	defer cancel()
+
+	cmd := probeCommand(ctx, sandbox, blenderExe, "--version", "--background")
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("no answer within %s", smokeTestTimeout)
+		}
+		result.Error = err.Error()
+		return result
+	}
+
+	for _, line := range strings.Split(output.String(), "\n") {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "Blender") {
+			result.Version = line
+			break
+		}
+	}
+	if result.Version == "" {
+		result.Error = "exited without printing its version"
+		return result
+	}
+	result.Passed = true
+	return result
+}
+
+// SmokeTestAndSaveBuild runs the smoke test for the build in installDir and records the result in its version.json.
+func SmokeTestAndSaveBuild(installDir string, sandbox []string) (*model.BlenderBuild, error) {
+	build, err := ReadBuildInfo(installDir)
+	if err != nil {
+		return nil, err
+	}
+	if build == nil {
+		return nil, fmt.Errorf("no %s found in %s", versionMetaFilename, installDir)
+	}
+
+	build.SmokeTest = SmokeTest(installDir, sandbox)
+	if err := WriteBuildInfo(installDir, *build); err != nil {
+		return nil, err
+	}
+	return build, nil
+}
@@ -0,0 +1,75 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/download"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// TrashedDir is a directory moved to the trash of a download directory, see MoveToTrash.
+type TrashedDir struct {
+	Path string // Where the directory is kept in the trash
+	From string // Where it was moved from
+}
+
+// MoveToTrash moves dir to the trash of downloadDir, where it stays until EmptyTrash,
+// so deleting it can be undone with Restore. dir must be on the same file system.
+func MoveToTrash(downloadDir, dir string) (TrashedDir, error) {
+	trashDir := filepath.Join(downloadDir, download.TrashDir)
+	if err := os.MkdirAll(trashDir, 0750); err != nil {
+		return TrashedDir{}, fmt.Errorf("could not create %s directory: %w", download.TrashDir, err)
+	}
+	// Each directory gets its own slot, trashed directories may have the same name
+	slot, err := os.MkdirTemp(trashDir, "")
+	if err != nil {
+		return TrashedDir{}, fmt.Errorf("could not create %s directory: %w", download.TrashDir, err)
+	}
+
+	trashed := TrashedDir{Path: filepath.Join(slot, filepath.Base(dir)), From: dir}
+	if err := os.Rename(dir, trashed.Path); err != nil {
+		os.Remove(slot)
+		return TrashedDir{}, fmt.Errorf("failed to move %s to %s: %w", filepath.Base(dir), download.TrashDir, err)
+	}
+	return trashed, nil
+}
+
+// Restore moves a trashed directory back where it was, unless something took its place since.
+func (t TrashedDir) Restore() error {
+	if _, err := os.Lstat(t.From); err == nil {
+		return fmt.Errorf("cannot restore %s: it exists again", filepath.Base(t.From))
+	}
+	if _, err := os.Stat(t.Path); err != nil {
+		return fmt.Errorf("cannot restore %s: it is no longer in %s", filepath.Base(t.From), download.TrashDir)
+	}
+	if err := os.MkdirAll(filepath.Dir(t.From), 0750); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", filepath.Base(t.From), err)
+	}
+	if err := os.Rename(t.Path, t.From); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", filepath.Base(t.From), err)
+	}
+	os.Remove(filepath.Dir(t.Path))
+	return nil
+}
+
+// EmptyTrash deletes the directories in the trash of downloadDir.
+func EmptyTrash(downloadDir string) error {
+	if err := os.RemoveAll(filepath.Join(downloadDir, download.TrashDir)); err != nil {
+		return fmt.Errorf("failed to empty %s: %w", download.TrashDir, err)
+	}
+	return nil
+}
+
+// TrashBuild moves the local build matching version and architecture to the trash.
+// Returns false if there is no such build.
+func TrashBuild(downloadDir string, version string, arch string) (TrashedDir, bool, error) {
+	dirPath, err := FindBuildDir(downloadDir, version, arch)
+	if err != nil || dirPath == "" {
+		return TrashedDir{}, false, err
+	}
+	trashed, err := MoveToTrash(downloadDir, dirPath)
+	if err != nil {
+		return TrashedDir{}, false, err
+	}
+	return trashed, true, nil
+}
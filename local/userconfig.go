@@ -0,0 +1,177 @@
+package local
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// IsolatedConfigDir is the directory inside a build's installation used when it runs with isolated user config.
+const IsolatedConfigDir = "isolated-config"
+
+// BlenderUserConfigRoot returns the directory in which Blender keeps one user config directory per major.minor version.
+func BlenderUserConfigRoot() (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			return "", fmt.Errorf("APPDATA is not set")
+		}
+		return filepath.Join(appData, "Blender Foundation", "Blender"), nil
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not get home directory: %w", err)
+		}
+		return filepath.Join(home, "Library", "Application Support", "Blender"), nil
+	default:
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("could not get home directory: %w", err)
+			}
+			configHome = filepath.Join(home, ".config")
+		}
+		return filepath.Join(configHome, "blender"), nil
+	}
+}
+
+// SeriesFromVersion returns the major.minor series of a Blender version, e.g., "4.2" for "4.2.1".
+func SeriesFromVersion(buildVersion string) (string, error) {
+	v, err := version.NewVersion(buildVersion)
+	if err != nil {
+		return "", fmt.Errorf("invalid Blender version '%s': %w", buildVersion, err)
+	}
+	segments := v.Segments()
+	return fmt.Sprintf("%d.%d", segments[0], segments[1]), nil
+}
+
+// UserConfigMigration describes the user config situation for launching a given series.
+type UserConfigMigration struct {
+	Series         string // Series that is about to be launched, e.g., "4.2"
+	PreviousSeries string // Newest older series that has a user config, e.g., "4.1"
+	ConfigDir      string // User config directory that Blender would create
+	PreviousDir    string // User config directory of PreviousSeries
+}
+
+// CheckUserConfigMigration reports whether launching buildVersion would make Blender create a new
+// user config directory while an older one exists. Returns nil if no migration would happen.
+func CheckUserConfigMigration(buildVersion string) (*UserConfigMigration, error) {
+	series, err := SeriesFromVersion(buildVersion)
+	if err != nil {
+		return nil, err
+	}
+	root, err := BlenderUserConfigRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	configDir := filepath.Join(root, series)
+	if _, err := os.Stat(configDir); err == nil {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// First Blender run on this machine, nothing to migrate
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read Blender config directory %s: %w", root, err)
+	}
+
+	current, _ := version.NewVersion(series)
+	var older []*version.Version
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		v, err := version.NewVersion(entry.Name())
+		if err != nil || !v.LessThan(current) {
+			continue
+		}
+		older = append(older, v)
+	}
+	if len(older) == 0 {
+		return nil, nil
+	}
+	sort.Sort(version.Collection(older))
+	previous := older[len(older)-1].Original()
+
+	return &UserConfigMigration{
+		Series:         series,
+		PreviousSeries: previous,
+		ConfigDir:      configDir,
+		PreviousDir:    filepath.Join(root, previous),
+	}, nil
+}
+
+// CopyUserConfig copies the previous series' user config into the new series' directory,
+// like Blender's own "Load Previous Version Settings" does.
+func CopyUserConfig(migration UserConfigMigration) error {
+	return filepath.Walk(migration.PreviousDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(migration.PreviousDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(migration.ConfigDir, rel)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, 0750)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target, info.Mode())
+		}
+	})
+}
+
+// copyFile copies a single regular file, preserving its mode.
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	return out.Close()
+}
+
+// IsolatedConfigEnv returns the environment variables that make a build keep its user config
+// inside its own installation directory instead of the shared per-version directory.
+func IsolatedConfigEnv(installDir string) []string {
+	dir := filepath.Join(installDir, IsolatedConfigDir)
+	return []string{
+		"BLENDER_USER_RESOURCES=" + dir,
+		"BLENDER_USER_CONFIG=" + filepath.Join(dir, "config"),
+		"BLENDER_USER_SCRIPTS=" + filepath.Join(dir, "scripts"),
+	}
+}
+
+// UsesIsolatedConfig reports whether the build in installDir was set up to run with isolated user config.
+func UsesIsolatedConfig(installDir string) bool {
+	info, err := os.Stat(filepath.Join(installDir, IsolatedConfigDir))
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,79 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestUserConfigMigration(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the config root is set through XDG_CONFIG_HOME")
+	}
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	root := filepath.Join(configHome, "blender")
+
+	// First Blender run on this machine
+	if migration, err := CheckUserConfigMigration("4.2.0"); err != nil || migration != nil {
+		t.Fatalf("Expected nothing to migrate without a config root, got %+v (%v)", migration, err)
+	}
+
+	files := map[string]string{
+		"3.6/config/userpref.blend":        "3.6 prefs",
+		"4.1/config/userpref.blend":        "4.1 prefs",
+		"4.1/config/bookmarks.txt":         "/projects",
+		"4.1/scripts/addons/studio/api.py": "print('studio')",
+		"4.3/config/userpref.blend":        "4.3 prefs",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(root, "cache"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	// A series with its own config starts as it is
+	if migration, err := CheckUserConfigMigration("4.1.2"); err != nil || migration != nil {
+		t.Errorf("Expected no migration for 4.1, got %+v (%v)", migration, err)
+	}
+
+	// A new series takes the newest older config, not a newer one
+	migration, err := CheckUserConfigMigration("4.2.0")
+	if err != nil || migration == nil {
+		t.Fatalf("Expected a migration for 4.2, got %v", err)
+	}
+	if migration.Series != "4.2" || migration.PreviousSeries != "4.1" || migration.ConfigDir != filepath.Join(root, "4.2") {
+		t.Errorf("Expected 4.1 to be migrated to 4.2, got %+v", migration)
+	}
+
+	if err := CopyUserConfig(*migration); err != nil {
+		t.Fatalf("CopyUserConfig failed: %v", err)
+	}
+	for _, name := range []string{"config/userpref.blend", "config/bookmarks.txt", "scripts/addons/studio/api.py"} {
+		data, err := os.ReadFile(filepath.Join(root, "4.2", name))
+		if err != nil || string(data) != files["4.1/"+name] {
+			t.Errorf("Expected %s to be copied from 4.1, got %q (%v)", name, data, err)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(root, "4.2", "config", "userpref.blend")); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the copied preferences to keep their mode, got %v (%v)", info, err)
+	}
+
+	// Once copied, launching the series no longer asks
+	if migration, err := CheckUserConfigMigration("4.2.1"); err != nil || migration != nil {
+		t.Errorf("Expected no migration after the copy, got %+v (%v)", migration, err)
+	}
+
+	// Numbers rather than text decide which series is older
+	if migration, err := CheckUserConfigMigration("4.10.0"); err != nil || migration == nil || migration.PreviousSeries != "4.3" {
+		t.Errorf("Expected 4.3 to be migrated to 4.10, got %+v (%v)", migration, err)
+	}
+}
@@ -0,0 +1,56 @@
+package local
+
+import (
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+)
+
+// VerifyResult describes the outcome of re-verifying an installed build.
+type VerifyResult int
+
+const (
+	VerifyUnchanged VerifyResult = iota // Installed files match the install-time checksum
+	VerifyChanged                       // Installed files changed since installation
+	VerifyBaseline                      // No install-time checksum; the current files were recorded
+)
+
+// VerifyAndSaveBuild checks the installed files of a build against the checksum recorded at
+// install time and saves the outcome in version.json. Builds installed before verification
+// existed get the current state recorded as baseline for later checks.
+func VerifyAndSaveBuild(installDir string) (*model.BlenderBuild, VerifyResult, error) {
+	build, err := ReadBuildInfo(installDir)
+	if err != nil {
+		return nil, 0, err
+	}
+	if build == nil {
+		return nil, 0, fmt.Errorf("no %s found in %s", versionMetaFilename, installDir)
+	}
+
+	sum, err := download.TreeChecksum(installDir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Builds installed before GPU backends were recorded get them now
+	if build.GPUBackends == nil {
+		if backends, err := download.GPUBackends(installDir); err == nil {
+			build.GPUBackends = backends
+		}
+	}
+
+	result := VerifyUnchanged
+	switch {
+	case build.TreeSHA256 == "":
+		build.TreeSHA256 = sum
+		result = VerifyBaseline
+	case build.TreeSHA256 != sum:
+		build.Verification = model.VerificationFailed
+		result = VerifyChanged
+	}
+
+	if err := WriteBuildInfo(installDir, *build); err != nil {
+		return nil, 0, err
+	}
+	return build, result, nil
+}
@@ -2,21 +2,107 @@ package main
 
 import (
 	"TUI-Blender-Launcher/config" // Import config package
+	"TUI-Blender-Launcher/doctor" // Import the doctor diagnostics
+	"TUI-Blender-Launcher/farm"   // Import the render farm provisioning
+	"TUI-Blender-Launcher/local"  // Import the local build helpers
 	"TUI-Blender-Launcher/tui"    // Import the tui package
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
+	configDir := flag.String("config-dir", "", "directory holding config.toml, instead of the user config directory")
+	dataDir := flag.String("data-dir", "", "directory for cache and state files, e.g. for a portable install")
+	portable := flag.Bool("portable", false, "store config, cache, state and builds next to the executable")
+	flag.Parse()
+
+	// Portable mode is turned on by the flag or by a marker file next to the executable
+	if root, found := config.DetectPortable(); found || *portable {
+		if !found {
+			exe, err := os.Executable()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: could not find the executable directory: %v\n", err)
+				os.Exit(2)
+			}
+			root = filepath.Dir(exe)
+		}
+		if err := config.EnablePortable(root); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	if *configDir != "" {
+		if err := config.SetConfigDir(*configDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	if *dataDir != "" {
+		if err := config.SetDataDir(*dataDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	// Headless diagnostics, no TUI
+	if flag.Arg(0) == "doctor" {
+		if !doctor.Report(os.Stdout, doctor.Run()) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Load configuration
 	cfg, err := config.LoadConfig()
-	if err != nil {
+	var configErrs config.ValidationErrors
+	if err != nil && !errors.As(err, &configErrs) {
 		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Route all requests through the configured proxy
+	if err := config.ApplyProxy(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error applying proxy settings: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Apply IP version, DNS server and host overrides to all connections
+	if err := config.ApplyNetwork(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error applying network settings: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Headless render farm provisioning, no TUI
+	if command := flag.Arg(0); command == "farm-export" || command == "farm-sync" {
+		if configErrs != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", configErrs)
+			os.Exit(1)
+		}
+		if flag.Arg(1) == "" {
+			fmt.Fprintf(os.Stderr, "Usage: %s farm-export <directory> | farm-sync <manifest>\n", filepath.Base(os.Args[0]))
+			os.Exit(2)
+		}
+		ok := false
+		if command == "farm-export" {
+			ok = farm.ExportDir(os.Stdout, cfg, flag.Arg(1))
+		} else {
+			ok = farm.SyncFile(os.Stdout, cfg, flag.Arg(1))
+		}
+		if !ok {
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Check if config file *actually* exists (LoadConfig returns defaults if not)
 	configFilePath, _ := config.GetConfigPath()
 	needsInitialSetup := false
@@ -24,15 +110,60 @@ func main() {
 		needsInitialSetup = true
 	}
 
-	// Initialize the TUI model, passing the config and setup flag
-	m := tui.InitialModel(cfg, needsInitialSetup)
+	// Initialize the TUI model, passing the config and setup flag.
+	// An invalid config file opens the config error screen instead.
+	// The commands hold the download manager and are shared by all views for the whole run
+	commands := tui.NewCommands(cfg)
+	var m *tui.Model
+	if configErrs != nil {
+		m = tui.ConfigErrorModel(configErrs, commands)
+	} else {
+		m = tui.InitialModel(cfg, commands, needsInitialSetup)
+		// A project directory can pin the build to use in a .blender-version file
+		if cwd, err := os.Getwd(); err == nil {
+			pinPath, pinVersion, err := local.FindPinFile(cwd)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			} else if pinPath != "" {
+				m.PinBuild(pinPath, pinVersion)
+			}
+		}
+		// Smart launch: open a .blend file with a build of the version that saved it
+		if file := flag.Arg(0); strings.EqualFold(filepath.Ext(file), ".blend") {
+			m.OpenBlendFile(file)
+		}
+	}
 
 	// Create and run the Bubble Tea program
 	p := tea.NewProgram(m,
 		tea.WithAltScreen(),       // Use AltScreen
 		tea.WithMouseCellMotion(), // Enable mouse support
+		tea.WithFPS(tui.FPS(cfg)), // Cap redraws for slow terminals and SSH
 	)
-	if _, err := p.Run(); err != nil {
+	// A closed terminal or SSH connection quits like q; Bubble Tea handles SIGINT and SIGTERM the same way
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		<-hangup
+		p.Quit()
+	}()
+	_, err = p.Run()
+	signal.Stop(hangup)
+
+	// The local builds are listed right away at the next start, until the local scan replaced them
+	if err := local.SaveScanCache(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// Running downloads and extractions are cancelled, cleaning up their partial files and journal entries
+	if err := commands.Shutdown(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	// Builds deleted during the session can't be restored once it ends
+	if err := commands.EmptyTrash(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
 	}
@@ -3,7 +3,10 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -104,11 +107,242 @@ type BlenderBuild struct {
 	FileExtension   string    `json:"file_extension"` // e.g., "zip", "tar.gz", "sha256", "msi"
 	ReleaseCycle    string    `json:"release_cycle"`  // e.g., "daily", "stable", "candidate" (replaces previous 'Type')
 
+	// Local metadata (persisted in version.json, not from API)
+	Label           string              `json:"label,omitempty"`            // Custom label set by the user
+	Notes           string              `json:"notes,omitempty"`            // Free-form notes set by the user
+	Tags            []string            `json:"tags,omitempty"`             // Tags set by the user, see ParseTags
+	Promotion       string              `json:"promotion,omitempty"`        // Review state in a studio, see the Promotion constants
+	Artifacts       []Artifact          `json:"artifacts,omitempty"`        // Companion files listed next to the build
+	Source          string              `json:"source,omitempty"`           // Where the build came from, see SourceLabel
+	InstalledSize   int64               `json:"installed_size,omitempty"`   // Bytes on disk after extraction
+	Extraction      *ExtractionStats    `json:"extraction,omitempty"`       // Throughput of the last extraction
+	SHA256          string              `json:"sha256,omitempty"`           // Archive checksum, checked against the builder at install time
+	TreeSHA256      string              `json:"tree_sha256,omitempty"`      // Checksum of the installed files, for re-verification
+	Verification    string              `json:"verification,omitempty"`     // Verification state, see the Verification constants
+	DownloadedFrom  string              `json:"downloaded_from,omitempty"`  // Host that served the archive (builder or mirror)
+	Introspection   *BuildIntrospection `json:"introspection,omitempty"`    // Probed after installation
+	GPUProbe        *GPUProbeResult     `json:"gpu_probe,omitempty"`        // Optional GPU device probe
+	SmokeTest       *SmokeTestResult    `json:"smoke_test,omitempty"`       // Optional start check after installation
+	GPUBackends     []string            `json:"gpu_backends"`               // Cycles GPU backends found in the installed files, nil when not checked
+	SnoozedUntil    *Timestamp          `json:"snoozed_until,omitempty"`    // Updates of the installed build aren't shown until then, see UpdateSnoozed
+	ExtractWarnings []string            `json:"extract_warnings,omitempty"` // Archive entries that couldn't be installed as they are, e.g. symlinks
+	ArchiveDir      string              `json:"archive_dir,omitempty"`      // Original name of the build directory in the archive, when dir_template renamed it
+	LaunchProfile   string              `json:"launch_profile,omitempty"`   // Launch profile used instead of the global one, see config.LaunchProfiles
+
 	// Internal state (not from API)
-	Status BuildState // Changed from types.BuildState to BuildState
+	Status    BuildState    // Changed from types.BuildState to BuildState
+	Installed *BlenderBuild `json:"-"` // Local build an update would replace (only set for StateUpdate)
+	Shared    bool          `json:"-"` // Installed in the read-only shared builds directory
 	// Selected field removed - we only work with highlighted builds now
 }
 
+// Artifact is a companion file the builder lists for a build, e.g. debug symbols, a checksum or an installer
+type Artifact struct {
+	FileName      string `json:"file_name"`
+	FileExtension string `json:"file_extension"`
+	URL           string `json:"url"`
+	Size          int64  `json:"file_size"`
+}
+
+// Build sources
+const (
+	SourceDaily        = "daily"
+	SourcePatch        = "patch"
+	SourceExperimental = "experimental"
+	SourceStable       = "stable"
+	SourceExternal     = "external" // Installed by other means or before sources were recorded
+)
+
+// SourceLabel returns where the build came from, falling back to SourceExternal
+func (b BlenderBuild) SourceLabel() string {
+	if b.Source == "" {
+		return SourceExternal
+	}
+	return b.Source
+}
+
+// Verification states of installed builds; empty means installed before verification existed
+const (
+	VerificationVerified   = "verified"    // Archive matched the published checksum, files unchanged since
+	VerificationNoChecksum = "no-checksum" // Builder published no checksum for the archive
+	VerificationFailed     = "failed"      // Installed files changed since installation
+)
+
+// Promotion states of a build in a studio's review workflow
+const (
+	PromotionTesting  = "testing"  // Being evaluated, the state of builds nobody reviewed yet
+	PromotionApproved = "approved" // Cleared for production use
+	PromotionBlocked  = "blocked"  // Must not be used
+)
+
+// Promotions lists the promotion states in the order they are cycled through
+var Promotions = []string{PromotionTesting, PromotionApproved, PromotionBlocked}
+
+// PromotionState returns the promotion of the build, testing when none was set
+func (b BlenderBuild) PromotionState() string {
+	if b.Promotion == "" {
+		return PromotionTesting
+	}
+	return b.Promotion
+}
+
+// Cycles GPU backends a build can ship kernels for
+const (
+	GPUBackendCUDA   = "CUDA"
+	GPUBackendOptiX  = "OptiX"
+	GPUBackendHIP    = "HIP"
+	GPUBackendOneAPI = "oneAPI"
+	GPUBackendMetal  = "Metal"
+)
+
+// GPUBackendOrder lists the GPU backends in the order they are shown
+var GPUBackendOrder = []string{GPUBackendCUDA, GPUBackendOptiX, GPUBackendHIP, GPUBackendOneAPI, GPUBackendMetal}
+
+// ExpectedGPUBackends returns the Cycles GPU backends the official builds for the platform and version of a build ship with
+func ExpectedGPUBackends(b BlenderBuild) []string {
+	series := BuildSeries(b.Version)
+	var backends []string
+	switch {
+	case b.OperatingSystem == "darwin":
+		if !VersionLess(series, "3.1") {
+			backends = append(backends, GPUBackendMetal)
+		}
+	case (b.OperatingSystem == "linux" || b.OperatingSystem == "windows") && b.Architecture != "arm64":
+		backends = append(backends, GPUBackendCUDA)
+		if !VersionLess(series, "2.81") {
+			backends = append(backends, GPUBackendOptiX)
+		}
+		if !VersionLess(series, "3.0") {
+			backends = append(backends, GPUBackendHIP)
+		}
+		if !VersionLess(series, "3.3") {
+			backends = append(backends, GPUBackendOneAPI)
+		}
+	}
+	return backends
+}
+
+// SupportedGPUBackends returns the Cycles GPU backends of the build: the ones found in its installed files
+// once checked, the ones its official builds ship with otherwise. found reports the former.
+func (b BlenderBuild) SupportedGPUBackends() (backends []string, found bool) {
+	if b.GPUBackends != nil {
+		return b.GPUBackends, true
+	}
+	return ExpectedGPUBackends(b), false
+}
+
+// NextPromotion returns the promotion state following the one of the build
+func (b BlenderBuild) NextPromotion() string {
+	for i, p := range Promotions {
+		if p == b.PromotionState() {
+			return Promotions[(i+1)%len(Promotions)]
+		}
+	}
+	return PromotionTesting
+}
+
+// installedSizeRatios are typical extracted/archive size ratios per archive format
+var installedSizeRatios = map[string]float64{
+	"tar.xz": 3.3,
+	"xz":     3.3,
+	"zip":    2.6,
+	"dmg":    3.0,
+}
+
+// defaultInstalledSizeRatio is used for archive formats without a known ratio
+const defaultInstalledSizeRatio = 3.0
+
+// EstimateInstalledSize returns the disk space a build takes once extracted.
+// The recorded size is used when known; otherwise the archive size is scaled by the
+// ratio observed on installed builds of the same format, or a typical ratio.
+func EstimateInstalledSize(build BlenderBuild, installed []BlenderBuild) int64 {
+	if build.InstalledSize > 0 {
+		return build.InstalledSize
+	}
+
+	ratio, ok := installedSizeRatios[strings.ToLower(build.FileExtension)]
+	if !ok {
+		ratio = defaultInstalledSizeRatio
+	}
+
+	// Prefer what actual installs of the same format measured
+	var sum float64
+	var count int
+	for _, b := range installed {
+		if b.InstalledSize > 0 && b.Size > 0 && strings.EqualFold(b.FileExtension, build.FileExtension) {
+			sum += float64(b.InstalledSize) / float64(b.Size)
+			count++
+		}
+	}
+	if count > 0 {
+		ratio = sum / float64(count)
+	}
+
+	return int64(float64(build.Size) * ratio)
+}
+
+// pullRequestPattern matches the pull request identifier in patch build branch and file names, e.g., "PR112345"
+var pullRequestPattern = regexp.MustCompile(`(?i)\bPR[-_]?(\d+)`)
+
+// PullRequest returns the number of the pull request a patch build was made from, or "" for other builds
+func (b BlenderBuild) PullRequest() string {
+	for _, name := range []string{b.Branch, b.FileName} {
+		if match := pullRequestPattern.FindStringSubmatch(name); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// ParseTags splits a comma-separated tag list into tags.
+// Tags are lowercased with inner spaces replaced by dashes; empty and repeated tags are dropped.
+func ParseTags(s string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		tag := strings.ToLower(strings.Join(strings.Fields(part), "-"))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// HasTag reports whether the build carries the given tag
+func (b BlenderBuild) HasTag(tag string) bool {
+	for _, t := range b.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether the build has the given version and architecture.
+// An empty architecture on either side matches any, for builds installed before it was recorded.
+func (b BlenderBuild) Matches(version, arch string) bool {
+	return b.Version == version && (arch == "" || b.Architecture == "" || b.Architecture == arch)
+}
+
+// BuildIntrospection holds information obtained by running an installed build once.
+// It is cached in version.json so the probe does not need to be repeated.
+type BuildIntrospection struct {
+	BlenderVersion string            `json:"blender_version,omitempty"` // e.g., "4.2.0 Alpha"
+	PythonVersion  string            `json:"python_version,omitempty"`  // Bundled Python, e.g., "3.11.7"
+	Libraries      map[string]string `json:"libraries,omitempty"`       // Library name -> version, e.g., "USD" -> "0.24.5"
+	ProbedAt       Timestamp         `json:"probed_at"`                 // When the probe was run
+}
+
+// Commit is a single entry of the source history between two builds.
+type Commit struct {
+	Hash   string    // Full commit hash
+	Title  string    // First line of the commit message
+	Author string    // Author name
+	Date   time.Time // Author date
+}
+
 // BlenderLaunchedMsg is sent when Blender is successfully launched
 // This allows the UI to handle launched state appropriately
 type BlenderLaunchedMsg struct {
@@ -118,21 +352,115 @@ type BlenderLaunchedMsg struct {
 // BlenderExecMsg is sent when Blender should be executed directly
 // This will cause the TUI to exit and exec Blender in its place
 type BlenderExecMsg struct {
-	Version    string // The version of Blender to launch
-	Executable string // The path to the Blender executable
+	Version    string   // The version of Blender to launch
+	Executable string   // The path to the Blender executable
+	InstallDir string   // The installation directory of the build
+	ConfigRoot string   // Directory holding the isolated user config, see local.IsolatedConfigEnv
+	Source     string   // Where the build came from, selects the sandbox it is launched in
+	Quarantine bool     // macOS Gatekeeper would block the first launch, see local.Quarantined
+	Env        []string // Extra environment variables (KEY=value) for the Blender process
+	Args       []string // Extra arguments for Blender, e.g. a .blend file to open
+	Profile    string   // Launch profile picked for this launch or set for the build, empty for the global one
+}
+
+// GPUProbeResult records which Cycles devices an installed build found on this machine
+type GPUProbeResult struct {
+	Compute  map[string]bool `json:"compute,omitempty"`       // Cycles device type -> available, e.g., "OptiX" -> true
+	Crashed  bool            `json:"probe_crashed,omitempty"` // Blender crashed looking for the devices
+	Renderer string          `json:"renderer,omitempty"`      // Name of the first GPU device Cycles found
+	ProbedAt Timestamp       `json:"probed_at"`               // When the probe was run
+}
+
+// ExtractionStats records how fast a build was extracted and with which tuning, see extract_workers
+type ExtractionStats struct {
+	Bytes      int64         `json:"bytes"` // Installed size
+	Duration   time.Duration `json:"duration"`
+	Workers    int           `json:"workers"`
+	BufferSize int           `json:"buffer_size"`
+}
+
+// Throughput returns the extracted bytes per second, 0 when unknown
+func (e ExtractionStats) Throughput() float64 {
+	if e.Duration <= 0 {
+		return 0
+	}
+	return float64(e.Bytes) / e.Duration.Seconds()
+}
+
+// Summary describes the extraction, e.g. "850.0MB in 9.2s (92.4 MB/s), 16 workers, 8MB buffer"
+func (e ExtractionStats) Summary() string {
+	return fmt.Sprintf("%s in %s (%.1f MB/s), %d workers, %dMB buffer", FormatByteSize(e.Bytes), e.Duration.Round(100*time.Millisecond),
+		e.Throughput()/1024/1024, e.Workers, e.BufferSize>>20)
+}
+
+// SmokeTestResult records whether an installed build started with --version --background after installation.
+type SmokeTestResult struct {
+	Passed   bool      `json:"passed"`
+	Version  string    `json:"version,omitempty"` // First line printed by the build, e.g. "Blender 4.2.0 Alpha"
+	Error    string    `json:"error,omitempty"`   // Why the build failed to start
+	TestedAt Timestamp `json:"tested_at"`
+}
+
+// Broken reports whether the smoke test found the build unable to start
+func (b BlenderBuild) Broken() bool {
+	return b.SmokeTest != nil && !b.SmokeTest.Passed
+}
+
+// NeedsRepair reports whether the build failed its smoke test or its installed files changed since installation
+func (b BlenderBuild) NeedsRepair() bool {
+	return b.Broken() || b.Verification == VerificationFailed
+}
+
+// glibcRequirements lists the minimum glibc of the official Linux builds from a series on, oldest first.
+// Builds up to 3.6 were made on CentOS 7, later ones follow the VFX reference platform on Rocky Linux 8.
+var glibcRequirements = []struct{ series, glibc string }{
+	{"2.80", "2.17"},
+	{"4.0", "2.28"},
+}
+
+// RequiredGlibc returns the minimum glibc version the official Linux builds of a version need, empty if unknown.
+func RequiredGlibc(version string) string {
+	series := BuildSeries(version)
+	required := ""
+	for _, r := range glibcRequirements {
+		if VersionLess(series, r.series) {
+			break
+		}
+		required = r.glibc
+	}
+	return required
+}
+
+// GlibcRequirement returns the glibc version the build needs when systemGlibc is older, empty if it should run.
+// Builds for other platforms and an unknown system glibc are never reported.
+func (b BlenderBuild) GlibcRequirement(systemGlibc string) string {
+	if systemGlibc == "" || (b.OperatingSystem != "" && b.OperatingSystem != "linux") {
+		return ""
+	}
+	required := RequiredGlibc(b.Version)
+	if required == "" || !VersionLess(systemGlibc, required) {
+		return ""
+	}
+	return required
 }
 
 // DownloadState holds progress info for an active download
 type DownloadState struct {
-	BuildID     string        // Unique identifier for build (version + hash)
-	Progress    float64       // Progress from 0.0 to 1.0
-	Current     int64         // Bytes downloaded so far (renamed from CurrentBytes)
-	Total       int64         // Total bytes to download (renamed from TotalBytes)
-	Speed       float64       // Download speed in bytes/sec
-	BuildState  BuildState    // Changed from Message to BuildState
-	LastUpdated time.Time     // Timestamp of last progress update
-	StartTime   time.Time     // When the download started
-	CancelCh    chan struct{} // Per-download cancel channel
+	BuildID     string           // Unique identifier for build (version + hash)
+	Progress    float64          // Progress from 0.0 to 1.0
+	Current     int64            // Bytes downloaded so far (renamed from CurrentBytes)
+	Total       int64            // Total bytes to download (renamed from TotalBytes)
+	Speed       float64          // Download speed in bytes/sec
+	BuildState  BuildState       // Changed from Message to BuildState
+	LastUpdated time.Time        // Timestamp of last progress update
+	StartTime   time.Time        // When the download started
+	CancelCh    chan struct{}    // Per-download cancel channel
+	SmokeTest   *SmokeTestResult // Smoke test of the installed build, nil when not run
+	Paused      bool             // Transfer stopped by the user, the partial file is kept to resume it
+	Queued      bool             // Waiting for a free download slot
+	Phase       InstallPhase     // Step of the install running now, Progress is that of the phase
+	PhaseStart  time.Time        // When the running phase started
+	Phases      []PhaseTiming    // Durations of the finished phases, in order
 }
 
 // FormatByteSize converts bytes to human-readable sizes
@@ -186,10 +514,35 @@ func SortBuilds(builds []BlenderBuild, column int, reverse bool) []BlenderBuild
 		6: func(a, b BlenderBuild) bool { // Build Date
 			return a.BuildDate.Time().Before(b.BuildDate.Time())
 		},
+		7: func(a, b BlenderBuild) bool { // Source
+			return a.SourceLabel() < b.SourceLabel()
+		},
+		8: func(a, b BlenderBuild) bool { // PR
+			aPR, _ := strconv.Atoi(a.PullRequest())
+			bPR, _ := strconv.Atoi(b.PullRequest())
+			return aPR < bPR
+		},
+		9: func(a, b BlenderBuild) bool { // Verified
+			return a.Verification < b.Verification
+		},
+		10: func(a, b BlenderBuild) bool { // Label
+			return a.Label < b.Label
+		},
+		11: func(a, b BlenderBuild) bool { // Tags
+			return strings.Join(a.Tags, ",") < strings.Join(b.Tags, ",")
+		},
+		12: func(a, b BlenderBuild) bool { // Promotion
+			return a.PromotionState() < b.PromotionState()
+		},
+		13: func(a, b BlenderBuild) bool { // GPU
+			aBackends, _ := a.SupportedGPUBackends()
+			bBackends, _ := b.SupportedGPUBackends()
+			return strings.Join(aBackends, ",") < strings.Join(bBackends, ",")
+		},
 	}
 
 	// Order of columns to compare for stability (use all columns as secondary sort criteria)
-	allColumns := []int{0, 1, 2, 3, 4, 5, 6}
+	allColumns := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}
 
 	// Sort using the primary column and then all other columns as tiebreakers
 	sort.SliceStable(sortedBuilds, func(i, j int) bool {
@@ -233,3 +586,138 @@ func SortBuilds(builds []BlenderBuild, column int, reverse bool) []BlenderBuild
 
 	return sortedBuilds
 }
+
+// BuildSeries returns the major.minor series of a version string, e.g., "4.2" for "4.2.1".
+func BuildSeries(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// ltsSeries lists the Blender series with long-term support; the builder listing has no LTS flag
+var ltsSeries = []string{"2.83", "2.93", "3.3", "3.6", "4.2", "4.5"}
+
+// IsLTS reports whether a build belongs to a long-term support series, by its series
+// or an "lts" marker in its branch or file name
+func (b BlenderBuild) IsLTS() bool {
+	for _, series := range ltsSeries {
+		if BuildSeries(b.Version) == series {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(b.Branch), "lts") || strings.Contains(strings.ToLower(b.FileName), "-lts")
+}
+
+// VersionLess compares two dotted versions, e.g. major.minor series or full versions, numerically part by part,
+// so 4.10 comes after 4.9. It falls back to string order when a part isn't a number.
+func VersionLess(a, b string) bool {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+		if aErr != nil || bErr != nil {
+			return a < b
+		}
+		if aNum != bNum {
+			return aNum < bNum
+		}
+	}
+	return len(aParts) < len(bParts)
+}
+
+// installedDate returns the build date of the build on disk; update rows carry the date of the online build
+func installedDate(b BlenderBuild) time.Time {
+	if b.Status == StateUpdate && b.Installed != nil {
+		return b.Installed.BuildDate.Time()
+	}
+	return b.BuildDate.Time()
+}
+
+// CompatibleBuild picks the installed build to open a file saved by the given series with.
+// A build of the same series is preferred, the newest one if there are several; otherwise the
+// build of the oldest newer series, which can read the file. exact reports a build of the same series.
+// Older series are never picked, they could lose data the newer version saved.
+func CompatibleBuild(builds []BlenderBuild, series string) (build BlenderBuild, exact bool, found bool) {
+	var newest time.Time
+	for _, b := range builds {
+		if b.Status != StateLocal && b.Status != StateUpdate {
+			continue
+		}
+		s := BuildSeries(b.Version)
+		if s != series && VersionLess(s, series) {
+			continue
+		}
+		date := installedDate(b)
+		switch {
+		case !found:
+		case s == series && (!exact || date.After(newest)):
+		case s != series && !exact && VersionLess(s, BuildSeries(build.Version)):
+		default:
+			continue
+		}
+		build, exact, found, newest = b, s == series, true, date
+	}
+	return build, exact, found
+}
+
+// MatchesPin reports whether the build satisfies a pinned version, either a full version or a major.minor series
+func (b BlenderBuild) MatchesPin(pin string) bool {
+	return b.Version == pin || BuildSeries(b.Version) == pin
+}
+
+// PinnedBuild returns the newest installed build satisfying a pinned version, see MatchesPin
+func PinnedBuild(builds []BlenderBuild, pin string) (BlenderBuild, bool) {
+	var pinned BlenderBuild
+	found := false
+	for _, b := range builds {
+		if (b.Status != StateLocal && b.Status != StateUpdate) || !b.MatchesPin(pin) {
+			continue
+		}
+		if !found || installedDate(b).After(installedDate(pinned)) {
+			pinned, found = b, true
+		}
+	}
+	return pinned, found
+}
+
+// NewBuilds returns the fetched builds the previous fetch at fetchedAt didn't list.
+// Builds older than that fetch only show up because the version filter was lowered, so they don't count as new.
+func NewBuilds(previous, fetched []BlenderBuild, fetchedAt time.Time) []BlenderBuild {
+	var newBuilds []BlenderBuild
+	// Build dates only have whole seconds
+	fetchedAt = fetchedAt.Truncate(time.Second)
+	for _, b := range fetched {
+		if b.BuildDate.Time().Before(fetchedAt) {
+			continue
+		}
+		listed := false
+		for _, p := range previous {
+			if p.Matches(b.Version, b.Architecture) && p.Hash == b.Hash {
+				listed = true
+				break
+			}
+		}
+		if !listed {
+			newBuilds = append(newBuilds, b)
+		}
+	}
+	return newBuilds
+}
+
+// GroupBuildsBySeries reorders already sorted builds so that builds of the same
+// major.minor series are adjacent, newest series first, keeping the order within each series.
+func GroupBuildsBySeries(builds []BlenderBuild) []BlenderBuild {
+	grouped := make([]BlenderBuild, len(builds))
+	copy(grouped, builds)
+	sort.SliceStable(grouped, func(i, j int) bool {
+		a, b := BuildSeries(grouped[i].Version), BuildSeries(grouped[j].Version)
+		if a == b {
+			return false
+		}
+		return VersionLess(b, a)
+	})
+	return grouped
+}
@@ -0,0 +1,356 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildSeries(t *testing.T) {
+	testCases := []struct {
+		version  string
+		expected string
+	}{
+		{"4.2.1", "4.2"},
+		{"4.2", "4.2"},
+		{"3.6.12", "3.6"},
+		{"4", "4"},
+	}
+
+	for _, tc := range testCases {
+		if got := BuildSeries(tc.version); got != tc.expected {
+			t.Errorf("BuildSeries(%q) = %q, expected %q", tc.version, got, tc.expected)
+		}
+	}
+}
+
+func TestIsLTS(t *testing.T) {
+	testCases := []struct {
+		build    BlenderBuild
+		expected bool
+	}{
+		{BlenderBuild{Version: "3.6.12"}, true},
+		{BlenderBuild{Version: "4.2.0", Branch: "blender-v4.2-release"}, true},
+		{BlenderBuild{Version: "4.3.0"}, false},
+		{BlenderBuild{Version: "3.36.0"}, false},
+		{BlenderBuild{Version: "5.1.0", FileName: "blender-5.1.0-lts-linux-x64.tar.xz"}, true},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.build.IsLTS(); got != tc.expected {
+			t.Errorf("IsLTS(%+v) = %v, expected %v", tc.build, got, tc.expected)
+		}
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"4.9", "4.10", true},
+		{"4.10", "4.9", false},
+		{"4.9.1", "4.10.0", true},
+		{"4.2.1", "4.2.10", true},
+		{"4.2", "4.2.0", true},
+		{"4.2.0", "4.2.0", false},
+		{"2.35", "2.4", false}, // glibc versions
+	}
+
+	for _, tc := range testCases {
+		if got := VersionLess(tc.a, tc.b); got != tc.expected {
+			t.Errorf("VersionLess(%q, %q) = %v, expected %v", tc.a, tc.b, got, tc.expected)
+		}
+	}
+}
+
+func TestGlibcRequirement(t *testing.T) {
+	testCases := []struct {
+		build    BlenderBuild
+		system   string
+		expected string
+	}{
+		{BlenderBuild{Version: "4.2.0", OperatingSystem: "linux"}, "2.17", "2.28"},
+		{BlenderBuild{Version: "4.2.0"}, "2.17", "2.28"},
+		{BlenderBuild{Version: "4.2.0", OperatingSystem: "linux"}, "2.28", ""},
+		{BlenderBuild{Version: "4.2.0", OperatingSystem: "linux"}, "2.35", ""},
+		{BlenderBuild{Version: "3.6.12", OperatingSystem: "linux"}, "2.17", ""},
+		{BlenderBuild{Version: "2.79", OperatingSystem: "linux"}, "2.12", ""},
+		{BlenderBuild{Version: "4.2.0", OperatingSystem: "windows"}, "2.17", ""},
+		{BlenderBuild{Version: "4.2.0", OperatingSystem: "linux"}, "", ""},
+	}
+
+	for _, tc := range testCases {
+		if result := tc.build.GlibcRequirement(tc.system); result != tc.expected {
+			t.Errorf("GlibcRequirement(%q) of %s on %s = %q, expected %q", tc.system, tc.build.Version, tc.build.OperatingSystem, result, tc.expected)
+		}
+	}
+}
+
+func TestSupportedGPUBackends(t *testing.T) {
+	testCases := []struct {
+		build    BlenderBuild
+		expected []string
+		found    bool
+	}{
+		{BlenderBuild{Version: "4.2.0", OperatingSystem: "linux", Architecture: "x86_64"}, []string{GPUBackendCUDA, GPUBackendOptiX, GPUBackendHIP, GPUBackendOneAPI}, false},
+		{BlenderBuild{Version: "2.93.18", OperatingSystem: "windows", Architecture: "amd64"}, []string{GPUBackendCUDA, GPUBackendOptiX}, false},
+		{BlenderBuild{Version: "4.2.0", OperatingSystem: "windows", Architecture: "arm64"}, nil, false},
+		{BlenderBuild{Version: "4.2.0", OperatingSystem: "darwin", Architecture: "arm64"}, []string{GPUBackendMetal}, false},
+		{BlenderBuild{Version: "3.0.1", OperatingSystem: "darwin", Architecture: "x86_64"}, nil, false},
+		// Found in the installed files, including none at all
+		{BlenderBuild{Version: "4.2.0", OperatingSystem: "linux", GPUBackends: []string{GPUBackendCUDA}}, []string{GPUBackendCUDA}, true},
+		{BlenderBuild{Version: "4.2.0", OperatingSystem: "linux", GPUBackends: []string{}}, []string{}, true},
+	}
+
+	for _, tc := range testCases {
+		backends, found := tc.build.SupportedGPUBackends()
+		if !slices.Equal(backends, tc.expected) || found != tc.found {
+			t.Errorf("SupportedGPUBackends() of %s on %s/%s = %v, %v, expected %v, %v",
+				tc.build.Version, tc.build.OperatingSystem, tc.build.Architecture, backends, found, tc.expected, tc.found)
+		}
+	}
+}
+
+func TestGroupBuildsBySeries(t *testing.T) {
+	builds := []BlenderBuild{
+		{Version: "4.2.1"},
+		{Version: "3.6.5"},
+		{Version: "4.10.0"},
+		{Version: "4.2.0"},
+	}
+
+	grouped := GroupBuildsBySeries(builds)
+
+	// Newest series first (numeric, so 4.10 before 4.2), original order kept inside a series
+	expected := []string{"4.10.0", "4.2.1", "4.2.0", "3.6.5"}
+	if len(grouped) != len(expected) {
+		t.Fatalf("Expected %d builds, got %d", len(expected), len(grouped))
+	}
+	for i, version := range expected {
+		if grouped[i].Version != version {
+			t.Errorf("Position %d: expected %s, got %s", i, version, grouped[i].Version)
+		}
+	}
+}
+
+func TestSortBuildsBySource(t *testing.T) {
+	builds := []BlenderBuild{
+		{Version: "4.2.0", Source: SourcePatch},
+		{Version: "4.2.0", Source: SourceDaily},
+		{Version: "4.2.0"},
+	}
+
+	sorted := SortBuilds(builds, 7, false)
+
+	// Builds without a recorded source sort as external
+	expected := []string{SourceDaily, SourceExternal, SourcePatch}
+	for i, source := range expected {
+		if got := sorted[i].SourceLabel(); got != source {
+			t.Errorf("Position %d: expected %s, got %s", i, source, got)
+		}
+	}
+}
+
+func TestSortBuildsByLabel(t *testing.T) {
+	builds := []BlenderBuild{
+		{Version: "4.2.0", Label: "studio"},
+		{Version: "4.3.0"},
+		{Version: "4.1.0", Label: "addon dev"},
+	}
+
+	sorted := SortBuilds(builds, 10, false)
+
+	// Unlabelled builds sort first
+	expected := []string{"4.3.0", "4.1.0", "4.2.0"}
+	for i, version := range expected {
+		if sorted[i].Version != version {
+			t.Errorf("Position %d: expected %s, got %s", i, version, sorted[i].Version)
+		}
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{"", nil},
+		{"production", []string{"production"}},
+		{" Production , testing,,production ", []string{"production", "testing"}},
+		{"gpu  bug, GPU-Bug", []string{"gpu-bug"}},
+	}
+
+	for _, tc := range testCases {
+		got := ParseTags(tc.input)
+		if strings.Join(got, ",") != strings.Join(tc.expected, ",") {
+			t.Errorf("ParseTags(%q) = %v, expected %v", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestNextPromotion(t *testing.T) {
+	testCases := []struct {
+		promotion string
+		expected  string
+	}{
+		{"", PromotionApproved},
+		{PromotionTesting, PromotionApproved},
+		{PromotionApproved, PromotionBlocked},
+		{PromotionBlocked, PromotionTesting},
+	}
+
+	for _, tc := range testCases {
+		if got := (BlenderBuild{Promotion: tc.promotion}).NextPromotion(); got != tc.expected {
+			t.Errorf("NextPromotion after %q = %q, expected %q", tc.promotion, got, tc.expected)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	testCases := []struct {
+		build    BlenderBuild
+		version  string
+		arch     string
+		expected bool
+	}{
+		{BlenderBuild{Version: "4.2.0", Architecture: "arm64"}, "4.2.0", "arm64", true},
+		{BlenderBuild{Version: "4.2.0", Architecture: "arm64"}, "4.2.0", "x86_64", false},
+		{BlenderBuild{Version: "4.2.0", Architecture: "arm64"}, "4.3.0", "arm64", false},
+		{BlenderBuild{Version: "4.2.0"}, "4.2.0", "x86_64", true},
+		{BlenderBuild{Version: "4.2.0", Architecture: "x86_64"}, "4.2.0", "", true},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.build.Matches(tc.version, tc.arch); got != tc.expected {
+			t.Errorf("%s/%s Matches(%q, %q) = %v, expected %v", tc.build.Version, tc.build.Architecture, tc.version, tc.arch, got, tc.expected)
+		}
+	}
+}
+
+func TestPullRequest(t *testing.T) {
+	testCases := []struct {
+		build    BlenderBuild
+		expected string
+	}{
+		{BlenderBuild{Branch: "PR112345"}, "112345"},
+		{BlenderBuild{FileName: "blender-4.2.0-alpha+PR112345.7b8f2c1d-linux.x86_64-release.tar.xz"}, "112345"},
+		{BlenderBuild{Branch: "main", FileName: "blender-4.2.0-alpha+main.7b8f2c1d-linux.x86_64-release.tar.xz"}, ""},
+		{BlenderBuild{Branch: "sprint"}, ""},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.build.PullRequest(); got != tc.expected {
+			t.Errorf("PullRequest() for %+v = %q, expected %q", tc.build, got, tc.expected)
+		}
+	}
+}
+
+func TestEstimateInstalledSize(t *testing.T) {
+	build := BlenderBuild{Size: 100, FileExtension: "zip"}
+
+	// Typical ratio without any installed builds
+	if got := EstimateInstalledSize(build, nil); got != 260 {
+		t.Errorf("Expected heuristic estimate 260, got %d", got)
+	}
+
+	// Installed builds of the same format take precedence
+	installed := []BlenderBuild{
+		{Size: 100, InstalledSize: 400, FileExtension: "zip"},
+		{Size: 100, InstalledSize: 900, FileExtension: "tar.xz"},
+	}
+	if got := EstimateInstalledSize(build, installed); got != 400 {
+		t.Errorf("Expected estimate from installed builds 400, got %d", got)
+	}
+
+	// A recorded size is returned as is
+	build.InstalledSize = 123
+	if got := EstimateInstalledSize(build, installed); got != 123 {
+		t.Errorf("Expected recorded size 123, got %d", got)
+	}
+}
+
+func TestCompatibleBuild(t *testing.T) {
+	day := func(d int) Timestamp { return Timestamp(time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)) }
+	builds := []BlenderBuild{
+		{Version: "4.1.1", Status: StateLocal},
+		{Version: "4.2.0", Status: StateLocal, BuildDate: day(1)},
+		{Version: "4.2.3", Status: StateLocal, BuildDate: day(5)},
+		{Version: "4.4.0", Status: StateLocal},
+		{Version: "4.3.0", Status: StateLocal},
+		{Version: "4.5.0", Status: StateOnline},
+	}
+
+	testCases := []struct {
+		series   string
+		expected string
+		exact    bool
+		found    bool
+	}{
+		{"4.2", "4.2.3", true, true},
+		{"4.1", "4.1.1", true, true},
+		{"3.6", "4.1.1", false, true},
+		{"4.3", "4.3.0", true, true},
+		{"4.25", "", false, false},
+		{"4.5", "", false, false},
+	}
+
+	for _, tc := range testCases {
+		build, exact, found := CompatibleBuild(builds, tc.series)
+		if found != tc.found || exact != tc.exact || (found && build.Version != tc.expected) {
+			t.Errorf("CompatibleBuild(%q) = %s, exact %v, found %v; expected %s, exact %v, found %v",
+				tc.series, build.Version, exact, found, tc.expected, tc.exact, tc.found)
+		}
+	}
+}
+
+func TestPinnedBuild(t *testing.T) {
+	day := func(d int) Timestamp { return Timestamp(time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)) }
+	builds := []BlenderBuild{
+		{Version: "4.2.3", Status: StateLocal, BuildDate: day(5)},
+		{Version: "4.2.1", Status: StateUpdate, BuildDate: day(9), Installed: &BlenderBuild{Version: "4.2.1", BuildDate: day(1)}},
+		{Version: "4.3.0", Status: StateOnline},
+	}
+
+	testCases := []struct {
+		pin      string
+		expected string
+		found    bool
+	}{
+		{"4.2", "4.2.3", true},
+		{"4.2.1", "4.2.1", true},
+		{"4.2.2", "", false},
+		{"4.3", "", false},
+	}
+
+	for _, tc := range testCases {
+		build, found := PinnedBuild(builds, tc.pin)
+		if found != tc.found || (found && build.Version != tc.expected) {
+			t.Errorf("PinnedBuild(%q) = %s, found %v; expected %s, found %v", tc.pin, build.Version, found, tc.expected, tc.found)
+		}
+	}
+}
+
+func TestNewBuilds(t *testing.T) {
+	fetchedAt := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	day := func(d int) Timestamp { return Timestamp(time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)) }
+	previous := []BlenderBuild{
+		{Version: "4.3.0", Hash: "aaa", BuildDate: day(4)},
+		{Version: "4.2.1", Hash: "bbb", BuildDate: day(3)},
+	}
+	fetched := []BlenderBuild{
+		{Version: "4.3.0", Hash: "ccc", BuildDate: day(6)}, // Rebuilt since
+		{Version: "4.2.1", Hash: "bbb", BuildDate: day(3)}, // Unchanged
+		{Version: "4.4.0", Hash: "ddd", BuildDate: day(7)}, // New version
+		{Version: "4.0.2", Hash: "eee", BuildDate: day(1)}, // Only let through by a lower version filter
+	}
+
+	var got []string
+	for _, b := range NewBuilds(previous, fetched, fetchedAt) {
+		got = append(got, b.Hash)
+	}
+	if expected := []string{"ccc", "ddd"}; !slices.Equal(got, expected) {
+		t.Errorf("NewBuilds() = %v, expected %v", got, expected)
+	}
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DirTemplateFields lists the placeholders of an install directory template, see DirName
+var DirTemplateFields = []string{"version", "cycle", "hash", "branch", "arch", "date"}
+
+// dirTemplatePlaceholder matches a {field} placeholder
+var dirTemplatePlaceholder = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// unsafeDirChars matches characters that aren't safe in a directory name on every platform
+var unsafeDirChars = regexp.MustCompile(`[^A-Za-z0-9._+-]+`)
+
+// repeatedSeparators matches separators left next to each other by empty fields
+var repeatedSeparators = regexp.MustCompile(`([-_.])[-_.]+`)
+
+// CheckDirTemplate reports an unknown placeholder in an install directory template, or a missing {version}:
+// replacing a build looks for the installed one by version in the directory names
+func CheckDirTemplate(template string) error {
+	for _, match := range dirTemplatePlaceholder.FindAllStringSubmatch(template, -1) {
+		known := false
+		for _, field := range DirTemplateFields {
+			known = known || match[1] == field
+		}
+		if !known {
+			return fmt.Errorf("unknown placeholder {%s}, expected one of {%s}", match[1], strings.Join(DirTemplateFields, "}, {"))
+		}
+	}
+	if !strings.Contains(template, "{version}") {
+		return fmt.Errorf("must contain {version}")
+	}
+	return nil
+}
+
+// DirName returns the install directory name of the build following template, e.g. "{version}-{cycle}-{hash}".
+// Characters that aren't safe in file names become "-" and empty fields leave no dangling separators.
+func (b BlenderBuild) DirName(template string) string {
+	values := map[string]string{
+		"version": b.Version,
+		"cycle":   b.ReleaseCycle,
+		"hash":    b.Hash,
+		"branch":  b.Branch,
+		"arch":    b.Architecture,
+	}
+	if !b.BuildDate.Time().IsZero() {
+		values["date"] = b.BuildDate.Time().Format("20060102")
+	}
+	name := dirTemplatePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		return values[strings.Trim(placeholder, "{}")]
+	})
+	name = unsafeDirChars.ReplaceAllString(name, "-")
+	name = repeatedSeparators.ReplaceAllString(name, "$1")
+	return strings.Trim(name, "-_.")
+}
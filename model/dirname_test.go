@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDirName(t *testing.T) {
+	build := BlenderBuild{
+		Version:      "4.3.0",
+		ReleaseCycle: "alpha",
+		Hash:         "a1b2c3d4e5f6",
+		Branch:       "npr/prototype",
+		Architecture: "amd64",
+		BuildDate:    Timestamp(time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)),
+	}
+	for template, want := range map[string]string{
+		"{version}-{cycle}-{hash}":    "4.3.0-alpha-a1b2c3d4e5f6",
+		"blender {version} {branch}":  "blender-4.3.0-npr-prototype",
+		"{version}_{date}_{arch}":     "4.3.0_20241001_amd64",
+		"{version}-{cycle}-{missing}": "4.3.0-alpha",
+	} {
+		if got := build.DirName(template); got != want {
+			t.Errorf("DirName(%q) = %q, expected %q", template, got, want)
+		}
+	}
+
+	// An empty field leaves no dangling separator
+	build.ReleaseCycle = ""
+	if got := build.DirName("{version}-{cycle}-{hash}"); got != "4.3.0-a1b2c3d4e5f6" {
+		t.Errorf("Expected the empty cycle to be dropped, got %q", got)
+	}
+}
+
+func TestCheckDirTemplate(t *testing.T) {
+	if err := CheckDirTemplate("{version}-{cycle}-{hash}"); err != nil {
+		t.Errorf("Expected a valid template, got %v", err)
+	}
+	if err := CheckDirTemplate("{version}-{commit}"); err == nil {
+		t.Error("Expected an error for an unknown placeholder")
+	}
+	if err := CheckDirTemplate("{cycle}-{hash}"); err == nil {
+		t.Error("Expected an error for a template without {version}")
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+// InstallPhase is a step of installing a build, from the download to the saved metadata
+type InstallPhase string
+
+// Phases of an install, in the order they run. Installs from the archive cache start at PhaseVerify.
+const (
+	PhaseDownload InstallPhase = "download" // Archive is downloaded
+	PhaseVerify   InstallPhase = "verify"   // Archive is checked against its checksum
+	PhaseBackup   InstallPhase = "backup"   // Previous install of the build is moved to .oldbuilds
+	PhaseExtract  InstallPhase = "extract"  // Archive is extracted
+	PhaseFinalize InstallPhase = "finalize" // Build is moved into place, its metadata saved, tested and probed
+)
+
+// Label returns the name of the phase shown in the status column
+func (p InstallPhase) Label() string {
+	switch p {
+	case PhaseDownload:
+		return "Downloading"
+	case PhaseVerify:
+		return "Verifying"
+	case PhaseBackup:
+		return "Backing up"
+	case PhaseExtract:
+		return "Extracting"
+	case PhaseFinalize:
+		return "Finalizing"
+	}
+	return string(p)
+}
+
+// PhaseTiming is how long a phase of an install took
+type PhaseTiming struct {
+	Phase    InstallPhase  `json:"phase"`
+	Duration time.Duration `json:"duration"`
+}
+
+// EnterPhase ends the running phase and starts the next one with its own progress.
+// Entering the running phase again, e.g. when a paused download resumes, keeps it running.
+func (s *DownloadState) EnterPhase(phase InstallPhase, now time.Time) {
+	if s.Phase == phase {
+		return
+	}
+	s.EndPhase(now)
+	s.Phase = phase
+	s.PhaseStart = now
+	s.Progress = 0
+	s.Current = 0
+	s.Total = 0
+}
+
+// EndPhase records the duration of the running phase, if any
+func (s *DownloadState) EndPhase(now time.Time) {
+	if s.Phase == "" {
+		return
+	}
+	s.Phases = append(s.Phases, PhaseTiming{Phase: s.Phase, Duration: now.Sub(s.PhaseStart)})
+	s.Phase = ""
+}
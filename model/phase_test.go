@@ -0,0 +1,41 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEnterPhase(t *testing.T) {
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	s := &DownloadState{}
+	s.EnterPhase(PhaseDownload, start)
+	s.Progress, s.Current, s.Total = 1, 100, 100
+
+	// Resuming a paused download stays in the same phase
+	s.EnterPhase(PhaseDownload, start.Add(10*time.Second))
+	if s.PhaseStart != start || s.Progress != 1 {
+		t.Errorf("Expected the download phase to keep running, got %+v", s)
+	}
+
+	s.EnterPhase(PhaseVerify, start.Add(30*time.Second))
+	if s.Phase != PhaseVerify || s.Progress != 0 || s.Total != 0 {
+		t.Errorf("Expected the verify phase with its own progress, got %+v", s)
+	}
+	s.EnterPhase(PhaseExtract, start.Add(32*time.Second))
+	s.EndPhase(start.Add(50 * time.Second))
+	s.EndPhase(start.Add(60 * time.Second)) // No phase running
+
+	want := []PhaseTiming{
+		{PhaseDownload, 30 * time.Second},
+		{PhaseVerify, 2 * time.Second},
+		{PhaseExtract, 18 * time.Second},
+	}
+	if len(s.Phases) != len(want) {
+		t.Fatalf("Expected %d phases, got %+v", len(want), s.Phases)
+	}
+	for i := range want {
+		if s.Phases[i] != want[i] {
+			t.Errorf("Phase %d: expected %+v, got %+v", i, want[i], s.Phases[i])
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// Update detection policies, deciding when an online build is an update of an installed one
+// with the same version, branch and release cycle
+const (
+	UpdatePolicyEither    = "either"     // A matching hash means no update, otherwise a later build date is one
+	UpdatePolicyHash      = "hash"       // Any other hash is an update, whatever the build dates
+	UpdatePolicyBuildDate = "build_date" // A later build date is an update, whatever the hashes
+)
+
+// UpdatePolicies lists the accepted update detection policies
+var UpdatePolicies = []string{UpdatePolicyEither, UpdatePolicyHash, UpdatePolicyBuildDate}
+
+// UpdateCheck is the outcome of comparing an installed build with an online one
+type UpdateCheck struct {
+	Status BuildState
+	Field  string // Compared field that decided the status: "hash", "version", "branch", "cycle" or "date"
+	Reason string // Why, e.g. "online build date is after the installed one"
+}
+
+// CheckUpdate decides whether an online build is an update of an installed one under an update
+// policy, and records which comparison decided it. An empty policy is UpdatePolicyEither.
+func CheckUpdate(localBuild, onlineBuild BlenderBuild, policy string) UpdateCheck {
+	// The same hash is the same build, unless only dates count
+	if policy != UpdatePolicyBuildDate && onlineBuild.Hash != "" && onlineBuild.Hash == localBuild.Hash {
+		return UpdateCheck{StateLocal, "hash", "same hash, the installed build is the online one"}
+	}
+
+	// Builds of another version, branch or release cycle aren't the same build
+	switch {
+	case localBuild.Version != onlineBuild.Version:
+		return UpdateCheck{StateOnline, "version", "different version, not the same build"}
+	case localBuild.Branch != onlineBuild.Branch:
+		return UpdateCheck{StateOnline, "branch", "different branch, not the same build"}
+	case localBuild.ReleaseCycle != onlineBuild.ReleaseCycle:
+		return UpdateCheck{StateOnline, "cycle", "different release cycle, not the same build"}
+	}
+
+	if policy == UpdatePolicyHash {
+		switch {
+		case localBuild.Hash == "":
+			return UpdateCheck{StateUpdate, "hash", "no installed hash, assumed older"}
+		case onlineBuild.Hash == "":
+			return UpdateCheck{StateOnline, "hash", "no online hash"}
+		}
+		return UpdateCheck{StateUpdate, "hash", "online hash differs from the installed one"}
+	}
+
+	// If local build date is not set, assume update is available
+	if localBuild.BuildDate.Time().IsZero() {
+		return UpdateCheck{StateUpdate, "date", "no installed build date, assumed older"}
+	}
+	if onlineBuild.BuildDate.Time().IsZero() {
+		return UpdateCheck{StateOnline, "date", "no online build date"}
+	}
+	if onlineBuild.BuildDate.Time().After(localBuild.BuildDate.Time()) {
+		return UpdateCheck{StateUpdate, "date", "online build date is after the installed one"}
+	}
+	return UpdateCheck{StateLocal, "date", "online build date is not after the installed one"}
+}
+
+// UpdateSnoozed reports whether updates of the installed build are snoozed at now
+func (b BlenderBuild) UpdateSnoozed(now time.Time) bool {
+	return b.SnoozedUntil != nil && now.Before(b.SnoozedUntil.Time())
+}
+
+// SnoozeDaysLeft returns the started days until the update snooze of the installed build ends, 0 when not snoozed
+func (b BlenderBuild) SnoozeDaysLeft(now time.Time) int {
+	if !b.UpdateSnoozed(now) {
+		return 0
+	}
+	return int(math.Ceil(b.SnoozedUntil.Time().Sub(now).Hours() / 24))
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// Samples in the formats they come in: builder listing entries with Unix file times,
+// version.json files with RFC3339 dates
+const (
+	installedSample = `{"version": "4.3.0", "branch": "main", "hash": "a1b2c3d4e5f6", "file_mtime": "2026-10-14T02:15:00Z", "release_cycle": "alpha", "platform": "linux", "architecture": "x86_64"}`
+	// Installed before hashes and build dates were recorded
+	legacySample = `{"version": "4.3.0", "branch": "main", "release_cycle": "alpha"}`
+
+	sameListing    = `{"version": "4.3.0", "branch": "main", "hash": "a1b2c3d4e5f6", "file_mtime": 1791944100, "release_cycle": "alpha", "platform": "linux", "architecture": "x86_64"}`
+	reuploaded     = `{"version": "4.3.0", "branch": "main", "hash": "a1b2c3d4e5f6", "file_mtime": 1792030500, "release_cycle": "alpha", "platform": "linux", "architecture": "x86_64"}`
+	newCommit      = `{"version": "4.3.0", "branch": "main", "hash": "f6e5d4c3b2a1", "file_mtime": 1792030500, "release_cycle": "alpha", "platform": "linux", "architecture": "x86_64"}`
+	sameMtime      = `{"version": "4.3.0", "branch": "main", "hash": "0123456789ab", "file_mtime": 1791944100, "release_cycle": "alpha", "platform": "linux", "architecture": "x86_64"}`
+	nextCycle      = `{"version": "4.3.0", "branch": "main", "hash": "f6e5d4c3b2a1", "file_mtime": 1792030500, "release_cycle": "beta", "platform": "linux", "architecture": "x86_64"}`
+	noHashListing  = `{"version": "4.3.0", "branch": "main", "file_mtime": 1792030500, "release_cycle": "alpha", "platform": "linux", "architecture": "x86_64"}`
+	otherBranchPR  = `{"version": "4.3.0", "branch": "PR123456", "hash": "f6e5d4c3b2a1", "file_mtime": 1792030500, "release_cycle": "alpha", "platform": "linux", "architecture": "x86_64"}`
+	sampleMtimeUTC = "2026-10-14T02:15:00Z" // file_mtime of sameListing
+)
+
+func decodeBuild(t *testing.T, sample string) BlenderBuild {
+	t.Helper()
+	var build BlenderBuild
+	if err := json.Unmarshal([]byte(sample), &build); err != nil {
+		t.Fatalf("Failed to decode sample: %v", err)
+	}
+	return build
+}
+
+func TestCheckUpdate(t *testing.T) {
+	if got := decodeBuild(t, sameListing).BuildDate.Time().UTC().Format("2006-01-02T15:04:05Z"); got != sampleMtimeUTC {
+		t.Fatalf("Samples out of sync: listing date %s, installed date %s", got, sampleMtimeUTC)
+	}
+
+	testCases := []struct {
+		name      string
+		installed string
+		online    string
+		expected  map[string]BuildState // Status per policy
+	}{
+		{"unchanged listing", installedSample, sameListing, map[string]BuildState{
+			UpdatePolicyEither: StateLocal, UpdatePolicyHash: StateLocal, UpdatePolicyBuildDate: StateLocal,
+		}},
+		{"same build uploaded again", installedSample, reuploaded, map[string]BuildState{
+			UpdatePolicyEither: StateLocal, UpdatePolicyHash: StateLocal, UpdatePolicyBuildDate: StateUpdate,
+		}},
+		{"new commit", installedSample, newCommit, map[string]BuildState{
+			UpdatePolicyEither: StateUpdate, UpdatePolicyHash: StateUpdate, UpdatePolicyBuildDate: StateUpdate,
+		}},
+		{"new commit with the same file time", installedSample, sameMtime, map[string]BuildState{
+			UpdatePolicyEither: StateLocal, UpdatePolicyHash: StateUpdate, UpdatePolicyBuildDate: StateLocal,
+		}},
+		{"listing without hash", installedSample, noHashListing, map[string]BuildState{
+			UpdatePolicyEither: StateUpdate, UpdatePolicyHash: StateOnline, UpdatePolicyBuildDate: StateUpdate,
+		}},
+		{"installed before hashes and dates were recorded", legacySample, newCommit, map[string]BuildState{
+			UpdatePolicyEither: StateUpdate, UpdatePolicyHash: StateUpdate, UpdatePolicyBuildDate: StateUpdate,
+		}},
+		{"next release cycle", installedSample, nextCycle, map[string]BuildState{
+			UpdatePolicyEither: StateOnline, UpdatePolicyHash: StateOnline, UpdatePolicyBuildDate: StateOnline,
+		}},
+		{"pull request build of the same version", installedSample, otherBranchPR, map[string]BuildState{
+			UpdatePolicyEither: StateOnline, UpdatePolicyHash: StateOnline, UpdatePolicyBuildDate: StateOnline,
+		}},
+	}
+
+	for _, tc := range testCases {
+		installed, online := decodeBuild(t, tc.installed), decodeBuild(t, tc.online)
+		for _, policy := range UpdatePolicies {
+			check := CheckUpdate(installed, online, policy)
+			if check.Status != tc.expected[policy] {
+				t.Errorf("%s with policy %s: expected %s, got %s (%s)", tc.name, policy, tc.expected[policy], check.Status, check.Reason)
+			}
+		}
+		// No policy is the default one
+		if CheckUpdate(installed, online, "") != CheckUpdate(installed, online, UpdatePolicyEither) {
+			t.Errorf("%s: expected an empty policy to behave like %s", tc.name, UpdatePolicyEither)
+		}
+	}
+}
+
+func TestUpdateSnoozed(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	snoozed := func(until time.Time) BlenderBuild {
+		ts := Timestamp(until)
+		return BlenderBuild{Version: "4.3.0", SnoozedUntil: &ts}
+	}
+
+	testCases := []struct {
+		name     string
+		build    BlenderBuild
+		snoozed  bool
+		daysLeft int
+	}{
+		{"never snoozed", BlenderBuild{Version: "4.3.0"}, false, 0},
+		{"snoozed for a week", snoozed(now.AddDate(0, 0, 7)), true, 7},
+		{"last hours of the snooze", snoozed(now.Add(3 * time.Hour)), true, 1},
+		{"snooze ended", snoozed(now.Add(-time.Minute)), false, 0},
+	}
+	for _, tc := range testCases {
+		if got := tc.build.UpdateSnoozed(now); got != tc.snoozed {
+			t.Errorf("%s: expected snoozed %v, got %v", tc.name, tc.snoozed, got)
+		}
+		if got := tc.build.SnoozeDaysLeft(now); got != tc.daysLeft {
+			t.Errorf("%s: expected %d days left, got %d", tc.name, tc.daysLeft, got)
+		}
+	}
+
+	// The snooze survives a round trip through version.json
+	data, err := json.Marshal(snoozed(now.AddDate(0, 0, 3)))
+	if err != nil {
+		t.Fatalf("Failed to encode build: %v", err)
+	}
+	if !decodeBuild(t, string(data)).UpdateSnoozed(now) {
+		t.Errorf("Expected the snooze to be read back from %s", data)
+	}
+	if data, _ := json.Marshal(BlenderBuild{Version: "4.3.0"}); containsKey(data, "snoozed_until") {
+		t.Errorf("Expected no snoozed_until key for a build that was never snoozed: %s", data)
+	}
+}
+
+// containsKey reports whether a JSON object has a key
+func containsKey(data []byte, key string) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+	_, found := fields[key]
+	return found
+}
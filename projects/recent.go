@@ -0,0 +1,115 @@
+package projects
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// recentFilename is the file in the state directory holding the recently opened projects
+const recentFilename = "recent.json"
+
+// MaxRecent is how many projects the list keeps, the oldest are dropped
+const MaxRecent = 20
+
+// Project is a .blend file opened through the launcher
+type Project struct {
+	Path         string    `json:"path"`
+	Version      string    `json:"version"`                // Build it was last opened with
+	Architecture string    `json:"architecture,omitempty"` // Architecture of that build, empty for native
+	OpenedAt     time.Time `json:"opened_at"`
+}
+
+// Name returns the file name of the project
+func (p Project) Name() string {
+	return filepath.Base(p.Path)
+}
+
+// Recent holds the recently opened projects, most recent first
+type Recent struct {
+	Projects []Project `json:"projects"`
+}
+
+// GetRecentPath returns the full path to the recent projects file in the state directory.
+func GetRecentPath() (string, error) {
+	stateDir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, recentFilename), nil
+}
+
+// Load reads the recent projects from disk. A missing file yields an empty list.
+func Load() (*Recent, error) {
+	path, err := GetRecentPath()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recent{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Save writes the recent projects to disk.
+func (r *Recent) Save() error {
+	path, err := GetRecentPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("could not create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal recent projects: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Record moves a project to the top of the list with the build it was opened with.
+// Only the MaxRecent most recent projects are kept.
+func (r *Recent) Record(path string, build model.BlenderBuild, at time.Time) {
+	r.Remove(path)
+	r.Projects = append(r.Projects, Project{
+		Path:         path,
+		Version:      build.Version,
+		Architecture: build.Architecture,
+		OpenedAt:     at,
+	})
+	sort.SliceStable(r.Projects, func(i, j int) bool {
+		return r.Projects[i].OpenedAt.After(r.Projects[j].OpenedAt)
+	})
+	if len(r.Projects) > MaxRecent {
+		r.Projects = r.Projects[:MaxRecent]
+	}
+}
+
+// Remove drops a project from the list. Returns true if it was listed.
+func (r *Recent) Remove(path string) bool {
+	for i, p := range r.Projects {
+		if p.Path == path {
+			r.Projects = append(r.Projects[:i], r.Projects[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
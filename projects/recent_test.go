@@ -0,0 +1,69 @@
+package projects
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRecord(t *testing.T) {
+	now := time.Now()
+	r := &Recent{}
+	r.Record("/shots/010.blend", model.BlenderBuild{Version: "4.2.0"}, now.Add(-2*time.Hour))
+	r.Record("/shots/020.blend", model.BlenderBuild{Version: "4.3.0"}, now.Add(-time.Hour))
+	r.Record("/shots/010.blend", model.BlenderBuild{Version: "4.2.3", Architecture: "x86_64"}, now) // Moves to the top
+
+	if len(r.Projects) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(r.Projects))
+	}
+	first := r.Projects[0]
+	if first.Path != "/shots/010.blend" || first.Version != "4.2.3" || first.Architecture != "x86_64" {
+		t.Errorf("Expected 010.blend opened with 4.2.3 x86_64 first, got %+v", first)
+	}
+	if r.Projects[1].Path != "/shots/020.blend" {
+		t.Errorf("Expected 020.blend second, got %s", r.Projects[1].Path)
+	}
+}
+
+func TestRecordKeepsMostRecent(t *testing.T) {
+	now := time.Now()
+	r := &Recent{}
+	for i := 0; i < MaxRecent+5; i++ {
+		r.Record(fmt.Sprintf("/shots/%03d.blend", i), model.BlenderBuild{Version: "4.2.0"}, now.Add(time.Duration(i)*time.Minute))
+	}
+
+	if len(r.Projects) != MaxRecent {
+		t.Fatalf("Expected %d projects, got %d", MaxRecent, len(r.Projects))
+	}
+	if got := r.Projects[0].Path; got != fmt.Sprintf("/shots/%03d.blend", MaxRecent+4) {
+		t.Errorf("Expected the last opened project first, got %s", got)
+	}
+	if r.Remove("/shots/000.blend") {
+		t.Error("Expected the oldest project to be dropped")
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	r, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, got: %v", err)
+	}
+	if len(r.Projects) != 0 {
+		t.Fatalf("Expected no projects, got %d", len(r.Projects))
+	}
+
+	r.Record("/shots/010.blend", model.BlenderBuild{Version: "4.2.0"}, time.Now())
+	if err := r.Save(); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if len(loaded.Projects) != 1 || loaded.Projects[0].Version != "4.2.0" {
+		t.Errorf("Expected the saved project, got %+v", loaded.Projects)
+	}
+}
@@ -0,0 +1,68 @@
+package schedule
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// queueFilename is the file in the state directory holding the download queue
+const queueFilename = "queue.json"
+
+// Queue holds the downloads waiting for a free slot, in the order they start
+type Queue struct {
+	Builds []model.BlenderBuild `json:"builds"`
+}
+
+// GetQueuePath returns the full path to the download queue file in the state directory.
+func GetQueuePath() (string, error) {
+	stateDir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, queueFilename), nil
+}
+
+// LoadQueue reads the download queue from disk. A missing file yields an empty queue.
+func LoadQueue() (*Queue, error) {
+	path, err := GetQueuePath()
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Queue{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return q, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, q); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return q, nil
+}
+
+// Save writes the download queue to disk.
+func (q *Queue) Save() error {
+	path, err := GetQueuePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("could not create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal download queue: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package schedule
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// scheduleFilename is the file in the config directory holding scheduled downloads
+const scheduleFilename = "schedule.json"
+
+// Entry is a download scheduled for a later time
+type Entry struct {
+	Build model.BlenderBuild `json:"build"`
+	At    time.Time          `json:"at"`
+}
+
+// Schedule holds the scheduled downloads, ordered by time
+type Schedule struct {
+	Entries []Entry `json:"entries"`
+}
+
+// GetSchedulePath returns the full path to the schedule file in the state directory.
+func GetSchedulePath() (string, error) {
+	stateDir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, scheduleFilename), nil
+}
+
+// migrateLegacySchedule moves a schedule written next to config.toml by older versions to path
+func migrateLegacySchedule(path string) {
+	cfgPath, err := config.GetConfigPath()
+	if err != nil {
+		return
+	}
+	legacy := filepath.Join(filepath.Dir(cfgPath), scheduleFilename)
+	if legacy == path {
+		return
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		return
+	}
+	if _, err := os.Stat(path); err == nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return
+	}
+	os.Rename(legacy, path)
+}
+
+// Load reads the schedule from disk. A missing file yields an empty schedule.
+func Load() (*Schedule, error) {
+	path, err := GetSchedulePath()
+	if err != nil {
+		return nil, err
+	}
+	migrateLegacySchedule(path)
+
+	s := &Schedule{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the schedule to disk.
+func (s *Schedule) Save() error {
+	path, err := GetSchedulePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("could not create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Find returns the scheduled time for the build of a version and architecture, if any, see BlenderBuild.Matches.
+func (s *Schedule) Find(version, arch string) (time.Time, bool) {
+	for _, e := range s.Entries {
+		if e.Build.Matches(version, arch) {
+			return e.At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Add schedules a build, replacing an existing entry for the same version and architecture.
+func (s *Schedule) Add(build model.BlenderBuild, at time.Time) {
+	s.Remove(build.Version, build.Architecture)
+	s.Entries = append(s.Entries, Entry{Build: build, At: at})
+	sort.Slice(s.Entries, func(i, j int) bool {
+		return s.Entries[i].At.Before(s.Entries[j].At)
+	})
+}
+
+// Remove unschedules the build of a version and architecture. Returns true if it was scheduled.
+func (s *Schedule) Remove(version, arch string) bool {
+	for i, e := range s.Entries {
+		if e.Build.Matches(version, arch) {
+			s.Entries = append(s.Entries[:i], s.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// HasDue reports whether any entry is scheduled at or before now.
+func (s *Schedule) HasDue(now time.Time) bool {
+	for _, e := range s.Entries {
+		if !e.At.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// TakeDue removes and returns all entries scheduled at or before now.
+func (s *Schedule) TakeDue(now time.Time) []Entry {
+	var due, pending []Entry
+	for _, e := range s.Entries {
+		if !e.At.After(now) {
+			due = append(due, e)
+		} else {
+			pending = append(pending, e)
+		}
+	}
+	s.Entries = pending
+	return due
+}
+
+// ParseTime parses a schedule time relative to now. Accepted formats are
+// "HH:MM" (the next occurrence of that time), "YYYY-MM-DD HH:MM", and "+<duration>" (e.g. "+2h30m").
+func ParseTime(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+
+	if strings.HasPrefix(input, "+") {
+		d, err := time.ParseDuration(input[1:])
+		if err != nil || d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid delay '%s', expected e.g. +2h30m", input)
+		}
+		return now.Add(d), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02 15:04", input, now.Location()); err == nil {
+		if !t.After(now) {
+			return time.Time{}, fmt.Errorf("time %s is in the past", input)
+		}
+		return t, nil
+	}
+
+	clock, err := time.ParseInLocation("15:04", input, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time '%s', expected HH:MM, YYYY-MM-DD HH:MM or +duration", input)
+	}
+	t := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	if !t.After(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
@@ -0,0 +1,108 @@
+package schedule
+
+import (
+	"TUI-Blender-Launcher/model"
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2024, 5, 10, 22, 15, 0, 0, time.Local)
+
+	testCases := []struct {
+		name        string
+		input       string
+		expected    time.Time
+		expectError bool
+	}{
+		{"later today", "23:00", time.Date(2024, 5, 10, 23, 0, 0, 0, time.Local), false},
+		{"tonight after midnight", "02:00", time.Date(2024, 5, 11, 2, 0, 0, 0, time.Local), false},
+		{"full date", "2024-05-12 08:30", time.Date(2024, 5, 12, 8, 30, 0, 0, time.Local), false},
+		{"relative", "+1h30m", now.Add(90 * time.Minute), false},
+		{"past date", "2024-05-01 08:30", time.Time{}, true},
+		{"garbage", "tonight", time.Time{}, true},
+		{"negative delay", "+-1h", time.Time{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseTime(tc.input, now)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if !got.Equal(tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTakeDue(t *testing.T) {
+	now := time.Now()
+	s := &Schedule{}
+	s.Add(model.BlenderBuild{Version: "4.2.0"}, now.Add(time.Hour))
+	s.Add(model.BlenderBuild{Version: "4.1.0"}, now.Add(-time.Minute))
+	s.Add(model.BlenderBuild{Version: "4.2.0"}, now.Add(2*time.Hour)) // Replaces the first entry
+
+	due := s.TakeDue(now)
+	if len(due) != 1 || due[0].Build.Version != "4.1.0" {
+		t.Fatalf("Expected only 4.1.0 to be due, got %v", due)
+	}
+	if len(s.Entries) != 1 {
+		t.Fatalf("Expected 1 pending entry, got %d", len(s.Entries))
+	}
+	if at, ok := s.Find("4.2.0", ""); !ok || !at.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("Expected 4.2.0 to be rescheduled, got %v (found %v)", at, ok)
+	}
+}
+
+func TestScheduleArchitectures(t *testing.T) {
+	now := time.Now()
+	s := &Schedule{}
+	s.Add(model.BlenderBuild{Version: "4.3.0", Architecture: "x64"}, now.Add(time.Hour))
+	s.Add(model.BlenderBuild{Version: "4.3.0", Architecture: "arm64"}, now.Add(2*time.Hour))
+
+	// Builds of another architecture are separate downloads, scheduled on their own
+	if len(s.Entries) != 2 {
+		t.Fatalf("Expected both architectures to be scheduled, got %v", s.Entries)
+	}
+	if at, ok := s.Find("4.3.0", "arm64"); !ok || !at.Equal(now.Add(2*time.Hour)) {
+		t.Errorf("Expected the arm64 build at its own time, got %v (found %v)", at, ok)
+	}
+	if !s.Remove("4.3.0", "x64") || len(s.Entries) != 1 || s.Entries[0].Build.Architecture != "arm64" {
+		t.Errorf("Expected only the x64 build to be unscheduled, got %v", s.Entries)
+	}
+	if _, ok := s.Find("4.3.0", "x64"); ok {
+		t.Error("Expected the x64 build to be unscheduled")
+	}
+}
+
+func TestQueueRoundTrip(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	q, err := LoadQueue()
+	if err != nil {
+		t.Fatalf("Expected no error for a missing queue, got %v", err)
+	}
+	if len(q.Builds) != 0 {
+		t.Fatalf("Expected an empty queue, got %d builds", len(q.Builds))
+	}
+
+	q.Builds = []model.BlenderBuild{{Version: "4.3.0", Hash: "430a1b2c3d4"}, {Version: "4.2.0", Hash: "420a1b2c3d4"}}
+	if err := q.Save(); err != nil {
+		t.Fatalf("Failed to save queue: %v", err)
+	}
+	loaded, err := LoadQueue()
+	if err != nil {
+		t.Fatalf("Failed to load queue: %v", err)
+	}
+	if len(loaded.Builds) != 2 || loaded.Builds[0].Version != "4.3.0" || loaded.Builds[1].Version != "4.2.0" {
+		t.Errorf("Expected the queue order to be kept, got %+v", loaded.Builds)
+	}
+}
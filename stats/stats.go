@@ -0,0 +1,187 @@
+package stats
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// statsFilename is the file in the state directory holding the usage statistics
+const statsFilename = "stats.json"
+
+// keptMonths is how many months of download volume are kept, older ones are dropped
+const keptMonths = 12
+
+// keptTransfers is how many finished downloads are kept in the transfer history, older ones are dropped
+const keptTransfers = 50
+
+// WarnRatio is the share of the download budget from which downloads are warned about
+const WarnRatio = 0.8
+
+// Budget states of the monthly download volume
+const (
+	BudgetOK       = iota // Well within the budget, or no budget
+	BudgetNear            // Past WarnRatio of the budget
+	BudgetExceeded        // Over the budget
+)
+
+// Stats holds the usage statistics kept between runs
+type Stats struct {
+	Downloaded map[string]int64 `json:"downloaded"`          // Bytes downloaded per month, keyed like "2006-01"
+	Transfers  []Transfer       `json:"transfers,omitempty"` // Finished downloads, the most recent last
+}
+
+// Transfer sums up a finished download, to tell a slow network from a slow server
+type Transfer struct {
+	Version   string        `json:"version"`
+	Arch      string        `json:"arch,omitempty"`
+	Source    string        `json:"source,omitempty"` // Host that served the archive
+	Bytes     int64         `json:"bytes"`            // Bytes transferred, without the part of a resumed download fetched before
+	Duration  time.Duration `json:"duration"`
+	PeakSpeed float64       `json:"peak_speed"` // Highest speed in bytes/sec, averaged over a few tenths of a second
+	Finished  time.Time     `json:"finished"`
+	// Durations of the phases of the install, from the download to the saved metadata
+	Phases []model.PhaseTiming `json:"phases,omitempty"`
+}
+
+// AvgSpeed returns the average speed of the transfer in bytes/sec
+func (t Transfer) AvgSpeed() float64 {
+	if t.Duration <= 0 {
+		return 0
+	}
+	return float64(t.Bytes) / t.Duration.Seconds()
+}
+
+// Summary describes the size, duration and speeds of the transfer, e.g. "312.4MB in 1m12s, avg 4.3 MB/s, peak 9.1 MB/s"
+func (t Transfer) Summary() string {
+	return fmt.Sprintf("%s in %s, avg %.1f MB/s, peak %.1f MB/s",
+		model.FormatByteSize(t.Bytes), t.Duration.Round(time.Second), t.AvgSpeed()/1024/1024, t.PeakSpeed/1024/1024)
+}
+
+// monthKey returns the key of the month of t in Downloaded
+func monthKey(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+// GetStatsPath returns the full path to the stats file in the state directory.
+func GetStatsPath() (string, error) {
+	stateDir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, statsFilename), nil
+}
+
+// Load reads the statistics from disk. A missing file yields empty statistics.
+func Load() (*Stats, error) {
+	path, err := GetStatsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Stats{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the statistics to disk.
+func (s *Stats) Save() error {
+	path, err := GetStatsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("could not create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal stats: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// AddDownload adds a finished download to the volume of the month of at
+func (s *Stats) AddDownload(bytes int64, at time.Time) {
+	if s.Downloaded == nil {
+		s.Downloaded = make(map[string]int64)
+	}
+	s.Downloaded[monthKey(at)] += bytes
+
+	// Drop the oldest months
+	months := make([]string, 0, len(s.Downloaded))
+	for month := range s.Downloaded {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	for len(months) > keptMonths {
+		delete(s.Downloaded, months[0])
+		months = months[1:]
+	}
+}
+
+// PhaseSummary describes how long each phase of the install took, e.g. "download 1m12s · verify 1s · extract 14s"
+func (t Transfer) PhaseSummary() string {
+	parts := make([]string, 0, len(t.Phases))
+	for _, phase := range t.Phases {
+		parts = append(parts, fmt.Sprintf("%s %s", phase.Phase, phase.Duration.Round(100*time.Millisecond)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// AddTransfer adds a finished download to the transfer history
+func (s *Stats) AddTransfer(t Transfer) {
+	s.Transfers = append(s.Transfers, t)
+	if len(s.Transfers) > keptTransfers {
+		s.Transfers = s.Transfers[len(s.Transfers)-keptTransfers:]
+	}
+}
+
+// LastTransfer returns the most recent download of a build in the transfer history
+func (s *Stats) LastTransfer(version, arch string) (Transfer, bool) {
+	for i := len(s.Transfers) - 1; i >= 0; i-- {
+		if t := s.Transfers[i]; t.Version == version && t.Arch == arch {
+			return t, true
+		}
+	}
+	return Transfer{}, false
+}
+
+// DownloadedIn returns the bytes downloaded in the month of at
+func (s *Stats) DownloadedIn(at time.Time) int64 {
+	return s.Downloaded[monthKey(at)]
+}
+
+// Budget returns the budget state of the month of at once another download of next bytes finished.
+// A budget of 0 bytes is no budget.
+func (s *Stats) Budget(budget, next int64, at time.Time) int {
+	if budget <= 0 {
+		return BudgetOK
+	}
+	used := s.DownloadedIn(at) + next
+	switch {
+	case used > budget:
+		return BudgetExceeded
+	case float64(used) >= WarnRatio*float64(budget):
+		return BudgetNear
+	}
+	return BudgetOK
+}
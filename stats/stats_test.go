@@ -0,0 +1,112 @@
+package stats
+
+import (
+	"TUI-Blender-Launcher/model"
+	"testing"
+	"time"
+)
+
+func TestAddDownload(t *testing.T) {
+	october := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	s := &Stats{}
+	s.AddDownload(300<<20, october.AddDate(0, -1, 0))
+	s.AddDownload(400<<20, october)
+	s.AddDownload(200<<20, october.Add(time.Hour))
+
+	if got := s.DownloadedIn(october); got != 600<<20 {
+		t.Errorf("Expected 600 MiB in October, got %d", got)
+	}
+	if got := s.DownloadedIn(october.AddDate(0, -1, 0)); got != 300<<20 {
+		t.Errorf("Expected 300 MiB in September, got %d", got)
+	}
+	if got := s.DownloadedIn(october.AddDate(0, 1, 0)); got != 0 {
+		t.Errorf("Expected nothing in November, got %d", got)
+	}
+
+	// Only the last months are kept
+	for i := 1; i <= keptMonths; i++ {
+		s.AddDownload(1, october.AddDate(0, i, 0))
+	}
+	if len(s.Downloaded) != keptMonths {
+		t.Errorf("Expected %d months, got %d", keptMonths, len(s.Downloaded))
+	}
+	if got := s.DownloadedIn(october); got != 0 {
+		t.Errorf("Expected October to be dropped, got %d", got)
+	}
+}
+
+func TestAddTransfer(t *testing.T) {
+	s := &Stats{}
+	for i := 0; i < keptTransfers+5; i++ {
+		s.AddTransfer(Transfer{Version: "4.2.0", Arch: "x64", Bytes: int64(i)})
+	}
+	s.AddTransfer(Transfer{Version: "4.3.0", Arch: "x64", Bytes: 300 << 20, Duration: time.Minute, PeakSpeed: 10 << 20})
+	if len(s.Transfers) != keptTransfers {
+		t.Errorf("Expected %d transfers, got %d", keptTransfers, len(s.Transfers))
+	}
+
+	last, ok := s.LastTransfer("4.2.0", "x64")
+	if !ok || last.Bytes != keptTransfers+4 {
+		t.Errorf("Expected the most recent 4.2.0 transfer, got %+v", last)
+	}
+	last, ok = s.LastTransfer("4.3.0", "x64")
+	if !ok {
+		t.Fatal("Expected a 4.3.0 transfer")
+	}
+	if got := last.Summary(); got != "300.0MB in 1m0s, avg 5.0 MB/s, peak 10.0 MB/s" {
+		t.Errorf("Unexpected summary %q", got)
+	}
+	last.Phases = []model.PhaseTiming{{Phase: model.PhaseDownload, Duration: time.Minute}, {Phase: model.PhaseExtract, Duration: 1500 * time.Millisecond}}
+	if got := last.PhaseSummary(); got != "download 1m0s · extract 1.5s" {
+		t.Errorf("Unexpected phase summary %q", got)
+	}
+	if _, ok := s.LastTransfer("4.3.0", "arm64"); ok {
+		t.Error("Expected no arm64 transfer")
+	}
+}
+
+func TestBudget(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	const gb = 1 << 30
+	s := &Stats{}
+	s.AddDownload(7*gb, now)
+
+	testCases := []struct {
+		name     string
+		budget   int64
+		next     int64
+		expected int
+	}{
+		{"no budget", 0, 5 * gb, BudgetOK},
+		{"well within", 20 * gb, gb / 2, BudgetOK},
+		{"approaching", 10 * gb, gb, BudgetNear},
+		{"exactly at the budget", 10 * gb, 3 * gb, BudgetNear},
+		{"over", 10 * gb, 4 * gb, BudgetExceeded},
+	}
+	for _, tc := range testCases {
+		if got := s.Budget(tc.budget, tc.next, now); got != tc.expected {
+			t.Errorf("%s: expected budget state %d, got %d", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	s, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, got: %v", err)
+	}
+	now := time.Now()
+	s.AddDownload(123456, now)
+	if err := s.Save(); err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if got := loaded.DownloadedIn(now); got != 123456 {
+		t.Errorf("Expected the saved volume, got %d", got)
+	}
+}
@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// actionMenu lists the actions valid for the selected build, so they don't all need a footer hint
+type actionMenu struct {
+	build   model.BlenderBuild
+	actions []menuAction
+	cursor  int
+}
+
+// menuAction is an entry of the action menu, run by replaying the keys of its command
+type menuAction struct {
+	label string
+	keys  []string // Pressed in order, e.g. "y", "h" to copy the hash
+}
+
+// buildActions returns the actions valid for the state of a build
+func (m *Model) buildActions(build model.BlenderBuild) []menuAction {
+	var actions []menuAction
+	add := func(label string, keys ...string) {
+		actions = append(actions, menuAction{label: label, keys: keys})
+	}
+	installed := build.Status == model.StateLocal || build.Status == model.StateUpdate
+	editable := installed && !build.Shared
+
+	switch build.Status {
+	case model.StateLocal, model.StateUpdate:
+		add("Launch", "enter")
+		if len(m.config.LaunchProfiles) > 0 {
+			add("Launch with profile...", "p")
+		}
+		if build.Status == model.StateUpdate && !m.offline {
+			add("Download update", "d")
+		}
+		if build.Status == model.StateLocal && build.NeedsRepair() && !build.Shared {
+			add("Repair (download again)", "d")
+			if m.config.KeepArchives {
+				add("Repair (reinstall from archive cache)", "I")
+			}
+		}
+		add("Open directory...", "o")
+	case model.StateOnline, model.StateFailed, model.StateCancelled:
+		if !m.offline {
+			add("Download", "d")
+			add("Download and launch", "e")
+		}
+		if m.config.KeepArchives {
+			add("Reinstall from archive cache", "I")
+		}
+	case model.StateDownloading, model.StateExtracting:
+		add("Cancel download", "x")
+	}
+	if !m.scheduledAt(build).IsZero() {
+		add("Unschedule download", "W")
+	} else if build.Status != model.StateLocal && build.Status != model.StateDownloading && build.Status != model.StateExtracting {
+		add("Schedule download", "W")
+	}
+	add("Details", "i")
+	if editable {
+		add("Verify installed files", "V")
+		add("Label", "L")
+		add("Notes", "n")
+		add("Tags", "T")
+		add("Snooze updates", "z")
+		if m.config.PromotionAdmin {
+			add("Cycle promotion", "m")
+		}
+	}
+	if installed {
+		add("Open .blend files with this build", "a")
+	}
+	if len(build.Artifacts) > 0 && !build.Shared {
+		add("Additional files", "A")
+	}
+	if build.Hash != "" {
+		add("Copy hash", "y", "h")
+	}
+	if build.DownloadURL != "" {
+		add("Copy download URL", "y", "u")
+	}
+	if installed {
+		add("Copy install path", "y", "p")
+	}
+	if sourcePageURL(build) != "" {
+		add("Open commit page", "g")
+	}
+	if build.PullRequest() != "" {
+		add("Open pull request", "P")
+	}
+	if editable {
+		add("Delete", "x")
+	}
+	return actions
+}
+
+// openActionMenu shows the actions valid for the selected build
+func (m *Model) openActionMenu() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok {
+		return m, nil
+	}
+	m.actionMenu = &actionMenu{build: build, actions: m.buildActions(build)}
+	return m, nil
+}
+
+// updateActionMenu handles key events while the action menu is open
+func (m *Model) updateActionMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := m.actionMenu
+	switch msg.String() {
+	case "esc", ".", "q":
+		m.actionMenu = nil
+
+	case "up", "k":
+		menu.cursor = (menu.cursor - 1 + len(menu.actions)) % len(menu.actions)
+
+	case "down", "j":
+		menu.cursor = (menu.cursor + 1) % len(menu.actions)
+
+	case "enter":
+		action := menu.actions[menu.cursor]
+		m.actionMenu = nil
+		// The action runs as if its keys were pressed in the list
+		var cmds []tea.Cmd
+		for _, k := range action.keys {
+			_, cmd := m.Update(keyMsgFor(k))
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+	}
+	return m, nil
+}
+
+// keyMsgFor returns the key message of a key name, "enter" or a single character
+func keyMsgFor(k string) tea.KeyMsg {
+	if k == "enter" {
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// renderActionMenu renders the action menu popup
+func (m *Model) renderActionMenu(availableHeight int) string {
+	menu := m.actionMenu
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	keyStyle := lp.NewStyle().Faint(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Blender %s (%s)", versionCell(menu.build), menu.build.Status)))
+	b.WriteString("\n\n")
+	for i, action := range menu.actions {
+		line := fmt.Sprintf("%-34s", action.label)
+		if i == menu.cursor {
+			b.WriteString(selectedRowStyle.Render(line))
+		} else {
+			b.WriteString(regularRowStyle.Render(line))
+		}
+		b.WriteString(" " + keyStyle.Render(strings.Join(action.keys, " ")))
+		if i < len(menu.actions)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(b.String())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderActionMenuFooter renders the key hints for the action menu
+func (m *Model) renderActionMenuFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Run", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Select", keyStyle.Render("↑/↓")),
+		fmt.Sprintf("%s Close", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
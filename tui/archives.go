@@ -0,0 +1,289 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// archivePanel lists the archives kept in the archive cache, see keep_archives
+type archivePanel struct {
+	archives      []download.CachedArchive
+	cursor        int
+	confirmDelete bool // x was pressed once, the next x deletes the archive
+}
+
+// ListCachedArchives creates a command to list the archive cache
+func (c *Commands) ListCachedArchives() tea.Cmd {
+	cfg := c.cfg
+	return func() tea.Msg {
+		cacheDir, err := cfg.ArchiveCachePath()
+		if err != nil {
+			return archivesListedMsg{err: err}
+		}
+		archives, err := download.CachedArchives(cacheDir)
+		return archivesListedMsg{archives: archives, err: err}
+	}
+}
+
+// InstallFromArchive creates a command to install a build from the archive cache, without downloading it
+func (c *Commands) InstallFromArchive(archive download.CachedArchive) tea.Cmd {
+	return func() tea.Msg {
+		return c.downloads.StartArchiveInstall(archive)
+	}
+}
+
+// FindCachedArchive creates a command to look up the archive of a build in the archive cache
+func (c *Commands) FindCachedArchive(build model.BlenderBuild) tea.Cmd {
+	cfg := c.cfg
+	return func() tea.Msg {
+		cacheDir, err := cfg.ArchiveCachePath()
+		if err != nil {
+			return cachedArchiveFoundMsg{build: build, err: err}
+		}
+		archive, ok, err := download.FindCachedArchive(cacheDir, build)
+		return cachedArchiveFoundMsg{build: build, archive: archive, ok: ok, err: err}
+	}
+}
+
+// DeleteCachedArchive creates a command to delete an archive from the archive cache
+func (c *Commands) DeleteCachedArchive(archive download.CachedArchive) tea.Cmd {
+	return func() tea.Msg {
+		return archiveDeletedMsg{archive: archive, err: download.RemoveCachedArchive(archive)}
+	}
+}
+
+// ExportArchive creates a command to copy an archive from the archive cache to a directory
+func (c *Commands) ExportArchive(archive download.CachedArchive, dir string) tea.Cmd {
+	return func() tea.Msg {
+		path, err := download.ExportArchive(archive, dir)
+		return archiveExportedMsg{path: path, err: err}
+	}
+}
+
+// openArchivePanel lists the archive cache
+func (m *Model) openArchivePanel() (tea.Model, tea.Cmd) {
+	return m, m.commands.ListCachedArchives()
+}
+
+// handleArchivesListed opens the archive panel, or explains how to fill the cache when it is empty
+func (m *Model) handleArchivesListed(msg archivesListedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	if len(msg.archives) == 0 {
+		if m.config.KeepArchives {
+			m.showNotice("The archive cache is empty, archives are kept from the next download on")
+		} else {
+			m.showNotice("The archive cache is empty, set keep_archives = true to keep downloaded archives")
+		}
+		m.archivePanel = nil
+		return m, nil
+	}
+	cursor := 0
+	if m.archivePanel != nil {
+		cursor = min(m.archivePanel.cursor, len(msg.archives)-1)
+	}
+	m.archivePanel = &archivePanel{archives: msg.archives, cursor: cursor}
+	return m, nil
+}
+
+// updateArchivePanel handles key events while the archive panel is open
+func (m *Model) updateArchivePanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.archivePanel
+	key := msg.String()
+	if key != "x" {
+		panel.confirmDelete = false
+	}
+	switch key {
+	case "esc", "C", "q":
+		m.archivePanel = nil
+
+	case "up", "k":
+		panel.cursor = (panel.cursor - 1 + len(panel.archives)) % len(panel.archives)
+
+	case "down", "j":
+		panel.cursor = (panel.cursor + 1) % len(panel.archives)
+
+	case "enter":
+		// Extract the archive again, replacing an installed copy like a download would
+		archive := panel.archives[panel.cursor]
+		m.archivePanel = nil
+		return m, m.installFromArchive(archive)
+
+	case "e":
+		// Pick the directory to copy the archive to, the panel comes back afterwards
+		m.archivePanel = nil
+		m.archiveExport = panel
+		home, _ := os.UserHomeDir()
+		m.dirPicker = newDirPicker(home)
+
+	case "x":
+		if !panel.confirmDelete {
+			panel.confirmDelete = true
+			return m, nil
+		}
+		panel.confirmDelete = false
+		return m, m.commands.DeleteCachedArchive(panel.archives[panel.cursor])
+	}
+	return m, nil
+}
+
+// installFromArchive starts the install of a cached archive and shows it on the row of its build,
+// adding the row if the build isn't listed, e.g. while offline
+func (m *Model) installFromArchive(archive download.CachedArchive) tea.Cmd {
+	build := archive.Build
+	listed := false
+	for i := range m.builds {
+		if m.builds[i].Matches(build.Version, build.Architecture) {
+			m.builds[i].Status = model.StateExtracting
+			listed = true
+			break
+		}
+	}
+	if !listed {
+		build.Status = model.StateExtracting
+		m.builds = append(m.builds, build)
+		m.sortBuilds()
+	}
+	m.activeDownloadID = downloadID(build)
+	m.showNotice(fmt.Sprintf("Installing Blender %s from the archive cache", build.Version))
+	return tea.Batch(m.commands.InstallFromArchive(archive), m.scheduleTick(10*time.Millisecond))
+}
+
+// handleReinstallFromCache looks up the kept archive of the selected build to install it again,
+// e.g. after it broke or was deleted. Works offline, the network isn't used.
+func (m *Model) handleReinstallFromCache() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok || build.Status == model.StateDownloading || build.Status == model.StateExtracting {
+		return m, nil
+	}
+	if m.rejectShared(build) {
+		return m, nil
+	}
+	return m, m.commands.FindCachedArchive(build)
+}
+
+// handleCachedArchiveFound installs the archive found for a build, or explains why there is none
+func (m *Model) handleCachedArchiveFound(msg cachedArchiveFoundMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	if !msg.ok {
+		if m.config.KeepArchives {
+			m.showNotice(fmt.Sprintf("No archive of Blender %s in the archive cache", msg.build.Version))
+		} else {
+			m.showNotice(fmt.Sprintf("No archive of Blender %s in the archive cache, set keep_archives = true to keep archives of future downloads", msg.build.Version))
+		}
+		return m, nil
+	}
+	if m.repair.prompt != nil && downloadID(*m.repair.prompt) == downloadID(msg.build) {
+		m.repair.prompt = nil
+	}
+	m.err = nil
+	return m, m.installFromArchive(msg.archive)
+}
+
+// handleArchiveDeleted lists the archive cache again after deleting an archive
+func (m *Model) handleArchiveDeleted(msg archiveDeletedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.showNotice(fmt.Sprintf("Deleted %s from the archive cache, freed %s", archiveName(msg.archive), model.FormatByteSize(msg.archive.Size)))
+	if m.archivePanel == nil {
+		return m, nil
+	}
+	return m, m.commands.ListCachedArchives()
+}
+
+// handleArchiveExported reports where an archive was copied to
+func (m *Model) handleArchiveExported(msg archiveExportedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.showNotice("Exported " + abbreviatePath(msg.path, 0))
+	return m, nil
+}
+
+// archiveName returns the file name of a cached archive
+func archiveName(archive download.CachedArchive) string {
+	return archive.Path[strings.LastIndexAny(archive.Path, `/\`)+1:]
+}
+
+// renderArchivePanel renders the cached archives with their size and age
+func (m *Model) renderArchivePanel(availableHeight int) string {
+	panel := m.archivePanel
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	dimStyle := lp.NewStyle().Faint(true)
+	warnStyle := lp.NewStyle().Foreground(lp.Color(orangeColor))
+
+	var total int64
+	for _, archive := range panel.archives {
+		total += archive.Size
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("Archive cache (%d, %s)", len(panel.archives), model.FormatByteSize(total))))
+	lines = append(lines, "")
+	for i, archive := range panel.archives {
+		build := archive.Build
+		hash := build.Hash
+		if len(hash) > 8 {
+			hash = hash[:8]
+		}
+		line := fmt.Sprintf("%-16s %-10s %-8s %9s  %s", versionCell(build), build.ReleaseCycle, hash,
+			model.FormatByteSize(archive.Size), archive.Cached.Local().Format("2006-01-02"))
+		if i == panel.cursor {
+			lines = append(lines, selectedRowStyle.Render(line))
+		} else {
+			lines = append(lines, regularRowStyle.Render(line))
+		}
+	}
+	lines = append(lines, "")
+	if panel.confirmDelete {
+		lines = append(lines, warnStyle.Render(fmt.Sprintf("Press x again to delete %s", archiveName(panel.archives[panel.cursor]))))
+	} else {
+		lines = append(lines, dimStyle.Render(truncateMiddle(archiveName(panel.archives[panel.cursor]), max(m.terminalWidth-8, 10))))
+	}
+
+	// Keep the panel within the content area, the rest is cut
+	if maxLines := availableHeight - 2; maxLines > 0 && len(lines) > maxLines {
+		lines = append(lines[:maxLines-1], dimStyle.Render("  ..."))
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderArchivePanelFooter renders the key hints for the archive panel
+func (m *Model) renderArchivePanelFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Install", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Export", keyStyle.Render("e")),
+		fmt.Sprintf("%s Delete", keyStyle.Render("x")),
+		fmt.Sprintf("%s Select", keyStyle.Render("↑/↓")),
+		fmt.Sprintf("%s Close", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
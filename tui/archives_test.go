@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/model"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchivePanel(t *testing.T) {
+	cacheDir := t.TempDir()
+	archivePath := filepath.Join(t.TempDir(), "blender-4.3.0-linux-x64.tar.xz")
+	if err := os.WriteFile(archivePath, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	build := model.BlenderBuild{Version: "4.3.0", Architecture: "x64", SHA256: "abc"}
+	if _, err := download.CacheArchive(build, archivePath, cacheDir); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{DownloadDir: t.TempDir(), ArchiveCacheDir: cacheDir, KeepArchives: true}
+	m := &Model{config: cfg, commands: &Commands{cfg: cfg}}
+	_, cmd := m.openArchivePanel()
+	m.Update(cmd())
+	if m.archivePanel == nil || len(m.archivePanel.archives) != 1 {
+		t.Fatalf("Expected the cached archive to be listed, got %+v", m.archivePanel)
+	}
+
+	// e exports through the dir picker and brings the panel back
+	exportDir := t.TempDir()
+	m.updateArchivePanel(keyMsgFor("e"))
+	if m.archivePanel != nil || m.dirPicker == nil {
+		t.Fatal("Expected the dir picker to replace the panel")
+	}
+	m.dirPicker = newDirPicker(exportDir)
+	_, cmd = m.updateDirPicker(keyMsgFor("s"))
+	if m.archivePanel == nil || cmd == nil {
+		t.Fatal("Expected the panel back and the archive to be exported")
+	}
+	m.Update(cmd())
+	if _, err := os.Stat(filepath.Join(exportDir, "blender-4.3.0-linux-x64.tar.xz.sha256")); err != nil {
+		t.Errorf("Expected the archive and its checksum to be exported: %v", err)
+	}
+
+	// x deletes only when pressed twice
+	if _, cmd = m.updateArchivePanel(keyMsgFor("x")); cmd != nil || !m.archivePanel.confirmDelete {
+		t.Fatal("Expected the first x to ask for confirmation")
+	}
+	_, cmd = m.updateArchivePanel(keyMsgFor("x"))
+	if cmd == nil {
+		t.Fatal("Expected the second x to delete the archive")
+	}
+	_, cmd = m.Update(cmd())
+	m.Update(cmd())
+	if m.archivePanel != nil {
+		t.Error("Expected the panel to close once the cache is empty")
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "blender-4.3.0-linux-x64.tar.xz")); !os.IsNotExist(err) {
+		t.Errorf("Expected the archive to be deleted, got %v", err)
+	}
+}
+
+func TestReinstallFromCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	archivePath := filepath.Join(t.TempDir(), "blender-4.3.0-linux-x64.tar.xz")
+	if err := os.WriteFile(archivePath, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cached := model.BlenderBuild{Version: "4.3.0", Architecture: "x64", Hash: "a1b2c3d4e5f6"}
+	if _, err := download.CacheArchive(cached, archivePath, cacheDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// A deleted build is listed as online, another version has no archive
+	cfg := config.Config{DownloadDir: t.TempDir(), ArchiveCacheDir: cacheDir, KeepArchives: true}
+	m := &Model{config: cfg, commands: &Commands{cfg: cfg}, builds: []model.BlenderBuild{
+		{Version: "4.3.0", Architecture: "x64", Hash: "a1b2c3d4e5f6", Status: model.StateOnline},
+		{Version: "4.2.0", Architecture: "x64", Status: model.StateOnline},
+	}}
+
+	m.cursor = 1
+	_, cmd := m.handleReinstallFromCache()
+	if msg := cmd().(cachedArchiveFoundMsg); msg.ok {
+		t.Fatalf("Expected no archive of 4.2.0, got %+v", msg.archive)
+	}
+
+	m.cursor = 0
+	_, cmd = m.handleReinstallFromCache()
+	msg := cmd().(cachedArchiveFoundMsg)
+	if !msg.ok || msg.archive.Build.Hash != "a1b2c3d4e5f6" {
+		t.Fatalf("Expected the archive of 4.3.0, got %+v", msg)
+	}
+	if _, cmd = m.handleCachedArchiveFound(msg); cmd == nil {
+		t.Fatal("Expected the install to start")
+	}
+	if m.builds[0].Status != model.StateExtracting || m.activeDownloadID != downloadID(m.builds[0]) {
+		t.Errorf("Expected 4.3.0 to be extracting, got %v", m.builds[0].Status)
+	}
+}
@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// artifactMenu lists the companion files of a build for download into its folder
+type artifactMenu struct {
+	version   string
+	arch      string
+	installed bool // Build is installed, so artifacts can be downloaded into its folder
+	artifacts []model.Artifact
+	cursor    int
+}
+
+// DownloadArtifact creates a command to download a companion file into the folder of a local build
+func (c *Commands) DownloadArtifact(version, arch string, artifact model.Artifact) tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		dirPath, err := local.FindBuildDir(downloadDir, version, arch)
+		if err != nil {
+			return artifactDownloadedMsg{version: version, err: err}
+		}
+		if dirPath == "" {
+			return artifactDownloadedMsg{version: version, err: fmt.Errorf("build directory for Blender version %s not found", version)}
+		}
+		path, err := download.DownloadArtifact(artifact, dirPath, nil)
+		if err != nil {
+			return artifactDownloadedMsg{version: version, err: fmt.Errorf("failed to download %s: %w", artifact.FileName, err)}
+		}
+		return artifactDownloadedMsg{version: version, path: path, size: artifact.Size}
+	}
+}
+
+// openArtifactMenu shows the companion files of the selected build.
+// For updates, the files of the installed build are listed.
+func (m *Model) openArtifactMenu() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok || m.rejectShared(build) {
+		return m, nil
+	}
+	if build.Status == model.StateUpdate && build.Installed != nil && len(build.Installed.Artifacts) > 0 {
+		build = *build.Installed
+		build.Status = model.StateUpdate
+	}
+	if len(build.Artifacts) == 0 {
+		m.showNotice(fmt.Sprintf("The builder lists no additional files for Blender %s", build.Version))
+		return m, nil
+	}
+
+	m.artifactMenu = &artifactMenu{
+		version:   build.Version,
+		arch:      build.Architecture,
+		installed: build.Status == model.StateLocal || build.Status == model.StateUpdate,
+		artifacts: build.Artifacts,
+	}
+	return m, nil
+}
+
+// updateArtifactMenu handles key events while the artifact menu is open
+func (m *Model) updateArtifactMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := m.artifactMenu
+	switch msg.String() {
+	case "esc", "A", "q":
+		m.artifactMenu = nil
+
+	case "up", "k":
+		menu.cursor = (menu.cursor - 1 + len(menu.artifacts)) % len(menu.artifacts)
+
+	case "down", "j":
+		menu.cursor = (menu.cursor + 1) % len(menu.artifacts)
+
+	case "enter":
+		if !menu.installed {
+			m.err = fmt.Errorf("download Blender %s first to add files to its folder", menu.version)
+			return m, nil
+		}
+		artifact := menu.artifacts[menu.cursor]
+		m.artifactMenu = nil
+		m.showNotice(fmt.Sprintf("Downloading %s...", artifact.FileName))
+		return m, m.commands.DownloadArtifact(menu.version, menu.arch, artifact)
+	}
+	return m, nil
+}
+
+// handleArtifactDownloaded reports where a downloaded companion file was saved
+func (m *Model) handleArtifactDownloaded(msg artifactDownloadedMsg) (tea.Model, tea.Cmd) {
+	m.notice = ""
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.err = nil
+	m.showNotice(fmt.Sprintf("Saved %s to the folder of Blender %s", filepath.Base(msg.path), msg.version))
+	m.recordDownload(msg.size)
+	return m, nil
+}
+
+// renderArtifactMenu renders the artifact menu popup
+func (m *Model) renderArtifactMenu(availableHeight int) string {
+	menu := m.artifactMenu
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Files for Blender " + menu.version))
+	b.WriteString("\n\n")
+	for i, artifact := range menu.artifacts {
+		size := "-"
+		if artifact.Size > 0 {
+			size = model.FormatByteSize(artifact.Size)
+		}
+		line := fmt.Sprintf("%-8s %10s  %s", artifact.FileExtension, size, artifact.FileName)
+		if i == menu.cursor {
+			b.WriteString(selectedRowStyle.Render(line))
+		} else {
+			b.WriteString(regularRowStyle.Render(line))
+		}
+		if i < len(menu.artifacts)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(b.String())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderArtifactMenuFooter renders the key hints for the artifact menu
+func (m *Model) renderArtifactMenuFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Download into build folder", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Select", keyStyle.Render("↑/↓")),
+		fmt.Sprintf("%s Close", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// blendLaunch is a .blend file given on the command line, opened with a build of the series that saved it
+type blendLaunch struct {
+	path     string
+	series   string              // Series that saved the file, empty until the header was read
+	scanned  bool                // Local builds were scanned, so a build can be picked
+	prompt   bool                // No build of the series is installed, waiting for a decision
+	fallback *model.BlenderBuild // Installed build of a newer series that can open the file
+	download *model.BlenderBuild // Build of the series downloading from the prompt, launched once installed
+}
+
+// OpenBlendFile makes the launcher open path with the installed build closest to the version
+// that saved it, once the local builds are scanned
+func (m *Model) OpenBlendFile(path string) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	m.blendLaunch = &blendLaunch{path: path}
+}
+
+// readBlendVersionCmd reads the version that saved a .blend file from its header
+func readBlendVersionCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		series, err := local.ReadBlendVersion(path)
+		return blendVersionReadMsg{series: series, err: err}
+	}
+}
+
+// handleBlendVersionRead picks a build for the .blend file once its version is known
+func (m *Model) handleBlendVersionRead(msg blendVersionReadMsg) (tea.Model, tea.Cmd) {
+	if m.blendLaunch == nil {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.err = msg.err
+		m.blendLaunch = nil
+		return m, nil
+	}
+	m.blendLaunch.series = msg.series
+	return m, m.resolveBlendLaunch()
+}
+
+// resolveBlendLaunch opens the .blend file with the build pinned by the project or an installed build
+// of the series that saved it, or asks what to do when there is none.
+// It waits for both the file header and the local scan.
+func (m *Model) resolveBlendLaunch() tea.Cmd {
+	launch := m.blendLaunch
+	if launch == nil || launch.series == "" || !launch.scanned || launch.prompt || launch.download != nil {
+		return nil
+	}
+	if m.pin != nil && m.pin.covers(launch.path) {
+		if pinned, found := model.PinnedBuild(m.builds, m.pin.version); found {
+			m.blendLaunch = nil
+			return m.launchBlendFile(pinned, launch.path)
+		}
+	}
+
+	build, exact, found := model.CompatibleBuild(m.builds, launch.series)
+	if exact {
+		m.blendLaunch = nil
+		return m.launchBlendFile(build, launch.path)
+	}
+	launch.prompt = true
+	if found {
+		launch.fallback = &build
+	}
+	return nil
+}
+
+// launchBlendFile launches a build with the .blend file at path, through the checks of every launch
+func (m *Model) launchBlendFile(build model.BlenderBuild, path string) tea.Cmd {
+	return m.startLaunch(launchRequest{build: build, path: path})
+}
+
+// launchWithFile makes a launch command open the .blend file at path
+func launchWithFile(launchCmd tea.Cmd, path string) tea.Cmd {
+	return func() tea.Msg {
+		msg := launchCmd()
+		if execMsg, ok := msg.(model.BlenderExecMsg); ok {
+			execMsg.Args = append(execMsg.Args, path)
+			return execMsg
+		}
+		return msg
+	}
+}
+
+// blendDownloadCandidate returns the index of the newest listed online build of the series of the .blend file, -1 if none
+func (m *Model) blendDownloadCandidate() int {
+	candidate := -1
+	for i, build := range m.builds {
+		if build.Status != model.StateOnline || model.BuildSeries(build.Version) != m.blendLaunch.series {
+			continue
+		}
+		if candidate < 0 || build.BuildDate.Time().After(m.builds[candidate].BuildDate.Time()) {
+			candidate = i
+		}
+	}
+	return candidate
+}
+
+// updateBlendPrompt opens the .blend file with the newer fallback build on enter, downloads a build
+// of its series on d, fetches the online builds on f and gives up on esc
+func (m *Model) updateBlendPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	launch := m.blendLaunch
+	switch msg.String() {
+	case "enter":
+		if launch.fallback == nil {
+			return m, nil
+		}
+		m.blendLaunch = nil
+		return m, m.launchBlendFile(*launch.fallback, launch.path)
+
+	case "d":
+		i := m.blendDownloadCandidate()
+		if i < 0 {
+			return m, nil
+		}
+		if m.offline {
+			m.err = errOfflineDownloads
+			return m, nil
+		}
+		build := m.builds[i]
+		launch.prompt = false
+		launch.download = &build
+		m.cursor = i
+		return m.handleStartDownload()
+
+	case "f":
+		return m, m.commands.FetchBuilds()
+
+	case "esc":
+		m.blendLaunch = nil
+	}
+	return m, nil
+}
+
+// blendBuildInstalled opens the .blend file once the build downloaded for it from the prompt finished
+func (m *Model) blendBuildInstalled(finished model.BlenderBuild, installed bool) tea.Cmd {
+	launch := m.blendLaunch
+	if launch == nil || launch.download == nil || !launch.download.Matches(finished.Version, finished.Architecture) {
+		return nil
+	}
+	m.blendLaunch = nil
+	if !installed {
+		return nil
+	}
+	build := *launch.download
+	build.Status = model.StateLocal
+	return m.launchBlendFile(build, launch.path)
+}
+
+// renderBlendPrompt renders the choices for a .blend file whose series isn't installed in place of the contextual commands
+func (m *Model) renderBlendPrompt(keyStyle lp.Style, separator string) string {
+	warnStyle := lp.NewStyle().Foreground(lp.Color(orangeColor))
+	launch := m.blendLaunch
+
+	line := warnStyle.Render(fmt.Sprintf("%s was saved by Blender %s, which isn't installed", filepath.Base(launch.path), launch.series))
+	if launch.fallback != nil {
+		line += separator + fmt.Sprintf("%s Open with %s", keyStyle.Render("enter"), launch.fallback.Version)
+	}
+	if i := m.blendDownloadCandidate(); i >= 0 {
+		line += separator + fmt.Sprintf("%s Download %s", keyStyle.Render("d"), m.builds[i].Version)
+	} else {
+		line += separator + fmt.Sprintf("%s Fetch builds", keyStyle.Render("f"))
+	}
+	return line + separator + fmt.Sprintf("%s Cancel", keyStyle.Render("esc"))
+}
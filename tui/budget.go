@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/stats"
+	"fmt"
+	"time"
+)
+
+// budgetState returns the state of the monthly download budget once a download of next bytes finished
+func (m *Model) budgetState(next int64) int {
+	return m.stats.Budget(m.config.DownloadBudget(), next, time.Now())
+}
+
+// budgetUsage describes the download volume of this month against the budget, e.g. "8.1 GB of 10.0 GB"
+func (m *Model) budgetUsage() string {
+	return fmt.Sprintf("%s of %s", model.FormatByteSize(m.stats.DownloadedIn(time.Now())), model.FormatByteSize(m.config.DownloadBudget()))
+}
+
+// needsDownloadConfirm reports whether a manual download waits for a second key press:
+// on a metered connection, or when it takes the month close to or past the download budget
+func (m *Model) needsDownloadConfirm(build model.BlenderBuild) bool {
+	return m.config.Metered || m.budgetState(build.Size) != stats.BudgetOK
+}
+
+// recordTransfer adds a finished download to the transfer history and shows its duration and speeds
+func (m *Model) recordTransfer(t stats.Transfer) {
+	m.showNotice(fmt.Sprintf("Downloaded Blender %s from %s: %s", t.Version, t.Source, t.Summary()))
+	m.stats.AddTransfer(t)
+	if err := m.stats.Save(); err != nil {
+		m.err = fmt.Errorf("failed to save download statistics: %w", err)
+	}
+}
+
+// recordDownload adds a finished download to the volume of this month and warns when the budget runs out
+func (m *Model) recordDownload(bytes int64) {
+	if bytes <= 0 {
+		return
+	}
+	m.stats.AddDownload(bytes, time.Now())
+	if err := m.stats.Save(); err != nil {
+		m.err = fmt.Errorf("failed to save download statistics: %w", err)
+		return
+	}
+	switch m.budgetState(0) {
+	case stats.BudgetExceeded:
+		m.showNotice(fmt.Sprintf("Download budget exceeded: %s this month, automatic downloads are on hold", m.budgetUsage()))
+	case stats.BudgetNear:
+		m.showNotice(fmt.Sprintf("Download budget almost used up: %s this month", m.budgetUsage()))
+	}
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/api"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// staleList is the build list known from the caches, shown at the start until the local scan and the next fetch replace it
+type staleList struct {
+	savedAt time.Time // When the cached online builds were fetched, zero without any
+	scanned bool      // The local builds were replaced by the scanned ones, the online builds are left
+}
+
+// loadBuildList lists the builds known from the caches right away, marked stale until the
+// local scan and the next fetch replace them: the local builds of the last scan, see
+// local.CachedLocalBuilds, with their status against the builds of the last fetched listing.
+func (m *Model) loadBuildList() {
+	if m.commands == nil {
+		return
+	}
+	localBuilds := local.CachedLocalBuilds(m.config.DownloadDir)
+	online, fetchedAt, err := api.LoadCachedBuilds(m.config.VersionFilterFor(m.config.BuildType), m.config.BuildType)
+	if err != nil {
+		online, fetchedAt = nil, time.Time{}
+	}
+	if len(localBuilds) == 0 && len(online) == 0 {
+		return
+	}
+	m.builds = m.applyTagFilter(m.applyVersionFilter(mergeBuildStatus(localBuilds, online, m.config.UpdatePolicy)))
+	m.sortBuilds()
+	m.staleList = &staleList{savedAt: fetchedAt}
+}
+
+// refreshStaleList replaces the local builds of the stale list with the scanned ones.
+// The online builds of the last session stay listed until the next fetch, with their status
+// checked again against the scanned builds; without any the list is current.
+func (m *Model) refreshStaleList(scanned []model.BlenderBuild) tea.Cmd {
+	listed := append([]model.BlenderBuild{}, scanned...)
+	var online []model.BlenderBuild
+	for _, build := range m.builds {
+		if build.Status == model.StateLocal {
+			continue
+		}
+		if !containsBuild(scanned, build) {
+			listed = append(listed, build)
+		}
+		build.Status = model.StateOnline
+		build.Installed = nil
+		online = append(online, build)
+	}
+	m.builds = listed
+	if len(online) == 0 {
+		m.staleList = nil
+		return nil
+	}
+	m.staleList.scanned = true
+	return m.commands.UpdateBuildStatus(append(append([]model.BlenderBuild{}, scanned...), online...))
+}
+
+// containsBuild reports whether builds hold a build of the version and architecture of build
+func containsBuild(builds []model.BlenderBuild, build model.BlenderBuild) bool {
+	for _, b := range builds {
+		if b.Matches(build.Version, build.Architecture) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderStaleBanner creates the banner shown in place of the header while the list is stale
+func renderStaleBanner(width int, stale *staleList) string {
+	text := "STALE · showing the local builds of the last scan · scanning local builds"
+	if !stale.savedAt.IsZero() {
+		text = fmt.Sprintf("STALE · showing the build list of %s · scanning local builds", stale.savedAt.Format("2006-01-02 15:04"))
+	}
+	if stale.scanned {
+		text = fmt.Sprintf("STALE · online builds as of %s · press f to fetch", stale.savedAt.Format("2006-01-02 15:04"))
+	}
+	return lp.NewStyle().
+		Bold(true).
+		Foreground(lp.Color(textColor)).
+		Background(lp.Color(backgroundColor)).
+		Width(width).
+		Align(lp.Center).
+		Render(text)
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// noticeDuration is how long a notice stays in the status bar
+const noticeDuration = 3 * time.Second
+
+// showNotice displays a short-lived message in the status bar
+func (m *Model) showNotice(text string) {
+	m.notice = text
+	m.noticeUntil = time.Now().Add(noticeDuration)
+}
+
+// expireNotice clears the notice once its time is up
+func (m *Model) expireNotice() {
+	if m.notice != "" && time.Now().After(m.noticeUntil) {
+		m.notice = ""
+	}
+}
+
+// updateYank handles the key following the copy key: h hash, u download URL, p install path
+func (m *Model) updateYank(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.yankPending = false
+
+	build, ok := m.selectedBuild()
+	if !ok {
+		return m, nil
+	}
+
+	var label, text string
+	switch msg.String() {
+	case "h":
+		label, text = "hash", build.Hash
+	case "u":
+		label, text = "download URL", build.DownloadURL
+	case "p":
+		if build.Status != model.StateLocal && build.Status != model.StateUpdate {
+			m.err = fmt.Errorf("build %s is not installed", build.Version)
+			return m, nil
+		}
+		dir, err := m.buildDir(build)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		label, text = "install path", dir
+	default:
+		// Any other key cancels the copy
+		return m, nil
+	}
+
+	if text == "" {
+		m.err = fmt.Errorf("build %s has no %s", build.Version, label)
+		return m, nil
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		m.err = fmt.Errorf("failed to copy to clipboard: %w", err)
+		return m, nil
+	}
+	m.err = nil
+	m.showNotice(fmt.Sprintf("Copied %s: %s", label, text))
+	return m, nil
+}
@@ -4,55 +4,120 @@ import (
 	"TUI-Blender-Launcher/api"
 	"TUI-Blender-Launcher/config"
 	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/launch"
 	"TUI-Blender-Launcher/local"
 	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/schedule"
+	"TUI-Blender-Launcher/stats"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/cavaliergopher/grab/v3"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// shutdownTimeout is how long exiting waits for stopped transfers to clean up
+const shutdownTimeout = 10 * time.Second
+
 // DownloadManager handles all download operations with thread-safe state access
 type DownloadManager struct {
-	states map[string]*model.DownloadState
-	cfg    config.Config
+	states    map[string]*model.DownloadState
+	cfg       config.Config
+	msgs      chan<- tea.Msg // Completion messages for the program
+	mu        sync.Mutex     // Guards states, transfers, queue and cfg
+	transfers map[string]*transfer
+	queue     []string       // IDs of the queued downloads, the first starts next
+	wg        sync.WaitGroup // Running transfers and their cleanup, see Shutdown
+	stopping  chan struct{}  // Closed by Shutdown
+}
+
+// transfer is what a download needs to start, or to resume after a pause
+type transfer struct {
+	build model.BlenderBuild
+	cfg   config.Config // Settings changed while the download runs apply to the next one
+	path  string        // Archive being downloaded, kept while paused
+}
+
+// Transfer is a download in progress, paused or queued, as listed in the downloads panel
+type Transfer struct {
+	ID       string
+	Build    model.BlenderBuild
+	State    model.DownloadState
+	QueuePos int // Position in the queue starting at 1, 0 when not queued
 }
 
-// NewDownloadManager creates a new download manager
-func NewDownloadManager(cfg config.Config) *DownloadManager {
+// NewDownloadManager creates a new download manager reporting finished downloads on msgs
+func NewDownloadManager(cfg config.Config, msgs chan<- tea.Msg) *DownloadManager {
 	return &DownloadManager{
-		states: make(map[string]*model.DownloadState),
-		cfg:    cfg,
+		states:    make(map[string]*model.DownloadState),
+		cfg:       cfg,
+		msgs:      msgs,
+		transfers: make(map[string]*transfer),
+		stopping:  make(chan struct{}),
+	}
+}
+
+// send reports a finished download to the program. Once the manager shuts down nothing receives, so it is dropped.
+func (dm *DownloadManager) send(msg tea.Msg) {
+	select {
+	case dm.msgs <- msg:
+	case <-dm.stopping:
 	}
 }
 
-// GetState safely retrieves state for a build
+// GetState returns a snapshot of the state of a build, nil if it has none.
+// The transfers keep updating their own state, the snapshot is safe to read on the UI side.
 func (dm *DownloadManager) GetState(buildID string) *model.DownloadState {
-	return dm.states[buildID]
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	state := dm.states[buildID]
+	if state == nil {
+		return nil
+	}
+	snapshot := *state
+	return &snapshot
 }
 
-// GetAllStates returns a copy of all download states
+// GetAllStates returns snapshots of all download states, see GetState
 func (dm *DownloadManager) GetAllStates() map[string]*model.DownloadState {
-	result := make(map[string]*model.DownloadState)
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	result := make(map[string]*model.DownloadState, len(dm.states))
 	for k, v := range dm.states {
-		result[k] = v
+		snapshot := *v
+		result[k] = &snapshot
 	}
 	return result
 }
 
-// StartDownload begins a new download for a build
-func (dm *DownloadManager) StartDownload(build model.BlenderBuild) tea.Msg {
-	// Create a unique build ID
+// downloadID identifies the download of a build by version and hash.
+// Builds of another architecture than the native one get it appended, they share the hash.
+func downloadID(build model.BlenderBuild) string {
 	buildID := build.Version
 	if build.Hash != "" {
 		buildID = build.Version + "-" + build.Hash[:8]
 	}
+	if build.Architecture != "" && build.Architecture != api.NativeArch() {
+		buildID += "-" + build.Architecture
+	}
+	return buildID
+}
+
+// StartDownload begins a new download for a build, queued while max_downloads are running
+func (dm *DownloadManager) StartDownload(build model.BlenderBuild) tea.Msg {
+	// Create a unique build ID
+	buildID := downloadID(build)
+
+	dm.mu.Lock()
+	// Settings changed while the download runs apply to the next one, it finishes where it started
+	cfg := dm.cfg
 
 	// Clean up previous state if it was Failed or Cancelled before starting anew
 	if state, exists := dm.states[buildID]; exists {
@@ -61,6 +126,7 @@ func (dm *DownloadManager) StartDownload(build model.BlenderBuild) tea.Msg {
 			delete(dm.states, buildID)
 		} else if state.BuildState == model.StateDownloading || state.BuildState == model.StateExtracting {
 			// If already downloading/extracting this exact build, don't start another one
+			dm.mu.Unlock()
 			return nil
 		}
 	}
@@ -75,431 +141,1057 @@ func (dm *DownloadManager) StartDownload(build model.BlenderBuild) tea.Msg {
 		LastUpdated: now,
 		Progress:    0.0,
 		CancelCh:    cancelCh,
+		Queued:      true,
 	}
+	dm.mu.Unlock()
 
 	// Create a temporary directory for downloads if it doesn't exist
-	downloadTempDir := filepath.Join(dm.cfg.DownloadDir, download.DownloadingDir)
+	downloadTempDir := filepath.Join(cfg.DownloadDir, download.DownloadingDir)
 	if err := os.MkdirAll(downloadTempDir, 0750); err != nil {
 		// Handle error creating download directory
-		dm.states[buildID].BuildState = model.StateFailed
-		programCh <- downloadCompleteMsg{
+		dm.setBuildState(buildID, model.StateFailed)
+		dm.send(downloadCompleteMsg{
 			buildVersion: build.Version,
+			buildArch:    build.Architecture,
 			err:          fmt.Errorf("failed to create download directory: %w", err),
-		}
+		})
 		return nil
 	}
 
-	// Start the download in a goroutine
-	go func() {
-		// Get the filename from the download URL
-		downloadFileName := filepath.Base(build.DownloadURL)
-		downloadPath := filepath.Join(downloadTempDir, downloadFileName)
+	dm.mu.Lock()
+	dm.transfers[buildID] = &transfer{
+		build: build,
+		cfg:   cfg,
+		path:  filepath.Join(downloadTempDir, filepath.Base(build.DownloadURL)),
+	}
+	dm.queue = append(dm.queue, buildID)
+	dm.saveQueue()
+	dm.mu.Unlock()
 
-		// Set up the grab library context for cancellation
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
+	dm.StartQueued()
+	return nil
+}
 
-		// Create a go routine to handle cancellation via our channel
-		go func() {
-			select {
-			case <-cancelCh:
-				cancel() // Cancel grab request if our channel is closed
-			case <-ctx.Done():
-				// Context done normally
-			}
-		}()
+// StartArchiveInstall installs a build from the archive cache. It shows like a download in its extraction phase
+// but isn't queued, as it needs no network.
+func (dm *DownloadManager) StartArchiveInstall(archive download.CachedArchive) tea.Msg {
+	build := archive.Build
+	buildID := downloadID(build)
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	cfg := dm.cfg
+	if state := dm.states[buildID]; state != nil && (state.BuildState == model.StateDownloading || state.BuildState == model.StateExtracting) {
+		return nil
+	}
+	if dm.shuttingDown() {
+		return nil
+	}
+
+	now := time.Now()
+	cancelCh := make(chan struct{})
+	dm.states[buildID] = &model.DownloadState{
+		BuildID:     buildID,
+		BuildState:  model.StateExtracting,
+		StartTime:   now,
+		LastUpdated: now,
+		CancelCh:    cancelCh,
+	}
+	// No partial file to keep, the archive stays in the cache
+	dm.transfers[buildID] = &transfer{build: build, cfg: cfg}
 
-		// Create the grab client with extended timeouts
-		client := grab.NewClient()
-		client.UserAgent = "TUI-Blender-Launcher"
+	dm.wg.Add(1)
+	go func() {
+		defer dm.wg.Done()
+		extractedPath, err := download.InstallFromArchive(archive, cfg.DownloadDir, dm.installProgress(buildID, cancelCh), dm.installPhase(buildID), dm.conflictResolver(build, cfg, cancelCh), cancelCh)
+		dm.finishInstall(buildID, build, cfg, extractedPath, nil, err)
+	}()
+	return nil
+}
 
-		// Set custom HTTP client with timeouts
-		httpClient := &http.Client{
-			Timeout: 5 * time.Minute,
-			Transport: &http.Transport{
-				IdleConnTimeout:     2 * time.Minute,
-				DisableCompression:  false,
-				TLSHandshakeTimeout: 1 * time.Minute,
-			},
+// StartQueued starts queued downloads while fewer than max_downloads are running
+func (dm *DownloadManager) StartQueued() {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	if dm.shuttingDown() {
+		// Queued downloads stay in the queue file for the next start
+		return
+	}
+	started := false
+	for len(dm.queue) > 0 && (dm.cfg.MaxDownloads == 0 || dm.running() < dm.cfg.MaxDownloads) {
+		buildID := dm.queue[0]
+		dm.queue = dm.queue[1:]
+		started = true
+		state := dm.states[buildID]
+		t := dm.transfers[buildID]
+		if state == nil || t == nil || !state.Queued {
+			continue
 		}
-		client.HTTPClient = httpClient
+		state.Queued = false
+		state.LastUpdated = time.Now()
+		dm.wg.Add(1)
+		go func(cancelCh chan struct{}) {
+			defer dm.wg.Done()
+			dm.run(buildID, t, cancelCh)
+		}(state.CancelCh)
+	}
+	if started {
+		dm.saveQueue()
+	}
+}
 
-		// Create the request
-		req, err := grab.NewRequest(downloadPath, build.DownloadURL)
-		if err != nil {
-			dm.states[buildID].BuildState = model.StateFailed
-			programCh <- downloadCompleteMsg{
-				buildVersion: build.Version,
-				err:          fmt.Errorf("failed to create download request: %w", err),
-			}
-			return
+// saveQueue writes the unfinished downloads for RestoreQueue after a restart: the started ones, then the queued
+// ones in their order. A download stays in the file until it completes. Call with mu held.
+// Once shutting down the file keeps what Shutdown saved. It only restores the queue, so failing to write it is not an error.
+func (dm *DownloadManager) saveQueue() {
+	if dm.shuttingDown() {
+		return
+	}
+	q := &schedule.Queue{}
+	for _, id := range append(dm.started(), dm.queue...) {
+		// Installs from the archive cache have no partial file and aren't restored
+		if t := dm.transfers[id]; t != nil && t.path != "" {
+			q.Builds = append(q.Builds, t.build)
 		}
-		req = req.WithContext(ctx)
+	}
+	_ = q.Save()
+}
 
-		// Start download
-		resp := client.Do(req)
+// started returns the downloads and extractions in progress that aren't queued, running or paused,
+// by start time. Call with mu held.
+func (dm *DownloadManager) started() []string {
+	var ids []string
+	for id, state := range dm.states {
+		if (state.BuildState == model.StateDownloading || state.BuildState == model.StateExtracting) && !state.Queued {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return dm.states[ids[i]].StartTime.Before(dm.states[ids[j]].StartTime) })
+	return ids
+}
 
-		// Use a ticker to update the download state
-		var lastBytes int64
-		var lastTime time.Time
-		var speedSamples []float64
-		var speed float64
-		var speedUpdateCounter int
+// running returns the number of downloads and extractions neither paused nor queued. Call with mu held.
+func (dm *DownloadManager) running() int {
+	count := 0
+	for _, state := range dm.states {
+		if (state.BuildState == model.StateDownloading || state.BuildState == model.StateExtracting) && !state.Paused && !state.Queued {
+			count++
+		}
+	}
+	return count
+}
 
-		// Use a slightly longer interval for UI updates to reduce flickering
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
+// run downloads and extracts a build until it finishes or cancelCh is closed.
+// A download resumed after a pause continues the partial file.
+func (dm *DownloadManager) run(buildID string, t *transfer, cancelCh chan struct{}) {
+	build, cfg, downloadPath := t.build, t.cfg, t.path
+	dm.installPhase(buildID)(model.PhaseDownload)
 
-	downloadLoop:
-		for {
-			select {
-			case <-ticker.C:
-				// Update download state with grab response status
-				now := time.Now()
-				state := dm.states[buildID]
-				if state == nil {
-					break downloadLoop // State was deleted, exit loop
-				}
+	// Set up the grab library context for cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-				downloaded := resp.BytesComplete()
-				total := resp.Size()
+	// Create a go routine to handle cancellation via our channel
+	go func() {
+		select {
+		case <-cancelCh:
+			cancel() // Cancel grab request if our channel is closed
+		case <-ctx.Done():
+			// Context done normally
+		}
+	}()
 
-				// Calculate progress percentage
-				percent := 0.0
-				if total > 0 {
-					percent = float64(downloaded) / float64(total)
-				}
+	// Create the grab client with extended timeouts
+	client := grab.NewClient()
+	client.UserAgent = "TUI-Blender-Launcher"
+
+	// Set custom HTTP client with timeouts, keeping the proxy and network settings of the default transport
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.IdleConnTimeout = 2 * time.Minute
+	transport.DisableCompression = false
+	transport.TLSHandshakeTimeout = 1 * time.Minute
+	httpClient := &http.Client{
+		Timeout:   5 * time.Minute,
+		Transport: transport,
+	}
+	client.HTTPClient = httpClient
 
-				// Calculate download speed with moving average for smoothing
-				if !lastTime.IsZero() {
-					// Only update speed calculation every 2 ticks to further reduce fluctuations
-					speedUpdateCounter++
-					if speedUpdateCounter >= 2 {
-						speedUpdateCounter = 0
+	// Create the request
+	req, err := grab.NewRequest(downloadPath, build.DownloadURL)
+	if err != nil {
+		dm.setBuildState(buildID, model.StateFailed)
+		dm.send(downloadCompleteMsg{
+			buildVersion: build.Version,
+			buildArch:    build.Architecture,
+			err:          fmt.Errorf("failed to create download request: %w", err),
+		})
+		return
+	}
+	req = req.WithContext(ctx)
+
+	// Start download
+	resp := client.Do(req)
+
+	// Use a ticker to update the download state
+	var lastBytes int64
+	var lastTime time.Time
+	var speedSamples []float64
+	var speed float64
+	var speedUpdateCounter int
+	var peakSpeed float64
+
+	// Use a slightly longer interval for UI updates to reduce flickering
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+downloadLoop:
+	for {
+		select {
+		case <-ticker.C:
+			// Update download state with grab response status
+			now := time.Now()
+			dm.mu.Lock()
+			state := dm.states[buildID]
+			if state == nil || state.CancelCh != cancelCh {
+				dm.mu.Unlock()
+				break downloadLoop // State was deleted or the transfer paused, exit loop
+			}
 
-						bytesDiff := downloaded - lastBytes
-						timeDiff := now.Sub(lastTime).Seconds()
+			downloaded := resp.BytesComplete()
+			total := resp.Size()
 
-						// Calculate current sample
-						currentSpeed := float64(bytesDiff) / timeDiff
+			// Calculate progress percentage
+			percent := 0.0
+			if total > 0 {
+				percent = float64(downloaded) / float64(total)
+			}
 
-						// Add to samples for moving average (keep last 3 samples)
-						speedSamples = append(speedSamples, currentSpeed)
-						if len(speedSamples) > 3 {
-							speedSamples = speedSamples[1:]
-						}
+			// Calculate download speed with moving average for smoothing
+			if !lastTime.IsZero() {
+				// Only update speed calculation every 2 ticks to further reduce fluctuations
+				speedUpdateCounter++
+				if speedUpdateCounter >= 2 {
+					speedUpdateCounter = 0
 
-						// Calculate average speed from samples
-						speed = 0
-						for _, s := range speedSamples {
-							speed += s
-						}
-						speed /= float64(len(speedSamples))
+					bytesDiff := downloaded - lastBytes
+					timeDiff := now.Sub(lastTime).Seconds()
+
+					// Calculate current sample
+					currentSpeed := float64(bytesDiff) / timeDiff
 
-						lastBytes = downloaded
-						lastTime = now
+					// Add to samples for moving average (keep last 3 samples)
+					speedSamples = append(speedSamples, currentSpeed)
+					if len(speedSamples) > 3 {
+						speedSamples = speedSamples[1:]
 					}
-				} else if lastTime.IsZero() {
+
+					// Calculate average speed from samples
+					speed = 0
+					for _, s := range speedSamples {
+						speed += s
+					}
+					speed /= float64(len(speedSamples))
+					peakSpeed = max(peakSpeed, speed)
+
 					lastBytes = downloaded
 					lastTime = now
 				}
+			} else if lastTime.IsZero() {
+				lastBytes = downloaded
+				lastTime = now
+			}
 
-				// Update state
-				state.LastUpdated = now
-				state.Progress = percent
-				state.Current = downloaded
-				state.Total = total
-				state.Speed = speed
-
-			case <-resp.Done:
-				// Download completed or failed
-				if err := resp.Err(); err != nil {
-					// Handle download error
-					state := dm.states[buildID]
-					if state != nil {
-						// Check if this was a cancellation
-						if errors.Is(err, context.Canceled) {
-							state.BuildState = model.StateCancelled
-						} else {
-							state.BuildState = model.StateFailed
-							state.Progress = 0.0
-						}
+			// Update state
+			state.LastUpdated = now
+			state.Progress = percent
+			state.Current = downloaded
+			state.Total = total
+			state.Speed = speed
+			dm.mu.Unlock()
+
+		case <-resp.Done:
+			// Download completed or failed
+			if err := resp.Err(); err != nil {
+				// Handle download error
+				dm.mu.Lock()
+				state := dm.states[buildID]
+				if state != nil && state.CancelCh != cancelCh {
+					// Paused: the partial file is kept and another transfer resumes it
+					dm.mu.Unlock()
+					return
+				}
+				if state != nil {
+					// Check if this was a cancellation
+					if errors.Is(err, context.Canceled) {
+						state.BuildState = model.StateCancelled
+					} else {
+						state.BuildState = model.StateFailed
+						state.Progress = 0.0
 					}
+				}
+				dm.saveQueue()
+				dm.mu.Unlock()
 
-					// Clean up partial download
+				// Clean up partial download, at once when shutting down a failed one. Downloads stopped
+				// by Shutdown keep theirs, they resume from it at the next start.
+				if !dm.shuttingDown() {
+					dm.wg.Add(1)
 					go func() {
-						time.Sleep(500 * time.Millisecond) // Brief delay to allow UI update
+						defer dm.wg.Done()
+						select {
+						case <-time.After(500 * time.Millisecond): // Brief delay to allow UI update
+						case <-dm.stopping:
+						}
 						_ = os.RemoveAll(downloadPath)
 					}()
-
-					programCh <- downloadCompleteMsg{
-						buildVersion: build.Version,
-						err:          err,
-					}
-					return
 				}
 
-				// Download completed successfully, now proceed to extraction
-				state := dm.states[buildID]
-				if state != nil {
-					state.BuildState = model.StateExtracting
-					state.Progress = 0.0 // Reset progress for extraction phase
-				}
-
-				// Setup extraction progress callback
-				extractionAdapter := func(downloadedBytes, totalBytes int64) {
-					if totalBytes > 0 {
-						// Convert to estimation progress (0.0-1.0)
-						progress := float64(downloadedBytes) / float64(totalBytes)
+				dm.send(downloadCompleteMsg{
+					buildVersion: build.Version,
+					buildArch:    build.Architecture,
+					err:          err,
+				})
+				return
+			}
 
-						// Update state
-						state := dm.states[buildID]
-						if state == nil {
-							return
-						}
+			// Download completed successfully, sum it up before the extraction
+			source := req.URL().Host
+			if resp.HTTPResponse != nil && resp.HTTPResponse.Request != nil {
+				source = resp.HTTPResponse.Request.URL.Host // The host that served it after redirects
+			}
+			transferred := &stats.Transfer{
+				Version:   build.Version,
+				Arch:      build.Architecture,
+				Source:    source,
+				Bytes:     int64(resp.BytesPerSecond() * resp.Duration().Seconds()),
+				Duration:  resp.Duration(),
+				PeakSpeed: max(peakSpeed, resp.BytesPerSecond()),
+				Finished:  time.Now(),
+			}
 
-						select {
-						case <-cancelCh:
-							return
-						default:
-						}
+			// Now proceed to verification and extraction, each phase resets the progress
+			extractedPath, err := download.DownloadAndExtractBuild(build, cfg.DownloadDir, dm.installProgress(buildID, cancelCh), dm.installPhase(buildID), dm.conflictResolver(build, cfg, cancelCh), cancelCh)
 
-						now := time.Now()
-						state.LastUpdated = now
-						state.Progress = progress
-						state.Current = downloadedBytes
-						state.Total = totalBytes
-						state.BuildState = model.StateExtracting
-					}
-				}
+			dm.finishInstall(buildID, build, cfg, extractedPath, transferred, err)
+			return
 
-				// Start extraction
-				extractedPath, err := download.DownloadAndExtractBuild(build, dm.cfg.DownloadDir, extractionAdapter, cancelCh)
+		case <-cancelCh:
+			// Download was cancelled; once grab closed the file, remove it unless the download was paused
+			// or stopped by Shutdown
+			<-resp.Done
+			dm.mu.Lock()
+			state := dm.states[buildID]
+			paused := state != nil && state.CancelCh != cancelCh
+			dm.mu.Unlock()
+			if !paused && !dm.shuttingDown() {
+				_ = os.RemoveAll(downloadPath)
+			}
+			break downloadLoop
+		}
+	}
+}
 
-				// Update final state based on extraction result
-				state = dm.states[buildID]
-				if state == nil {
-					return
-				}
+// setBuildState moves the download state of buildID to buildState, if it has one
+func (dm *DownloadManager) setBuildState(buildID string, buildState model.BuildState) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	if state := dm.states[buildID]; state != nil {
+		state.BuildState = buildState
+	}
+}
 
-				if err != nil {
-					// Check if this was a cancellation
-					if errors.Is(err, download.ErrCancelled) {
-						state.BuildState = model.StateCancelled
-					} else {
-						// Any other error should mark as failed
-						state.BuildState = model.StateFailed
-						state.Progress = 0.0
-					}
-				} else {
-					state.BuildState = model.StateLocal
-					state.Progress = 1.0
-				}
+// installPhase returns the phase callback of an install, moving the download state of buildID to the next phase.
+// The phases after the download show as extracting.
+func (dm *DownloadManager) installPhase(buildID string) download.PhaseFunc {
+	return func(phase model.InstallPhase) {
+		dm.mu.Lock()
+		defer dm.mu.Unlock()
+		state := dm.states[buildID]
+		if state == nil {
+			return
+		}
+		now := time.Now()
+		state.EnterPhase(phase, now)
+		state.LastUpdated = now
+		if phase != model.PhaseDownload {
+			state.BuildState = model.StateExtracting
+		}
+	}
+}
 
-				// Send completion message
-				programCh <- downloadCompleteMsg{
-					buildVersion:  build.Version,
-					extractedPath: extractedPath,
-					err:           err,
-				}
+// installProgress returns the progress callback of the phases after the download, updating the download state of buildID
+func (dm *DownloadManager) installProgress(buildID string, cancelCh chan struct{}) download.ProgressCallback {
+	return func(downloadedBytes, totalBytes int64) {
+		if totalBytes > 0 {
+			// Convert to estimation progress (0.0-1.0)
+			progress := float64(downloadedBytes) / float64(totalBytes)
+
+			// Update state
+			dm.mu.Lock()
+			defer dm.mu.Unlock()
+			state := dm.states[buildID]
+			if state == nil {
 				return
+			}
 
+			select {
 			case <-cancelCh:
-				// Download was cancelled
-				break downloadLoop
+				return
+			default:
 			}
+
+			now := time.Now()
+			state.LastUpdated = now
+			state.Progress = progress
+			state.Current = downloadedBytes
+			state.Total = totalBytes
 		}
-	}()
+	}
+}
 
-	return nil
+// finishInstall smoke tests and probes a freshly installed build, records the outcome in its download state
+// and reports it to the program. transferred sums up the download, nil for installs from the archive cache.
+func (dm *DownloadManager) finishInstall(buildID string, build model.BlenderBuild, cfg config.Config, extractedPath string, transferred *stats.Transfer, err error) {
+	// Check that the build starts, then probe it once; probe failures only mean no introspection data.
+	// Builds of a sandboxed source run in their sandbox, they aren't run at all when it isn't available.
+	var smokeTest *model.SmokeTestResult
+	sandbox, sandboxErr := launch.SandboxArgs(cfg.Sandbox[build.SourceLabel()], extractedPath)
+	if err == nil && sandboxErr == nil && cfg.SmokeTest {
+		if tested, testErr := local.SmokeTestAndSaveBuild(extractedPath, sandbox); testErr == nil {
+			smokeTest = tested.SmokeTest
+		}
+	}
+	if err == nil && sandboxErr == nil && (smokeTest == nil || smokeTest.Passed) {
+		_, _ = local.ProbeAndSaveBuild(extractedPath, sandbox)
+		if cfg.GPUProbe {
+			_, _ = local.ProbeAndSaveGPU(extractedPath, sandbox)
+		}
+	}
+
+	// Update final state based on extraction result
+	dm.mu.Lock()
+	state := dm.states[buildID]
+	if state == nil {
+		dm.mu.Unlock()
+		return
+	}
+	// The finalize phase ends with the smoke test and probes
+	state.EndPhase(time.Now())
+	if transferred != nil {
+		transferred.Phases = state.Phases
+	}
+
+	if err != nil {
+		// Check if this was a cancellation
+		if errors.Is(err, download.ErrCancelled) {
+			state.BuildState = model.StateCancelled
+		} else {
+			// Any other error should mark as failed
+			state.BuildState = model.StateFailed
+			state.Progress = 0.0
+		}
+	} else {
+		state.SmokeTest = smokeTest
+		state.BuildState = model.StateLocal
+		state.Progress = 1.0
+	}
+	dm.saveQueue()
+	dm.mu.Unlock()
+
+	// Send completion message
+	dm.send(downloadCompleteMsg{
+		buildVersion:  build.Version,
+		buildArch:     build.Architecture,
+		extractedPath: extractedPath,
+		smokeTest:     smokeTest,
+		fromCache:     transferred == nil,
+		transfer:      transferred,
+		err:           err,
+	})
+}
+
+// conflictResolver returns how an install of build handles a directory in its way that the launcher didn't install:
+// by the conflict_policy, or for "ask" by asking in the TUI. Cancelling the download while the question is open aborts.
+func (dm *DownloadManager) conflictResolver(build model.BlenderBuild, cfg config.Config, cancelCh <-chan struct{}) download.ConflictFunc {
+	if cfg.ConflictPolicy != "" && cfg.ConflictPolicy != config.ConflictAsk {
+		return download.ConflictPolicy(cfg.ConflictPolicy)
+	}
+	return func(dir string) download.Resolution {
+		reply := make(chan download.Resolution, 1)
+		dm.send(conflictMsg{build: build, dir: dir, reply: reply})
+		select {
+		case resolution := <-reply:
+			return resolution
+		case <-cancelCh:
+		case <-dm.stopping:
+		}
+		return download.ResolveAbort
+	}
+}
+
+// PauseDownload stops a running download, keeping the partial file for ResumeDownload.
+// Returns false when the build isn't downloading, extraction can't be paused.
+func (dm *DownloadManager) PauseDownload(buildID string) bool {
+	dm.mu.Lock()
+	state := dm.states[buildID]
+	if state == nil || state.BuildState != model.StateDownloading || state.Paused || state.Queued {
+		dm.mu.Unlock()
+		return false
+	}
+	state.Paused = true
+	state.Speed = 0
+	close(state.CancelCh)
+	// The stopped transfer keeps the closed channel, cancelling the paused download closes this one
+	state.CancelCh = make(chan struct{})
+	dm.mu.Unlock()
+	dm.StartQueued()
+	return true
+}
+
+// ResumeDownload continues a paused download, first in the queue while max_downloads are running
+func (dm *DownloadManager) ResumeDownload(buildID string) {
+	dm.mu.Lock()
+	state := dm.states[buildID]
+	if state == nil || !state.Paused {
+		dm.mu.Unlock()
+		return
+	}
+	state.Paused = false
+	state.Queued = true
+	dm.queue = append([]string{buildID}, dm.queue...)
+	dm.saveQueue()
+	dm.mu.Unlock()
+	dm.StartQueued()
+}
+
+// MoveQueued moves a queued download delta places towards the back of the queue, or the front when negative,
+// stopping at either end. Returns how many places it moved, 0 when the build isn't queued.
+func (dm *DownloadManager) MoveQueued(buildID string, delta int) int {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	for i, id := range dm.queue {
+		if id != buildID {
+			continue
+		}
+		j := min(max(i+delta, 0), len(dm.queue)-1)
+		if j == i {
+			return 0
+		}
+		dm.queue = append(dm.queue[:i], dm.queue[i+1:]...)
+		dm.queue = append(dm.queue[:j], append([]string{buildID}, dm.queue[j:]...)...)
+		dm.saveQueue()
+		return j - i
+	}
+	return 0
+}
+
+// Transfers returns the running and paused downloads by start time, followed by the queued ones in queue order
+func (dm *DownloadManager) Transfers() []Transfer {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	queuePos := make(map[string]int)
+	for i, id := range dm.queue {
+		queuePos[id] = i + 1
+	}
+	var transfers []Transfer
+	for id, state := range dm.states {
+		t := dm.transfers[id]
+		if t == nil || (state.BuildState != model.StateDownloading && state.BuildState != model.StateExtracting) {
+			continue
+		}
+		transfers = append(transfers, Transfer{ID: id, Build: t.build, State: *state, QueuePos: queuePos[id]})
+	}
+	sort.Slice(transfers, func(i, j int) bool {
+		a, b := transfers[i], transfers[j]
+		if (a.QueuePos == 0) != (b.QueuePos == 0) {
+			return a.QueuePos == 0
+		}
+		if a.QueuePos != b.QueuePos {
+			return a.QueuePos < b.QueuePos
+		}
+		return a.State.StartTime.Before(b.State.StartTime)
+	})
+	return transfers
+}
+
+// heldPaths returns the archives of paused and queued downloads, and whether a download or extraction is running
+func (dm *DownloadManager) heldPaths() (map[string]bool, bool) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	held := make(map[string]bool)
+	for id, state := range dm.states {
+		t := dm.transfers[id]
+		if t == nil || (state.BuildState != model.StateDownloading && state.BuildState != model.StateExtracting) {
+			continue
+		}
+		if !state.Paused && !state.Queued {
+			return nil, true
+		}
+		held[t.path] = true
+	}
+	return held, false
 }
 
 // CancelDownload stops an in-progress download
 func (dm *DownloadManager) CancelDownload(buildID string) {
+	dm.mu.Lock()
 	state := dm.states[buildID]
 	if state == nil {
+		dm.mu.Unlock()
 		return
 	}
 
+	paused := state.Paused
 	close(state.CancelCh)
 	state.BuildState = model.StateCancelled
 	state.Progress = 0.0 // Reset progress
+	state.Paused = false
+	state.Queued = false
+
+	for i, id := range dm.queue {
+		if id == buildID {
+			dm.queue = append(dm.queue[:i], dm.queue[i+1:]...)
+			break
+		}
+	}
+	dm.saveQueue()
+	t := dm.transfers[buildID]
+	dm.mu.Unlock()
+	// A paused download has no transfer left to clean up its partial file
+	if paused && t != nil {
+		_ = os.RemoveAll(t.path)
+	}
+	dm.StartQueued()
 
 	// Don't delete the state so we can track that it was cancelled
 	// Keep it so it can be displayed with "Cancelled" status
 }
 
-// Commands generates tea commands for the TUI
+// CancelAll stops every in-progress download and extraction.
+// Returns the IDs of the builds that were cancelled.
+func (dm *DownloadManager) CancelAll() []string {
+	var cancelled []string
+	dm.mu.Lock()
+	for id, state := range dm.states {
+		if state.BuildState == model.StateDownloading || state.BuildState == model.StateExtracting {
+			cancelled = append(cancelled, id)
+		}
+	}
+	dm.mu.Unlock()
+	for _, id := range cancelled {
+		dm.CancelDownload(id)
+	}
+	return cancelled
+}
+
+// shuttingDown reports whether Shutdown was called
+func (dm *DownloadManager) shuttingDown() bool {
+	select {
+	case <-dm.stopping:
+		return true
+	default:
+		return false
+	}
+}
+
+// Shutdown stops the downloads and extractions in progress before the launcher exits and waits up to
+// timeout for them to clean up: partial extractions are rolled back and their journal entries cleared.
+// Running and paused downloads keep their partial files and are saved to the queue file ahead of the
+// queued ones, so they all resume at the next start.
+func (dm *DownloadManager) Shutdown(timeout time.Duration) error {
+	dm.mu.Lock()
+	if dm.shuttingDown() {
+		dm.mu.Unlock()
+		return nil
+	}
+	dm.saveQueue()
+	close(dm.stopping)
+	for _, id := range dm.started() {
+		state := dm.states[id]
+		if !state.Paused {
+			close(state.CancelCh)
+		}
+		state.BuildState = model.StateCancelled
+	}
+	dm.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		dm.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("downloads still stopping after %v, interrupted installs are recovered at the next start", timeout)
+	}
+}
+
+// pruneStates drops the states of finished downloads, keeping those in progress.
+// Terminal states like Failed/Cancelled are discarded.
+func (dm *DownloadManager) pruneStates() {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	for id, state := range dm.states {
+		if state.BuildState != model.StateDownloading && state.BuildState != model.StateExtracting {
+			delete(dm.states, id)
+		}
+	}
+}
+
+// ActiveCount returns the number of in-progress downloads and extractions
+func (dm *DownloadManager) ActiveCount() int {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	count := 0
+	for _, state := range dm.states {
+		if state.BuildState == model.StateDownloading || state.BuildState == model.StateExtracting {
+			count++
+		}
+	}
+	return count
+}
+
+// Commands generates tea commands for the TUI.
+// It holds the services that live as long as the program: the config, the download manager
+// and the channel background goroutines send their messages on. Create it once and share it,
+// a second instance wouldn't see the downloads of the first.
 type Commands struct {
 	cfg       config.Config
 	downloads *DownloadManager
+	msgs      chan tea.Msg
 }
 
-// NewCommands creates a new Commands instance
+// NewCommands creates the commands and services for a program
 func NewCommands(cfg config.Config) *Commands {
+	msgs := make(chan tea.Msg)
 	return &Commands{
 		cfg:       cfg,
-		downloads: NewDownloadManager(cfg),
+		downloads: NewDownloadManager(cfg, msgs),
+		msgs:      msgs,
 	}
 }
 
+// Shutdown stops the transfers in progress before exit, waiting up to shutdownTimeout for their cleanup.
+// See DownloadManager.Shutdown.
+func (c *Commands) Shutdown() error {
+	return c.downloads.Shutdown(shutdownTimeout)
+}
+
+// SetConfig applies a changed config to later commands and downloads.
+// The download manager is kept, so downloads in progress stay tracked.
+func (c *Commands) SetConfig(cfg config.Config) {
+	c.cfg = cfg
+	c.downloads.mu.Lock()
+	c.downloads.cfg = cfg
+	c.downloads.mu.Unlock()
+}
+
 // FetchBuilds fetches the list of builds from the API.
 func (c *Commands) FetchBuilds() tea.Cmd {
+	cfg := c.cfg
 	return func() tea.Msg {
 		// Clean up download states, keeping only active ones
-		newStates := make(map[string]*model.DownloadState)
-		if c.downloads != nil && c.downloads.states != nil {
-			for id, state := range c.downloads.states {
-				// Only keep states that are actively in progress, discard terminal states like Failed/Cancelled.
-				if state.BuildState == model.StateDownloading || state.BuildState == model.StateExtracting {
-					newStates[id] = state
-				}
-			}
-			c.downloads.states = newStates // Atomically replace the map
+		if c.downloads != nil {
+			c.downloads.pruneStates()
 		}
 
 		// Create API instance
 		a := api.NewAPI()
-		builds, err := a.FetchBuilds(c.cfg.VersionFilter, c.cfg.BuildType)
-		return buildsFetchedMsg{builds, err}
+
+		// The cached listing is the previous fetch, possibly from an earlier run.
+		// When the builder is unreachable, its builds are listed instead.
+		versionFilter := cfg.VersionFilterFor(cfg.BuildType)
+		previous, fetchedAt, cacheErr := api.LoadCachedBuilds(versionFilter, cfg.BuildType)
+		if err := a.CheckConnectivity(); err != nil {
+			return buildsFetchedMsg{builds: previous, err: cacheErr, offline: true, cachedAt: fetchedAt}
+		}
+
+		builds, notModified, err := a.FetchBuildsConditional(versionFilter, cfg.BuildType)
+		var newBuilds []model.BlenderBuild
+		if err == nil && cacheErr == nil && !notModified {
+			newBuilds = model.NewBuilds(previous, builds, fetchedAt)
+		}
+		return buildsFetchedMsg{builds: builds, err: err, notModified: notModified, newBuilds: newBuilds}
 	}
 }
 
-// ScanLocalBuilds creates a command to scan for local builds
-func (c *Commands) ScanLocalBuilds() tea.Cmd {
+// CheckConnectivity creates a command to check whether the builder is reachable
+func (c *Commands) CheckConnectivity() tea.Cmd {
 	return func() tea.Msg {
-		builds, err := local.ScanLocalBuilds(c.cfg.DownloadDir)
-		return localBuildsScannedMsg{builds: builds, err: err}
+		return connectivityMsg{err: api.NewAPI().CheckConnectivity()}
 	}
 }
 
-// CheckUpdateAvailable determines if an update is available for a local build by comparing build dates, branch, and release_cycle.
-func CheckUpdateAvailable(localBuild, onlineBuild model.BlenderBuild) model.BuildState {
-	// If online build hash is present and matches local build hash, treat as identical (no update)
-	if onlineBuild.Hash != "" && onlineBuild.Hash == localBuild.Hash {
-		return model.StateLocal
+// CheckDownloadFilesystem creates a command to find out whether the download directory is on a network share,
+// where builds are installed differently
+func (c *Commands) CheckDownloadFilesystem() tea.Cmd {
+	dir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		return filesystemCheckedMsg{warning: download.DetectFilesystem(dir).Warning(dir)}
 	}
+}
 
-	// Ensure version, branch, and release_cycle all match; if not, treat as no local match
-	if localBuild.Version != onlineBuild.Version || localBuild.Branch != onlineBuild.Branch || localBuild.ReleaseCycle != onlineBuild.ReleaseCycle {
-		return model.StateOnline
+// ScanLocalBuilds creates a command to scan for local builds.
+// Each build is reported as it is found with a localScanProgressMsg, ending with a localBuildsScannedMsg.
+func (c *Commands) ScanLocalBuilds() tea.Cmd {
+	cfg := c.cfg
+	return func() tea.Msg {
+		ch := make(chan tea.Msg)
+		go func() {
+			builds, err := local.ScanLocalBuildsFunc(cfg.DownloadDir, func(p local.ScanProgress) {
+				ch <- localScanProgressMsg{progress: p, next: ch}
+			})
+			if err == nil {
+				builds, err = local.MergeSharedBuilds(builds, cfg.SharedDir)
+			}
+			ch <- localBuildsScannedMsg{builds: builds, err: err}
+		}()
+		return <-ch
 	}
+}
 
-	// If local build date is not set, assume update is available
-	if localBuild.BuildDate.Time().IsZero() {
-		return model.StateUpdate
-	}
-	if onlineBuild.BuildDate.Time().IsZero() {
-		return model.StateOnline
+// waitForScan creates a command receiving the next message of a running local scan
+func waitForScan(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
 	}
+}
+
+// CheckUpdateAvailable determines if an update is available for a local build under the configured update policy
+func (c *Commands) CheckUpdateAvailable(localBuild, onlineBuild model.BlenderBuild) model.BuildState {
+	return model.CheckUpdate(localBuild, onlineBuild, c.cfg.UpdatePolicy).Status
+}
 
-	if onlineBuild.BuildDate.Time().After(localBuild.BuildDate.Time()) {
-		return model.StateUpdate
+// archKey appends the architecture of a build to a lookup key.
+// Builds installed before the architecture was recorded are native ones.
+func archKey(key string, build model.BlenderBuild) string {
+	arch := build.Architecture
+	if arch == "" {
+		arch = api.NativeArch()
 	}
-	return model.StateLocal
+	return key + "|" + arch
 }
 
 // UpdateBuildStatus creates a command to update status of builds based on local scan
 func (c *Commands) UpdateBuildStatus(onlineBuilds []model.BlenderBuild) tea.Cmd {
+	cfg := c.cfg
 	return func() tea.Msg {
-		localBuilds, err := local.ScanLocalBuilds(c.cfg.DownloadDir)
+		localBuilds, err := local.ScanLocalBuilds(cfg.DownloadDir)
+		if err == nil {
+			localBuilds, err = local.MergeSharedBuilds(localBuilds, cfg.SharedDir)
+		}
 		if err != nil {
 			return errMsg{fmt.Errorf("failed local scan during status update: %w", err)}
 		}
+		return buildsUpdatedMsg{builds: mergeBuildStatus(localBuilds, onlineBuilds, cfg.UpdatePolicy)}
+	}
+}
 
-		// Create maps for quick lookup by version and hash
-		localBuildMap := make(map[string]model.BlenderBuild)
-		localBuildHashMap := make(map[string]model.BlenderBuild)
-		for _, build := range localBuilds {
-			localBuildMap[build.Version] = build
-			if build.Hash != "" {
-				localBuildHashMap[build.Hash] = build
-			}
+// mergeBuildStatus lists the online builds with their status against the local builds: local when
+// installed, update when a newer build of an installed version under the update policy. Local builds
+// not listed online are kept.
+func mergeBuildStatus(localBuilds, onlineBuilds []model.BlenderBuild, updatePolicy string) []model.BlenderBuild {
+	// Create maps for quick lookup by version and hash; builds of another architecture are separate installs
+	localBuildMap := make(map[string]model.BlenderBuild)
+	localBuildHashMap := make(map[string]model.BlenderBuild)
+	for _, build := range localBuilds {
+		localBuildMap[archKey(build.Version, build)] = build
+		if build.Hash != "" {
+			localBuildHashMap[archKey(build.Hash, build)] = build
 		}
+	}
 
-		// Group online builds by composite key: version|branch|releaseCycle
-		grouped := make(map[string]model.BlenderBuild)
-		for _, onlineBuild := range onlineBuilds {
-			var localBuild *model.BlenderBuild
-			status := model.StateOnline
-
-			// First try to find exact match by hash
-			if onlineBuild.Hash != "" {
-				if lb, found := localBuildHashMap[onlineBuild.Hash]; found {
-					localBuild = &lb
-					status = model.StateLocal
-				}
+	// Group online builds by composite key: version|branch|releaseCycle|architecture
+	grouped := make(map[string]model.BlenderBuild)
+	now := time.Now()
+	for _, onlineBuild := range onlineBuilds {
+		var localBuild *model.BlenderBuild
+		status := model.StateOnline
+
+		// First try to find exact match by hash, unless only build dates count
+		if onlineBuild.Hash != "" && updatePolicy != model.UpdatePolicyBuildDate {
+			if lb, found := localBuildHashMap[archKey(onlineBuild.Hash, onlineBuild)]; found {
+				localBuild = &lb
+				status = model.StateLocal
 			}
+		}
 
-			// If no exact hash match, check for version match and update status
-			if localBuild == nil {
-				if lb, found := localBuildMap[onlineBuild.Version]; found {
-					localBuild = &lb
-					status = CheckUpdateAvailable(*localBuild, onlineBuild)
-				}
+		// If no exact hash match, check for version match and update status
+		if localBuild == nil {
+			if lb, found := localBuildMap[archKey(onlineBuild.Version, onlineBuild)]; found {
+				localBuild = &lb
+				status = model.CheckUpdate(*localBuild, onlineBuild, updatePolicy).Status
 			}
+		}
 
-			updated := onlineBuild
-			updated.Status = status
+		updated := onlineBuild
+		// Shared builds are managed by their owner and never updated from here,
+		// snoozed updates are held back until the snooze ends
+		if status == model.StateUpdate && (localBuild.Shared || localBuild.UpdateSnoozed(now)) {
+			updated = *localBuild
+			status = model.StateLocal
+		}
+		updated.Status = status
+		if status == model.StateUpdate {
+			updated.Installed = localBuild
+		}
+		if localBuild != nil {
+			updated.Shared = localBuild.Shared
+			updated.Label = localBuild.Label
+			updated.Notes = localBuild.Notes
+			updated.Tags = localBuild.Tags
+			updated.SnoozedUntil = localBuild.SnoozedUntil
+			updated.LaunchProfile = localBuild.LaunchProfile
+			// An update is a new build that has to be reviewed again; Installed keeps the promotion
+			if status != model.StateUpdate {
+				updated.Promotion = localBuild.Promotion
+			}
+		}
+		if localBuild != nil && updated.Introspection == nil {
+			updated.Introspection = localBuild.Introspection
+			updated.GPUProbe = localBuild.GPUProbe
+			updated.SmokeTest = localBuild.SmokeTest
+		}
 
-			// Composite key: version|branch|releaseCycle
-			key := onlineBuild.Version + "|" + onlineBuild.Branch + "|" + onlineBuild.ReleaseCycle
+		// Composite key: version|branch|releaseCycle|architecture
+		key := archKey(onlineBuild.Version+"|"+onlineBuild.Branch+"|"+onlineBuild.ReleaseCycle, onlineBuild)
 
-			// If an entry already exists, prefer the one with StateUpdate over StateLocal
-			if existing, exists := grouped[key]; exists {
-				if existing.Status == model.StateUpdate || status == model.StateUpdate {
-					grouped[key] = updated
-				}
-			} else {
+		// If an entry already exists, prefer the one with StateUpdate over StateLocal
+		if existing, exists := grouped[key]; exists {
+			if existing.Status == model.StateUpdate || status == model.StateUpdate {
 				grouped[key] = updated
 			}
+		} else {
+			grouped[key] = updated
 		}
+	}
 
-		// Build final list
-		finalBuilds := make([]model.BlenderBuild, 0, len(grouped))
-		for _, b := range grouped {
-			finalBuilds = append(finalBuilds, b)
+	// Keep local builds missing from the list, e.g. hidden earlier by the tag filter
+	for _, localBuild := range localBuilds {
+		key := archKey(localBuild.Version+"|"+localBuild.Branch+"|"+localBuild.ReleaseCycle, localBuild)
+		if _, exists := grouped[key]; !exists {
+			localBuild.Status = model.StateLocal
+			grouped[key] = localBuild
 		}
+	}
 
-		return buildsUpdatedMsg{builds: finalBuilds}
+	// Build final list
+	finalBuilds := make([]model.BlenderBuild, 0, len(grouped))
+	for _, b := range grouped {
+		finalBuilds = append(finalBuilds, b)
 	}
+	return finalBuilds
 }
 
-// DoDownload creates a command to download and extract a build
-func (c *Commands) DoDownload(build model.BlenderBuild) tea.Cmd {
+// DeleteBuild creates a command to move a local build to the trash, where it stays until the launcher exits
+func (c *Commands) DeleteBuild(build model.BlenderBuild) tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
 	return func() tea.Msg {
-		return c.downloads.StartDownload(build)
+		trashed, found, err := local.TrashBuild(downloadDir, build.Version, build.Architecture)
+		if err == nil && !found {
+			err = fmt.Errorf("failed to delete build %s", build.Version)
+		}
+		return buildDeletedMsg{build: build, trashed: trashed, err: err}
 	}
 }
 
-// StartTicker starts a ticker to regularly update the UI during downloads
-func (c *Commands) StartTicker() tea.Cmd {
+// ProbeBuild creates a command to (re)run the introspection probe for an installed build,
+// found by its version, architecture and hash
+func (c *Commands) ProbeBuild(installed model.BlenderBuild) tea.Cmd {
+	version := installed.Version
+	cfg := c.cfg
 	return func() tea.Msg {
-		ticker := time.NewTicker(500 * time.Millisecond)
-		done := make(chan bool)
-
-		go func() {
-			for {
-				select {
-				case <-done:
-					return
-				case t := <-ticker.C:
-					programCh <- tickMsg(t)
-				}
+		dirPath, err := local.FindBuildDirOf(cfg.DownloadDir, installed)
+		if err != nil {
+			return buildProbedMsg{version: version, err: err}
+		}
+		if dirPath == "" {
+			return buildProbedMsg{version: version, err: fmt.Errorf("build directory for Blender version %s not found", version)}
+		}
+		// The build runs in the sandbox of its source, like when it is launched
+		info, err := local.ReadBuildInfo(dirPath)
+		if err != nil || info == nil {
+			return buildProbedMsg{version: version, err: fmt.Errorf("no build info in %s", dirPath)}
+		}
+		sandbox, err := launch.SandboxArgs(cfg.Sandbox[info.SourceLabel()], dirPath)
+		if err != nil {
+			return buildProbedMsg{version: version, err: err}
+		}
+		if cfg.SmokeTest {
+			// A build that doesn't start has nothing to probe
+			tested, err := local.SmokeTestAndSaveBuild(dirPath, sandbox)
+			if err != nil || tested.Broken() {
+				return buildProbedMsg{version: version, build: tested, err: err}
 			}
-		}()
+		}
+		build, err := local.ProbeAndSaveBuild(dirPath, sandbox)
+		if err == nil && cfg.GPUProbe {
+			build, err = local.ProbeAndSaveGPU(dirPath, sandbox)
+		}
+		return buildProbedMsg{version: version, build: build, err: err}
+	}
+}
 
-		return nil
+// VerifyBuild creates a command to re-verify the installed files of a local build
+func (c *Commands) VerifyBuild(version, arch string) tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		dirPath, err := local.FindBuildDir(downloadDir, version, arch)
+		if err != nil {
+			return buildVerifiedMsg{version: version, err: err}
+		}
+		if dirPath == "" {
+			return buildVerifiedMsg{version: version, err: fmt.Errorf("build directory for Blender version %s not found", version)}
+		}
+		build, result, err := local.VerifyAndSaveBuild(dirPath)
+		return buildVerifiedMsg{version: version, build: build, result: result, err: err}
 	}
 }
 
-// Global channel for program messages - kept for compatibility
-var programCh = make(chan tea.Msg)
+// AssociateBlendFiles creates a command to register a local build as the .blend file handler
+func (c *Commands) AssociateBlendFiles(version, arch string) tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		dirPath, err := local.FindBuildDir(downloadDir, version, arch)
+		if err != nil {
+			return blendAssociatedMsg{version: version, err: err}
+		}
+		if dirPath == "" {
+			return blendAssociatedMsg{version: version, err: fmt.Errorf("build directory for Blender version %s not found", version)}
+		}
+		blenderExe := local.FindBlenderExecutable(dirPath)
+		if blenderExe == "" {
+			return blendAssociatedMsg{version: version, err: fmt.Errorf("could not find Blender executable in %s", dirPath)}
+		}
+		if err := launch.AssociateBlendFiles(blenderExe, version); err != nil {
+			return blendAssociatedMsg{version: version, err: fmt.Errorf("failed to associate .blend files: %w", err)}
+		}
+		return blendAssociatedMsg{version: version}
+	}
+}
+
+// FetchCommits creates a command to list the commits between an installed build and its update
+func (c *Commands) FetchCommits(base, head string) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := api.NewAPI().FetchCommitsBetween(base, head)
+		return commitsFetchedMsg{base: base, head: head, commits: commits, err: err}
+	}
+}
+
+// DoDownload creates a command to download and extract a build
+func (c *Commands) DoDownload(build model.BlenderBuild) tea.Cmd {
+	return func() tea.Msg {
+		return c.downloads.StartDownload(build)
+	}
+}
 
 // ProgramMsgListener returns a command that listens for program messages
 func (c *Commands) ProgramMsgListener() tea.Cmd {
 	return func() tea.Msg {
-		return <-programCh
+		return <-c.msgs
 	}
 }
 
@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// ConfigErrorModel creates a model that starts on the config error screen.
+// The user can reset the config to defaults or fix the file and reload it.
+func ConfigErrorModel(err error, commands *Commands) *Model {
+	m := InitialModel(config.DefaultConfig(), commands, false)
+	m.currentView = viewConfigError
+	m.configErr = err
+	return m
+}
+
+// leaveConfigError starts the regular list view with a usable config, doing the startup work Init skipped
+func (m *Model) leaveConfigError(cfg config.Config) (tea.Model, tea.Cmd) {
+	old := m.config
+	m.config = cfg
+	config.SetConfigInstance(cfg)
+	m.commands.SetConfig(cfg)
+	m.buildType = cfg.BuildType
+	m.lowBandwidth = cfg.LowBandwidth()
+	for i, opt := range m.buildTypeOptions {
+		if opt == cfg.BuildType {
+			m.buildTypeIndex = i
+		}
+	}
+	m.configModTime, _ = config.ModTime()
+	m.plaintextSecrets = config.PlaintextSecrets()
+	m.configErr = nil
+	m.err = applyNetworkSettings(cfg, old)
+	m.currentView = viewList
+	return m, m.startupCmds()
+}
+
+// updateConfigErrorView handles key events on the config error screen
+func (m *Model) updateConfigErrorView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	for _, cmd := range GetCommandsForView(viewConfigError) {
+		if key.Matches(msg, GetKeyBinding(cmd.Type)) {
+			switch cmd.Type {
+			case CmdQuit:
+				return m, tea.Quit
+
+			case CmdResetConfig:
+				cfg, err := config.ResetConfig()
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				return m.leaveConfigError(cfg)
+
+			case CmdRetryConfig:
+				cfg, err := config.LoadConfig()
+				if err != nil {
+					m.configErr = err
+					return m, nil
+				}
+				return m.leaveConfigError(cfg)
+			}
+		}
+	}
+	return m, nil
+}
+
+// renderConfigErrorContent lists every problem found in the config file
+func (m *Model) renderConfigErrorContent(availableHeight int) string {
+	titleStyle := lp.NewStyle().Foreground(lp.Color(redColor)).Bold(true)
+	locationStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("The configuration file is invalid"))
+	b.WriteString("\n\n")
+
+	var errs config.ValidationErrors
+	if errors.As(m.configErr, &errs) {
+		for _, e := range errs {
+			location := e.File
+			if e.Line > 0 {
+				location = fmt.Sprintf("%s:%d", e.File, e.Line)
+			}
+			b.WriteString(locationStyle.Render(location))
+			b.WriteString("\n  ")
+			// Show the problem without repeating the location
+			problem := *e
+			problem.File = ""
+			b.WriteString(problem.Error())
+			b.WriteString("\n")
+		}
+	} else if m.configErr != nil {
+		b.WriteString(m.configErr.Error())
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\nFix the file and press enter to reload it, or reset it to the defaults.\n")
+	b.WriteString("Resetting keeps the current file as config.toml.bak.")
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Left, lp.Top,
+		lp.NewStyle().MarginLeft(2).Width(m.terminalWidth-4).Render(b.String()))
+}
+
+// renderConfigErrorFooter renders the footer for the config error screen
+func (m *Model) renderConfigErrorFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Reload", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Reset to defaults", keyStyle.Render("r")),
+		fmt.Sprintf("%s Quit", keyStyle.Render("q")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/launch"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// configPrompt holds a launch that is waiting for the user to decide how to handle a new user config
+type configPrompt struct {
+	exec      model.BlenderExecMsg
+	migration local.UserConfigMigration
+}
+
+// launchBlenderCmd starts the given build in a new terminal with its launch profile,
+// inside the sandbox configured for its source
+func launchBlenderCmd(execInfo model.BlenderExecMsg) tea.Cmd {
+	return func() tea.Msg {
+		execInfo = withLaunchProfile(*config.GetConfigInstance(), execInfo)
+		sandbox, err := launch.SandboxArgs(config.GetConfigInstance().Sandbox[execInfo.Source], execInfo.InstallDir, blendFileArgs(execInfo.Args)...)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to launch Blender: %w", err)}
+		}
+		err = launch.BlenderInNewTerminal(append(append(sandbox, execInfo.Executable), execInfo.Args...), execInfo.Env)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to launch Blender: %w", err)}
+		}
+
+		// Return a message indicating Blender was launched successfully
+		return nil
+	}
+}
+
+// blendFileArgs returns the arguments of a launch that are .blend files, the sandbox lets the build read them
+func blendFileArgs(args []string) []string {
+	var files []string
+	for _, arg := range args {
+		if strings.HasSuffix(strings.ToLower(arg), ".blend") {
+			files = append(files, arg)
+		}
+	}
+	return files
+}
+
+// updateConfigPrompt handles key events while the user config prompt is shown
+func (m *Model) updateConfigPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := m.configPrompt
+	switch msg.String() {
+	case "c":
+		// Copy preferences from the previous version, then launch
+		m.configPrompt = nil
+		return m, func() tea.Msg {
+			if err := local.CopyUserConfig(prompt.migration); err != nil {
+				return errMsg{fmt.Errorf("failed to copy preferences from %s: %w", prompt.migration.PreviousSeries, err)}
+			}
+			return launchBlenderCmd(prompt.exec)()
+		}
+
+	case "i":
+		// Keep this build's config inside its installation directory from now on
+		m.configPrompt = nil
+		return m, func() tea.Msg {
+			dir := filepath.Join(prompt.exec.ConfigRoot, local.IsolatedConfigDir)
+			if err := os.MkdirAll(dir, 0750); err != nil {
+				return errMsg{fmt.Errorf("failed to create isolated config directory: %w", err)}
+			}
+			execInfo := prompt.exec
+			execInfo.Env = append(execInfo.Env, local.IsolatedConfigEnv(execInfo.ConfigRoot)...)
+			return launchBlenderCmd(execInfo)()
+		}
+
+	case "enter":
+		// Launch anyway and let Blender create a fresh config
+		m.configPrompt = nil
+		return m, launchBlenderCmd(prompt.exec)
+
+	case "esc", "q":
+		m.configPrompt = nil
+		return m, nil
+	}
+	return m, nil
+}
+
+// renderConfigPrompt renders the user config warning dialog
+func (m *Model) renderConfigPrompt(availableHeight int) string {
+	mig := m.configPrompt.migration
+	titleStyle := lp.NewStyle().Foreground(lp.Color(orangeColor)).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("⚠ New Blender user configuration"))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("Blender %s will create a new user config in:\n", mig.Series))
+	b.WriteString("  " + mig.ConfigDir + "\n\n")
+	b.WriteString(fmt.Sprintf("Your existing preferences are stored for %s in:\n", mig.PreviousSeries))
+	b.WriteString("  " + mig.PreviousDir + "\n")
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Center, box)
+}
+
+// renderConfigPromptFooter renders the choices for the user config warning dialog
+func (m *Model) renderConfigPromptFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Copy preferences from %s", keyStyle.Render("c"), m.configPrompt.migration.PreviousSeries),
+		fmt.Sprintf("%s Use isolated config", keyStyle.Render("i")),
+		fmt.Sprintf("%s Launch with new config", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
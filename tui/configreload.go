@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reloadConfigIfChanged re-reads config.toml when it was modified outside the TUI.
+// Invalid files are reported and the running configuration is kept.
+func (m *Model) reloadConfigIfChanged() tea.Cmd {
+	// Don't pull the config out from under the settings form
+	if m.currentView == viewSettings || m.currentView == viewInitialSetup || m.currentView == viewConfigError {
+		return nil
+	}
+
+	modTime, err := config.ModTime()
+	if err != nil || modTime.Equal(m.configModTime) {
+		return nil
+	}
+	m.configModTime = modTime
+	m.plaintextSecrets = config.PlaintextSecrets()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		m.err = fmt.Errorf("config not reloaded: %w", err)
+		return nil
+	}
+
+	// Our own saves also touch the file; nothing to apply then
+	if reflect.DeepEqual(cfg, m.config) {
+		return nil
+	}
+	return m.applyReloadedConfig(cfg)
+}
+
+// applyReloadedConfig switches the running TUI over to a new configuration
+func (m *Model) applyReloadedConfig(cfg config.Config) tea.Cmd {
+	m.err = nil
+	return m.applyConfig(cfg)
+}
+
+// applyConfig makes cfg the running configuration. The commands pick it up at once, so the next
+// scan, fetch or download uses it; the returned configChangedMsg refreshes what the change affects.
+func (m *Model) applyConfig(cfg config.Config) tea.Cmd {
+	old := m.config
+	m.config = cfg
+	m.buildType = cfg.BuildType
+	m.lowBandwidth = cfg.LowBandwidth()
+	for i, opt := range m.buildTypeOptions {
+		if opt == cfg.BuildType {
+			m.buildTypeIndex = i
+		}
+	}
+
+	config.SetConfigInstance(cfg)
+	if err := applyNetworkSettings(cfg, old); err != nil {
+		m.err = err
+	}
+
+	// Keep the running download manager so active downloads survive the change
+	m.commands.SetConfig(cfg)
+
+	return func() tea.Msg {
+		return configChangedMsg{old: old}
+	}
+}
+
+// applyNetworkSettings applies the proxy and network settings of cfg to the requests from now on,
+// when they differ from those of old
+func applyNetworkSettings(cfg, old config.Config) error {
+	if cfg.Proxy != old.Proxy || cfg.ProxyUser != old.ProxyUser || cfg.ProxyPassword != old.ProxyPassword {
+		if err := config.ApplyProxy(cfg); err != nil {
+			return fmt.Errorf("failed to apply proxy settings: %w", err)
+		}
+	}
+	if cfg.IPVersion != old.IPVersion || cfg.DNSServer != old.DNSServer || !maps.Equal(cfg.HostOverrides, old.HostOverrides) {
+		if err := config.ApplyNetwork(cfg); err != nil {
+			return fmt.Errorf("failed to apply network settings: %w", err)
+		}
+	}
+	return nil
+}
+
+// handleConfigChanged rescans the local builds when their directories changed
+// and fetches the online builds again when the listing changed
+func (m *Model) handleConfigChanged(msg configChangedMsg) (tea.Model, tea.Cmd) {
+	cfg, old := m.config, msg.old
+
+	var cmds []tea.Cmd
+	if cfg.DownloadDir != old.DownloadDir || cfg.SharedDir != old.SharedDir {
+		cmds = append(cmds, m.commands.ScanLocalBuilds())
+	}
+	if cfg.DownloadDir != old.DownloadDir {
+		cmds = append(cmds, m.commands.CheckDownloadFilesystem())
+	}
+	if cfg.DownloadDir != old.DownloadDir || cfg.SharedDir != old.SharedDir || cfg.VersionFilterFor(cfg.BuildType) != old.VersionFilterFor(old.BuildType) || cfg.BuildType != old.BuildType ||
+		cfg.TagFilter != old.TagFilter || cfg.ArchivePreference != old.ArchivePreference ||
+		cfg.UpdatePolicy != old.UpdatePolicy {
+		// The list is filtered differently even when the listing didn't change
+		m.fetchShown = false
+		cmds = append(cmds, m.commands.FetchBuilds())
+	}
+	if !slices.Equal(cfg.CustomColumns, old.CustomColumns) {
+		cmds = append(cmds, m.refreshCustomColumns())
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/download"
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// conflictQueue holds the installs waiting for a decision on a directory in their way
+type conflictQueue struct {
+	pending   []conflictMsg // The first is asked
+	overwrite bool          // Overwrite was chosen for the first, waiting for the confirmation
+}
+
+// handleConflict asks what to do with a directory in the way of an install. Several installs
+// can wait at once; they are asked one after the other.
+func (m *Model) handleConflict(msg conflictMsg) (tea.Model, tea.Cmd) {
+	m.conflicts.pending = append(m.conflicts.pending, msg)
+	if m.currentView != viewList {
+		m.showNotice(fmt.Sprintf("Install of Blender %s waits for a decision in the build list", msg.build.Version))
+	}
+	return m, m.commands.ProgramMsgListener()
+}
+
+// updateConflictPrompt answers the first waiting install: r overwrites the directory after a confirmation
+// with y, b keeps both and esc or n aborts the install. Other keys are ignored until it is answered.
+func (m *Model) updateConflictPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.conflicts.overwrite {
+		m.conflicts.overwrite = false
+		if msg.String() == "y" {
+			m.answerConflict(download.ResolveOverwrite)
+		}
+		return m, nil
+	}
+	switch msg.String() {
+	case "r":
+		m.conflicts.overwrite = true
+	case "b":
+		m.answerConflict(download.ResolveKeepBoth)
+	case "esc", "n":
+		m.answerConflict(download.ResolveAbort)
+	}
+	return m, nil
+}
+
+// answerConflict sends the decision to the first waiting install
+func (m *Model) answerConflict(resolution download.Resolution) {
+	conflict := m.conflicts.pending[0]
+	m.conflicts.pending = m.conflicts.pending[1:]
+	conflict.reply <- resolution
+}
+
+// renderConflictPrompt renders the question for the first waiting install in place of the contextual commands
+func (m *Model) renderConflictPrompt(keyStyle lp.Style, separator string) string {
+	warnStyle := lp.NewStyle().Foreground(lp.Color(orangeColor))
+	conflict := m.conflicts.pending[0]
+	if m.conflicts.overwrite {
+		return warnStyle.Render(fmt.Sprintf("Move %s to .oldbuilds and install Blender %s in its place?", conflict.dir, conflict.build.Version)) + separator +
+			fmt.Sprintf("%s Overwrite", keyStyle.Render("y")) + separator +
+			fmt.Sprintf("%s Back", keyStyle.Render("any other key"))
+	}
+	summary := fmt.Sprintf("Blender %s: %s exists and wasn't installed by the launcher", conflict.build.Version, filepath.Base(conflict.dir))
+	if len(m.conflicts.pending) > 1 {
+		summary += fmt.Sprintf(" (+%d more)", len(m.conflicts.pending)-1)
+	}
+	return warnStyle.Render(summary) + separator +
+		fmt.Sprintf("%s Overwrite", keyStyle.Render("r")) + separator +
+		fmt.Sprintf("%s Keep both", keyStyle.Render("b")) + separator +
+		fmt.Sprintf("%s Abort", keyStyle.Render("esc"))
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/model"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestConflictPrompt(t *testing.T) {
+	first := make(chan download.Resolution, 1)
+	second := make(chan download.Resolution, 1)
+	m := &Model{conflicts: conflictQueue{pending: []conflictMsg{
+		{build: model.BlenderBuild{Version: "4.3.0"}, dir: "/builds/blender-4.3.0", reply: first},
+		{build: model.BlenderBuild{Version: "4.4.0"}, dir: "/builds/blender-4.4.0", reply: second},
+	}}}
+
+	// List keys don't answer, not even those of the old prompt
+	for _, key := range []string{"o", "k", "a", "x"} {
+		m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+	if len(m.conflicts.pending) != 2 {
+		t.Fatalf("Expected both installs to wait, got %d", len(m.conflicts.pending))
+	}
+
+	// Overwrite asks for a confirmation, any other key goes back to the question
+	m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if len(m.conflicts.pending) != 2 || m.conflicts.overwrite {
+		t.Fatalf("Expected the overwrite to be called off, got %d waiting", len(m.conflicts.pending))
+	}
+	m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+
+	// The second one is asked next
+	m.updateKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := <-first; got != download.ResolveOverwrite {
+		t.Errorf("Expected overwrite for the first install, got %d", got)
+	}
+	if got := <-second; got != download.ResolveAbort {
+		t.Errorf("Expected abort for the second install, got %d", got)
+	}
+	if len(m.conflicts.pending) != 0 {
+		t.Errorf("Expected no install left waiting, got %d", len(m.conflicts.pending))
+	}
+}
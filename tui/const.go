@@ -14,6 +14,11 @@ const (
 	orangeColor     = "208" // Orange for local builds
 	greenColor      = "46"  // Green for updated builds
 	redColor        = "196" // Red for failed downloads
+	newColor        = "51"  // Cyan for builds new since the last fetch
+
+	// Smallest terminal the layout fits in; smaller ones get a notice instead
+	minTerminalWidth  = 80
+	minTerminalHeight = 20
 )
 
 // View states
@@ -23,6 +28,8 @@ const (
 	viewList viewState = iota
 	viewInitialSetup
 	viewSettings
+	viewDetails
+	viewConfigError
 )
 
 // Command types for key bindings
@@ -49,6 +56,43 @@ const (
 	CmdHome           // Add Home command
 	CmdEnd            // Add End command
 	CmdCleanOldBuilds // Add command for cleaning old builds
+	CmdShowDetails    // Show details of the selected build
+	CmdBack           // Return to the build list
+	CmdProbeBuild     // Re-run the introspection probe for a local build
+	CmdAssociateBlend // Register the selected build as .blend file handler
+	CmdQuickLaunch    // Open the quick-launch palette
+	CmdCycleBuildType // Switch to the next build type and refetch
+	CmdEditFilter     // Edit the version filter inline and refetch
+	CmdCancelAll      // Cancel all active downloads
+	CmdToggleGrouping // Toggle grouping of builds by series
+	CmdScheduleBuild  // Schedule or unschedule a download of the selected build
+	CmdResetConfig    // Replace an invalid config file with the defaults
+	CmdRetryConfig    // Load the config file again after fixing it
+	CmdRegenerateID   // Generate a new download ID
+	CmdOpenPR         // Open the pull request of a patch build in the browser
+	CmdOpenSource     // Open the commit or branch of a build in the browser
+	CmdYank           // Copy hash, download URL or install path to the clipboard
+	CmdBrowseDir      // Pick the download directory with the directory browser
+	CmdVerifyBuild    // Re-verify the installed files of a local build
+	CmdLabelBuild     // Set a custom label for a local build
+	CmdEditNotes      // Edit the free-form notes of a local build
+	CmdEditTags       // Edit the tags of a local build
+	CmdFilterTag      // Show only builds with a tag
+	CmdToggleDensity  // Switch between the comfortable and compact layout
+	CmdShowArtifacts  // List the companion files of a build for download
+	CmdPromoteBuild   // Cycle the promotion state of a local build (admins only)
+	CmdRecentProjects // List the recently opened .blend files
+	CmdToggleNewOnly  // Show only the builds that appeared since the previous fetch
+	CmdUndo           // Undo the last delete, cleanup or label/tag edit of the session
+	CmdDownloadLaunch // Download the selected build and launch it when ready
+	CmdSpeedTest      // Measure the download speed of the builder and the mirrors
+	CmdMetadataDiff   // Compare the version.json of a build with its online listing entry
+	CmdSnoozeUpdates  // Hold back the updates of a local build for some days
+	CmdActionMenu     // List the actions valid for the selected build
+	CmdDownloadsPanel // Show all running, paused and queued downloads
+	CmdLaunchProfile  // Launch the selected build with a launch profile or set its profile
+	CmdArchiveCache   // Browse the archives kept in the archive cache
+	CmdReinstallCache // Install the selected build again from the archive cache
 )
 
 // KeyCommand defines a keyboard command with its key binding and description
@@ -62,7 +106,7 @@ type KeyCommand struct {
 var (
 	// Common commands for all views
 	CommonCommands = []KeyCommand{
-		{Type: CmdQuit, Keys: []string{"q"}, Description: "Quit application"},
+		{Type: CmdQuit, Keys: []string{"q", "ctrl+c"}, Description: "Quit application"},
 	}
 
 	// List view commands
@@ -72,7 +116,8 @@ var (
 		{Type: CmdFetchBuilds, Keys: []string{"f"}, Description: "Fetch online builds"},
 		{Type: CmdDownloadBuild, Keys: []string{"d"}, Description: "Download selected build"},
 		{Type: CmdLaunchBuild, Keys: []string{"enter"}, Description: "Launch selected build"},
-		{Type: CmdOpenBuildDir, Keys: []string{"o"}, Description: "Open build directory"},
+		{Type: CmdLaunchProfile, Keys: []string{"p"}, Description: "Launch selected build with a profile"},
+		{Type: CmdOpenBuildDir, Keys: []string{"o"}, Description: "Open build, config, add-ons or crash log directory"},
 		{Type: CmdDeleteBuild, Keys: []string{"x"}, Description: "Delete build/Cancel download"},
 		{Type: CmdMoveUp, Keys: []string{"up", "k"}, Description: "Move cursor up"},
 		{Type: CmdMoveDown, Keys: []string{"down", "j"}, Description: "Move cursor down"},
@@ -82,6 +127,35 @@ var (
 		{Type: CmdPageDown, Keys: []string{"pgdown"}, Description: "Page down"},
 		{Type: CmdHome, Keys: []string{"home"}, Description: "Go to first item"},
 		{Type: CmdEnd, Keys: []string{"end"}, Description: "Go to last item"},
+		{Type: CmdShowDetails, Keys: []string{"i"}, Description: "Show build details"},
+		{Type: CmdAssociateBlend, Keys: []string{"a"}, Description: "Open .blend files with selected build"},
+		{Type: CmdQuickLaunch, Keys: []string{"ctrl+p"}, Description: "Quick-launch a local build"},
+		{Type: CmdCycleBuildType, Keys: []string{"t"}, Description: "Cycle build type"},
+		{Type: CmdEditFilter, Keys: []string{"v"}, Description: "Edit version filter"},
+		{Type: CmdCancelAll, Keys: []string{"X"}, Description: "Cancel all downloads"},
+		{Type: CmdToggleGrouping, Keys: []string{"G"}, Description: "Group builds by series"},
+		{Type: CmdScheduleBuild, Keys: []string{"W"}, Description: "Schedule download of selected build"},
+		{Type: CmdOpenPR, Keys: []string{"P"}, Description: "Open pull request of a patch build"},
+		{Type: CmdOpenSource, Keys: []string{"g"}, Description: "Open commit or branch page in the browser"},
+		{Type: CmdYank, Keys: []string{"y"}, Description: "Copy hash, URL or path to the clipboard"},
+		{Type: CmdVerifyBuild, Keys: []string{"V"}, Description: "Re-verify installed files of selected build"},
+		{Type: CmdLabelBuild, Keys: []string{"L"}, Description: "Label selected local build"},
+		{Type: CmdEditNotes, Keys: []string{"n"}, Description: "Edit notes of selected local build"},
+		{Type: CmdEditTags, Keys: []string{"T"}, Description: "Edit tags of selected local build"},
+		{Type: CmdFilterTag, Keys: []string{"F"}, Description: "Filter builds by tag"},
+		{Type: CmdToggleDensity, Keys: []string{"c"}, Description: "Toggle compact layout"},
+		{Type: CmdShowArtifacts, Keys: []string{"A"}, Description: "Download additional files of selected build"},
+		{Type: CmdPromoteBuild, Keys: []string{"m"}, Description: "Cycle promotion of selected build (testing/approved/blocked)"},
+		{Type: CmdRecentProjects, Keys: []string{"R"}, Description: "Open a recent project"},
+		{Type: CmdToggleNewOnly, Keys: []string{"N"}, Description: "Show only builds new since the last fetch"},
+		{Type: CmdUndo, Keys: []string{"u"}, Description: "Undo the last delete, cleanup or label/tag edit"},
+		{Type: CmdDownloadLaunch, Keys: []string{"e"}, Description: "Download selected build and launch it when ready"},
+		{Type: CmdSpeedTest, Keys: []string{"S"}, Description: "Speed test the builder and mirrors"},
+		{Type: CmdSnoozeUpdates, Keys: []string{"z"}, Description: "Snooze updates of selected local build"},
+		{Type: CmdActionMenu, Keys: []string{"."}, Description: "Show the actions of selected build"},
+		{Type: CmdDownloadsPanel, Keys: []string{"D"}, Description: "Show all downloads with pause, cancel and priority"},
+		{Type: CmdArchiveCache, Keys: []string{"C"}, Description: "Browse kept archives to reinstall or export them"},
+		{Type: CmdReinstallCache, Keys: []string{"I"}, Description: "Reinstall selected build from the archive cache"},
 	}
 
 	// Settings view commands
@@ -93,6 +167,22 @@ var (
 		{Type: CmdMoveLeft, Keys: []string{"left", "h"}, Description: "Select previous option"},
 		{Type: CmdMoveRight, Keys: []string{"right", "l"}, Description: "Select next option"},
 		{Type: CmdCleanOldBuilds, Keys: []string{"c"}, Description: "Clean old builds"},
+		{Type: CmdRegenerateID, Keys: []string{"r"}, Description: "Regenerate download ID"},
+		{Type: CmdBrowseDir, Keys: []string{"b"}, Description: "Browse for download directory"},
+	}
+
+	// Details view commands
+	DetailsCommands = []KeyCommand{
+		{Type: CmdBack, Keys: []string{"esc", "i"}, Description: "Back to build list"},
+		{Type: CmdProbeBuild, Keys: []string{"p"}, Description: "Probe build information"},
+		{Type: CmdEditNotes, Keys: []string{"n"}, Description: "Edit build notes"},
+		{Type: CmdMetadataDiff, Keys: []string{"m"}, Description: "Compare version.json with the online build"},
+	}
+
+	// Config error view commands
+	ConfigErrorCommands = []KeyCommand{
+		{Type: CmdResetConfig, Keys: []string{"r"}, Description: "Reset config to defaults"},
+		{Type: CmdRetryConfig, Keys: []string{"enter"}, Description: "Reload config file"},
 	}
 )
 
@@ -126,6 +216,24 @@ func GetKeyBinding(cmdType CommandType) key.Binding {
 		}
 	}
 
+	if keys == nil {
+		for _, cmd := range DetailsCommands {
+			if cmd.Type == cmdType {
+				keys = cmd.Keys
+				break
+			}
+		}
+	}
+
+	if keys == nil {
+		for _, cmd := range ConfigErrorCommands {
+			if cmd.Type == cmdType {
+				keys = cmd.Keys
+				break
+			}
+		}
+	}
+
 	return key.NewBinding(key.WithKeys(keys...))
 }
 
@@ -139,6 +247,10 @@ func GetCommandsForView(view viewState) []KeyCommand {
 		result = append(result, ListCommands...)
 	case viewSettings, viewInitialSetup:
 		result = append(result, SettingsCommands...)
+	case viewDetails:
+		result = append(result, DetailsCommands...)
+	case viewConfigError:
+		result = append(result, ConfigErrorCommands...)
 	}
 
 	return result
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// firstCustomColumn is the index of the first custom column, they follow the built-in columns
+const firstCustomColumn = len(builtinColumns)
+
+// columnValues holds the values of the custom columns, see config.CustomColumns
+type columnValues struct {
+	values  map[string][]string // Per download ID, in the order of the custom columns
+	running bool                // The column commands are running
+	pending bool                // The list changed while they ran, they run again once done
+}
+
+// CustomColumnValues creates a command to run the command of each custom column for builds
+func (c *Commands) CustomColumnValues(builds []model.BlenderBuild) tea.Cmd {
+	columns := c.cfg.CustomColumns
+	return func() tea.Msg {
+		msg := customColumnsMsg{values: make(map[string][]string, len(builds))}
+		for _, build := range builds {
+			msg.values[downloadID(build)] = make([]string, len(columns))
+		}
+		for i, column := range columns {
+			values, err := local.CustomColumnValues(column, builds)
+			if err != nil {
+				msg.failed = append(msg.failed, i)
+				if msg.err == nil {
+					msg.err = fmt.Errorf("custom column %s: %w", column.Name, err)
+				}
+				continue
+			}
+			for j, build := range builds {
+				msg.values[downloadID(build)][i] = values[j]
+			}
+		}
+		return msg
+	}
+}
+
+// refreshCustomColumns runs the column commands for the listed builds, or once more after
+// the running ones when they are already running
+func (m *Model) refreshCustomColumns() tea.Cmd {
+	if len(m.config.CustomColumns) == 0 || m.commands == nil {
+		m.customColumns = columnValues{}
+		return nil
+	}
+	if m.customColumns.running {
+		m.customColumns.pending = true
+		return nil
+	}
+	m.customColumns.running = true
+	return m.commands.CustomColumnValues(slices.Clone(m.builds))
+}
+
+// handleCustomColumns shows the values printed by the column commands.
+// A column whose command failed keeps its previous values.
+func (m *Model) handleCustomColumns(msg customColumnsMsg) (tea.Model, tea.Cmd) {
+	for id, values := range msg.values {
+		previous := m.customColumns.values[id]
+		for _, i := range msg.failed {
+			if i < len(previous) {
+				values[i] = previous[i]
+			}
+		}
+	}
+	m.customColumns.values = msg.values
+	m.customColumns.running = false
+	if msg.err != nil {
+		m.err = msg.err
+	}
+	if m.sortColumn >= firstCustomColumn {
+		m.sortBuilds()
+	}
+
+	if m.customColumns.pending {
+		m.customColumns.pending = false
+		return m, m.refreshCustomColumns()
+	}
+	return m, nil
+}
+
+// customColumnValues returns the values of the custom columns for a build, nil until its commands ran
+func (m *Model) customColumnValues(build model.BlenderBuild) []string {
+	return m.customColumns.values[downloadID(build)]
+}
+
+// sortByCustomColumn orders builds by the values of a custom column, numbers by their value.
+// Builds of the same value keep the order of the built-in columns, which doesn't change when reversed.
+func (m *Model) sortByCustomColumn(builds []model.BlenderBuild, column int, reverse bool) []model.BlenderBuild {
+	sorted := model.SortBuilds(builds, 0, false)
+	value := func(build model.BlenderBuild) string {
+		if values := m.customColumnValues(build); column < len(values) {
+			return values[column]
+		}
+		return ""
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		c := compareColumnValues(value(sorted[i]), value(sorted[j]))
+		if reverse {
+			return c > 0
+		}
+		return c < 0
+	})
+	return sorted
+}
+
+// compareColumnValues compares two custom column values, numerically when both are numbers
+func compareColumnValues(a, b string) int {
+	aNum, aErr := strconv.ParseFloat(a, 64)
+	bNum, bErr := strconv.ParseFloat(b, 64)
+	if aErr == nil && bErr == nil {
+		return cmp.Compare(aNum, bNum)
+	}
+	return cmp.Compare(a, b)
+}
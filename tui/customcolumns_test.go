@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCustomColumns(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the column commands are sh scripts")
+	}
+	cfg := config.Config{CustomColumns: []config.CustomColumn{
+		{Name: "Queue", Command: "cat > /dev/null; printf '10\\n2\\n'"},
+		{Name: "Approval", Command: "echo 'studio API unreachable' >&2; exit 1"},
+	}}
+	m := &Model{config: cfg, commands: &Commands{cfg: cfg}, builds: []model.BlenderBuild{
+		{Version: "4.3.0", Hash: "a1b2c3d4e5f6", Status: model.StateOnline},
+		{Version: "4.2.0", Hash: "b1b2c3d4e5f6", Status: model.StateLocal},
+	}}
+	m.customColumns.values = map[string][]string{downloadID(m.builds[1]): {"", "approved"}}
+
+	m.Update(m.refreshCustomColumns()())
+	if got := m.customColumnValues(m.builds[0]); len(got) != 2 || got[0] != "10" {
+		t.Fatalf("Expected the first line for 4.3.0, got %q", got)
+	}
+	// The failed column keeps the values of the previous run
+	if got := m.customColumnValues(m.builds[1]); got[0] != "2" || got[1] != "approved" {
+		t.Errorf("Expected 2 and the previous approval for 4.2.0, got %q", got)
+	}
+	if m.err == nil || !strings.Contains(m.err.Error(), "studio API unreachable") {
+		t.Errorf("Expected the failure of the Approval command, got %v", m.err)
+	}
+
+	// Numbers sort by their value
+	m.sortColumn = firstCustomColumn
+	m.sortBuilds()
+	if m.builds[0].Version != "4.2.0" {
+		t.Errorf("Expected 2 before 10, got %s first", m.builds[0].Version)
+	}
+	m.updateSortColumn("right")
+	if m.sortColumn != firstCustomColumn+1 {
+		t.Errorf("Expected the second custom column to be sortable, got column %d", m.sortColumn)
+	}
+
+	columns := GetBuildColumns(200, false, cfg.CustomColumns)
+	if last := columns[len(columns)-1]; last.Name != "Approval" || last.Width == 0 {
+		t.Fatalf("Expected the custom columns last, got %+v", last)
+	}
+	if first := columns[len(builtinColumns)]; first.Name != "Queue" || first.Index != firstCustomColumn {
+		t.Errorf("Expected the first custom column right after the built-in ones, got %+v", first)
+	}
+	row := NewRow(m.builds[0], false, nil)
+	row.Custom = m.customColumnValues(m.builds[0])
+	if rendered := row.Render(columns); !strings.Contains(rendered, "approved") {
+		t.Errorf("Expected the approval in the row, got %q", rendered)
+	}
+	if compact := GetBuildColumns(200, true, cfg.CustomColumns); len(compact) != compactMaxPriority {
+		t.Errorf("Expected no custom columns in compact mode, got %d columns", len(compact))
+	}
+
+	// Removing the column resets the sort
+	m.config.CustomColumns = nil
+	m.sortBuilds()
+	if m.sortColumn != 0 {
+		t.Errorf("Expected the sort to fall back to Version, got column %d", m.sortColumn)
+	}
+}
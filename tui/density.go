@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// compact reports whether the compact layout density is selected
+func (m *Model) compact() bool {
+	return m.config.Density == config.DensityCompact
+}
+
+// handleToggleDensity switches between the comfortable and compact layout and saves the choice
+func (m *Model) handleToggleDensity() (tea.Model, tea.Cmd) {
+	notice := "Compact layout"
+	if m.compact() {
+		m.config.Density = config.DensityComfortable
+		notice = "Comfortable layout"
+	} else {
+		m.config.Density = config.DensityCompact
+	}
+	if err := config.SaveConfig(m.config); err != nil {
+		m.err = fmt.Errorf("failed to save config: %w", err)
+		return m, nil
+	}
+	m.commands.SetConfig(m.config)
+
+	// Keep the cursor on screen when fewer rows fit
+	visibleRowsCount := m.contentHeight() - 1
+	if visibleRowsCount < 1 {
+		visibleRowsCount = 1
+	}
+	if m.cursor >= m.startIndex+visibleRowsCount {
+		m.startIndex = m.cursor - visibleRowsCount + 1
+	}
+	m.showNotice(notice)
+	return m, nil
+}
@@ -0,0 +1,360 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// detailField is a single label/value line in the details view
+type detailField struct {
+	label string
+	value string
+}
+
+// selectedBuild returns the build under the cursor, if any
+func (m *Model) selectedBuild() (model.BlenderBuild, bool) {
+	if len(m.builds) == 0 || m.cursor >= len(m.builds) {
+		return model.BlenderBuild{}, false
+	}
+	return m.builds[m.cursor], true
+}
+
+// installedSizeLabel describes the disk space of a build: measured for installs, estimated otherwise
+func (m *Model) installedSizeLabel(build model.BlenderBuild) string {
+	if build.Status == model.StateLocal && build.InstalledSize > 0 {
+		return model.FormatByteSize(build.InstalledSize)
+	}
+	return "~" + model.FormatByteSize(model.EstimateInstalledSize(build, m.installedBuilds())) + " (estimated)"
+}
+
+// installedBuilds returns the local builds whose installed size is known
+func (m *Model) installedBuilds() []model.BlenderBuild {
+	var builds []model.BlenderBuild
+	for _, b := range m.builds {
+		if b.Status == model.StateLocal && b.InstalledSize > 0 {
+			builds = append(builds, b)
+		}
+		if b.Installed != nil && b.Installed.InstalledSize > 0 {
+			builds = append(builds, *b.Installed)
+		}
+	}
+	return builds
+}
+
+// buildStatusLabel returns the status shown in the details page
+func buildStatusLabel(build model.BlenderBuild) string {
+	if build.Shared {
+		return build.Status.String() + " (shared, read-only)"
+	}
+	return build.Status.String()
+}
+
+// buildDetailFields collects the general information shown for a build
+func buildDetailFields(build model.BlenderBuild) []detailField {
+	return []detailField{
+		{"Version", build.Version},
+		{"Status", buildStatusLabel(build)},
+		{"Branch", build.Branch},
+		{"Type", build.ReleaseCycle},
+		{"Source", build.SourceLabel()},
+		{"Pull Request", pullRequestLabel(build)},
+		{"Hash", build.Hash},
+		{"Size", model.FormatByteSize(build.Size)},
+		{"Build Date", model.FormatBuildDate(build.BuildDate)},
+		{"Platform", build.OperatingSystem + " " + build.Architecture},
+		{"File", build.FileName},
+	}
+}
+
+// pullRequestLabel returns the pull request of a patch build with its number, or "-"
+func pullRequestLabel(build model.BlenderBuild) string {
+	if pr := build.PullRequest(); pr != "" {
+		return "#" + pr + "  " + pullRequestURL(pr)
+	}
+	return "-"
+}
+
+// introspectionFields collects the probed Python and library versions for a build
+func introspectionFields(info *model.BuildIntrospection) []detailField {
+	if info == nil {
+		return nil
+	}
+	fields := []detailField{
+		{"Blender", info.BlenderVersion},
+		{"Python", info.PythonVersion},
+	}
+
+	// Sort library names for a stable display order
+	names := make([]string, 0, len(info.Libraries))
+	for name := range info.Libraries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields = append(fields, detailField{name, info.Libraries[name]})
+	}
+
+	fields = append(fields, detailField{"Probed", model.FormatBuildDate(info.ProbedAt)})
+	return fields
+}
+
+// smokeTestFields shows the result of the smoke test run after installing a build
+func smokeTestFields(result *model.SmokeTestResult) []detailField {
+	if result == nil {
+		return nil
+	}
+	fields := []detailField{{"Result", "passed"}}
+	if !result.Passed {
+		fields = []detailField{{"Result", "broken"}, {"Error", result.Error}}
+	}
+	if result.Version != "" {
+		fields = append(fields, detailField{"Reported", result.Version})
+	}
+	return append(fields, detailField{"Tested", model.FormatBuildDate(result.TestedAt)})
+}
+
+// gpuProbeFields collects the results of the GPU device probe for a build
+func gpuProbeFields(result *model.GPUProbeResult) []detailField {
+	if result == nil {
+		return nil
+	}
+	describe := func(ok bool) string {
+		if ok {
+			return "available"
+		}
+		return "unavailable"
+	}
+
+	var fields []detailField
+	if result.Crashed {
+		fields = append(fields, detailField{"GPU probe", "crashed"})
+	}
+	names := make([]string, 0, len(result.Compute))
+	for name := range result.Compute {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields = append(fields, detailField{name, describe(result.Compute[name])})
+	}
+	if result.Renderer != "" {
+		fields = append(fields, detailField{"GPU device", result.Renderer})
+	}
+	fields = append(fields, detailField{"Probed", model.FormatBuildDate(result.ProbedAt)})
+	return fields
+}
+
+// gpuBackendsLabel lists the Cycles GPU backends of a build and where the list comes from
+func gpuBackendsLabel(build model.BlenderBuild) string {
+	backends, found := build.SupportedGPUBackends()
+	label := "none"
+	if len(backends) > 0 {
+		label = strings.Join(backends, ", ")
+	}
+	if found {
+		return label + " (found in the installed files)"
+	}
+	return label + " (expected for official builds)"
+}
+
+// renderDetailSection renders a titled block of label/value lines
+func renderDetailSection(title string, fields []detailField) string {
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	labelStyle := lp.NewStyle().Bold(true).Width(14)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(title))
+	sb.WriteString("\n")
+	for _, f := range fields {
+		sb.WriteString(labelStyle.Render(f.label))
+		sb.WriteString(f.value)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// renderDetailsContent renders the details page for the selected build
+func (m *Model) renderDetailsContent(availableHeight int) string {
+	build, ok := m.selectedBuild()
+	if !ok {
+		return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, "No build selected.")
+	}
+
+	fields := buildDetailFields(build)
+	fields = append(fields, detailField{"Installed Size", m.installedSizeLabel(build)})
+	if build.Extraction != nil {
+		fields = append(fields, detailField{"Extraction", build.Extraction.Summary()})
+	}
+	if build.Label != "" {
+		fields = append(fields, detailField{"Label", build.Label})
+	}
+	if build.DownloadedFrom != "" {
+		fields = append(fields, detailField{"Downloaded From", build.DownloadedFrom})
+	}
+	if m.stats != nil {
+		if t, ok := m.stats.LastTransfer(build.Version, build.Architecture); ok {
+			fields = append(fields, detailField{"Last Download", t.Finished.Local().Format("2006-01-02 15:04") + ", " + t.Summary()})
+			if len(t.Phases) > 0 {
+				fields = append(fields, detailField{"Phases", t.PhaseSummary()})
+			}
+		}
+	}
+	if build.ArchiveDir != "" {
+		fields = append(fields, detailField{"Archive Directory", build.ArchiveDir})
+	}
+	if badge := verificationBadge(build); badge != "" {
+		fields = append(fields, detailField{"Verified", badge})
+	}
+	if badge := promotionBadge(build); badge != "" {
+		fields = append(fields, detailField{"Promotion", badge})
+	}
+	for i, value := range m.customColumnValues(build) {
+		if value != "" && i < len(m.config.CustomColumns) {
+			fields = append(fields, detailField{m.config.CustomColumns[i].Name, value})
+		}
+	}
+	if installed := installedBuild(build); installed.UpdateSnoozed(time.Now()) {
+		fields = append(fields, detailField{"Updates", "snoozed until " + installed.SnoozedUntil.Time().Local().Format("2006-01-02 15:04")})
+	}
+	fields = append(fields, detailField{"Cycles GPU", gpuBackendsLabel(build)})
+	if required := build.GlibcRequirement(m.systemGlibc); required != "" {
+		fields = append(fields, detailField{"glibc", fmt.Sprintf("needs %s or newer, this system has %s", required, m.systemGlibc)})
+	}
+	if profile := m.launchProfileLabel(build); profile != "" {
+		fields = append(fields, detailField{"Launch Profile", profile})
+	}
+	if tool := m.config.Sandbox[build.SourceLabel()]; tool != "" && tool != config.SandboxNone {
+		fields = append(fields, detailField{"Sandbox", "launched with " + tool})
+	}
+	if build.Version == m.config.BlendHandler {
+		fields = append(fields, detailField{".blend files", "opened by this build"})
+	}
+
+	var b strings.Builder
+	b.WriteString(renderDetailSection("Build", fields))
+	b.WriteString("\n")
+
+	if build.Status == model.StateUpdate && build.Installed != nil {
+		b.WriteString(renderDetailSection("Update", updateDeltaFields(*build.Installed, build)))
+		b.WriteString("\n")
+		b.WriteString(m.renderCommitLog(*build.Installed, build))
+		b.WriteString("\n")
+	}
+
+	if build.Notes != "" {
+		b.WriteString(renderNotesSection(build.Notes, m.terminalWidth-4))
+		b.WriteString("\n")
+	}
+
+	if len(build.ExtractWarnings) > 0 {
+		fields := make([]detailField, len(build.ExtractWarnings))
+		for i, warning := range build.ExtractWarnings {
+			fields[i] = detailField{"Warning", warning}
+		}
+		b.WriteString(renderDetailSection("Extraction", fields))
+		b.WriteString("\n")
+	}
+
+	if fields := smokeTestFields(build.SmokeTest); fields != nil {
+		b.WriteString(renderDetailSection("Smoke Test", fields))
+		b.WriteString("\n")
+	}
+
+	if fields := introspectionFields(build.Introspection); fields != nil {
+		b.WriteString(renderDetailSection("Bundled Components", fields))
+	} else if build.Status == model.StateLocal || build.Status == model.StateUpdate {
+		b.WriteString(renderDetailSection("Bundled Components", []detailField{
+			{"Not probed", "press p to run the build once and collect versions"},
+		}))
+	}
+
+	if fields := gpuProbeFields(build.GPUProbe); fields != nil {
+		b.WriteString("\n")
+		b.WriteString(renderDetailSection("GPU Devices", fields))
+	}
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Left, lp.Top,
+		lp.NewStyle().MarginLeft(2).Render(b.String()))
+}
+
+// renderDetailsFooter renders the footer for the details view
+func (m *Model) renderDetailsFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{}
+	if build, ok := m.selectedBuild(); ok && (build.Status == model.StateLocal || build.Status == model.StateUpdate) {
+		commands = append(commands,
+			fmt.Sprintf("%s Probe build", keyStyle.Render("p")),
+			fmt.Sprintf("%s Edit notes", keyStyle.Render("n")),
+		)
+	}
+	commands = append(commands,
+		fmt.Sprintf("%s Compare metadata", keyStyle.Render("m")),
+		fmt.Sprintf("%s Back", keyStyle.Render("esc")),
+		fmt.Sprintf("%s Quit", keyStyle.Render("q")),
+	)
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
+
+// updateDetailsView handles key events in the details view
+func (m *Model) updateDetailsView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	for _, cmd := range GetCommandsForView(viewDetails) {
+		if key.Matches(msg, GetKeyBinding(cmd.Type)) {
+			switch cmd.Type {
+			case CmdQuit:
+				return m, tea.Quit
+
+			case CmdBack:
+				m.currentView = viewList
+				return m, nil
+
+			case CmdEditNotes:
+				return m.openNotesEditor()
+
+			case CmdProbeBuild:
+				build, ok := m.selectedBuild()
+				if ok && (build.Status == model.StateLocal || build.Status == model.StateUpdate) && !m.rejectShared(build) {
+					return m, m.commands.ProbeBuild(installedBuild(build))
+				}
+				return m, nil
+
+			case CmdMetadataDiff:
+				return m.openMetadataDiff()
+			}
+		}
+	}
+	return m, nil
+}
+
+// handleBuildProbed stores freshly probed introspection data on the rows of the probed build,
+// matched by hash so another install of the same version keeps its own data
+func (m *Model) handleBuildProbed(msg buildProbedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	var repairCmd tea.Cmd
+	for i := range m.builds {
+		if installed := installedBuild(m.builds[i]); installed.Matches(msg.version, msg.build.Architecture) && installed.Hash == msg.build.Hash {
+			m.builds[i].Introspection = msg.build.Introspection
+			m.builds[i].GPUProbe = msg.build.GPUProbe
+			m.builds[i].SmokeTest = msg.build.SmokeTest
+			if m.builds[i].Broken() {
+				repairCmd = m.offerRepair(m.builds[i])
+			}
+		}
+	}
+	return m, repairCmd
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildProbedMatchesHash(t *testing.T) {
+	downloadDir := t.TempDir()
+	builds := []model.BlenderBuild{
+		{Version: "4.3.0", Hash: "a1b2c3d4e5f6", Status: model.StateLocal},
+		{Version: "4.3.0", Hash: "b1b2c3d4e5f6", Status: model.StateLocal},
+	}
+	for i, build := range builds {
+		dir := filepath.Join(downloadDir, "blender-4.3.0-"+build.Hash)
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := local.WriteBuildInfo(dir, build); err != nil {
+			t.Fatal(err)
+		}
+		if found, err := local.FindBuildDirOf(downloadDir, build); err != nil || found != dir {
+			t.Errorf("Expected %s for build %d, got %q (%v)", dir, i, found, err)
+		}
+	}
+
+	m := &Model{builds: builds}
+	probed := builds[1]
+	probed.Introspection = &model.BuildIntrospection{BlenderVersion: "4.3.0", PythonVersion: "3.11.9"}
+	m.handleBuildProbed(buildProbedMsg{version: probed.Version, build: &probed})
+	if m.builds[0].Introspection != nil {
+		t.Error("Expected the other install of 4.3.0 to keep its own probe data")
+	}
+	if m.builds[1].Introspection == nil || m.builds[1].Introspection.PythonVersion != "3.11.9" {
+		t.Errorf("Expected the probed build to show the probe data, got %+v", m.builds[1].Introspection)
+	}
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/digest"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// digestMaxBuilds is how many builds of a series the digest lists, the others are only counted
+const digestMaxBuilds = 5
+
+// digestPanel summarizes the builds published since the last session, shown once after a break
+type digestPanel struct {
+	since  time.Time
+	groups []digest.Group
+}
+
+// startSession records that the launcher runs now and returns when it ran before,
+// or zero when that was too recent for a digest
+func startSession() (time.Time, error) {
+	session, err := digest.LoadSession()
+	if err != nil {
+		return time.Time{}, err
+	}
+	var since time.Time
+	now := time.Now()
+	if session.Due(now) {
+		since = session.LastRun
+	}
+	session.LastRun = now
+	return since, session.Save()
+}
+
+// showDigest opens the digest of the builds published since the last session, once per session.
+// A cached listing doesn't tell what was published meanwhile, the digest waits for the builder.
+func (m *Model) showDigest(msg buildsFetchedMsg) {
+	if m.digestSince.IsZero() || msg.offline || msg.err != nil {
+		return
+	}
+	since := m.digestSince
+	m.digestSince = time.Time{}
+
+	groups := digest.Build(m.config.BuildType, msg.builds, since)
+	if len(groups) == 0 {
+		m.showNotice(fmt.Sprintf("No new %s builds since your last session on %s", m.config.BuildType, since.Format("Jan 2")))
+		return
+	}
+	m.digest = &digestPanel{since: since, groups: groups}
+}
+
+// updateDigest closes the digest
+func (m *Model) updateDigest(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter", "q":
+		m.digest = nil
+	}
+	return m, nil
+}
+
+// renderDigest renders the builds published since the last session per series
+func (m *Model) renderDigest(availableHeight int) string {
+	panel := m.digest
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	seriesStyle := lp.NewStyle().Bold(true)
+	dimStyle := lp.NewStyle().Faint(true)
+
+	total := 0
+	for _, group := range panel.groups {
+		total += len(group.Builds)
+	}
+
+	title := fmt.Sprintf("%d new builds since your last session", total)
+	if total == 1 {
+		title = "1 new build since your last session"
+	}
+	var lines []string
+	lines = append(lines, titleStyle.Render(title))
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("Published after %s", panel.since.Format("Mon Jan 2 15:04"))))
+	for _, group := range panel.groups {
+		lines = append(lines, "")
+		lines = append(lines, seriesStyle.Render(fmt.Sprintf("%s %s (%d)", group.BuildType, group.Series, len(group.Builds))))
+		for i, build := range group.Builds {
+			if i == digestMaxBuilds {
+				lines = append(lines, dimStyle.Render(fmt.Sprintf("  and %d more", len(group.Builds)-digestMaxBuilds)))
+				break
+			}
+			lines = append(lines, fmt.Sprintf("  %s %s %s %s", fillText(versionCell(build), 16), fillText(build.Branch, 12), fillText(build.Hash, 10), model.FormatBuildDate(build.BuildDate)))
+		}
+	}
+
+	// Keep the panel within the content area, the rest is cut
+	if maxLines := availableHeight - 2; maxLines > 0 && len(lines) > maxLines {
+		lines = append(lines[:maxLines-1], dimStyle.Render("  ..."))
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderDigestFooter renders the key hints for the digest
+func (m *Model) renderDigestFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	footerContent := newlineStyle + fmt.Sprintf("%s Dismiss", keyStyle.Render("esc"))
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// dirMenu lists the directories of an installed build to open in the file explorer
+type dirMenu struct {
+	build  model.BlenderBuild
+	dirs   []local.BuildDir
+	cursor int
+}
+
+// FindBuildDirs creates a command to look up the directories of a local build, see local.BuildDirs
+func (c *Commands) FindBuildDirs(build model.BlenderBuild) tea.Cmd {
+	buildsDir := c.cfg.DownloadDir
+	if build.Shared {
+		buildsDir = c.cfg.SharedDir
+	}
+	return func() tea.Msg {
+		installDir, err := local.FindBuildDir(buildsDir, build.Version, build.Architecture)
+		if err != nil {
+			return buildDirsFoundMsg{build: build, err: err}
+		}
+		if installDir == "" {
+			return buildDirsFoundMsg{build: build, err: fmt.Errorf("build directory for Blender version %s not found", build.Version)}
+		}
+		dirs, err := local.BuildDirs(installDir, build.Version)
+		return buildDirsFoundMsg{build: build, dirs: dirs, err: err}
+	}
+}
+
+// handleBuildDirsFound opens the directory menu of a build
+func (m *Model) handleBuildDirsFound(msg buildDirsFoundMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.dirMenu = &dirMenu{build: msg.build, dirs: msg.dirs}
+	return m, nil
+}
+
+// updateDirMenu handles key events while the directory menu is open
+func (m *Model) updateDirMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := m.dirMenu
+	switch msg.String() {
+	case "esc", "o", "q":
+		m.dirMenu = nil
+
+	case "up", "k":
+		menu.cursor = (menu.cursor - 1 + len(menu.dirs)) % len(menu.dirs)
+
+	case "down", "j":
+		menu.cursor = (menu.cursor + 1) % len(menu.dirs)
+
+	case "enter":
+		dir := menu.dirs[menu.cursor]
+		// Missing user directories aren't created, Blender decides about them at its first start
+		if !dir.Exists {
+			m.err = fmt.Errorf("%s does not exist yet, Blender %s creates it when needed", dir.Path, menu.build.Version)
+			return m, nil
+		}
+		m.dirMenu = nil
+		return m, func() tea.Msg {
+			if err := local.OpenFileExplorer(dir.Path); err != nil {
+				return errMsg{fmt.Errorf("failed to open directory: %w", err)}
+			}
+			return nil
+		}
+	}
+	return m, nil
+}
+
+// renderDirMenu renders the directory menu popup
+func (m *Model) renderDirMenu(availableHeight int) string {
+	menu := m.dirMenu
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	pathStyle := lp.NewStyle().Faint(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Open directory of Blender " + versionCell(menu.build)))
+	b.WriteString("\n\n")
+	for i, dir := range menu.dirs {
+		name := dir.Name
+		if !dir.Exists {
+			name += " (not created yet)"
+		}
+		line := fillText(name, 34)
+		if i == menu.cursor {
+			b.WriteString(selectedRowStyle.Render(line))
+		} else {
+			b.WriteString(regularRowStyle.Render(line))
+		}
+		b.WriteString(" " + pathStyle.Render(truncateMiddle(abbreviatePath(dir.Path, 0), max(m.terminalWidth-44, 10))))
+		if i < len(menu.dirs)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(b.String())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderDirMenuFooter renders the key hints for the directory menu
+func (m *Model) renderDirMenuFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Open", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Select", keyStyle.Render("↑/↓")),
+		fmt.Sprintf("%s Close", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
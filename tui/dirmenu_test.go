@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDirMenu(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+
+	downloadDir := t.TempDir()
+	build := model.BlenderBuild{Version: "4.3.0", Status: model.StateLocal}
+	installDir := filepath.Join(downloadDir, "blender-4.3.0-linux-x64")
+	if err := os.Mkdir(installDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(build)
+	if err := os.WriteFile(filepath.Join(installDir, "version.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Commands{cfg: config.Config{DownloadDir: downloadDir}}
+	msg, ok := c.FindBuildDirs(build)().(buildDirsFoundMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("Expected the build directories, got %+v", msg)
+	}
+	var names []string
+	for _, dir := range msg.dirs {
+		names = append(names, dir.Name)
+	}
+	if len(msg.dirs) != 5 || msg.dirs[0].Path != installDir || !msg.dirs[0].Exists {
+		t.Fatalf("Expected the installation, user config, extensions, add-ons and temp directories, got %v", names)
+	}
+	// Blender 4.3 keeps its settings in the 4.3 user config directory, not created before its first start
+	if userDir := msg.dirs[1]; filepath.Base(userDir.Path) != "4.3" || userDir.Exists {
+		t.Errorf("Expected a missing 4.3 user config directory, got %+v", userDir)
+	}
+
+	m := &Model{}
+	m.handleBuildDirsFound(msg)
+	m.updateDirMenu(tea.KeyMsg{Type: tea.KeyDown})
+	m.updateDirMenu(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.err == nil || m.dirMenu == nil {
+		t.Error("Expected a missing directory to be reported and the menu to stay open")
+	}
+	if _, err := os.Stat(msg.dirs[1].Path); !os.IsNotExist(err) {
+		t.Error("Expected the user config directory not to be created")
+	}
+}
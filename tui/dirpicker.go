@@ -0,0 +1,326 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// dirPickerDrives is the pseudo-directory listing the drives on Windows
+const dirPickerDrives = ""
+
+// dirPicker is a directory browser that can be embedded wherever a directory has to be chosen
+type dirPicker struct {
+	dir      string           // Directory being listed, dirPickerDrives for the drive list
+	entries  []string         // Names of the subdirectories (or drive roots)
+	cursor   int              // Selected entry; 0 is the parent entry when there is one
+	offset   int              // First visible entry
+	free     string           // Free space on the current filesystem, empty if unknown
+	creating *textinput.Model // New folder name prompt, nil when closed
+	err      error            // Last error, shown below the listing
+}
+
+// newDirPicker opens a directory picker at the nearest existing ancestor of start
+func newDirPicker(start string) *dirPicker {
+	dir := start
+	if strings.HasPrefix(dir, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, dir[1:])
+		}
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	p := &dirPicker{}
+	p.open(dir)
+	return p
+}
+
+// hasParent reports whether the listing starts with a ".." entry
+func (p *dirPicker) hasParent() bool {
+	if p.dir == dirPickerDrives {
+		return false
+	}
+	return filepath.Dir(p.dir) != p.dir || len(local.ListDrives()) > 0
+}
+
+// open lists the subdirectories of dir
+func (p *dirPicker) open(dir string) {
+	p.cursor = 0
+	p.offset = 0
+	p.err = nil
+	p.free = ""
+
+	if dir == dirPickerDrives {
+		p.dir = dir
+		p.entries = local.ListDrives()
+		return
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		p.err = err
+		return
+	}
+	p.dir = dir
+	p.entries = p.entries[:0]
+	for _, entry := range entries {
+		// Hidden directories only clutter the picker
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			p.entries = append(p.entries, entry.Name())
+		}
+	}
+	sort.Slice(p.entries, func(i, j int) bool {
+		return strings.ToLower(p.entries[i]) < strings.ToLower(p.entries[j])
+	})
+
+	if free, err := local.FreeSpace(dir); err == nil {
+		p.free = model.FormatByteSize(int64(free))
+	}
+}
+
+// parent opens the parent directory, or the drive list at a drive root
+func (p *dirPicker) parent() {
+	parent := filepath.Dir(p.dir)
+	if parent == p.dir {
+		if len(local.ListDrives()) == 0 {
+			return
+		}
+		parent = dirPickerDrives
+	}
+	previous := p.dir
+	p.open(parent)
+
+	// Keep the directory we came from selected
+	for i, name := range p.entries {
+		if filepath.Join(parent, name) == previous || name == previous {
+			p.cursor = i + p.parentOffset()
+		}
+	}
+}
+
+// parentOffset is 1 when the listing starts with the ".." entry
+func (p *dirPicker) parentOffset() int {
+	if p.hasParent() {
+		return 1
+	}
+	return 0
+}
+
+// enter opens the selected entry
+func (p *dirPicker) enter() {
+	if p.hasParent() && p.cursor == 0 {
+		p.parent()
+		return
+	}
+	index := p.cursor - p.parentOffset()
+	if index < 0 || index >= len(p.entries) {
+		return
+	}
+	if p.dir == dirPickerDrives {
+		p.open(p.entries[index])
+		return
+	}
+	p.open(filepath.Join(p.dir, p.entries[index]))
+}
+
+// createFolder creates a new folder in the current directory and opens it
+func (p *dirPicker) createFolder(name string) {
+	name = strings.TrimSpace(name)
+	if name == "" || p.dir == dirPickerDrives {
+		return
+	}
+	dir := filepath.Join(p.dir, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		p.err = fmt.Errorf("failed to create folder: %w", err)
+		return
+	}
+	p.open(dir)
+}
+
+// update handles a key press. It returns the chosen directory once the user
+// selects one, and done is true when the picker should close.
+func (p *dirPicker) update(msg tea.KeyMsg, visibleRows int) (selected string, done bool, cmd tea.Cmd) {
+	if p.creating != nil {
+		switch msg.String() {
+		case "esc":
+			p.creating = nil
+		case "enter":
+			name := p.creating.Value()
+			p.creating = nil
+			p.createFolder(name)
+		default:
+			input, cmd := p.creating.Update(msg)
+			p.creating = &input
+			return "", false, cmd
+		}
+		return "", false, nil
+	}
+
+	total := len(p.entries) + p.parentOffset()
+	switch msg.String() {
+	case "esc", "q":
+		return "", true, nil
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < total-1 {
+			p.cursor++
+		}
+	case "enter", "right", "l":
+		p.enter()
+	case "left", "h", "backspace":
+		p.parent()
+	case "n":
+		if p.dir != dirPickerDrives {
+			input := textinput.New()
+			input.Prompt = "New folder: "
+			input.CharLimit = 128
+			input.Width = 40
+			input.Focus()
+			p.creating = &input
+			return "", false, textinput.Blink
+		}
+	case "s", " ":
+		if p.dir != dirPickerDrives {
+			return p.dir, true, nil
+		}
+	}
+
+	// Keep the cursor visible
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if visibleRows > 0 && p.cursor >= p.offset+visibleRows {
+		p.offset = p.cursor - visibleRows + 1
+	}
+	return "", false, nil
+}
+
+// view renders the directory listing
+func (p *dirPicker) view(width, height int) string {
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	dirStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+
+	var b strings.Builder
+	location := p.dir
+	if location == dirPickerDrives {
+		location = "Drives"
+	}
+	b.WriteString(titleStyle.Render(location))
+	if p.free != "" {
+		b.WriteString(fmt.Sprintf("  (%s free)", p.free))
+	}
+	b.WriteString("\n\n")
+
+	var lines []string
+	if p.hasParent() {
+		lines = append(lines, "..")
+	}
+	for _, name := range p.entries {
+		lines = append(lines, name+string(filepath.Separator))
+	}
+	if len(lines) == 0 {
+		b.WriteString(lp.NewStyle().Italic(true).Render("No subdirectories"))
+		b.WriteString("\n")
+	}
+
+	visibleRows := p.visibleRows(height)
+	for i := p.offset; i < len(lines) && i < p.offset+visibleRows; i++ {
+		if i == p.cursor {
+			b.WriteString(selectedRowStyle.Render(lines[i]))
+		} else {
+			b.WriteString(dirStyle.Render(lines[i]))
+		}
+		b.WriteString("\n")
+	}
+
+	if p.creating != nil {
+		b.WriteString("\n")
+		b.WriteString(p.creating.View())
+	}
+	if p.err != nil {
+		b.WriteString("\n")
+		b.WriteString(lp.NewStyle().Foreground(lp.Color(redColor)).Render(p.err.Error()))
+	}
+
+	return lp.Place(width, height, lp.Left, lp.Top, lp.NewStyle().MarginLeft(2).Render(b.String()))
+}
+
+// visibleRows returns how many entries fit below the title and above the prompts
+func (p *dirPicker) visibleRows(height int) int {
+	rows := height - 5
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// footer renders the key hints for the directory picker
+func (p *dirPicker) footer(width int) string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	var commands []string
+	if p.creating != nil {
+		commands = []string{
+			fmt.Sprintf("%s Create", keyStyle.Render("enter")),
+			fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+		}
+	} else {
+		commands = []string{
+			fmt.Sprintf("%s Open", keyStyle.Render("enter/→")),
+			fmt.Sprintf("%s Up", keyStyle.Render("←")),
+			fmt.Sprintf("%s New folder", keyStyle.Render("n")),
+			fmt.Sprintf("%s Use this directory", keyStyle.Render("s")),
+			fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+		}
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(width).Render(footerContent)
+}
+
+// updateDirPicker routes keys to the open directory picker and applies the chosen directory
+func (m *Model) updateDirPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	visibleRows := m.dirPicker.visibleRows(m.contentHeight())
+	selected, done, cmd := m.dirPicker.update(msg, visibleRows)
+	if done {
+		m.dirPicker = nil
+		if panel := m.archiveExport; panel != nil {
+			// The picker was opened from the archive panel to export the selected archive
+			m.archiveExport = nil
+			m.archivePanel = panel
+			if selected == "" {
+				return m, cmd
+			}
+			return m, tea.Batch(cmd, m.commands.ExportArchive(panel.archives[panel.cursor], selected))
+		}
+		if selected != "" && len(m.settingsInputs) > 0 {
+			m.settingsInputs[0].SetValue(selected)
+			m.settingsInputs[0].CursorEnd()
+		}
+	}
+	return m, cmd
+}
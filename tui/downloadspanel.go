@@ -0,0 +1,206 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// downloadsPanel lists every running, paused and queued download, independent of the selected row
+type downloadsPanel struct {
+	cursor int
+}
+
+// toggleDownloadsPanel opens or closes the downloads panel
+func (m *Model) toggleDownloadsPanel() (tea.Model, tea.Cmd) {
+	if m.downloadsPanel != nil {
+		m.downloadsPanel = nil
+		return m, nil
+	}
+	m.downloadsPanel = &downloadsPanel{}
+	return m, nil
+}
+
+// selectedTransfer returns the download under the panel cursor, keeping the cursor within the list
+func (m *Model) selectedTransfer() (Transfer, bool) {
+	transfers := m.commands.downloads.Transfers()
+	panel := m.downloadsPanel
+	if panel.cursor >= len(transfers) {
+		panel.cursor = len(transfers) - 1
+	}
+	if panel.cursor < 0 {
+		panel.cursor = 0
+	}
+	if len(transfers) == 0 {
+		return Transfer{}, false
+	}
+	return transfers[panel.cursor], true
+}
+
+// updateDownloadsPanel handles key events while the downloads panel is open
+func (m *Model) updateDownloadsPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.downloadsPanel
+	dm := m.commands.downloads
+	switch msg.String() {
+	case "esc", "D", "q":
+		m.downloadsPanel = nil
+
+	case "up", "k":
+		if panel.cursor > 0 {
+			panel.cursor--
+		}
+
+	case "down", "j":
+		if panel.cursor < len(dm.Transfers())-1 {
+			panel.cursor++
+		}
+
+	case "p":
+		t, ok := m.selectedTransfer()
+		if !ok {
+			break
+		}
+		if t.State.Paused {
+			dm.ResumeDownload(t.ID)
+		} else if !dm.PauseDownload(t.ID) {
+			m.showNotice(fmt.Sprintf("Blender %s can't be paused while it is %s", versionCell(t.Build), transferStatus(t)))
+		}
+
+	case "x":
+		if t, ok := m.selectedTransfer(); ok {
+			m.cancelTransfer(t.ID)
+		}
+
+	case "+", "-", "!":
+		t, ok := m.selectedTransfer()
+		if !ok {
+			break
+		}
+		if t.QueuePos == 0 {
+			m.showNotice("Only queued downloads can be reordered")
+			break
+		}
+		delta := -1
+		switch msg.String() {
+		case "-":
+			delta = 1
+		case "!":
+			// High priority: the next download to start
+			delta = -t.QueuePos
+		}
+		// Follow the moved download with the cursor
+		panel.cursor += dm.MoveQueued(t.ID, delta)
+	}
+	return m, nil
+}
+
+// cancelTransfer cancels a download from the panel and marks its row Cancelled
+func (m *Model) cancelTransfer(id string) {
+	m.commands.downloads.CancelDownload(id)
+	for i := range m.builds {
+		if downloadID(m.builds[i]) == id &&
+			(m.builds[i].Status == model.StateDownloading || m.builds[i].Status == model.StateExtracting) {
+			m.builds[i].Status = model.StateCancelled
+			m.dropPendingLaunch(m.builds[i])
+		}
+	}
+	if m.activeDownloadID == id {
+		m.activeDownloadID = ""
+	}
+}
+
+// transferStatus describes the state of a download in the panel
+func transferStatus(t Transfer) string {
+	switch {
+	case t.State.Paused:
+		return "Paused"
+	case t.QueuePos > 0:
+		return fmt.Sprintf("Queued #%d", t.QueuePos)
+	case t.State.Queued:
+		return "Queued"
+	}
+	return phaseLabel(t.State)
+}
+
+// transferETA estimates the time left of a running download from its current speed, "" when unknown
+func transferETA(state model.DownloadState) string {
+	if state.BuildState != model.StateDownloading || state.Paused || state.Speed <= 0 || state.Total <= 0 {
+		return ""
+	}
+	left := time.Duration(float64(state.Total-state.Current) / state.Speed * float64(time.Second))
+	return "ETA " + left.Round(time.Second).String()
+}
+
+// renderDownloadsPanel renders the downloads with their progress, speed and time left
+func (m *Model) renderDownloadsPanel(availableHeight int) string {
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	dimStyle := lp.NewStyle().Faint(true)
+
+	transfers := m.commands.downloads.Transfers()
+	m.selectedTransfer() // Clamp the cursor to the current list
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("Downloads (%d)", len(transfers))))
+	lines = append(lines, "")
+	if len(transfers) == 0 {
+		lines = append(lines, dimStyle.Render("No downloads in progress"))
+	}
+	for i, t := range transfers {
+		speed := ""
+		if t.State.BuildState == model.StateDownloading && !t.State.Paused && t.State.Speed > 0 {
+			speed = fmt.Sprintf("%6.1f MB/s", t.State.Speed/1024/1024)
+		}
+		line := fmt.Sprintf("%-16s %-11s ", versionCell(t.Build), transferStatus(t))
+		size := fmt.Sprintf(" %s of %s", model.FormatByteSize(t.State.Current), model.FormatByteSize(t.State.Total))
+		if t.State.Total <= 0 {
+			size = ""
+		}
+		rest := fmt.Sprintf("%-22s %11s %s", size, speed, transferETA(t.State))
+		if i == m.downloadsPanel.cursor {
+			line = selectedRowStyle.Render(line)
+		} else {
+			line = regularRowStyle.Render(line)
+		}
+		lines = append(lines, line+renderProgress(m.progressStyle(), t.State.Progress, 20)+rest)
+	}
+	if m.config.MaxDownloads > 0 {
+		lines = append(lines, "")
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("Up to %d downloads run at the same time (max_downloads)", m.config.MaxDownloads)))
+	}
+
+	// Keep the panel within the content area, the rest is cut
+	if maxLines := availableHeight - 2; maxLines > 0 && len(lines) > maxLines {
+		lines = append(lines[:maxLines-1], dimStyle.Render("  ..."))
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderDownloadsPanelFooter renders the key hints for the downloads panel
+func (m *Model) renderDownloadsPanelFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Pause/resume", keyStyle.Render("p")),
+		fmt.Sprintf("%s Cancel", keyStyle.Render("x")),
+		fmt.Sprintf("%s Priority", keyStyle.Render("+/-")),
+		fmt.Sprintf("%s Next", keyStyle.Render("!")),
+		fmt.Sprintf("%s Select", keyStyle.Render("↑/↓")),
+		fmt.Sprintf("%s Close", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"TUI-Blender-Launcher/download"
 	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/stats"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -21,43 +22,76 @@ func (m *Model) renderBuildFooter() string {
 	// General commands always available
 	generalCommands := []string{
 		fmt.Sprintf("%s Fetch", keyStyle.Render("f")),
+		fmt.Sprintf("%s Type", keyStyle.Render("t")),
+		fmt.Sprintf("%s Filter", keyStyle.Render("v")),
 		fmt.Sprintf("%s Reverse Sort", keyStyle.Render("r")),
+		fmt.Sprintf("%s Details", keyStyle.Render("i")),
+		fmt.Sprintf("%s Group", keyStyle.Render("G")),
 		fmt.Sprintf("%s Settings", keyStyle.Render("s")),
 		fmt.Sprintf("%s Quit", keyStyle.Render("q")),
 	}
 
 	// Contextual commands based on the highlighted build
 	contextualCommands := []string{}
-	if len(m.builds) > 0 && m.cursor < len(m.builds) {
+	if len(m.builds) > 0 && m.cursor < len(m.builds) && !m.onCollapsedHeader() {
 		build := m.builds[m.cursor]
 		if build.Status == model.StateLocal {
 			contextualCommands = append(contextualCommands,
 				fmt.Sprintf("%s Launch", keyStyle.Render("enter")),
 				fmt.Sprintf("%s Open Dir", keyStyle.Render("o")),
 			)
+			if build.Version != m.config.BlendHandler {
+				contextualCommands = append(contextualCommands,
+					fmt.Sprintf("%s Open .blend files", keyStyle.Render("a")),
+				)
+			}
+			if build.NeedsRepair() && m.canRepair(build) == nil {
+				contextualCommands = append(contextualCommands,
+					fmt.Sprintf("%s Repair", keyStyle.Render("d")),
+				)
+			}
 			contextualCommands = append(contextualCommands,
 				fmt.Sprintf("%s Delete", keyStyle.Render("x")),
 			)
 		} else if build.Status == model.StateUpdate {
 			contextualCommands = append(contextualCommands,
 				fmt.Sprintf("%s Download", keyStyle.Render("d")),
+				fmt.Sprintf("%s What changed", keyStyle.Render("i")),
 				fmt.Sprintf("%s Launch", keyStyle.Render("enter")),
 				fmt.Sprintf("%s Open Dir", keyStyle.Render("o")),
 				fmt.Sprintf("%s Delete", keyStyle.Render("x")),
 			)
-		} else if build.Status == model.StateOnline ||
+		} else if (build.Status == model.StateOnline ||
 			build.Status == model.StateCancelled ||
-			build.Status == model.StateFailed {
+			build.Status == model.StateFailed) && !m.offline {
 			contextualCommands = append(contextualCommands,
 				fmt.Sprintf("%s Download", keyStyle.Render("d")),
 			)
 		}
 
-		// Check for active download state
-		buildID := build.Version
-		if build.Hash != "" {
-			buildID = build.Version + "-" + build.Hash[:8]
+		if build.PullRequest() != "" {
+			contextualCommands = append(contextualCommands,
+				fmt.Sprintf("%s Open PR", keyStyle.Render("P")),
+			)
+		}
+		if sourcePageURL(build) != "" {
+			contextualCommands = append(contextualCommands,
+				fmt.Sprintf("%s Web", keyStyle.Render("g")),
+			)
+		}
+
+		if !m.scheduledAt(build).IsZero() {
+			contextualCommands = append(contextualCommands,
+				fmt.Sprintf("%s Unschedule", keyStyle.Render("W")),
+			)
+		} else if build.Status != model.StateLocal && build.Status != model.StateDownloading && build.Status != model.StateExtracting {
+			contextualCommands = append(contextualCommands,
+				fmt.Sprintf("%s Schedule", keyStyle.Render("W")),
+			)
 		}
+
+		// Check for active download state
+		buildID := downloadID(build)
 		state := m.commands.downloads.GetState(buildID)
 		if state != nil && (state.BuildState == model.StateDownloading || state.BuildState == model.StateExtracting) {
 			// Remove any existing download command
@@ -72,9 +106,100 @@ func (m *Model) renderBuildFooter() string {
 				fmt.Sprintf("%s Cancel", keyStyle.Render("x")),
 			)
 		}
+
+		if m.launchPending(build) {
+			contextualCommands = append(contextualCommands,
+				fmt.Sprintf("%s Cancel launch", keyStyle.Render("e")),
+			)
+		} else if build.Status == model.StateDownloading || build.Status == model.StateExtracting ||
+			((build.Status == model.StateOnline || build.Status == model.StateUpdate ||
+				build.Status == model.StateCancelled || build.Status == model.StateFailed) && !m.offline) {
+			contextualCommands = append(contextualCommands,
+				fmt.Sprintf("%s Launch when ready", keyStyle.Render("e")),
+			)
+		}
+
+		contextualCommands = append(contextualCommands,
+			fmt.Sprintf("%s Actions", keyStyle.Render(".")),
+		)
+	}
+
+	if len(m.undoStack) > 0 {
+		contextualCommands = append(contextualCommands,
+			fmt.Sprintf("%s Undo", keyStyle.Render("u")),
+		)
+	}
+
+	// Offer cancelling everything while any download is running, the key asks for confirmation
+	if m.commands.downloads.ActiveCount() > 0 {
+		contextualCommands = append(contextualCommands,
+			fmt.Sprintf("%s Downloads", keyStyle.Render("D")),
+			fmt.Sprintf("%s Cancel all", keyStyle.Render("X")),
+		)
 	}
 
 	line1 := strings.Join(contextualCommands, separator)
+
+	if m.confirmCancelAll {
+		warnStyle := lp.NewStyle().Foreground(lp.Color(redColor))
+		line1 = warnStyle.Render(fmt.Sprintf("Cancel %d active download(s)?", m.commands.downloads.ActiveCount())) + separator +
+			fmt.Sprintf("%s Confirm", keyStyle.Render("X")) + separator +
+			fmt.Sprintf("%s Keep downloading", keyStyle.Render("any other key"))
+	}
+
+	if m.pin != nil && m.pin.prompt {
+		line1 = m.renderPinPrompt(keyStyle, separator)
+	}
+
+	if m.blendLaunch != nil && m.blendLaunch.prompt {
+		line1 = m.renderBlendPrompt(keyStyle, separator)
+	}
+
+	if m.repair.prompt != nil {
+		line1 = m.renderRepairPrompt(keyStyle, separator)
+	}
+
+	if m.partialsPrompt != nil {
+		line1 = m.renderPartialsPrompt(keyStyle, separator)
+	}
+
+	if len(m.conflicts.pending) > 0 {
+		line1 = m.renderConflictPrompt(keyStyle, separator)
+	}
+
+	// Purging old builds is asked first and takes the keys first
+	if m.purgeConfirm != nil {
+		line1 = m.renderPurgeConfirm(keyStyle, separator)
+	}
+
+	if m.yankPending {
+		line1 = "Copy: " + fmt.Sprintf("%s Hash", keyStyle.Render("h")) + separator +
+			fmt.Sprintf("%s Download URL", keyStyle.Render("u")) + separator +
+			fmt.Sprintf("%s Install path", keyStyle.Render("p")) + separator +
+			fmt.Sprintf("%s Cancel", keyStyle.Render("any other key"))
+	}
+
+	// A metered or over budget download confirmation shows the size prominently
+	if m.downloadConfirm != "" && len(m.builds) > 0 && m.cursor < len(m.builds) && m.builds[m.cursor].Version == m.downloadConfirm {
+		build := m.builds[m.cursor]
+		sizeStyle := lp.NewStyle().Foreground(lp.Color(orangeColor)).Bold(true)
+		installed := model.FormatByteSize(model.EstimateInstalledSize(build, m.installedBuilds()))
+		reason := "Metered connection"
+		if m.budgetState(build.Size) != stats.BudgetOK {
+			reason = fmt.Sprintf("Download budget %s used this month", m.budgetUsage())
+		}
+		line1 = fmt.Sprintf("%s: download %s of Blender %s (~%s on disk)?", reason, sizeStyle.Render(model.FormatByteSize(build.Size)), build.Version, installed) + separator +
+			fmt.Sprintf("%s Confirm", keyStyle.Render("d")) + separator +
+			fmt.Sprintf("%s Cancel", keyStyle.Render("any other key"))
+	}
+
+	// A pending launch warning replaces the contextual commands
+	if m.launchWarning != nil {
+		warnStyle := lp.NewStyle().Foreground(lp.Color(redColor))
+		line1 = warnStyle.Render("⚠ "+m.launchWarning.warning) + separator +
+			fmt.Sprintf("%s Launch anyway", keyStyle.Render("enter")) + separator +
+			fmt.Sprintf("%s Cancel", keyStyle.Render("any other key"))
+	}
 	line2 := strings.Join(generalCommands, separator)
 
 	// Combine lines with styled newline
@@ -110,11 +235,21 @@ func (m *Model) renderSettingsFooter() string {
 		commands = append(commands, fmt.Sprintf("%s Clean old Builds Dir", keyStyle.Render("c")))
 	}
 
+	if m.focusIndex == 0 && !m.editMode {
+		commands = append(commands, fmt.Sprintf("%s Browse", keyStyle.Render("b")))
+	}
+	if m.focusIndex == len(m.settingsInputs)+1 {
+		commands = append(commands, fmt.Sprintf("%s Regenerate ID", keyStyle.Render("r")))
+	}
+
 	commands = append(commands, fmt.Sprintf("%s Quit", keyStyle.Render("q")))
 
 	line2 := strings.Join(commands, separator)
 
+	// Downloads keep running while the settings are open
+	line1 := m.renderDownloadSummary()
+
 	// Combine lines with styled newline
-	footerContent := newlineStyle + line2
+	footerContent := line1 + newlineStyle + line2
 	return footerStyle.Width(m.terminalWidth).Render(footerContent)
 }
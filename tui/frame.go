@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxFPS caps how often the terminal is redrawn, which keeps slow terminals and SSH sessions from flickering
+	MaxFPS = 20
+	// frameProgressStep is the smallest progress change of a transfer that redraws the page on a tick
+	frameProgressStep = 0.005
+)
+
+// frameCache holds the last rendered page, so ticks that change nothing visible don't render it again
+type frameCache struct {
+	view        string
+	dirty       bool   // A message other than a tick arrived since the page was rendered
+	fingerprint string // What the page showed of the transfers, see frameFingerprint
+}
+
+// markDirty makes the next View render the page again
+func (m *Model) markDirty() {
+	m.frame.dirty = true
+}
+
+// frameFingerprint describes what ticks change on the page: the transfers with their progress rounded to
+// progressStep, the notice, the error and the highlighted rows. The page is rendered again when it changes;
+// the speed shown is refreshed along with the progress. The transfers are read from the snapshots of
+// SyncDownloadStates, never from the states the download goroutines update.
+func (m *Model) frameFingerprint() string {
+	ids := make([]string, 0, len(m.downloadStates))
+	for id := range m.downloadStates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var b strings.Builder
+	for _, id := range ids {
+		state := m.downloadStates[id]
+		fmt.Fprintf(&b, "%s:%d:%t:%t:%d;", id, state.BuildState, state.Paused, state.Queued, int(state.Progress/m.progressStep()))
+	}
+	b.WriteString(m.notice)
+	if m.err != nil {
+		b.WriteString(m.err.Error())
+	}
+	b.WriteString(m.highlightFingerprint(time.Now()))
+
+	// The braille spinner and the time left in the downloads panel move on their own
+	if m.transfersRunning() && (m.progressStyle() == "braille" || m.downloadsPanel != nil) {
+		fmt.Fprintf(&b, "@%d", time.Now().UnixMilli()/100)
+	}
+	return b.String()
+}
+
+// renderFrame returns the page, rendering it again only when it is dirty or its fingerprint changed
+func (m *Model) renderFrame(render func() string) string {
+	fingerprint := m.frameFingerprint()
+	if !m.frame.dirty && m.frame.view != "" && fingerprint == m.frame.fingerprint {
+		return m.frame.view
+	}
+	m.frame = frameCache{view: render(), fingerprint: fingerprint}
+	return m.frame.view
+}
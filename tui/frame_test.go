@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"testing"
+)
+
+func TestRenderFrame(t *testing.T) {
+	state := &model.DownloadState{BuildState: model.StateDownloading, Progress: 0.1}
+	m := &Model{downloadStates: map[string]*model.DownloadState{"4.3.0": state}}
+	renders := 0
+	render := func() string {
+		renders++
+		return "page"
+	}
+
+	m.renderFrame(render)
+	m.renderFrame(render)
+	if renders != 1 {
+		t.Errorf("Expected an unchanged page to be rendered once, got %d renders", renders)
+	}
+
+	// Progress below the step doesn't redraw, a larger change or a pause does
+	state.Progress += frameProgressStep / 4
+	m.renderFrame(render)
+	if renders != 1 {
+		t.Errorf("Expected a small progress change to reuse the page, got %d renders", renders)
+	}
+	state.Progress += frameProgressStep
+	m.renderFrame(render)
+	state.Paused = true
+	m.renderFrame(render)
+	if renders != 3 {
+		t.Errorf("Expected progress and pause to redraw, got %d renders", renders)
+	}
+
+	// Any other message marks the page dirty
+	m.markDirty()
+	m.renderFrame(render)
+	m.renderFrame(render)
+	if renders != 4 {
+		t.Errorf("Expected a dirty page to be rendered once, got %d renders", renders)
+	}
+}
+
+func TestFrameFingerprintWhileInstalling(t *testing.T) {
+	dm := NewDownloadManager(config.Config{}, nil)
+	dm.states["4.3.0"] = &model.DownloadState{BuildState: model.StateExtracting}
+	m := &Model{commands: &Commands{downloads: dm}, downloadStates: make(map[string]*model.DownloadState)}
+
+	// The extraction reports progress while the page is rendered, go test -race catches shared reads
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		progress, phase := dm.installProgress("4.3.0", make(chan struct{})), dm.installPhase("4.3.0")
+		phase(model.PhaseExtract)
+		for i := int64(1); i <= 100; i++ {
+			progress(i, 100)
+		}
+	}()
+	for range 100 {
+		m.SyncDownloadStates()
+		m.frameFingerprint()
+	}
+	<-done
+
+	m.SyncDownloadStates()
+	if state := m.downloadStates["4.3.0"]; state.Progress != 1 || state == dm.states["4.3.0"] {
+		t.Errorf("Expected a snapshot of the finished extraction, got %+v", state)
+	}
+}
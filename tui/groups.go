@@ -0,0 +1,236 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strings"
+	"time"
+
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// listLine is a single line of the grouped build list: either a series header or a build
+type listLine struct {
+	series     string
+	header     bool
+	buildIndex int // Index into m.builds; for headers, the first build of the series
+}
+
+// sortBuilds sorts the build list by the selected column, grouping by series when enabled
+func (m *Model) sortBuilds() {
+	if m.sortColumn >= firstCustomColumn+len(m.config.CustomColumns) {
+		// The custom column was removed from the config
+		m.sortColumn = 0
+	}
+	if m.sortColumn >= firstCustomColumn {
+		m.builds = m.sortByCustomColumn(m.builds, m.sortColumn-firstCustomColumn, m.sortReversed)
+	} else {
+		m.builds = model.SortBuilds(m.builds, m.sortColumn, m.sortReversed)
+	}
+	if m.grouped {
+		m.builds = model.GroupBuildsBySeries(m.builds)
+	}
+}
+
+// listLines returns the lines of the grouped list, skipping builds of collapsed series
+func (m *Model) listLines() []listLine {
+	var lines []listLine
+	currentSeries := ""
+	for i, build := range m.builds {
+		series := model.BuildSeries(build.Version)
+		if i == 0 || series != currentSeries {
+			currentSeries = series
+			lines = append(lines, listLine{series: series, header: true, buildIndex: i})
+		}
+		if !m.collapsedSeries[series] {
+			lines = append(lines, listLine{series: series, buildIndex: i})
+		}
+	}
+	return lines
+}
+
+// cursorLine returns the index of the line the cursor is on.
+// In a collapsed series the header stands in for its builds.
+func (m *Model) cursorLine(lines []listLine) int {
+	if len(m.builds) == 0 || m.cursor >= len(m.builds) {
+		return 0
+	}
+	series := model.BuildSeries(m.builds[m.cursor].Version)
+	collapsed := m.collapsedSeries[series]
+	for i, line := range lines {
+		if collapsed && line.header && line.series == series {
+			return i
+		}
+		if !collapsed && !line.header && line.buildIndex == m.cursor {
+			return i
+		}
+	}
+	return 0
+}
+
+// moveGroupedCursor moves the cursor between the selectable lines of the grouped list
+func (m *Model) moveGroupedCursor(direction string, visibleRowsCount int) {
+	// Selectable lines: builds of expanded series, headers of collapsed ones
+	lines := m.listLines()
+	var targets []int
+	pos := 0
+	current := m.cursorLine(lines)
+	for i, line := range lines {
+		if line.header == m.collapsedSeries[line.series] {
+			if i == current {
+				pos = len(targets)
+			}
+			targets = append(targets, line.buildIndex)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	switch direction {
+	case "up":
+		pos = (pos - 1 + len(targets)) % len(targets)
+	case "down":
+		pos = (pos + 1) % len(targets)
+	case "home":
+		pos = 0
+	case "end":
+		pos = len(targets) - 1
+	case "pageup":
+		pos -= visibleRowsCount
+		if pos < 0 {
+			pos = 0
+		}
+	case "pagedown":
+		pos += visibleRowsCount
+		if pos >= len(targets) {
+			pos = len(targets) - 1
+		}
+	}
+	m.cursor = targets[pos]
+}
+
+// listWideCommands are the list commands that don't act on the selected build
+var listWideCommands = map[CommandType]bool{
+	CmdQuit: true, CmdShowSettings: true, CmdToggleSortOrder: true, CmdFetchBuilds: true,
+	CmdMoveUp: true, CmdMoveDown: true, CmdMoveLeft: true, CmdMoveRight: true,
+	CmdPageUp: true, CmdPageDown: true, CmdHome: true, CmdEnd: true,
+	CmdQuickLaunch: true, CmdCycleBuildType: true, CmdEditFilter: true, CmdCancelAll: true,
+	CmdToggleGrouping: true, CmdFilterTag: true, CmdToggleDensity: true, CmdRecentProjects: true,
+	CmdToggleNewOnly: true, CmdUndo: true, CmdSpeedTest: true, CmdDownloadsPanel: true, CmdArchiveCache: true,
+}
+
+// onCollapsedHeader reports whether the cursor is on the header of a collapsed series.
+// The header stands in for the builds of the series in the list but selects none of them.
+func (m *Model) onCollapsedHeader() bool {
+	build, ok := m.selectedBuild()
+	return ok && m.grouped && m.collapsedSeries[model.BuildSeries(build.Version)]
+}
+
+// setSeriesCollapsed collapses or expands the series of the build under the cursor
+func (m *Model) setSeriesCollapsed(collapsed bool) {
+	if len(m.builds) == 0 || m.cursor >= len(m.builds) {
+		return
+	}
+	series := model.BuildSeries(m.builds[m.cursor].Version)
+	m.collapsedSeries[series] = collapsed
+
+	// Keep the cursor on the series: a collapsed series is represented by its first build
+	if collapsed {
+		for i, build := range m.builds {
+			if model.BuildSeries(build.Version) == series {
+				m.cursor = i
+				break
+			}
+		}
+	}
+}
+
+// renderSeriesHeader renders the summary row shown above the builds of a series
+func renderSeriesHeader(series string, builds []model.BlenderBuild, collapsed, selected bool, width int) string {
+	installed, updates := 0, 0
+	var totalSize int64
+	lts := false
+	for _, build := range builds {
+		lts = lts || build.IsLTS()
+		switch build.Status {
+		case model.StateLocal:
+			installed++
+		case model.StateUpdate:
+			installed++
+			updates++
+		}
+		totalSize += build.Size
+	}
+
+	marker := "▾"
+	if collapsed {
+		marker = "▸"
+	}
+	if lts {
+		series += " LTS"
+	}
+	parts := []string{fmt.Sprintf("%s %s", marker, series), fmt.Sprintf("%d build(s)", len(builds))}
+	if installed > 0 {
+		parts = append(parts, fmt.Sprintf("%d installed", installed))
+	}
+	if updates > 0 {
+		parts = append(parts, fmt.Sprintf("%d update(s) available", updates))
+	}
+	parts = append(parts, model.FormatByteSize(totalSize))
+	text := strings.Join(parts, " · ")
+
+	style := lp.NewStyle().Bold(true).Width(width).Foreground(lp.Color(highlightColor))
+	if selected {
+		style = selectedRowStyle.Bold(true).Width(width)
+	}
+	return style.Render(truncateText(text, width))
+}
+
+// renderGroupedRows renders the visible part of the grouped build list
+func renderGroupedRows(m *Model, visibleRowsCount int) string {
+	columns := GetBuildColumns(m.terminalWidth, m.compact(), m.config.CustomColumns)
+	lines := m.listLines()
+	cursorLine := m.cursorLine(lines)
+
+	// Scroll so the cursor line stays visible
+	if cursorLine < m.groupStart {
+		m.groupStart = cursorLine
+	} else if cursorLine >= m.groupStart+visibleRowsCount {
+		m.groupStart = cursorLine - visibleRowsCount + 1
+	}
+	if m.groupStart > len(lines)-1 {
+		m.groupStart = 0
+	}
+	endIndex := m.groupStart + visibleRowsCount
+	if endIndex > len(lines) {
+		endIndex = len(lines)
+	}
+
+	var rendered []string
+	for i := m.groupStart; i < endIndex; i++ {
+		line := lines[i]
+		if line.header {
+			var seriesBuilds []model.BlenderBuild
+			for j := line.buildIndex; j < len(m.builds) && model.BuildSeries(m.builds[j].Version) == line.series; j++ {
+				seriesBuilds = append(seriesBuilds, m.builds[j])
+			}
+			rendered = append(rendered, renderSeriesHeader(line.series, seriesBuilds,
+				m.collapsedSeries[line.series], i == cursorLine, sumColumnWidths(columns)))
+			continue
+		}
+
+		build := m.builds[line.buildIndex]
+		row := NewRow(build, i == cursorLine, m.downloadStateFor(build))
+		row.ScheduledAt = m.scheduledAt(build)
+		row.ProgressStyle = m.progressStyle()
+		row.New = m.isNew(build)
+		row.LaunchPending = m.launchPending(build)
+		row.RequiredGlibc = build.GlibcRequirement(m.systemGlibc)
+		row.Highlight = m.statusHighlight(build, time.Now())
+		row.Custom = m.customColumnValues(build)
+		rendered = append(rendered, row.Render(columns))
+	}
+
+	return strings.Join(rendered, lp.NewStyle().Render("\n"))
+}
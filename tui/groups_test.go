@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCollapsedHeaderNotActionable(t *testing.T) {
+	cfg := config.Config{DownloadDir: t.TempDir()}
+	m := &Model{config: cfg, commands: &Commands{cfg: cfg}, grouped: true, collapsedSeries: make(map[string]bool), builds: []model.BlenderBuild{
+		{Version: "4.3.1", Status: model.StateLocal},
+		{Version: "4.3.0", Status: model.StateLocal},
+		{Version: "4.2.0", Status: model.StateLocal},
+	}}
+	m.sortBuilds()
+	m.setSeriesCollapsed(true)
+	if !m.onCollapsedHeader() {
+		t.Fatal("Expected the cursor on the collapsed 4.3 header")
+	}
+
+	// Delete on the header deletes none of the builds it stands in for
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}); cmd != nil {
+		t.Error("Expected delete on a collapsed header to do nothing")
+	}
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Expected launch on a collapsed header to do nothing")
+	}
+
+	// Moving off the header makes the builds actionable again
+	m.updateKey(tea.KeyMsg{Type: tea.KeyDown})
+	if m.onCollapsedHeader() || m.builds[m.cursor].Version != "4.2.0" {
+		t.Fatalf("Expected the cursor on 4.2.0, got %s", m.builds[m.cursor].Version)
+	}
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}); cmd == nil {
+		t.Error("Expected delete on a build to delete it")
+	}
+}
+
+func TestSeriesHeader(t *testing.T) {
+	lts := renderSeriesHeader("3.6", []model.BlenderBuild{
+		{Version: "3.6.12", Status: model.StateLocal, Size: 1 << 20},
+		{Version: "3.6.11", Status: model.StateUpdate},
+	}, false, false, 120)
+	for _, text := range []string{"▾ 3.6 LTS", "2 build(s)", "2 installed", "1 update(s) available"} {
+		if !strings.Contains(lts, text) {
+			t.Errorf("Expected %q in the 3.6 header, got %q", text, lts)
+		}
+	}
+	if header := renderSeriesHeader("4.3", []model.BlenderBuild{{Version: "4.3.0"}}, true, false, 120); !strings.Contains(header, "▸ 4.3 ·") {
+		t.Errorf("Expected the collapsed 4.3 header without LTS, got %q", header)
+	}
+}
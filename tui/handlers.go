@@ -2,19 +2,19 @@ package tui
 
 import (
 	"TUI-Blender-Launcher/config"
-	"TUI-Blender-Launcher/download"
-	"TUI-Blender-Launcher/launch"
 	"TUI-Blender-Launcher/local"
 	"TUI-Blender-Launcher/model"
 	"fmt"
+	"maps"
 	"math"
-	"os"
+	"net/url"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	version "github.com/hashicorp/go-version"
 )
 
 // Helper to update focused input
@@ -39,54 +39,219 @@ func (m *Model) updateInputs(msg tea.Msg) tea.Cmd {
 
 // Helper functions for handling specific actions in list view
 func (m *Model) handleLaunchBlender() (tea.Model, tea.Cmd) {
+	return m.launchSelected("")
+}
+
+// launchRequest is a launch of a build from the list, the palette, a .blend file or a recent project
+type launchRequest struct {
+	build   model.BlenderBuild
+	profile string // Launch profile, "" for the build's own or the global one
+	path    string // .blend file to open, "" for none
+}
+
+// pendingLaunch is a launch waiting for confirmation after a warning
+type pendingLaunch struct {
+	launchRequest
+	warning string
+}
+
+// launchSelected launches the selected build with a launch profile, "" for the build's own or the global one
+func (m *Model) launchSelected(profile string) (tea.Model, tea.Cmd) {
 	if len(m.builds) > 0 && m.cursor < len(m.builds) {
 		selectedBuild := m.builds[m.cursor]
 		// Only attempt to launch if it's a local build or has an update available
 		if selectedBuild.Status == model.StateLocal || selectedBuild.Status == model.StateUpdate {
-			cmd := local.LaunchBlenderCmd(m.config.DownloadDir, selectedBuild.Version)
-			return m, cmd
+			return m, m.startLaunch(launchRequest{build: selectedBuild, profile: profile})
 		}
 	}
 	return m, nil
 }
 
-// handleOpenBuildDir opens the build directory for a specific version
-func (m *Model) handleOpenBuildDir() (tea.Model, tea.Cmd) {
+// startLaunch is the path every launch takes. It asks for confirmation first if the smoke test or GPU probe
+// found the build unable to start, or if it needs a newer glibc than this machine has, see Model.launchWarning.
+func (m *Model) startLaunch(req launchRequest) tea.Cmd {
+	warning := smokeTestWarning(installedBuild(req.build))
+	if warning == "" {
+		warning = gpuLaunchWarning(req.build)
+	}
+	if warning == "" {
+		warning = m.glibcLaunchWarning(installedBuild(req.build))
+	}
+	if warning != "" {
+		m.launchWarning = &pendingLaunch{launchRequest: req, warning: warning}
+		return nil
+	}
+	return m.launch(req)
+}
+
+// launch launches a build past its warnings, unless approved_only forbids it
+func (m *Model) launch(req launchRequest) tea.Cmd {
+	if !m.launchAllowed(req.build) {
+		return nil
+	}
+	launchCmd := m.launchBuildCmd(req.build)
+	if req.profile != "" {
+		launchCmd = launchWithProfile(launchCmd, req.profile)
+	}
+	if req.path != "" {
+		m.showNotice(fmt.Sprintf("Opening %s with Blender %s", filepath.Base(req.path), req.build.Version))
+		m.recordProject(req.path, req.build)
+		launchCmd = launchWithFile(launchCmd, req.path)
+	}
+	return launchCmd
+}
+
+// smokeTestWarning returns a warning if the build failed its smoke test
+func smokeTestWarning(build model.BlenderBuild) string {
+	if !build.Broken() {
+		return ""
+	}
+	return fmt.Sprintf("Build failed its smoke test (%s)", build.SmokeTest.Error)
+}
+
+// gpuLaunchWarning returns a warning if the GPU probe recorded problems for the build
+func gpuLaunchWarning(build model.BlenderBuild) string {
+	result := build.GPUProbe
+	if result == nil || !result.Crashed {
+		return ""
+	}
+	return "Build crashed looking for GPU devices during the probe"
+}
+
+// glibcLaunchWarning returns a warning if the build needs a newer glibc than this machine has
+func (m *Model) glibcLaunchWarning(build model.BlenderBuild) string {
+	required := build.GlibcRequirement(m.systemGlibc)
+	if required == "" {
+		return ""
+	}
+	return fmt.Sprintf("Blender %s needs glibc %s or newer, this system has %s", build.Version, required, m.systemGlibc)
+}
+
+// handleAssociateBlend registers the selected local build as the .blend file handler
+func (m *Model) handleAssociateBlend() (tea.Model, tea.Cmd) {
 	if len(m.builds) > 0 && m.cursor < len(m.builds) {
 		selectedBuild := m.builds[m.cursor]
-		// Only open dir if it's a local build or has an update available
 		if selectedBuild.Status == model.StateLocal || selectedBuild.Status == model.StateUpdate {
-			// Create a command that locates the correct build directory by version
-			return m, func() tea.Msg {
-				entries, err := os.ReadDir(m.config.DownloadDir)
-				if err != nil {
-					return errMsg{fmt.Errorf("failed to read download directory %s: %w", m.config.DownloadDir, err)}
-				}
+			return m, m.commands.AssociateBlendFiles(selectedBuild.Version, selectedBuild.Architecture)
+		}
+	}
+	return m, nil
+}
 
-				version := selectedBuild.Version
-				for _, entry := range entries {
-					if entry.IsDir() && entry.Name() != download.DownloadingDir && entry.Name() != download.OldBuildsDir {
-						dirPath := filepath.Join(m.config.DownloadDir, entry.Name())
-						buildInfo, err := local.ReadBuildInfo(dirPath)
-						if err != nil {
-							// Error reading build info, but continue checking other directories
-							continue
-						}
-
-						// Check if this is the build we want to open
-						if buildInfo != nil && buildInfo.Version == version {
-							// Open this directory
-							if err := local.OpenFileExplorer(dirPath); err != nil {
-								return errMsg{fmt.Errorf("failed to open directory: %w", err)}
-							}
-							return nil // Success
-						}
-					}
-				}
+// handleBlendAssociated remembers which build now handles .blend files
+func (m *Model) handleBlendAssociated(msg blendAssociatedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.config.BlendHandler = msg.version
+	if err := config.SaveConfig(m.config); err != nil {
+		m.err = fmt.Errorf("failed to save config: %w", err)
+		return m, nil
+	}
+	m.err = nil
+	return m, nil
+}
 
-				return errMsg{fmt.Errorf("build directory for Blender version %s not found", version)}
-			}
+// handleOpenBuildDir offers the directories of the selected local build to open: its installation,
+// user config, add-ons and crash logs
+func (m *Model) handleOpenBuildDir() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	// Only local builds and builds with an update available have directories
+	if !ok || (build.Status != model.StateLocal && build.Status != model.StateUpdate) {
+		return m, nil
+	}
+	return m, m.commands.FindBuildDirs(build)
+}
+
+// pullRequestURL returns the projects.blender.org page of a pull request
+func pullRequestURL(pr string) string {
+	return "https://projects.blender.org/blender/blender/pulls/" + pr
+}
+
+// handleOpenPullRequest opens the pull request of the selected patch build in the browser
+func (m *Model) handleOpenPullRequest() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok {
+		return m, nil
+	}
+	pr := build.PullRequest()
+	if pr == "" {
+		m.err = fmt.Errorf("build %s is not a patch build", build.Version)
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		if err := local.OpenURL(pullRequestURL(pr)); err != nil {
+			return errMsg{fmt.Errorf("failed to open browser: %w", err)}
+		}
+		return nil
+	}
+}
+
+// sourcePageURL returns the projects.blender.org page a build was made from:
+// the branch for experimental builds, the commit otherwise
+func sourcePageURL(build model.BlenderBuild) string {
+	const repoURL = "https://projects.blender.org/blender/blender"
+	if build.Source == model.SourceExperimental && build.Branch != "" {
+		return repoURL + "/src/branch/" + url.PathEscape(build.Branch)
+	}
+	if build.Hash != "" {
+		return repoURL + "/commit/" + build.Hash
+	}
+	return ""
+}
+
+// handleOpenSourcePage opens the commit or branch page of the selected build in the browser
+func (m *Model) handleOpenSourcePage() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok {
+		return m, nil
+	}
+	pageURL := sourcePageURL(build)
+	if pageURL == "" {
+		m.err = fmt.Errorf("build %s has no commit hash or branch", build.Version)
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		if err := local.OpenURL(pageURL); err != nil {
+			return errMsg{fmt.Errorf("failed to open browser: %w", err)}
 		}
+		return nil
+	}
+}
+
+// handleBuildVerified stores the re-verification outcome and reports it
+func (m *Model) handleBuildVerified(msg buildVerifiedMsg) (tea.Model, tea.Cmd) {
+	m.notice = ""
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	var changed *model.BlenderBuild
+	for i := range m.builds {
+		target := &m.builds[i]
+		if target.Status == model.StateUpdate && target.Installed != nil {
+			target = target.Installed
+		}
+		if target.Matches(msg.version, msg.build.Architecture) {
+			target.TreeSHA256 = msg.build.TreeSHA256
+			target.Verification = msg.build.Verification
+			changed = target
+		}
+	}
+
+	switch msg.result {
+	case local.VerifyChanged:
+		m.err = fmt.Errorf("installed files of Blender %s changed since installation", msg.version)
+		if changed != nil {
+			return m, m.offerRepair(*changed)
+		}
+	case local.VerifyBaseline:
+		m.err = nil
+		m.showNotice(fmt.Sprintf("Blender %s has no install-time checksum; recorded its current files for later checks", msg.version))
+	default:
+		m.err = nil
+		m.showNotice(fmt.Sprintf("Blender %s verified: installed files unchanged", msg.version))
 	}
 	return m, nil
 }
@@ -95,16 +260,33 @@ func (m *Model) handleOpenBuildDir() (tea.Model, tea.Cmd) {
 func (m *Model) handleStartDownload() (tea.Model, tea.Cmd) {
 	if len(m.builds) > 0 && m.cursor < len(m.builds) {
 		selectedBuild := m.builds[m.cursor]
+		// Local builds that are broken or changed on disk are downloaded again
+		if selectedBuild.Status == model.StateLocal && selectedBuild.NeedsRepair() {
+			if err := m.canRepair(selectedBuild); err != nil {
+				m.err = err
+				return m, nil
+			}
+			if m.needsDownloadConfirm(selectedBuild) && m.downloadConfirm != selectedBuild.Version {
+				m.downloadConfirm = selectedBuild.Version
+				return m, nil
+			}
+			m.downloadConfirm = ""
+			return m, m.startRepair(selectedBuild)
+		}
 		// Allow downloading Online, Update, Failed, and Cancelled builds
 		if selectedBuild.Status == model.StateOnline ||
 			selectedBuild.Status == model.StateUpdate ||
 			selectedBuild.Status == model.StateFailed ||
 			selectedBuild.Status == model.StateCancelled { // StateNone == Cancelled
-			// Generate a unique build ID using version and hash
-			buildID := selectedBuild.Version
-			if selectedBuild.Hash != "" {
-				buildID = selectedBuild.Version + "-" + selectedBuild.Hash[:8]
+			// On a metered connection or close to the download budget, show the size and require a second key press
+			if m.needsDownloadConfirm(selectedBuild) && m.downloadConfirm != selectedBuild.Version {
+				m.downloadConfirm = selectedBuild.Version
+				return m, nil
 			}
+			m.downloadConfirm = ""
+
+			// Generate a unique build ID using version and hash
+			buildID := downloadID(selectedBuild)
 
 			// Update status to Downloading immediately for UI feedback
 			selectedBuild.Status = model.StateDownloading
@@ -128,10 +310,7 @@ func (m *Model) handleCancelDownload() (tea.Model, tea.Cmd) {
 
 	// Create buildID for the selected build first
 	selectedBuild := m.builds[m.cursor]
-	selectedBuildID := selectedBuild.Version
-	if selectedBuild.Hash != "" {
-		selectedBuildID = selectedBuild.Version + "-" + selectedBuild.Hash[:8]
-	}
+	selectedBuildID := downloadID(selectedBuild)
 
 	// Use activeDownloadID if set; otherwise, use the selected build ID
 	buildID := m.activeDownloadID
@@ -145,10 +324,7 @@ func (m *Model) handleCancelDownload() (tea.Model, tea.Cmd) {
 	// Update the build status to Cancelled (StateNone) after cancellation
 	// so it shows as cancelled until next fetch
 	for i, build := range m.builds {
-		buildID := build.Version
-		if build.Hash != "" {
-			buildID = build.Version + "-" + build.Hash[:8]
-		}
+		buildID := downloadID(build)
 
 		// Update the status of both the selected build and any build matching the active download
 		if buildID == m.activeDownloadID || buildID == selectedBuildID {
@@ -156,6 +332,7 @@ func (m *Model) handleCancelDownload() (tea.Model, tea.Cmd) {
 			if m.builds[i].Status == model.StateDownloading ||
 				m.builds[i].Status == model.StateExtracting {
 				m.builds[i].Status = model.StateCancelled // Set to Cancelled
+				m.dropPendingLaunch(m.builds[i])
 			}
 		}
 	}
@@ -166,34 +343,42 @@ func (m *Model) handleCancelDownload() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleCancelAllDownloads asks for confirmation, then cancels every active download in one pass
+func (m *Model) handleCancelAllDownloads() (tea.Model, tea.Cmd) {
+	if m.commands.downloads.ActiveCount() == 0 {
+		return m, nil
+	}
+	if !m.confirmCancelAll {
+		m.confirmCancelAll = true
+		return m, nil
+	}
+	m.confirmCancelAll = false
+
+	cancelled := make(map[string]bool)
+	for _, id := range m.commands.downloads.CancelAll() {
+		cancelled[id] = true
+	}
+
+	for i, build := range m.builds {
+		buildID := downloadID(build)
+		if cancelled[buildID] {
+			m.builds[i].Status = model.StateCancelled
+			m.dropPendingLaunch(m.builds[i])
+		}
+	}
+
+	m.activeDownloadID = ""
+	m.sortBuilds()
+	return m, nil
+}
+
 // handleShowSettings shows the settings screen
 func (m *Model) handleShowSettings() (tea.Model, tea.Cmd) {
 	m.currentView = viewSettings
 	m.editMode = false // Ensure we start in navigation mode
 
-	// Initialize settings inputs if not already done
-	if len(m.settingsInputs) == 0 {
-		m.settingsInputs = make([]textinput.Model, 2)
-
-		// Download Dir input
-		var t textinput.Model
-		t = textinput.New()
-		t.Placeholder = m.config.DownloadDir
-		t.CharLimit = 256
-		t.Width = 50
-		m.settingsInputs[0] = t
-
-		// Version Filter input
-		t = textinput.New()
-		t.Placeholder = "e.g., 4.0, 3.6 (leave empty for none)"
-		t.CharLimit = 10
-		t.Width = 50
-		m.settingsInputs[1] = t
-	}
-
-	// Copy current config values
-	m.settingsInputs[0].SetValue(m.config.DownloadDir)
-	m.settingsInputs[1].SetValue(m.config.VersionFilter)
+	// Fill the inputs with the current config values
+	m.settingsInputs = newSettingsInputs(m.config, m.buildTypeOptions)
 
 	// Update build type selection with current build type
 	for i, opt := range m.buildTypeOptions {
@@ -204,6 +389,10 @@ func (m *Model) handleShowSettings() (tea.Model, tea.Cmd) {
 		}
 	}
 
+	m.sendDownloadID = m.config.SendDownloadID
+	m.downloadID = m.config.UUID
+	m.downloadingSize = -1
+
 	// Focus first input (but don't focus for editing yet)
 	m.focusIndex = 0
 
@@ -218,7 +407,7 @@ func (m *Model) handleShowSettings() (tea.Model, tea.Cmd) {
 		m.settingsInputs[i].Blur()
 	}
 
-	return m, nil
+	return m, m.commands.MeasureDownloading()
 }
 
 // handleDeleteBuild prepares to delete a build
@@ -228,42 +417,75 @@ func (m *Model) handleDeleteBuild() (tea.Model, tea.Cmd) {
 		if selectedBuild.Status == model.StateDownloading || selectedBuild.Status == model.StateExtracting {
 			return m.handleCancelDownload()
 		}
+		if m.rejectShared(selectedBuild) {
+			return m, nil
+		}
 		// Only allow deleting local builds or builds that can be updated
 		if selectedBuild.Status == model.StateLocal || selectedBuild.Status == model.StateUpdate {
-			return m, func() tea.Msg {
-				success, err := local.DeleteBuild(m.config.DownloadDir, selectedBuild.Version)
-				if err != nil {
-					return errMsg{err}
-				}
-				if !success {
-					return errMsg{fmt.Errorf("failed to delete build %s", selectedBuild.Version)}
-				}
-				// Remove the deleted build from the list
-				indexToRemove := -1
-				for i, b := range m.builds {
-					if b.Version == selectedBuild.Version {
-						indexToRemove = i
-						break
-					}
-				}
-				if indexToRemove != -1 {
-					m.builds = append(m.builds[:indexToRemove], m.builds[indexToRemove+1:]...)
-					if len(m.builds) == 0 {
-						m.cursor = 0
-					} else if m.cursor >= len(m.builds) {
-						m.cursor = len(m.builds) - 1
-					}
-				}
-				m.builds = model.SortBuilds(m.builds, m.sortColumn, m.sortReversed)
-				return nil
-			}
+			return m, m.commands.DeleteBuild(selectedBuild)
+		}
+	}
+	return m, nil
+}
+
+// handleBuildDeleted removes a deleted build from the list; u moves it back from the trash
+func (m *Model) handleBuildDeleted(msg buildDeletedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	indexToRemove := -1
+	for i, b := range m.builds {
+		if b.Matches(msg.build.Version, msg.build.Architecture) {
+			indexToRemove = i
+			break
+		}
+	}
+	if indexToRemove != -1 {
+		m.builds = append(m.builds[:indexToRemove], m.builds[indexToRemove+1:]...)
+		// A refetch lists its online build again
+		m.fetchShown = false
+		if len(m.builds) == 0 {
+			m.cursor = 0
+		} else if m.cursor >= len(m.builds) {
+			m.cursor = len(m.builds) - 1
 		}
 	}
+	m.sortBuilds()
+
+	action := fmt.Sprintf("delete of Blender %s", msg.build.Version)
+	m.pushUndo(action, m.commands.RestoreTrashed(action, []local.TrashedDir{msg.trashed}))
+	m.showNotice(fmt.Sprintf("Deleted Blender %s (u to undo)", msg.build.Version))
 	return m, nil
 }
 
+// handleLocalScanProgress shows a build found by the running local scan right away
+func (m *Model) handleLocalScanProgress(msg localScanProgressMsg) (tea.Model, tea.Cmd) {
+	progress := msg.progress
+	m.scanProgress = &progress
+
+	// Local builds are listed regardless of the version filter, but not of the tag and new builds filters
+	if build := progress.Build; build != nil && (m.config.TagFilter == "" || build.HasTag(m.config.TagFilter)) && (!m.newOnly || m.isNew(*build)) {
+		listed := false
+		for _, b := range m.builds {
+			if b.Matches(build.Version, build.Architecture) && b.Hash == build.Hash {
+				listed = true
+				break
+			}
+		}
+		if !listed {
+			m.builds = append(m.builds, *build)
+			m.sortBuilds()
+		}
+	}
+	return m, waitForScan(msg.next)
+}
+
 // handleLocalBuildsScanned processes the result of scanning local builds
 func (m *Model) handleLocalBuildsScanned(msg localBuildsScannedMsg) (tea.Model, tea.Cmd) {
+	m.scanProgress = nil
+	m.fetchShown = false
+
 	// If there was an error scanning builds, store it but continue with empty list
 	if msg.err != nil {
 		m.err = msg.err
@@ -271,16 +493,24 @@ func (m *Model) handleLocalBuildsScanned(msg localBuildsScannedMsg) (tea.Model,
 		return m, nil
 	}
 
-	// Set builds to local builds only, don't fetch online builds automatically
-	m.builds = msg.builds
+	// Set builds to local builds only, don't fetch online builds automatically.
+	// The online builds of a stale list stay until the next fetch.
+	var refresh tea.Cmd
+	if m.staleList != nil {
+		refresh = m.refreshStaleList(msg.builds)
+	} else {
+		m.builds = msg.builds
+	}
 
 	// Apply version filter if set
-	if m.config.VersionFilter != "" {
+	if m.versionFilter() != "" {
 		m.builds = m.applyVersionFilter(m.builds)
 	}
+	m.builds = m.applyTagFilter(m.builds)
+	m.builds = m.applyNewFilter(m.builds)
 
 	// Sort builds immediately for better visual feedback
-	m.builds = model.SortBuilds(m.builds, m.sortColumn, m.sortReversed)
+	m.sortBuilds()
 
 	// Reset cursor and startIndex when loading new builds
 	if len(m.builds) > 0 {
@@ -288,20 +518,50 @@ func (m *Model) handleLocalBuildsScanned(msg localBuildsScannedMsg) (tea.Model,
 		m.startIndex = 0
 	}
 
-	return m, nil
+	// The pinned build and a .blend file given on the command line can be handled now
+	m.applyProjectPin()
+	if m.blendLaunch != nil && !m.blendLaunch.scanned {
+		m.blendLaunch.scanned = true
+		return m, tea.Batch(refresh, m.refreshCustomColumns(), m.resolveBlendLaunch())
+	}
+
+	return m, tea.Batch(refresh, m.refreshCustomColumns())
 }
 
 // handleBuildsFetched processes the result of fetching builds from the API
 func (m *Model) handleBuildsFetched(msg buildsFetchedMsg) (tea.Model, tea.Cmd) {
+	m.offline = msg.offline
+	m.cachedAt = msg.cachedAt
 	if msg.err != nil {
 		m.err = msg.err
 		return m, nil
 	}
+	if !msg.offline {
+		m.err = nil
+	}
+	m.staleList = nil
+	m.fetched = msg.builds
+	m.showDigest(msg)
+	restore := m.restoreQueue(msg)
+
+	// The same listing would rebuild the same list
+	if msg.notModified && m.fetchShown {
+		for i := range m.builds {
+			// Failed/Cancelled states were reset by the fetch command
+			if m.builds[i].Status == model.StateFailed || m.builds[i].Status == model.StateCancelled {
+				m.builds[i].Status = model.StateOnline
+			}
+		}
+		m.showNotice("Build list up to date (not modified)")
+		return m, restore
+	}
+	m.fetchShown = !msg.offline
+	m.markNewBuilds(msg.newBuilds)
 
-	// Preserve only local builds from the current list.
+	// Preserve only local builds from the current list, including those the new builds filter hides.
 	// Failed/Cancelled states are reset by the fetch command itself.
 	var localBuilds []model.BlenderBuild
-	for _, build := range m.builds {
+	for _, build := range append(m.builds, m.hiddenOld...) {
 		if build.Status == model.StateLocal {
 			localBuilds = append(localBuilds, build)
 		}
@@ -316,7 +576,7 @@ func (m *Model) handleBuildsFetched(msg buildsFetchedMsg) (tea.Model, tea.Cmd) {
 	// based on comparison between local and the combined list.
 
 	// Apply version filter if set *before* updating status
-	if m.config.VersionFilter != "" {
+	if m.versionFilter() != "" {
 		m.builds = m.applyVersionFilter(m.builds)
 	}
 
@@ -332,12 +592,18 @@ func (m *Model) handleBuildsFetched(msg buildsFetchedMsg) (tea.Model, tea.Cmd) {
 	// Update the status based on what's available locally vs online.
 	// This command now receives the combined list (local + fetched)
 	// and should correctly assign Local, Online, or Update status.
-	return m, m.commands.UpdateBuildStatus(m.builds)
+	return m, tea.Batch(m.commands.UpdateBuildStatus(slices.Clone(m.builds)), restore)
+}
+
+// versionFilter returns the version filter of the selected build type
+func (m *Model) versionFilter() string {
+	return m.config.VersionFilterFor(m.config.BuildType)
 }
 
 // applyVersionFilter filters builds by version, keeping only builds with version >= filter value
 func (m *Model) applyVersionFilter(builds []model.BlenderBuild) []model.BlenderBuild {
-	if m.config.VersionFilter == "" {
+	filter := m.versionFilter()
+	if filter == "" {
 		return builds
 	}
 
@@ -350,7 +616,7 @@ func (m *Model) applyVersionFilter(builds []model.BlenderBuild) []model.BlenderB
 		}
 
 		// Compare versions (simple string comparison works for Blender's versioning scheme)
-		if build.Version >= m.config.VersionFilter {
+		if build.Version >= filter {
 			filtered = append(filtered, build)
 		}
 	}
@@ -367,10 +633,7 @@ func (m *Model) handleBuildsUpdated(msg buildsUpdatedMsg) (tea.Model, tea.Cmd) {
 	activeDownloadIDs := make(map[string]bool)
 	for _, build := range m.builds {
 		if build.Status == model.StateDownloading || build.Status == model.StateExtracting {
-			buildID := build.Version
-			if build.Hash != "" {
-				buildID = build.Version + "-" + build.Hash[:8]
-			}
+			buildID := downloadID(build)
 			activeDownloadIDs[buildID] = true
 		}
 	}
@@ -384,14 +647,16 @@ func (m *Model) handleBuildsUpdated(msg buildsUpdatedMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Apply version filter if set
-	if m.config.VersionFilter != "" {
+	if m.versionFilter() != "" {
 		m.builds = m.applyVersionFilter(m.builds)
 	}
+	m.builds = m.applyTagFilter(m.builds)
+	m.builds = m.applyNewFilter(m.builds)
 
-	m.builds = model.SortBuilds(m.builds, m.sortColumn, m.sortReversed)
+	m.sortBuilds()
 
 	// Ensure cursor is within bounds and visible
-	visibleRowsCount := m.terminalHeight - 7
+	visibleRowsCount := m.contentHeight() - 1 // Without the table header
 	if visibleRowsCount < 1 {
 		visibleRowsCount = 1
 	}
@@ -411,28 +676,32 @@ func (m *Model) handleBuildsUpdated(msg buildsUpdatedMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	// No further commands needed here, just update the UI state.
-	return m, nil
+	// The custom columns are filled in for the final list
+	return m, m.refreshCustomColumns()
 }
 
 // handleBlenderExec handles launching Blender after selecting it
 func (m *Model) handleBlenderExec(msg model.BlenderExecMsg) (tea.Model, tea.Cmd) {
-	// Store Blender info
-	execInfo := msg
-
-	// Launch Blender directly using the launch package
-	return m, func() tea.Msg {
-		blenderExe := execInfo.Executable
-
-		// Import the launch package at the top of the file if needed
-		err := launch.BlenderInNewTerminal(blenderExe)
-		if err != nil {
-			return errMsg{fmt.Errorf("failed to launch Blender: %w", err)}
+	// Gatekeeper blocks quarantined builds started from a terminal
+	if msg.Quarantine {
+		if m.config.Unquarantine {
+			return m, unquarantineCmd(msg)
 		}
+		m.quarantinePrompt = &msg
+		return m, nil
+	}
 
-		// Return a message indicating Blender was launched successfully
-		return nil
+	// Builds with isolated config never touch the shared per-version config
+	if len(msg.Env) == 0 {
+		migration, err := local.CheckUserConfigMigration(msg.Version)
+		if err == nil && migration != nil {
+			// Ask before Blender silently creates a new per-version config
+			m.configPrompt = &configPrompt{exec: msg, migration: *migration}
+			return m, nil
+		}
 	}
+
+	return m, launchBlenderCmd(msg)
 }
 
 // handleDownloadProgress processes tick messages for download progress updates
@@ -447,6 +716,9 @@ func (m *Model) handleDownloadProgress(msg tickMsg) (tea.Model, tea.Cmd) {
 
 	// If commands exists, sync download states from it
 	if m.commands != nil && m.commands.downloads != nil {
+		// Downloads finished since the last tick free their slots for queued ones
+		m.commands.downloads.StartQueued()
+
 		// Get states from download manager
 		states := m.commands.downloads.GetAllStates()
 
@@ -457,7 +729,7 @@ func (m *Model) handleDownloadProgress(msg tickMsg) (tea.Model, tea.Cmd) {
 				m.downloadStates[id] = state
 
 				// Check for stalled downloads - detect if a download hasn't progressed in 15 seconds
-				if state.BuildState == model.StateDownloading && time.Since(state.LastUpdated) > 15*time.Second {
+				if state.BuildState == model.StateDownloading && !state.Paused && !state.Queued && time.Since(state.LastUpdated) > 15*time.Second {
 					// Mark as stalled (will transition to failed)
 					stalledDownloads = append(stalledDownloads, id)
 
@@ -523,10 +795,7 @@ func (m *Model) handleDownloadProgress(msg tickMsg) (tea.Model, tea.Cmd) {
 	// Update build statuses for downloads/extractions to ensure they display correctly
 	needsSort := false
 	for i := range m.builds {
-		buildID := m.builds[i].Version
-		if m.builds[i].Hash != "" {
-			buildID = m.builds[i].Version + "-" + m.builds[i].Hash[:8]
-		}
+		buildID := downloadID(m.builds[i])
 
 		// Update status for active downloads - force update for any active download
 		if state, ok := tempStates[buildID]; ok {
@@ -553,17 +822,16 @@ func (m *Model) handleDownloadProgress(msg tickMsg) (tea.Model, tea.Cmd) {
 
 	// Process completed, stalled, and cancelled downloads
 	// For each completed download, find the matching build and update its status
+	var finished []model.BlenderBuild
 	for _, id := range completedDownloads {
 		if state, ok := tempStates[id]; ok {
-			// Extract the version from the BuildID (before the hash if present)
-			version := state.BuildID
-			if strings.Contains(version, "-") {
-				version = strings.Split(version, "-")[0]
-			}
-
 			for i := range m.builds {
-				if m.builds[i].Version == version {
+				if downloadID(m.builds[i]) == state.BuildID {
 					m.builds[i].Status = state.BuildState
+					if state.SmokeTest != nil {
+						m.builds[i].SmokeTest = state.SmokeTest
+					}
+					finished = append(finished, m.builds[i])
 					needsSort = true
 					break
 				}
@@ -574,14 +842,8 @@ func (m *Model) handleDownloadProgress(msg tickMsg) (tea.Model, tea.Cmd) {
 	// Same for stalled downloads
 	for _, id := range stalledDownloads {
 		if state, ok := tempStates[id]; ok {
-			// Extract the version from the BuildID
-			version := state.BuildID
-			if strings.Contains(version, "-") {
-				version = strings.Split(version, "-")[0]
-			}
-
 			for i := range m.builds {
-				if m.builds[i].Version == version {
+				if downloadID(m.builds[i]) == state.BuildID {
 					m.builds[i].Status = state.BuildState
 					needsSort = true
 					break
@@ -593,17 +855,12 @@ func (m *Model) handleDownloadProgress(msg tickMsg) (tea.Model, tea.Cmd) {
 	// And for cancelled downloads
 	for _, id := range cancelledDownloads {
 		if state, ok := tempStates[id]; ok {
-			// Extract the version from the BuildID
-			version := state.BuildID
-			if strings.Contains(version, "-") {
-				version = strings.Split(version, "-")[0]
-			}
-
 			for i := range m.builds {
-				if m.builds[i].Version == version {
+				if downloadID(m.builds[i]) == state.BuildID {
 					// Keep the build with Cancelled status (StateNone)
 					// Don't convert to online immediately - wait for explicit fetch
 					m.builds[i].Status = model.StateCancelled
+					m.dropPendingLaunch(m.builds[i])
 					needsSort = true
 					break
 				}
@@ -613,7 +870,21 @@ func (m *Model) handleDownloadProgress(msg tickMsg) (tea.Model, tea.Cmd) {
 
 	// Sort if needed
 	if needsSort {
-		m.builds = model.SortBuilds(m.builds, m.sortColumn, m.sortReversed)
+		m.sortBuilds()
+	}
+
+	// Finish what was waiting for a download started from the pin or .blend file prompts,
+	// the completion message may not arrive when the program listener is busy
+	for _, build := range finished {
+		installed := build.Status == model.StateLocal && !build.Broken()
+		if build.Broken() {
+			m.err = fmt.Errorf("Blender %s is broken: %s", build.Version, build.SmokeTest.Error)
+			if m.repair.firstReport(build) {
+				progressCmds = append(progressCmds, m.offerRepair(build))
+			}
+		}
+		m.pinnedBuildInstalled(build, installed)
+		progressCmds = append(progressCmds, m.blendBuildInstalled(build, installed), m.pendingBuildInstalled(build, installed))
 	}
 
 	// Return any progress bar update commands
@@ -657,7 +928,6 @@ func updateFocusStyles(m *Model, oldFocus int) {
 func saveSettings(m *Model) (tea.Model, tea.Cmd) {
 	// Ensure we get the current values from the inputs
 	downloadDir := m.settingsInputs[0].Value()
-	versionFilter := m.settingsInputs[1].Value()
 	buildType := m.buildType
 
 	// Validate and sanitize inputs
@@ -669,36 +939,48 @@ func saveSettings(m *Model) (tea.Model, tea.Cmd) {
 
 	// Build type validation is not needed as dropdown guarantees valid values
 
+	// Update config values, with a copy of the filters so the old config stays as it was
+	cfg := m.config
+	cfg.DownloadDir = downloadDir
+	cfg.BuildType = buildType
+	cfg.SendDownloadID = m.sendDownloadID
+	cfg.UUID = m.downloadID
+	cfg.VersionFilters = maps.Clone(m.config.VersionFilters)
+	for i, filterType := range m.buildTypeOptions {
+		filter := strings.TrimSpace(m.settingsInputs[i+1].Value())
+		if filter != "" {
+			if _, err := version.NewVersion(filter); err != nil {
+				m.err = fmt.Errorf("invalid %s version filter format '%s'", filterType, filter)
+				return m, nil
+			}
+		}
+		cfg.SetVersionFilterFor(filterType, filter)
+	}
+
 	// Check if version filter changed
-	versionFilterChanged := m.config.VersionFilter != versionFilter
+	versionFilterChanged := m.versionFilter() != cfg.VersionFilterFor(buildType)
 	buildTypeChanged := m.config.BuildType != buildType
 
-	// Update config values
-	m.config.DownloadDir = downloadDir
-	m.config.VersionFilter = versionFilter
-	m.config.BuildType = buildType
-
 	// Save the config
-	err := config.SaveConfig(m.config)
+	err := config.SaveConfig(cfg)
 	if err != nil {
 		m.err = fmt.Errorf("failed to save config: %w", err)
 		return m, nil
 	}
 
-	// Recreate commands with updated config
-	m.commands = NewCommands(m.config)
-
-	// Clear any errors and trigger rescans if needed
+	// Clear any errors and switch the scanner, fetcher and download manager over,
+	// rescanning and refetching what the change affects
 	m.err = nil
+	changed := m.applyConfig(cfg)
 
 	// If returning to list view, apply version filter if it changed
 	if m.currentView == viewList {
 		if (versionFilterChanged || buildTypeChanged) && len(m.builds) > 0 {
 			// Re-apply version filter and sort
-			if m.config.VersionFilter != "" {
+			if m.versionFilter() != "" {
 				m.builds = m.applyVersionFilter(m.builds)
 			}
-			m.builds = model.SortBuilds(m.builds, m.sortColumn, m.sortReversed)
+			m.sortBuilds()
 
 			// Reset cursor if needed
 			if len(m.builds) > 0 && m.cursor >= len(m.builds) {
@@ -706,10 +988,9 @@ func saveSettings(m *Model) (tea.Model, tea.Cmd) {
 				m.startIndex = 0
 			}
 		} else if len(m.builds) == 0 {
-			return m, m.commands.ScanLocalBuilds()
+			return m, tea.Batch(changed, m.commands.ScanLocalBuilds())
 		}
-		return m, nil
 	}
 
-	return m, nil
+	return m, changed
 }
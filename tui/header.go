@@ -1,6 +1,9 @@
 package tui
 
 import (
+	"fmt"
+	"time"
+
 	lp "github.com/charmbracelet/lipgloss"
 )
 
@@ -14,3 +17,18 @@ func renderHeader(width int) string {
 		Align(lp.Center).
 		Render("TUI Blender Launcher")
 }
+
+// renderOfflineBanner creates the banner shown in place of the header while offline
+func renderOfflineBanner(width int, cachedAt time.Time) string {
+	text := "OFFLINE · builder.blender.org unreachable · press f to retry"
+	if !cachedAt.IsZero() {
+		text = fmt.Sprintf("OFFLINE · showing build list cached %s · press f to retry", cachedAt.Format("2006-01-02 15:04"))
+	}
+	return lp.NewStyle().
+		Bold(true).
+		Foreground(lp.Color(textColor)).
+		Background(lp.Color(redColor)).
+		Width(width).
+		Align(lp.Center).
+		Render(text)
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// statusHighlightDuration is how long the row of a build stands out after its status changed
+	statusHighlightDuration = 4 * time.Second
+	// statusFlashDuration is how long the row blinks first, unless animations are off
+	statusFlashDuration = 1500 * time.Millisecond
+	// statusFlashInterval switches a blinking row between reversed and plain
+	statusFlashInterval = 250 * time.Millisecond
+)
+
+// highlight is how a row stands out after the status of its build changed
+type highlight int
+
+const (
+	highlightNone   highlight = iota
+	highlightFlash            // Reversed, every other statusFlashInterval while blinking
+	highlightRecent           // Underlined for the rest of statusHighlightDuration
+)
+
+// statusTracker follows the status of the listed builds to highlight the rows of those that changed
+type statusTracker struct {
+	last    map[string]model.BuildState // Status of each listed build when last checked
+	changed map[string]time.Time        // When the status of a build changed, by download ID
+}
+
+// trackStatusChanges notes the listed builds whose status changed since the last call, so their rows
+// stand out even when a download moves from Online to Local between two looks. Rows that appear or
+// disappear, e.g. on a fetch, aren't changes. Returns whether a highlight started.
+func (m *Model) trackStatusChanges(now time.Time) bool {
+	statuses := make(map[string]model.BuildState, len(m.builds))
+	started := false
+	for _, build := range m.builds {
+		id := downloadID(build)
+		statuses[id] = build.Status
+		if previous, ok := m.statusChanges.last[id]; ok && previous != build.Status {
+			if m.statusChanges.changed == nil {
+				m.statusChanges.changed = make(map[string]time.Time)
+			}
+			m.statusChanges.changed[id] = now
+			started = true
+		}
+	}
+	m.statusChanges.last = statuses
+
+	for id, at := range m.statusChanges.changed {
+		if now.Sub(at) >= statusHighlightDuration {
+			delete(m.statusChanges.changed, id)
+		}
+	}
+	return started
+}
+
+// animate reports whether rows blink when their status changes; not with reduce_motion or in low bandwidth mode
+func (m *Model) animate() bool {
+	return !m.config.ReduceMotion && !m.lowBandwidth
+}
+
+// statusHighlight returns how the row of a build stands out at now: blinking right after its status
+// changed, then underlined. Without animations it is underlined the whole time.
+func (m *Model) statusHighlight(build model.BlenderBuild, now time.Time) highlight {
+	at, ok := m.statusChanges.changed[downloadID(build)]
+	elapsed := now.Sub(at)
+	if !ok || elapsed >= statusHighlightDuration {
+		return highlightNone
+	}
+	if m.animate() && elapsed < statusFlashDuration {
+		if (elapsed/statusFlashInterval)%2 == 0 {
+			return highlightFlash
+		}
+		return highlightNone
+	}
+	return highlightRecent
+}
+
+// nextHighlightChange returns when a highlighted row looks different next, ok is false when none is highlighted
+func (m *Model) nextHighlightChange(now time.Time) (next time.Time, ok bool) {
+	for _, at := range m.statusChanges.changed {
+		elapsed := now.Sub(at)
+		change := at.Add(statusHighlightDuration)
+		if m.animate() && elapsed < statusFlashDuration {
+			change = now.Add(statusFlashInterval - elapsed%statusFlashInterval)
+		}
+		if !ok || change.Before(next) {
+			next, ok = change, true
+		}
+	}
+	return next, ok
+}
+
+// highlightFingerprint describes the highlighted rows as they look at now, see frameFingerprint
+func (m *Model) highlightFingerprint(now time.Time) string {
+	if len(m.statusChanges.changed) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, build := range m.builds {
+		if level := m.statusHighlight(build, now); level != highlightNone {
+			parts = append(parts, fmt.Sprintf("%s=%d", downloadID(build), level))
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ";")
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"testing"
+	"time"
+)
+
+func TestStatusHighlight(t *testing.T) {
+	build := model.BlenderBuild{Version: "4.3.0", Hash: "a1b2c3d4e5f6", Status: model.StateOnline}
+	other := model.BlenderBuild{Version: "4.2.0", Hash: "b1b2c3d4e5f6", Status: model.StateLocal}
+	m := &Model{builds: []model.BlenderBuild{build, other}}
+	start := time.Now()
+
+	// The first look only records the statuses
+	if m.trackStatusChanges(start) {
+		t.Fatal("Expected no change on the first look")
+	}
+	m.builds[0].Status = model.StateDownloading
+	if !m.trackStatusChanges(start) {
+		t.Fatal("Expected the status change to start a highlight")
+	}
+	if m.statusHighlight(m.builds[1], start) != highlightNone {
+		t.Error("Expected the unchanged build not to be highlighted")
+	}
+
+	// The row blinks, then stays underlined until the highlight ends
+	if got := m.statusHighlight(m.builds[0], start); got != highlightFlash {
+		t.Errorf("Expected the row to flash first, got %d", got)
+	}
+	if got := m.statusHighlight(m.builds[0], start.Add(statusFlashInterval)); got != highlightNone {
+		t.Errorf("Expected the row to blink off, got %d", got)
+	}
+	if next, ok := m.nextHighlightChange(start); !ok || next.Sub(start) != statusFlashInterval {
+		t.Errorf("Expected the next frame after %v, got %v", statusFlashInterval, next.Sub(start))
+	}
+	if got := m.statusHighlight(m.builds[0], start.Add(statusFlashDuration)); got != highlightRecent {
+		t.Errorf("Expected the row to be underlined after blinking, got %d", got)
+	}
+	if got := m.statusHighlight(m.builds[0], start.Add(statusHighlightDuration)); got != highlightNone {
+		t.Errorf("Expected the highlight to end, got %d", got)
+	}
+
+	// Without animations the row is only underlined
+	m.config.ReduceMotion = true
+	if got := m.statusHighlight(m.builds[0], start); got != highlightRecent {
+		t.Errorf("Expected no blinking with reduce_motion, got %d", got)
+	}
+
+	// Expired highlights are dropped, a row that appears isn't a change
+	m.builds = append(m.builds, model.BlenderBuild{Version: "4.4.0", Hash: "c1b2c3d4e5f6", Status: model.StateOnline})
+	if m.trackStatusChanges(start.Add(statusHighlightDuration)) || len(m.statusChanges.changed) != 0 {
+		t.Errorf("Expected no highlight left, got %v", m.statusChanges.changed)
+	}
+}
@@ -0,0 +1,1110 @@
+//go:build integration
+// +build integration
+
+package tui
+
+import (
+	"TUI-Blender-Launcher/api"
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/digest"
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/integration"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/schedule"
+	"TUI-Blender-Launcher/stats"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// waitTimeout bounds how long a test waits for the screen to show something
+const waitTimeout = 10 * time.Second
+
+// syncBuffer collects the program output, which is written from the renderer goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// testProgram runs the TUI headless, feeding it scripted keys and recording the rendered frames
+type testProgram struct {
+	t       *testing.T
+	program *tea.Program
+	out     *syncBuffer
+	seen    int // Output already matched by waitFor
+	done    chan struct{}
+	final   tea.Model
+	err     error
+}
+
+// startProgram runs m in a headless program with a fixed terminal size
+func startProgram(t *testing.T, m *Model) *testProgram {
+	t.Helper()
+	tp := &testProgram{
+		t:    t,
+		out:  &syncBuffer{},
+		done: make(chan struct{}),
+	}
+	tp.program = tea.NewProgram(m,
+		tea.WithInput(nil),
+		tea.WithOutput(tp.out),
+		tea.WithoutSignalHandler(),
+	)
+	go func() {
+		tp.final, tp.err = tp.program.Run()
+		close(tp.done)
+	}()
+	t.Cleanup(func() {
+		tp.program.Kill()
+		<-tp.done
+		// Like main, stop the transfers before the fake builder goes away
+		if m.commands != nil {
+			_ = m.commands.Shutdown()
+		}
+	})
+	tp.program.Send(tea.WindowSizeMsg{Width: 180, Height: 40})
+	return tp
+}
+
+// press sends key presses: key names like "down" and "enter", or text typed rune by rune
+func (tp *testProgram) press(keys ...string) {
+	names := map[string]tea.KeyType{
+		"up": tea.KeyUp, "down": tea.KeyDown, "left": tea.KeyLeft, "right": tea.KeyRight,
+		"enter": tea.KeyEnter, "esc": tea.KeyEsc, "backspace": tea.KeyBackspace, "ctrl+d": tea.KeyCtrlD,
+		"ctrl+c": tea.KeyCtrlC,
+	}
+	for _, k := range keys {
+		if keyType, ok := names[k]; ok {
+			tp.program.Send(tea.KeyMsg{Type: keyType})
+		} else {
+			tp.program.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		}
+	}
+}
+
+// waitFor waits until the output rendered since the last match contains all of texts
+func (tp *testProgram) waitFor(texts ...string) {
+	tp.t.Helper()
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		out := tp.out.String()
+		screen := ansi.Strip(out[tp.seen:])
+		found := true
+		for _, text := range texts {
+			if !strings.Contains(screen, text) {
+				found = false
+			}
+		}
+		if found {
+			tp.seen = len(out)
+			return
+		}
+		if time.Now().After(deadline) {
+			tp.t.Fatalf("Timed out waiting for %q, screen since last match:\n%s", texts, screen)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// quit presses q, or the given quit key, and returns the final model
+func (tp *testProgram) quit(keys ...string) *Model {
+	tp.t.Helper()
+	if len(keys) == 0 {
+		keys = []string{"q"}
+	}
+	tp.press(keys...)
+	select {
+	case <-tp.done:
+	case <-time.After(waitTimeout):
+		tp.t.Fatal("Timed out waiting for the program to quit")
+	}
+	if tp.err != nil {
+		tp.t.Fatalf("Program failed: %v", tp.err)
+	}
+	return tp.final.(*Model)
+}
+
+// setupTUI starts a fake builder with the given daily versions and returns a model using a temporary config
+func setupTUI(t *testing.T, versions ...string) (*Model, *integration.FakeBuilder) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, ".local", "state"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, ".cache"))
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+
+	cfg := config.DefaultConfig()
+	cfg.DownloadDir = filepath.Join(home, "blender-builds")
+	if err := config.SaveConfig(cfg); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+	config.SetConfigInstance(cfg)
+
+	builder := integration.NewFakeBuilder()
+	t.Cleanup(builder.Close)
+	t.Cleanup(builder.Install())
+	for _, version := range versions {
+		if _, err := builder.AddBuild("daily", version, "main", strings.ReplaceAll(version, ".", "")+"a1b2c3d4"); err != nil {
+			t.Fatalf("Failed to add build %s: %v", version, err)
+		}
+	}
+	return InitialModel(cfg, NewCommands(cfg), false), builder
+}
+
+func TestNavigateRows(t *testing.T) {
+	m, _ := setupTUI(t, "4.1.0", "4.3.0", "4.2.0")
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.1.0", "4.2.0", "4.3.0")
+	tp.press("down", "down", "up")
+
+	final := tp.quit()
+	if len(final.builds) != 3 {
+		t.Fatalf("Expected 3 builds, got %d", len(final.builds))
+	}
+	// Newest first: 4.3.0, 4.2.0, 4.1.0
+	if final.cursor != 1 || final.builds[final.cursor].Version != "4.2.0" {
+		t.Errorf("Expected cursor on 4.2.0 (row 1), got row %d", final.cursor)
+	}
+	if frame := ansi.Strip(final.View()); !strings.Contains(frame, "Online") {
+		t.Errorf("Expected online builds in the final frame:\n%s", frame)
+	}
+}
+
+func TestRefetchUnchangedListing(t *testing.T) {
+	m, builder := setupTUI(t, "4.2.0", "4.3.0")
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.2.0", "4.3.0")
+	tp.press("f")
+	tp.waitFor("up to date (not modified)")
+
+	if _, err := builder.AddBuild("daily", "4.4.0", "main", "440a1b2c3d4"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	tp.press("f")
+	tp.waitFor("4.4.0")
+
+	final := tp.quit()
+	if len(final.builds) != 3 {
+		t.Errorf("Expected 3 builds after the listing changed, got %d", len(final.builds))
+	}
+}
+
+func TestMarkNewBuilds(t *testing.T) {
+	m, builder := setupTUI(t, "4.2.0")
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.2.0")
+	if _, err := builder.AddBuild("daily", "4.3.0", "main", "430a1b2c3d4"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	tp.press("f")
+	tp.waitFor("4.3.0 NEW", "1 new build since the last fetch")
+	tp.press("N")
+	tp.waitFor("Showing only new builds")
+
+	final := tp.quit()
+	if len(final.builds) != 1 || final.builds[0].Version != "4.3.0" {
+		t.Errorf("Expected only the new 4.3.0 build, got %v", final.builds)
+	}
+	if len(final.hiddenOld) != 1 {
+		t.Errorf("Expected 1 build kept aside, got %d", len(final.hiddenOld))
+	}
+}
+
+func TestDownloadBuild(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("d")
+	tp.waitFor("Local")
+
+	final := tp.quit()
+	if status := final.builds[final.cursor].Status; status != model.StateLocal {
+		t.Errorf("Expected downloaded build to be Local, got %s", status)
+	}
+	builds, err := local.ScanLocalBuilds(final.config.DownloadDir)
+	if err != nil || len(builds) != 1 {
+		t.Fatalf("Expected 1 installed build, got %d (%v)", len(builds), err)
+	}
+	if builds[0].Verification != model.VerificationVerified {
+		t.Errorf("Expected installed build to be verified, got %q", builds[0].Verification)
+	}
+	transfer, ok := final.stats.LastTransfer("4.3.0", builds[0].Architecture)
+	if !ok || transfer.Bytes == 0 || transfer.Duration <= 0 || transfer.PeakSpeed < transfer.AvgSpeed() {
+		t.Errorf("Expected the download in the transfer history, got %+v", transfer)
+	}
+}
+
+func TestSmokeTestBrokenBuild(t *testing.T) {
+	m, builder := setupTUI(t)
+	build, err := builder.AddBuild("daily", "4.3.0", "main", "430a1b2c3d4")
+	if err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	if err := builder.BreakBuild(build); err != nil {
+		t.Fatalf("Failed to break build: %v", err)
+	}
+	m.config.SmokeTest = true
+	m.commands.SetConfig(m.config)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("d")
+	tp.waitFor("Broken")
+	tp.press("enter")
+	tp.waitFor("failed its smoke test")
+
+	final := tp.quit()
+	if !final.builds[final.cursor].Broken() {
+		t.Errorf("Expected the installed build to be broken")
+	}
+	builds, err := local.ScanLocalBuilds(final.config.DownloadDir)
+	if err != nil || len(builds) != 1 {
+		t.Fatalf("Expected 1 installed build, got %d (%v)", len(builds), err)
+	}
+	if result := builds[0].SmokeTest; result == nil || result.Passed || result.Error == "" {
+		t.Errorf("Expected a failed smoke test in version.json, got %+v", result)
+	}
+}
+
+func TestGlibcWarning(t *testing.T) {
+	m, builder := setupTUI(t)
+	if _, err := builder.AddBuild("daily", "4.3.0", "main", "430a1b2c3d4"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	m.systemGlibc = "2.17"
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "glibc 2.28")
+	tp.press("d")
+	tp.waitFor("1 local")
+	tp.press("enter")
+	// The launch waits for confirmation
+	tp.waitFor("needs glibc 2.28 or newer, this system has 2.17", "Launch anyway")
+	tp.quit()
+}
+
+func TestRepairBrokenBuild(t *testing.T) {
+	m, builder := setupTUI(t)
+	build, err := builder.AddBuild("daily", "4.3.0", "main", "430a1b2c3d4")
+	if err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	if err := builder.BreakBuild(build); err != nil {
+		t.Fatalf("Failed to break build: %v", err)
+	}
+	m.config.SmokeTest = true
+	m.commands.SetConfig(m.config)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("d")
+	tp.waitFor("Blender 4.3.0 is broken", "Download again")
+
+	// The user config set up in the broken build survives the repair
+	installDir, err := local.FindBuildDir(m.config.DownloadDir, "4.3.0", "")
+	if err != nil || installDir == "" {
+		t.Fatalf("Expected the broken build to be installed, got %q (%v)", installDir, err)
+	}
+	userPref := filepath.Join(local.IsolatedConfigDir, "4.3", "config", "userpref.blend")
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(installDir, userPref)), 0750); err != nil {
+		t.Fatalf("Failed to create user config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(installDir, userPref), []byte("prefs"), 0644); err != nil {
+		t.Fatalf("Failed to write user config: %v", err)
+	}
+
+	if err := builder.FixBuild(build); err != nil {
+		t.Fatalf("Failed to fix build: %v", err)
+	}
+	tp.press("r")
+	// The broken build shows as Local until the download starts, wait for the install to finish
+	tp.waitFor("Downloaded Blender 4.3.0", "Local")
+
+	final := tp.quit()
+	if final.builds[final.cursor].Broken() {
+		t.Errorf("Expected the repaired build to start")
+	}
+	builds, err := local.ScanLocalBuilds(final.config.DownloadDir)
+	if err != nil || len(builds) != 1 {
+		t.Fatalf("Expected 1 installed build, got %d (%v)", len(builds), err)
+	}
+	if result := builds[0].SmokeTest; result == nil || !result.Passed {
+		t.Errorf("Expected a passed smoke test in version.json, got %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(installDir, userPref)); err != nil {
+		t.Errorf("Expected the user config to be restored: %v", err)
+	}
+	oldBuilds, _ := os.ReadDir(filepath.Join(final.config.DownloadDir, download.OldBuildsDir))
+	if len(oldBuilds) != 1 {
+		t.Errorf("Expected the broken build to be moved to the old builds, found %d", len(oldBuilds))
+	}
+}
+
+func TestUndoDelete(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("d")
+	tp.waitFor("Local")
+	tp.press("L", "lighting", "enter")
+	tp.waitFor(`Labelled Blender 4.3.0 as "lighting"`)
+	tp.press("x")
+	tp.waitFor("Deleted Blender 4.3.0 (u to undo)")
+	if builds, err := local.ScanLocalBuilds(m.config.DownloadDir); err != nil || len(builds) != 0 {
+		t.Fatalf("Expected the deleted build to be gone, found %d (%v)", len(builds), err)
+	}
+
+	tp.press("u")
+	tp.waitFor("Undid delete of Blender 4.3.0", "lighting")
+	tp.press("u")
+	tp.waitFor("Removed the label of Blender 4.3.0")
+	tp.press("u")
+	tp.waitFor("Nothing to undo")
+
+	final := tp.quit()
+	builds, err := local.ScanLocalBuilds(final.config.DownloadDir)
+	if err != nil || len(builds) != 1 {
+		t.Fatalf("Expected the restored build, found %d (%v)", len(builds), err)
+	}
+	if builds[0].Label != "" {
+		t.Errorf("Expected the label to be undone, got %q", builds[0].Label)
+	}
+}
+
+func TestCancelDownload(t *testing.T) {
+	m, builder := setupTUI(t, "4.3.0")
+	builder.StallDownloads(true)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("d")
+	// A single running download offers cancelling all of them too
+	tp.waitFor("Downloading", "X Cancel all")
+	tp.press("x")
+	tp.waitFor("Cancelled")
+
+	final := tp.quit()
+	if status := final.builds[final.cursor].Status; status != model.StateCancelled {
+		t.Errorf("Expected cancelled build, got %s", status)
+	}
+	builds, err := local.ScanLocalBuilds(final.config.DownloadDir)
+	if err != nil || len(builds) != 0 {
+		t.Errorf("A cancelled download must not install a build, found %d (%v)", len(builds), err)
+	}
+}
+
+func TestDownloadAcrossSettings(t *testing.T) {
+	m, builder := setupTUI(t, "4.3.0")
+	builder.StallDownloads(true)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("d")
+	tp.waitFor("Downloading")
+	tp.press("s")
+	tp.waitFor("Download Directory:", "Downloading Blender 4.3.0")
+	tp.press("s")
+	tp.waitFor("Downloading")
+	tp.press("x")
+	tp.waitFor("Cancelled")
+	tp.quit()
+}
+
+func TestChangeBuildTypeInSettings(t *testing.T) {
+	m, builder := setupTUI(t, "4.3.0")
+	if _, err := builder.AddBuild("experimental", "4.4.0", "sprint", "440a1b2c3d4"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("s")
+	tp.waitFor("Download Directory:", "Build Type:")
+	tp.press("down", "down", "down", "down", "right", "s")
+	// The new build type is fetched right away
+	tp.waitFor("4.4.0", "sprint")
+	tp.quit()
+}
+
+func TestChangeSettings(t *testing.T) {
+	m, _ := setupTUI(t)
+	tp := startProgram(t, m)
+
+	tp.press("s")
+	tp.waitFor("Download Directory:", "Version Filters:")
+	tp.press("down", "enter", "4.2", "enter", "s")
+	tp.waitFor("Filter: 4.2")
+
+	final := tp.quit()
+	if final.currentView != viewList {
+		t.Errorf("Expected to be back in the list view, got view %d", final.currentView)
+	}
+	if filter := final.config.VersionFilterFor("daily"); filter != "4.2" {
+		t.Errorf("Expected daily version filter 4.2 in the model, got %q", filter)
+	}
+	if filter := final.config.VersionFilterFor("patch"); filter != "" {
+		t.Errorf("Expected no patch version filter, got %q", filter)
+	}
+	saved, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("Failed to load saved config: %v", err)
+	}
+	if filter := saved.VersionFilterFor("daily"); filter != "4.2" {
+		t.Errorf("Expected daily version filter 4.2 in config.toml, got %q", filter)
+	}
+}
+
+func TestOfflineFallback(t *testing.T) {
+	m, builder := setupTUI(t, "4.3.0")
+	tp := startProgram(t, m)
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+
+	// The builder goes away: the next fetch lists the builds of the last one
+	builder.Close()
+	tp.press("f")
+	tp.waitFor("OFFLINE · showing build list cached")
+	tp.press("d")
+	tp.waitFor("downloads are disabled")
+
+	final := tp.quit()
+	if !final.offline || final.cachedAt.IsZero() {
+		t.Errorf("Expected offline mode with the time of the cached list, got offline %v at %v", final.offline, final.cachedAt)
+	}
+	if len(final.builds) != 1 || final.builds[0].Status != model.StateOnline {
+		t.Errorf("Expected the cached 4.3.0 build, got %+v", final.builds)
+	}
+}
+
+func TestStaleBuildList(t *testing.T) {
+	m, builder := setupTUI(t)
+	online, err := builder.AddBuild("daily", "4.3.0", "main", "430a1b2c3d4")
+	if err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	if _, err := builder.AddBuild("daily", "4.4.0", "main", "440a1b2c3d4"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+
+	// The last session fetched the listing and scanned an older 4.3.0
+	if _, err := api.NewAPI().FetchBuilds("", "daily"); err != nil {
+		t.Fatalf("Failed to fetch build list: %v", err)
+	}
+	installed := online
+	installed.Hash = "430old"
+	installed.BuildDate = model.Timestamp(time.Now().Add(-48 * time.Hour))
+	installDir := filepath.Join(m.config.DownloadDir, "blender-4.3.0-old")
+	if err := os.MkdirAll(installDir, 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", installDir, err)
+	}
+	if err := local.WriteBuildInfo(installDir, installed); err != nil {
+		t.Fatalf("Failed to write build info: %v", err)
+	}
+	scanned, err := local.ScanLocalBuilds(m.config.DownloadDir)
+	if err != nil || len(scanned) != 1 {
+		t.Fatalf("Expected the installed build to be scanned, got %v (%v)", scanned, err)
+	}
+
+	// The next start lists the cached builds with their status before the scan
+	m = InitialModel(m.config, &Commands{cfg: m.config}, false)
+	if m.staleList == nil || m.staleList.savedAt.IsZero() || len(m.builds) != 2 {
+		t.Fatalf("Expected the 2 cached builds marked stale, got %d (%+v)", len(m.builds), m.staleList)
+	}
+	statuses := map[string]model.BuildState{}
+	for _, build := range m.builds {
+		statuses[build.Version] = build.Status
+		if build.Status == model.StateUpdate && (build.Installed == nil || build.Installed.Hash != "430old") {
+			t.Errorf("Expected the update to keep its installed build, got %+v", build.Installed)
+		}
+	}
+	if statuses["4.3.0"] != model.StateUpdate || statuses["4.4.0"] != model.StateOnline {
+		t.Errorf("Unexpected statuses %v", statuses)
+	}
+
+	// The scan replaces the local builds, the online ones wait for the next fetch
+	_, cmd := m.handleLocalBuildsScanned(localBuildsScannedMsg{builds: scanned})
+	if cmd == nil || m.staleList == nil || !m.staleList.scanned {
+		t.Fatalf("Expected the online builds to stay stale and their status to be checked, got %+v", m.staleList)
+	}
+	if len(m.builds) != 2 {
+		t.Errorf("Expected the scanned build and 4.4.0, got %v", m.builds)
+	}
+	m.handleBuildsFetched(buildsFetchedMsg{builds: []model.BlenderBuild{{Version: "4.4.0", Architecture: online.Architecture, Status: model.StateOnline}}})
+	if m.staleList != nil {
+		t.Error("Expected the fetch to make the list current")
+	}
+}
+
+func TestResumePartialDownload(t *testing.T) {
+	m, builder := setupTUI(t)
+	build, err := builder.AddBuild("daily", "4.3.0", "main", "430a1b2c3d4")
+	if err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	// The build list was fetched before the crash
+	if _, err := api.NewAPI().FetchBuilds("", "daily"); err != nil {
+		t.Fatalf("Failed to fetch build list: %v", err)
+	}
+
+	// A download killed halfway
+	archive := builder.Archive(build)
+	partial := filepath.Join(m.config.DownloadDir, download.DownloadingDir, build.FileName)
+	if err := os.MkdirAll(filepath.Dir(partial), 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", download.DownloadingDir, err)
+	}
+	if err := os.WriteFile(partial, archive[:len(archive)/2], 0644); err != nil {
+		t.Fatalf("Failed to write partial download: %v", err)
+	}
+	crashed := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(partial, crashed, crashed); err != nil {
+		t.Fatalf("Failed to age partial download: %v", err)
+	}
+
+	tp := startProgram(t, m)
+	tp.waitFor("Partial download "+build.FileName, "Resume 1")
+	tp.press("r")
+	tp.waitFor("Local")
+
+	final := tp.quit()
+	builds, err := local.ScanLocalBuilds(final.config.DownloadDir)
+	if err != nil || len(builds) != 1 || builds[0].Verification != model.VerificationVerified {
+		t.Fatalf("Expected the resumed build to be installed and verified, got %+v (%v)", builds, err)
+	}
+	if _, err := os.Stat(partial); !os.IsNotExist(err) {
+		t.Error("The partial download should be gone after the install")
+	}
+}
+
+func TestTerminalTooSmall(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.program.Send(tea.WindowSizeMsg{Width: 60, Height: 15})
+	tp.waitFor("Terminal too small", "need 80x20, have 60x15")
+
+	// The list comes back once the terminal is large enough again
+	tp.program.Send(tea.WindowSizeMsg{Width: 120, Height: 30})
+	tp.waitFor("4.3.0", "Online")
+
+	final := tp.quit()
+	if frame := ansi.Strip(final.View()); strings.Contains(frame, "Terminal too small") {
+		t.Errorf("Expected the build list after resizing:\n%s", frame)
+	}
+}
+
+func TestToggleDensity(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("c")
+	tp.waitFor("Compact layout")
+
+	final := tp.quit()
+	frame := ansi.Strip(final.View())
+	if strings.Contains(frame, "TUI Blender Launcher") || strings.Contains(frame, "Verified") {
+		t.Errorf("Expected no title and no low-priority columns in compact mode:\n%s", frame)
+	}
+	saved, err := config.LoadConfig()
+	if err != nil || saved.Density != config.DensityCompact {
+		t.Errorf("Expected density compact to be saved, got %q (%v)", saved.Density, err)
+	}
+}
+
+func TestSharedBuildIsReadOnly(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	sharedDir := filepath.Join(t.TempDir(), "shared")
+	installDir := filepath.Join(sharedDir, "blender-4.2.0")
+	if err := os.MkdirAll(installDir, 0755); err != nil {
+		t.Fatalf("Failed to create shared build: %v", err)
+	}
+	if err := local.WriteBuildInfo(installDir, model.BlenderBuild{Version: "4.2.0", Branch: "main", ReleaseCycle: "daily"}); err != nil {
+		t.Fatalf("Failed to write shared build info: %v", err)
+	}
+	m.config.SharedDir = sharedDir
+	m.commands.SetConfig(m.config)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "4.2.0", "Shared")
+	tp.press("down", "x")
+	tp.waitFor("shared build and can't be changed")
+
+	final := tp.quit()
+	if _, err := os.Stat(installDir); err != nil {
+		t.Errorf("Expected the shared build to be kept: %v", err)
+	}
+	if build := final.builds[final.cursor]; !build.Shared || build.Version != "4.2.0" {
+		t.Errorf("Expected the shared build 4.2.0 to be selected, got %s (shared %v)", build.Version, build.Shared)
+	}
+}
+
+func TestPromoteBuild(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	m.config.ApprovedOnly = true
+	m.config.PromotionAdmin = true
+	m.commands.SetConfig(m.config)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("d")
+	tp.waitFor("Local")
+	tp.press("enter")
+	tp.waitFor("only approved builds can be launched")
+	tp.press("m")
+	tp.waitFor("Blender 4.3.0 is now approved")
+
+	final := tp.quit()
+	builds, err := local.ScanLocalBuilds(final.config.DownloadDir)
+	if err != nil || len(builds) != 1 {
+		t.Fatalf("Expected 1 installed build, got %d (%v)", len(builds), err)
+	}
+	if builds[0].Promotion != model.PromotionApproved {
+		t.Errorf("Expected the promotion to be saved, got %q", builds[0].Promotion)
+	}
+}
+
+func TestSnoozeUpdates(t *testing.T) {
+	m, builder := setupTUI(t, "4.3.0")
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("d")
+	tp.waitFor("1 local")
+
+	// A newer build of the same version makes the installed one outdated
+	dirPath, err := local.FindBuildDir(m.config.DownloadDir, "4.3.0", "")
+	if err != nil || dirPath == "" {
+		t.Fatalf("Failed to find the installed build: %v", err)
+	}
+	installed, err := local.ReadBuildInfo(dirPath)
+	if err != nil || installed == nil {
+		t.Fatalf("Failed to read the installed build: %v", err)
+	}
+	installed.BuildDate = model.Timestamp(installed.BuildDate.Time().Add(-time.Hour))
+	if err := local.WriteBuildInfo(dirPath, *installed); err != nil {
+		t.Fatalf("Failed to write the installed build: %v", err)
+	}
+	if _, err := builder.AddBuild("daily", "4.3.0", "main", "430f6e5d4c3"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	tp.press("f")
+	tp.waitFor("Update")
+
+	tp.press("z")
+	tp.waitFor("Snooze updates of 4.3.0 for days")
+	tp.press("enter")
+	tp.waitFor("snoozed for 7 days", "Snoozed 7d")
+	tp.press("z", "backspace", "0", "enter")
+	tp.waitFor("are shown again", "1 local / 1 online")
+
+	final := tp.quit()
+	if status := final.builds[final.cursor].Status; status != model.StateUpdate {
+		t.Errorf("Expected the update to be listed again, got %s", status)
+	}
+	builds, err := local.ScanLocalBuilds(final.config.DownloadDir)
+	if err != nil || len(builds) != 1 {
+		t.Fatalf("Expected 1 installed build, got %d (%v)", len(builds), err)
+	}
+	if builds[0].SnoozedUntil != nil {
+		t.Errorf("Expected the snooze to be removed, got %v", builds[0].SnoozedUntil.Time())
+	}
+}
+
+func TestDigestAfterBreak(t *testing.T) {
+	m, builder := setupTUI(t, "4.2.0", "4.3.0")
+	if _, err := builder.AddBuild("daily", "4.2.1", "v42", "421a1b2c3d4"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	session := &digest.Session{LastRun: time.Now().AddDate(0, 0, -3)}
+	if err := session.Save(); err != nil {
+		t.Fatalf("Failed to save session: %v", err)
+	}
+	m = InitialModel(m.config, NewCommands(m.config), false)
+	tp := startProgram(t, m)
+
+	// The listing is fetched at startup for the digest
+	tp.waitFor("3 new builds since your last session", "daily 4.3 (1)", "daily 4.2 (2)", "4.2.1")
+	tp.press("esc")
+	tp.waitFor("4.2.1", "Online")
+
+	final := tp.quit()
+	if final.digest != nil || !final.digestSince.IsZero() {
+		t.Errorf("Expected the digest to be shown once, got %+v since %v", final.digest, final.digestSince)
+	}
+	saved, err := digest.LoadSession()
+	if err != nil || time.Since(saved.LastRun) > time.Minute {
+		t.Errorf("Expected this session to be recorded, got %+v (%v)", saved, err)
+	}
+}
+
+func TestDownloadBudget(t *testing.T) {
+	m, builder := setupTUI(t)
+	var size, total int64
+	for _, version := range []string{"4.2.0", "4.3.0"} {
+		build, err := builder.AddBuild("daily", version, "main", strings.ReplaceAll(version, ".", "")+"a1b2c3d4")
+		if err != nil {
+			t.Fatalf("Failed to add build %s: %v", version, err)
+		}
+		size = max(size, build.Size)
+		total += build.Size
+	}
+	// Room for one download and a half
+	m.config.DownloadBudgetGB = float64(size) * 1.5 / (1 << 30)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.2.0", "4.3.0")
+	tp.press("d")
+	tp.waitFor("1 local")
+	tp.press("down", "d")
+	tp.waitFor("used this month: download")
+	tp.press("d")
+	tp.waitFor("Download budget exceeded")
+
+	final := tp.quit()
+	if got := final.stats.DownloadedIn(time.Now()); got != total {
+		t.Errorf("Expected both downloads to be counted, %d bytes, got %d", total, got)
+	}
+	saved, err := stats.Load()
+	if err != nil || saved.DownloadedIn(time.Now()) != final.stats.DownloadedIn(time.Now()) {
+		t.Errorf("Expected the download volume to be saved, got %+v (%v)", saved, err)
+	}
+}
+
+func TestOpenBlendFile(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	m.config.ApprovedOnly = true // Stops at the launch instead of starting the fake build
+	m.commands.SetConfig(m.config)
+	blendFile := filepath.Join(t.TempDir(), "scene.blend")
+	if err := os.WriteFile(blendFile, []byte("BLENDER-v403REND"), 0644); err != nil {
+		t.Fatalf("Failed to write .blend file: %v", err)
+	}
+	m.OpenBlendFile(blendFile)
+	tp := startProgram(t, m)
+
+	tp.waitFor("scene.blend was saved by Blender 4.3")
+	tp.press("f")
+	tp.waitFor("Download 4.3.0")
+	tp.press("d")
+	tp.waitFor("Blender 4.3.0 is testing, only approved builds can be launched")
+	tp.quit()
+}
+
+func TestMetadataDiff(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("i")
+	tp.press("m")
+	tp.waitFor("Online: no version.json found")
+	tp.press("esc")
+	tp.press("esc")
+	tp.press("d")
+	tp.waitFor("1 local")
+	tp.press("i")
+	tp.press("m")
+	tp.waitFor("Local: same hash", "▶ Hash")
+	tp.press("esc")
+	tp.waitFor("Compare metadata")
+
+	final := tp.quit()
+	if len(final.fetched) != 1 {
+		t.Errorf("Expected the fetched listing to be kept, got %d builds", len(final.fetched))
+	}
+}
+
+func TestSpeedTest(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	m.config.Mirrors = []string{"https://mirror.invalid"}
+	m.config.DownloadSource = "https://mirror.invalid"
+	m.commands.SetConfig(m.config)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+	tp.press("S")
+	tp.waitFor("builder.blender.org (builder)", "MB/s", "mirror.invalid *", "failed")
+	tp.press("enter")
+	tp.waitFor("Downloads now start from builder.blender.org")
+
+	final := tp.quit()
+	if final.config.DownloadSource != "" {
+		t.Errorf("Expected downloads to start from the builder, got %q", final.config.DownloadSource)
+	}
+}
+
+func TestDownloadAndLaunch(t *testing.T) {
+	m, builder := setupTUI(t, "4.3.0")
+	if _, err := builder.AddBuild("daily", "4.2.0", "main", "420a1b2c3d4"); err != nil {
+		t.Fatalf("Failed to add build: %v", err)
+	}
+	m.config.ApprovedOnly = true // Stops at the launch instead of starting the fake build
+	m.commands.SetConfig(m.config)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "4.2.0", "Online")
+	tp.press("e")
+	tp.waitFor("Blender 4.3.0 is testing, only approved builds can be launched")
+
+	// A pending launch can be cancelled while the download goes on
+	builder.StallDownloads(true)
+	tp.press("down", "e")
+	tp.waitFor("4.2.0 LAUNCH", "Cancel launch")
+	tp.press("e")
+	tp.waitFor("Blender 4.2.0 won't be launched when ready")
+	tp.press("x")
+	tp.waitFor("Cancelled")
+
+	final := tp.quit()
+	if len(final.pendingLaunches) != 0 {
+		t.Errorf("Expected no pending launch, got %d", len(final.pendingLaunches))
+	}
+}
+
+func TestDownloadPinnedBuild(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	m.PinBuild(filepath.Join(t.TempDir(), local.PinFileName), "4.3")
+	tp := startProgram(t, m)
+
+	tp.waitFor("pins Blender 4.3, which isn't installed")
+	tp.press("f")
+	tp.waitFor("Download 4.3.0")
+	tp.press("d")
+	tp.waitFor("Installed Blender 4.3.0 pinned by")
+	tp.quit()
+}
+
+func TestOpenRecentProject(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	blendFile := filepath.Join(t.TempDir(), "scene.blend")
+	if err := os.WriteFile(blendFile, []byte("BLENDER-v403REND"), 0644); err != nil {
+		t.Fatalf("Failed to write .blend file: %v", err)
+	}
+	// The build it was last opened with is gone, so the version is read from the file
+	m.recent.Record(blendFile, model.BlenderBuild{Version: "4.2.0"}, time.Now())
+	tp := startProgram(t, m)
+
+	tp.press("R")
+	tp.waitFor("Recent projects", "scene.blend", "4.2.0")
+	tp.press("enter")
+	tp.waitFor("scene.blend was saved by Blender 4.3")
+	tp.press("esc")
+	tp.quit()
+}
+
+func TestActionMenu(t *testing.T) {
+	m, _ := setupTUI(t, "4.3.0")
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.3.0", "Online")
+
+	// The first action of an online build downloads it
+	tp.press(".")
+	tp.waitFor("Download and launch", "Schedule download")
+	tp.press("enter")
+	tp.waitFor("1 local")
+
+	tp.press(".")
+	tp.waitFor("Launch", "Verify installed files", "Copy install path")
+	tp.press("esc")
+	tp.waitFor("Actions")
+
+	final := tp.quit()
+	if final.actionMenu != nil {
+		t.Error("Expected the action menu to be closed")
+	}
+	builds, err := local.ScanLocalBuilds(final.config.DownloadDir)
+	if err != nil || len(builds) != 1 {
+		t.Fatalf("Expected 1 installed build, got %d (%v)", len(builds), err)
+	}
+}
+
+func TestDownloadsPanel(t *testing.T) {
+	m, builder := setupTUI(t, "4.2.0", "4.3.0")
+	builder.StallDownloads(true)
+	m.config.MaxDownloads = 1
+	m.commands.SetConfig(m.config)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.2.0", "4.3.0")
+	tp.press("d")
+	tp.waitFor("Downloading")
+	tp.press("down", "d")
+	tp.waitFor("Queued")
+
+	tp.press("D")
+	tp.waitFor("Downloads (2)", "Queued #1")
+
+	// Pausing the running download gives its slot to the queued one
+	tp.press("p")
+	tp.waitFor("Paused")
+	tp.press("x")
+	tp.waitFor("Downloads (1)")
+	tp.press("esc")
+	tp.waitFor("Cancelled")
+
+	final := tp.quit()
+	if final.downloadsPanel != nil {
+		t.Error("Expected the downloads panel to be closed")
+	}
+	transfers := final.commands.downloads.Transfers()
+	if len(transfers) != 1 || transfers[0].State.Queued || transfers[0].State.Paused {
+		t.Errorf("Expected one running download, got %+v", transfers)
+	}
+	final.commands.downloads.CancelAll()
+}
+
+func TestQueuePriority(t *testing.T) {
+	m, builder := setupTUI(t, "4.1.0", "4.2.0", "4.3.0")
+	builder.StallDownloads(true)
+	m.config.MaxDownloads = 1
+	m.commands.SetConfig(m.config)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.1.0", "4.2.0", "4.3.0")
+	tp.press("d", "down", "d", "down", "d")
+	tp.waitFor("Queued")
+
+	// The last queued download jumps ahead of the other one
+	tp.press("D")
+	tp.waitFor("Downloads (3)", "Queued #2")
+	tp.press("down", "down", "!")
+	tp.waitFor("Queued #1")
+	tp.press("esc")
+	tp.waitFor("Downloading")
+
+	final := tp.quit()
+	transfers := final.commands.downloads.Transfers()
+	if len(transfers) != 3 {
+		t.Fatalf("Expected 3 downloads, got %d", len(transfers))
+	}
+	next := transfers[1].Build
+	if next.Version != final.builds[2].Version {
+		t.Errorf("Expected %s to start next, got %s", final.builds[2].Version, next.Version)
+	}
+
+	// After a restart the queue starts in the same order, the running download stays in it until it completes
+	running := transfers[0].Build
+	queue, err := schedule.LoadQueue()
+	if err != nil || len(queue.Builds) != 3 {
+		t.Fatalf("Expected 3 unfinished builds on disk, got %+v (%v)", queue, err)
+	}
+	if queue.Builds[0].Version != running.Version || queue.Builds[1].Version != next.Version {
+		t.Errorf("Expected %s and %s first in the saved queue, got %s and %s", running.Version, next.Version, queue.Builds[0].Version, queue.Builds[1].Version)
+	}
+	// Stop the stalled transfers of the first run, cancelling empties the saved queue
+	final.commands.downloads.CancelAll()
+	_ = final.commands.Shutdown()
+	if err := queue.Save(); err != nil {
+		t.Fatalf("Failed to save queue: %v", err)
+	}
+	restarted := InitialModel(final.config, NewCommands(final.config), false)
+	tp = startProgram(t, restarted)
+	tp.press("f")
+	tp.waitFor("Restored 3 queued downloads")
+
+	final = tp.quit()
+	transfers = final.commands.downloads.Transfers()
+	if len(transfers) != 3 || transfers[0].Build.Version != running.Version || transfers[1].Build.Version != next.Version || transfers[1].QueuePos != 1 {
+		t.Errorf("Expected %s running and %s queued next, got %+v", running.Version, next.Version, transfers)
+	}
+	final.commands.downloads.CancelAll()
+}
+
+func TestShutdown(t *testing.T) {
+	m, builder := setupTUI(t, "4.1.0", "4.2.0", "4.3.0")
+	builder.StallDownloads(true)
+	m.config.MaxDownloads = 1
+	m.commands.SetConfig(m.config)
+	tp := startProgram(t, m)
+
+	tp.press("f")
+	tp.waitFor("4.1.0", "4.2.0", "4.3.0")
+	tp.press("d", "down", "d", "down", "d")
+	tp.waitFor("Downloading", "Queued")
+	tp.press("D")
+	tp.waitFor("Downloads (3)")
+	tp.press("p")
+	tp.waitFor("Paused")
+	tp.press("esc")
+
+	// The download taking the paused one's slot writes its partial file before quitting
+	downloadingDir := filepath.Join(m.config.DownloadDir, download.DownloadingDir)
+	for deadline := time.Now().Add(waitTimeout); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if entries, _ := os.ReadDir(downloadingDir); len(entries) == 2 {
+			break
+		}
+	}
+
+	// ctrl+c quits like q, and exiting stops the running download keeping its partial file, like the paused one's
+	final := tp.quit("ctrl+c")
+	transfers := final.commands.downloads.Transfers()
+	if len(transfers) != 3 {
+		t.Fatalf("Expected a running, a paused and a queued download, got %+v", transfers)
+	}
+	if err := final.commands.Shutdown(); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	entries, _ := os.ReadDir(downloadingDir)
+	if len(entries) != 2 {
+		t.Errorf("Expected the partial files of the running and the paused download to be kept, got %v", entries)
+	}
+
+	// All are restored at the next start, the stopped downloads ahead of the queued one
+	queue, err := schedule.LoadQueue()
+	if err != nil || len(queue.Builds) != 3 {
+		t.Fatalf("Expected the three builds to be kept, got %+v (%v)", queue, err)
+	}
+	if queue.Builds[2].Version != transfers[2].Build.Version {
+		t.Errorf("Expected the queued %s last in the saved queue, got %s", transfers[2].Build.Version, queue.Builds[2].Version)
+	}
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// labelCharLimit keeps labels short enough to fit the table column
+const labelCharLimit = 40
+
+// LabelBuild creates a command to save the custom label of a local build
+func (c *Commands) LabelBuild(version, arch, label string) tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		dirPath, err := local.FindBuildDir(downloadDir, version, arch)
+		if err != nil {
+			return buildLabelledMsg{version: version, err: err}
+		}
+		if dirPath == "" {
+			return buildLabelledMsg{version: version, err: fmt.Errorf("build directory for Blender version %s not found", version)}
+		}
+		build, err := local.SetBuildLabel(dirPath, label)
+		if err != nil {
+			return buildLabelledMsg{version: version, err: fmt.Errorf("failed to save label: %w", err)}
+		}
+		return buildLabelledMsg{version: version, build: build}
+	}
+}
+
+// openLabelPrompt shows the inline label prompt for the selected local build
+func (m *Model) openLabelPrompt() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok || (build.Status != model.StateLocal && build.Status != model.StateUpdate) || m.rejectShared(build) {
+		return m, nil
+	}
+
+	input := textinput.New()
+	input.Prompt = fmt.Sprintf("Label for %s: ", build.Version)
+	input.Placeholder = "empty to remove"
+	input.CharLimit = labelCharLimit
+	input.Width = 30
+	input.SetValue(build.Label)
+	input.CursorEnd()
+	input.Focus()
+
+	m.labelPrompt = &input
+	return m, textinput.Blink
+}
+
+// updateLabelPrompt handles key events while the label prompt is open
+func (m *Model) updateLabelPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.labelPrompt = nil
+		return m, nil
+
+	case "enter":
+		label := strings.TrimSpace(m.labelPrompt.Value())
+		m.labelPrompt = nil
+		build, ok := m.selectedBuild()
+		if !ok {
+			return m, nil
+		}
+		if label != build.Label {
+			m.pushUndo(fmt.Sprintf("label of Blender %s", build.Version), m.commands.LabelBuild(build.Version, build.Architecture, build.Label))
+		}
+		return m, m.commands.LabelBuild(build.Version, build.Architecture, label)
+	}
+
+	var cmd tea.Cmd
+	*m.labelPrompt, cmd = m.labelPrompt.Update(msg)
+	return m, cmd
+}
+
+// handleBuildLabelled shows the saved label on every row of the build
+func (m *Model) handleBuildLabelled(msg buildLabelledMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.err = nil
+	for i := range m.builds {
+		if !m.builds[i].Matches(msg.version, msg.build.Architecture) {
+			continue
+		}
+		m.builds[i].Label = msg.build.Label
+		if m.builds[i].Installed != nil {
+			m.builds[i].Installed.Label = msg.build.Label
+		}
+	}
+	if msg.build.Label == "" {
+		m.showNotice(fmt.Sprintf("Removed the label of Blender %s", msg.version))
+	} else {
+		m.showNotice(fmt.Sprintf("Labelled Blender %s as %q", msg.version, msg.build.Label))
+	}
+	return m, nil
+}
+
+// renderLabelPromptFooter renders the label prompt in place of the footer
+func (m *Model) renderLabelPromptFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	hints := strings.Join([]string{
+		fmt.Sprintf("%s Save label", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+	}, separator)
+
+	return footerStyle.Width(m.terminalWidth).Render(m.labelPrompt.View() + newlineStyle + hints)
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/projects"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPaletteLaunchWarning(t *testing.T) {
+	cfg := config.Config{DownloadDir: t.TempDir()}
+	broken := model.BlenderBuild{Version: "4.3.0", Status: model.StateLocal, SmokeTest: &model.SmokeTestResult{Error: "exit status 1"}}
+	m := &Model{config: cfg, commands: &Commands{cfg: cfg}, builds: []model.BlenderBuild{broken}}
+
+	// A broken build launched from the palette waits for confirmation like one launched from the list
+	m.palette = &palette{matches: []model.BlenderBuild{broken}}
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || m.launchWarning == nil {
+		t.Fatalf("Expected the launch to wait for confirmation")
+	}
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyEsc}); cmd != nil || m.launchWarning != nil {
+		t.Errorf("Expected esc to call the launch off")
+	}
+
+	m.palette = &palette{matches: []model.BlenderBuild{broken}}
+	m.updateKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || m.launchWarning != nil {
+		t.Errorf("Expected enter to launch the build anyway")
+	}
+
+	// approved_only applies as well
+	m.config.ApprovedOnly = true
+	m.palette = &palette{matches: []model.BlenderBuild{{Version: "4.2.0", Status: model.StateLocal}}}
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Errorf("Expected an unapproved build not to launch")
+	}
+}
+
+func TestRecentProjectLaunchWarning(t *testing.T) {
+	cfg := config.Config{DownloadDir: t.TempDir()}
+	broken := model.BlenderBuild{Version: "4.3.0", Architecture: "x86_64", Status: model.StateLocal, SmokeTest: &model.SmokeTestResult{Error: "exit status 1"}}
+	m := &Model{config: cfg, commands: &Commands{cfg: cfg}, builds: []model.BlenderBuild{broken}}
+	project := projects.Project{Path: filepath.Join(t.TempDir(), "shot.blend"), Version: "4.3.0", Architecture: "x86_64"}
+
+	// Opening a project goes through the same checks as launching from the list
+	if cmd := m.openRecentProject(project); cmd != nil || m.launchWarning == nil {
+		t.Fatalf("Expected the launch to wait for confirmation")
+	}
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Fatal("Expected enter to launch the build anyway")
+	}
+	if !strings.Contains(m.notice, "Opening shot.blend with Blender 4.3.0") {
+		t.Errorf("Expected the project to be opened, got notice %q", m.notice)
+	}
+}
+
+func TestGPULaunchWarning(t *testing.T) {
+	// A machine without GPU devices is fine, only a crash is worth a warning
+	none := model.BlenderBuild{GPUProbe: &model.GPUProbeResult{Compute: map[string]bool{"CUDA": false, "OptiX": false}}}
+	if warning := gpuLaunchWarning(none); warning != "" {
+		t.Errorf("Expected no warning without GPU devices, got %q", warning)
+	}
+	crashed := model.BlenderBuild{GPUProbe: &model.GPUProbeResult{Crashed: true}}
+	if warning := gpuLaunchWarning(crashed); !strings.Contains(warning, "crashed") {
+		t.Errorf("Expected a warning for the crash, got %q", warning)
+	}
+}
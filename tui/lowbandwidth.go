@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"time"
+)
+
+const (
+	// lowBandwidthFPS caps redraws in low bandwidth mode, where progress changes once per refresh interval anyway
+	lowBandwidthFPS = 4
+	// lowBandwidthProgressStep is the progress change redrawing the page in low bandwidth mode, one shown percent
+	lowBandwidthProgressStep = 0.01
+)
+
+// FPS returns the redraw cap for cfg: MaxFPS, or lower in low bandwidth mode
+func FPS(cfg config.Config) int {
+	if cfg.LowBandwidth() {
+		return lowBandwidthFPS
+	}
+	return MaxFPS
+}
+
+// progressStyle returns how progress is drawn: the configured style, or plain text in low bandwidth mode
+func (m *Model) progressStyle() string {
+	if m.lowBandwidth {
+		return "text"
+	}
+	return m.config.ProgressStyle
+}
+
+// activeInterval returns how often ticks refresh running transfers
+func (m *Model) activeInterval() time.Duration {
+	if m.lowBandwidth {
+		return m.config.RefreshInterval()
+	}
+	return activeTickInterval
+}
+
+// progressStep returns the smallest progress change redrawing the page, see frameFingerprint
+func (m *Model) progressStep() float64 {
+	if m.lowBandwidth {
+		return lowBandwidthProgressStep
+	}
+	return frameProgressStep
+}
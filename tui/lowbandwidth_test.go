@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLowBandwidthMode(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.ProgressStyle = "blocks"
+	cfg.RefreshIntervalMS = 2000
+	state := &model.DownloadState{BuildState: model.StateDownloading, Progress: 0.421}
+	m := &Model{config: cfg, lowBandwidth: true, downloadStates: map[string]*model.DownloadState{"4.3.0": state}}
+
+	if got := m.progressStyle(); got != "text" {
+		t.Errorf("Expected plain text progress, got %q", got)
+	}
+	bar := renderProgress(m.progressStyle(), state.Progress, 8)
+	if bar != "42%     " {
+		t.Errorf("Expected the whole percent padded to 8 cells, got %q", bar)
+	}
+	if strings.Contains(bar, "\x1b[") {
+		t.Errorf("Expected no escape sequences in plain text progress, got %q", bar)
+	}
+
+	// Ticks follow the configured interval while the download runs
+	m.nextTick()
+	if d := time.Until(m.tickAt); d < 1900*time.Millisecond || d > 2*time.Second {
+		t.Errorf("Expected the next tick in about 2s, got %v", d)
+	}
+
+	// Progress below one percent doesn't redraw
+	renders := 0
+	render := func() string {
+		renders++
+		return "page"
+	}
+	m.renderFrame(render)
+	state.Progress += 0.004
+	m.renderFrame(render)
+	if renders != 1 {
+		t.Errorf("Expected a change below one percent to reuse the page, got %d renders", renders)
+	}
+}
@@ -1,8 +1,14 @@
 package tui
 
 import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/local"
 	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/stats"
 	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // Define messages for communication between components
@@ -10,8 +16,20 @@ import (
 type (
 	// Data update messages
 	buildsFetchedMsg struct { // Online builds fetched
-		builds []model.BlenderBuild
-		err    error // Add error field
+		builds   []model.BlenderBuild
+		err      error     // Add error field
+		offline  bool      // Builder unreachable; builds come from the cache
+		cachedAt time.Time // When the cached builds were fetched
+		// The builder listing didn't change since the last fetch
+		notModified bool
+		newBuilds   []model.BlenderBuild // Builds the previous fetch didn't list
+	}
+	connectivityMsg struct { // Result of a connectivity check
+		err error // nil when the builder is reachable
+	}
+	localScanProgressMsg struct { // A directory was scanned during the local scan
+		progress local.ScanProgress
+		next     <-chan tea.Msg // Delivers the following scan message
 	}
 	localBuildsScannedMsg struct { // Initial local scan complete
 		builds []model.BlenderBuild
@@ -28,9 +46,156 @@ type (
 	}
 	downloadCompleteMsg struct { // Download & extraction finished
 		buildVersion  string // Version of the build that finished
+		buildArch     string // Architecture of the build that finished
 		extractedPath string
+		smokeTest     *model.SmokeTestResult // nil when the smoke test is off
+		fromCache     bool                   // Installed from the archive cache, nothing was downloaded
+		transfer      *stats.Transfer        // Duration and speeds of the download, nil when nothing was downloaded
 		err           error
 	}
+	launchProfileSetMsg struct { // Launch profile saved for a local build
+		version string
+		build   *model.BlenderBuild
+		err     error
+	}
+	buildDirsFoundMsg struct { // Directories of a local build were looked up for the directory menu
+		build model.BlenderBuild
+		dirs  []local.BuildDir
+		err   error
+	}
+	conflictMsg struct { // An install found a directory in its way that the launcher didn't install
+		build model.BlenderBuild
+		dir   string
+		reply chan<- download.Resolution // Receives the answer, the install waits for it
+	}
+	installsRecoveredMsg struct { // Installs interrupted by a crash were resolved
+		recovered []download.Recovery
+		err       error
+	}
+	partialsFoundMsg struct { // Orphaned partial downloads were found in .downloading
+		partials  []local.PartialDownload
+		resumable []model.BlenderBuild
+		err       error
+	}
+	partialsDeletedMsg struct { // Orphaned partial downloads were deleted
+		count int
+		freed int64
+		err   error
+	}
+	archivesListedMsg struct { // Archive cache was listed for the archive panel
+		archives []download.CachedArchive
+		err      error
+	}
+	cachedArchiveFoundMsg struct { // Archive cache searched for the archive of a build to reinstall
+		build   model.BlenderBuild
+		archive download.CachedArchive
+		ok      bool // The cache holds an archive of the build
+		err     error
+	}
+	archiveDeletedMsg struct { // Archive deleted from the archive cache
+		archive download.CachedArchive
+		err     error
+	}
+	archiveExportedMsg struct { // Cached archive copied to a directory
+		path string
+		err  error
+	}
+	downloadingMeasuredMsg struct { // Size of .downloading was measured, after deleting leftovers past the quota
+		size    int64
+		evicted []local.PartialDownload
+		err     error
+	}
+	blendVersionReadMsg struct { // Version that saved the .blend file to open was read
+		series string
+		err    error
+	}
+	oldBuildsExpiredMsg struct { // Old builds past the retention period were found
+		builds []local.OldBuild
+		err    error
+	}
+	oldBuildsPurgedMsg struct { // Old builds were moved to the trash
+		trashed []local.TrashedDir
+		freed   int64
+		err     error
+	}
+	buildVerifiedMsg struct { // Re-verification finished for a local build
+		version string
+		build   *model.BlenderBuild
+		result  local.VerifyResult
+		err     error
+	}
+	buildDeletedMsg struct { // Local build moved to the trash
+		build   model.BlenderBuild
+		trashed local.TrashedDir
+		err     error
+	}
+	undoneMsg struct { // Files of an undone action restored
+		action string
+		err    error
+	}
+	buildLabelledMsg struct { // Custom label saved for a local build
+		version string
+		build   *model.BlenderBuild
+		err     error
+	}
+	updatesSnoozedMsg struct { // Update snooze saved for a local build
+		version string
+		build   *model.BlenderBuild
+		err     error
+	}
+	buildPromotedMsg struct { // Promotion state saved for a local build
+		version string
+		build   *model.BlenderBuild
+		err     error
+	}
+	buildNotesSavedMsg struct { // Notes saved for a local build
+		version string
+		build   *model.BlenderBuild
+		err     error
+	}
+	buildTaggedMsg struct { // Tags saved for a local build
+		version string
+		build   *model.BlenderBuild
+		err     error
+	}
+	metadataDiffMsg struct { // version.json of a build read for the metadata diff
+		diff *metadataDiff
+		err  error
+	}
+	speedTestMsg struct { // Speed test of the builder and the mirrors finished
+		results []download.SourceSpeed // Ranked fastest first
+	}
+	artifactDownloadedMsg struct { // Companion file of a build downloaded into its folder
+		version string
+		path    string
+		size    int64 // Bytes downloaded
+		err     error
+	}
+	buildProbedMsg struct { // Introspection probe finished for a local build
+		version string
+		build   *model.BlenderBuild
+		err     error
+	}
+	blendAssociatedMsg struct { // Build registered as .blend file handler
+		version string
+		err     error
+	}
+	commitsFetchedMsg struct { // Commit list between two build hashes fetched
+		base, head string
+		commits    []model.Commit
+		err        error
+	}
+	configChangedMsg struct { // Config changed in the settings or on disk, already applied to the commands
+		old config.Config
+	}
+	filesystemCheckedMsg struct { // Filesystem of the download directory inspected
+		warning string // Empty for a local filesystem
+	}
+	customColumnsMsg struct { // Commands of the custom columns ran for the listed builds
+		values map[string][]string // Per download ID, in the order of the custom columns
+		failed []int               // Columns whose command failed
+		err    error               // First failure
+	}
 	// Error message
 	errMsg struct{ err error }
 
@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// metadataDiff compares the version.json of an installed build with its online listing entry,
+// to find out why a build is or isn't listed as an update
+type metadataDiff struct {
+	version string
+	local   *model.BlenderBuild // Read from version.json, nil when not installed
+	online  *model.BlenderBuild // Entry of the fetched listing, nil when not listed
+}
+
+// metadataField is a row of the metadata diff
+type metadataField struct {
+	label string
+	field string // Name of the comparison in model.UpdateCheck.Field deciding on it, empty for informative rows
+	value func(model.BlenderBuild) string
+}
+
+// metadataFields lists the compared fields, those update detection uses first
+var metadataFields = []metadataField{
+	{"Hash", "hash", func(b model.BlenderBuild) string { return b.Hash }},
+	{"Version", "version", func(b model.BlenderBuild) string { return b.Version }},
+	{"Branch", "branch", func(b model.BlenderBuild) string { return b.Branch }},
+	{"Release cycle", "cycle", func(b model.BlenderBuild) string { return b.ReleaseCycle }},
+	{"Build date", "date", func(b model.BlenderBuild) string {
+		if b.BuildDate.Time().IsZero() {
+			return ""
+		}
+		return b.BuildDate.Time().UTC().Format(time.RFC3339)
+	}},
+	{"Architecture", "", func(b model.BlenderBuild) string { return b.Architecture }},
+	{"Platform", "", func(b model.BlenderBuild) string { return b.OperatingSystem }},
+	{"File", "", func(b model.BlenderBuild) string { return b.FileName }},
+	{"Size", "", func(b model.BlenderBuild) string {
+		if b.Size == 0 {
+			return ""
+		}
+		return model.FormatByteSize(b.Size)
+	}},
+	{"Source", "", func(b model.BlenderBuild) string { return b.Source }},
+}
+
+// ReadInstalledMetadata creates a command to read the version.json of an installed build for the metadata diff
+func (c *Commands) ReadInstalledMetadata(version, arch string, online *model.BlenderBuild) tea.Cmd {
+	cfg := c.cfg
+	return func() tea.Msg {
+		diff := &metadataDiff{version: version, online: online}
+		for _, dir := range []string{cfg.DownloadDir, cfg.SharedDir} {
+			// Nothing was installed yet without the directory
+			if _, err := os.Stat(dir); dir == "" || err != nil {
+				continue
+			}
+			dirPath, err := local.FindBuildDir(dir, version, arch)
+			if err != nil {
+				return metadataDiffMsg{err: err}
+			}
+			if dirPath == "" {
+				continue
+			}
+			build, err := local.ReadBuildInfo(dirPath)
+			if err != nil {
+				return metadataDiffMsg{err: err}
+			}
+			diff.local = build
+			break
+		}
+		return metadataDiffMsg{diff: diff}
+	}
+}
+
+// fetchedEntry returns the entry of the fetched listing update detection compares an installed
+// build with: the one with the same hash, otherwise one of the same version
+func (m *Model) fetchedEntry(build model.BlenderBuild) *model.BlenderBuild {
+	var sameVersion *model.BlenderBuild
+	for i := range m.fetched {
+		entry := m.fetched[i]
+		if archKey("", entry) != archKey("", build) {
+			continue
+		}
+		if build.Hash != "" && entry.Hash == build.Hash {
+			return &entry
+		}
+		if entry.Version == build.Version && (sameVersion == nil || entry.Branch == build.Branch) {
+			sameVersion = &entry
+		}
+	}
+	return sameVersion
+}
+
+// openMetadataDiff compares the installed and the online metadata of the selected build
+func (m *Model) openMetadataDiff() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok {
+		return m, nil
+	}
+	var online *model.BlenderBuild
+	switch build.Status {
+	case model.StateUpdate, model.StateOnline:
+		entry := build
+		entry.Installed = nil
+		online = &entry
+		if build.Installed != nil {
+			build = *build.Installed
+		}
+	default:
+		online = m.fetchedEntry(build)
+	}
+	return m, m.commands.ReadInstalledMetadata(build.Version, build.Architecture, online)
+}
+
+// handleMetadataDiff shows a metadata diff once the version.json was read
+func (m *Model) handleMetadataDiff(msg metadataDiffMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.metadataDiff = msg.diff
+	return m, nil
+}
+
+// updateMetadataDiff closes the metadata diff on esc
+func (m *Model) updateMetadataDiff(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "m", "q":
+		m.metadataDiff = nil
+	}
+	return m, nil
+}
+
+// check returns the update detection decision for the compared builds
+func (d *metadataDiff) check(policy string) string {
+	switch {
+	case d.local == nil && d.online == nil:
+		return "not installed and not in the fetched listing"
+	case d.local == nil:
+		return fmt.Sprintf("%s: no version.json found, the build isn't installed", model.StateOnline)
+	case d.online == nil:
+		return fmt.Sprintf("%s: not in the fetched listing (fetch with f, or it was removed from the builder)", model.StateLocal)
+	}
+	check := model.CheckUpdate(*d.local, *d.online, policy)
+	return fmt.Sprintf("%s: %s", check.Status, check.Reason)
+}
+
+// renderMetadataDiff renders the fields of both sides, differing values in orange and the deciding comparison highlighted
+func (m *Model) renderMetadataDiff(availableHeight int) string {
+	diff := m.metadataDiff
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	decidingStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	differentStyle := lp.NewStyle().Foreground(lp.Color(orangeColor))
+
+	deciding := ""
+	if diff.local != nil && diff.online != nil {
+		deciding = model.CheckUpdate(*diff.local, *diff.online, m.config.UpdatePolicy).Field
+	}
+	side := func(b *model.BlenderBuild, f metadataField) string {
+		if b == nil {
+			return "-"
+		}
+		if v := f.value(*b); v != "" {
+			return v
+		}
+		return "(empty)"
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Metadata of Blender " + diff.version))
+	b.WriteString("\n")
+	b.WriteString("Status decision: " + diff.check(m.config.UpdatePolicy))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("  %-14s %-32s %s", "", "version.json", "Online"))
+	for _, f := range metadataFields {
+		localValue, onlineValue := side(diff.local, f), side(diff.online, f)
+		marker := " "
+		if f.field != "" && f.field == deciding {
+			marker = "▶"
+		}
+		line := fmt.Sprintf("%s %s %s %s", marker, fillText(f.label, 14), fillText(localValue, 32), onlineValue)
+		switch {
+		case marker != " ":
+			line = decidingStyle.Render(line)
+		case diff.local != nil && diff.online != nil && localValue != onlineValue:
+			line = differentStyle.Render(line)
+		}
+		b.WriteString("\n")
+		b.WriteString(line)
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(b.String())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderMetadataDiffFooter renders the key hints for the metadata diff
+func (m *Model) renderMetadataDiffFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	footerContent := newlineStyle + fmt.Sprintf("%s Close", keyStyle.Render("esc"))
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
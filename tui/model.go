@@ -2,7 +2,12 @@ package tui
 
 import (
 	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/local"
 	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/projects"
+	"TUI-Blender-Launcher/schedule"
+	"TUI-Blender-Launcher/stats"
+	"time"
 
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -30,11 +35,78 @@ type Model struct {
 	commands         *Commands
 	activeDownloadID string // Store the active download build ID for tracking
 	downloadStates   map[string]*model.DownloadState
-	lastRenderState  map[string]float64 // Track last rendered progress for each download
+	lastRenderState  map[string]float64    // Track last rendered progress for each download
+	launchWarning    *pendingLaunch        // Launch of a risky build waiting for confirmation, nil when none
+	configPrompt     *configPrompt         // Pending launch waiting on a user config decision
+	quarantinePrompt *model.BlenderExecMsg // Pending launch of a build Gatekeeper would block
+	palette          *palette              // Quick-launch palette, nil when closed
+	filterPrompt     *textinput.Model      // Inline version filter prompt, nil when closed
+	confirmCancelAll bool                  // Waiting for confirmation to cancel all downloads
+	grouped          bool                  // Show builds nested under their major.minor series
+	collapsedSeries  map[string]bool       // Series collapsed in the grouped view
+	groupStart       int                   // First visible line of the grouped view
+	commitLogs       map[string]*commitLog // Commits between installed builds and their updates
+	schedule         *schedule.Schedule    // Downloads scheduled for a later time
+	schedulePrompt   *textinput.Model      // Schedule time prompt, nil when closed
+	labelPrompt      *textinput.Model      // Custom label prompt for the selected build, nil when closed
+	snoozePrompt     *textinput.Model      // Update snooze prompt for the selected build, nil when closed
+	notesEditor      *notesEditor          // Notes editor dialog for the selected build, nil when closed
+	tagPrompt        *textinput.Model      // Tag editor for the selected build, nil when closed
+	tagFilterPrompt  *textinput.Model      // Inline tag filter prompt, nil when closed
+	artifactMenu     *artifactMenu         // Companion files of the selected build, nil when closed
+	speedTest        *speedTestMenu        // Speed test of the download sources, nil when closed
+	metadataDiff     *metadataDiff         // Installed vs online metadata of a build, nil when closed
+	digest           *digestPanel          // Builds published since the last session, nil when dismissed
+	actionMenu       *actionMenu           // Actions valid for the selected build, nil when closed
+	dirMenu          *dirMenu              // Directories of the selected build to open, nil when closed
+	profileMenu      *profileMenu          // Launch profiles for the selected build, nil when closed
+	downloadsPanel   *downloadsPanel       // Running, paused and queued downloads, nil when closed
+	archivePanel     *archivePanel         // Archives kept in the archive cache, nil when closed
+	archiveExport    *archivePanel         // Archive panel hidden while the dir picker chooses where its selected archive goes
+	restoredQueue    []model.BlenderBuild  // Downloads queued when the launcher quit, queued again after the first fetch
+	digestSince      time.Time             // Last session before a break, its digest is shown after the first fetch; zero when not due
+	fetched          []model.BlenderBuild  // Builds of the last fetched listing, before they were matched with the local ones
+	downloadConfirm  string                // Version awaiting download confirmation on a metered connection or close to the budget
+	stats            *stats.Stats          // Download volume per month, checked against the download budget
+	downloadingSize  int64                 // Bytes used by .downloading as shown in the settings, -1 until measured
+	offline          bool                  // Builder unreachable; fetch and download are disabled
+	cachedAt         time.Time             // When the cached build list shown while offline was fetched
+	staleList        *staleList            // Build list known from the caches shown at the start, nil once replaced
+	fetchShown       bool                  // The list shows the builds of the last fetch, so an unchanged listing needs no rebuild
+	newBuilds        map[string]bool       // Download IDs of builds that appeared since the previous fetch, marked NEW for the session
+	newOnly          bool                  // Only the builds marked NEW are listed
+	hiddenOld        []model.BlenderBuild  // Builds left out while only new builds are listed
+	configModTime    time.Time             // Modification time of config.toml when it was last read
+	configErr        error                 // Problems found in config.toml, shown on the config error screen
+	plaintextSecrets bool                  // config.toml holds secrets because no keyring is available
+	sendDownloadID   bool                  // Download ID setting being edited in the settings view
+	downloadID       string                // Download ID being edited in the settings view
+	yankPending      bool                  // Copy key pressed, waiting for what to copy
+	notice           string                // Short-lived message shown in the status bar
+	noticeUntil      time.Time             // When the notice disappears
+	tickAt           time.Time             // When the next tick is due, zero when none is scheduled, see scheduleTick
+	frame            frameCache            // Last rendered page, reused while nothing visible changed
+	lowBandwidth     bool                  // Progress is redrawn less often and as plain text, see config.LowBandwidth
+	dirPicker        *dirPicker            // Directory browser for the download directory, nil when closed
+	purgeConfirm     []local.OldBuild      // Expired old builds awaiting confirmation to be purged
+	partialsPrompt   *partialsPrompt       // Orphaned partial downloads awaiting a decision, nil when none
+	conflicts        conflictQueue         // Installs waiting for a decision on a directory in their way, the first is asked
+	blendLaunch      *blendLaunch          // .blend file to open with a build of its version, nil when none
+	pin              *projectPin           // Version pinned by the project's .blender-version file, nil when none
+	repair           buildRepair           // Re-downloads of builds that failed their smoke test or verification
+	undoStack        []undoAction          // Actions of the session that u can undo, the last one at the end
+	pendingLaunches  []model.BlenderBuild  // Builds launched as soon as their download finishes
+	systemGlibc      string                // glibc version of this machine, empty when builds don't use it
+	recent           *projects.Recent      // .blend files recently opened through the launcher
+	recentPanel      *recentPanel          // Recent projects panel, nil when closed
+	scanProgress     *local.ScanProgress   // Progress of the running local scan, nil when not scanning
+	statusChanges    statusTracker         // Builds whose status changed moments ago, their rows are highlighted
+	customColumns    columnValues          // Values of the custom columns printed by their commands
 }
 
 // InitialModel creates the initial state of the TUI model.
-func InitialModel(cfg config.Config, needsSetup bool) *Model {
+// commands is shared for the whole run, see NewCommands.
+func InitialModel(cfg config.Config, commands *Commands, needsSetup bool) *Model {
 	// Configure the progress bar with fixed settings for consistent column display
 	progModel := progress.New(
 		progress.WithGradient(highlightColor, "255"), // Use accent color with white gradient
@@ -55,42 +127,76 @@ func InitialModel(cfg config.Config, needsSetup bool) *Model {
 
 	m := &Model{
 		config:           cfg,
-		commands:         NewCommands(cfg),
+		commands:         commands,
 		progressBar:      progModel,
 		sortColumn:       0,     // Default sort by Version
 		sortReversed:     true,  // Default descending sort (newest versions first)
 		editMode:         false, // Start in navigation mode, not edit mode
 		downloadStates:   make(map[string]*model.DownloadState),
 		lastRenderState:  make(map[string]float64),
+		collapsedSeries:  make(map[string]bool),
+		commitLogs:       make(map[string]*commitLog),
 		buildTypeOptions: buildTypeOptions,
 		buildTypeIndex:   buildTypeIndex,
 		buildType:        cfg.BuildType,
+		sendDownloadID:   cfg.SendDownloadID,
+		downloadID:       cfg.UUID,
 	}
 
+	// Remember the config file state so external edits can be picked up
+	m.configModTime, _ = config.ModTime()
+	m.plaintextSecrets = config.PlaintextSecrets()
+	m.lowBandwidth = cfg.LowBandwidth()
+	m.systemGlibc = local.SystemGlibc()
+
+	// Load scheduled downloads; a broken schedule file shouldn't prevent startup
+	sched, err := schedule.Load()
+	if err != nil {
+		m.err = err
+		sched = &schedule.Schedule{}
+	}
+	m.schedule = sched
+
+	// Same for the recent projects
+	recent, err := projects.Load()
+	if err != nil {
+		m.err = err
+		recent = &projects.Recent{}
+	}
+	m.recent = recent
+
+	// And the download statistics
+	downloadStats, err := stats.Load()
+	if err != nil {
+		m.err = err
+		downloadStats = &stats.Stats{}
+	}
+	m.stats = downloadStats
+
+	// And the downloads left in the queue
+	queue, err := schedule.LoadQueue()
+	if err != nil {
+		m.err = err
+		queue = &schedule.Queue{}
+	}
+	m.restoredQueue = queue.Builds
+
+	// After a break, catch up on the builds published since the last session
+	since, err := startSession()
+	if err != nil {
+		m.err = err
+	}
+	m.digestSince = since
+
 	if needsSetup {
 		m.currentView = viewInitialSetup
-		m.settingsInputs = make([]textinput.Model, 2) // Only need 2 inputs now (download dir and version filter)
-
-		var t textinput.Model
-		// Download Dir input
-		t = textinput.New()
-		t.Placeholder = cfg.DownloadDir // Show default as placeholder
-		t.SetValue(cfg.DownloadDir)     // Set initial value
-		t.CharLimit = 256
-		t.Width = 50
-		m.settingsInputs[0] = t
-
-		// Version Filter input (renamed from Cutoff)
-		t = textinput.New()
-		t.Placeholder = "e.g., 4.0, 3.6 (leave empty for none)"
-		t.SetValue(cfg.VersionFilter)
-		t.CharLimit = 10
-		t.Width = 50
-		m.settingsInputs[1] = t
+		m.settingsInputs = newSettingsInputs(cfg, buildTypeOptions)
 
 		m.focusIndex = 0 // Start focus on the first input
 	} else {
 		m.currentView = viewList
+		// Show the builds of the last session until the scan finished
+		m.loadBuildList()
 	}
 
 	return m
@@ -124,8 +230,12 @@ func (m *Model) SyncDownloadStates() {
 func (m *Model) SaveSettings() error {
 	// Update config values from settings inputs
 	m.config.DownloadDir = m.settingsInputs[0].Value()
-	m.config.VersionFilter = m.settingsInputs[1].Value()
+	for i, buildType := range m.buildTypeOptions {
+		m.config.SetVersionFilterFor(buildType, m.settingsInputs[i+1].Value())
+	}
 	m.config.BuildType = m.buildType
+	m.config.SendDownloadID = m.sendDownloadID
+	m.config.UUID = m.downloadID
 
 	// Save the config
 	return config.SaveConfig(m.config)
@@ -135,6 +245,6 @@ func (m *Model) View() string {
 	// Sync download states before rendering
 	m.SyncDownloadStates()
 
-	// Render the page using the custom render function.
-	return m.renderPageForView()
+	// Render the page using the custom render function, unless the last one is still current
+	return m.renderFrame(m.renderPageForView)
 }
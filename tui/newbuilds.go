@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// markNewBuilds remembers the builds a fetch found since the previous one, marked NEW until the launcher exits
+func (m *Model) markNewBuilds(builds []model.BlenderBuild) {
+	if len(builds) == 0 {
+		return
+	}
+	if m.newBuilds == nil {
+		m.newBuilds = make(map[string]bool)
+	}
+	for _, build := range builds {
+		m.newBuilds[downloadID(build)] = true
+	}
+	if len(builds) == 1 {
+		m.showNotice(fmt.Sprintf("1 new build since the last fetch: %s", versionCell(builds[0])))
+	} else {
+		m.showNotice(fmt.Sprintf("%d new builds since the last fetch", len(builds)))
+	}
+}
+
+// isNew reports whether a build appeared during this session
+func (m *Model) isNew(build model.BlenderBuild) bool {
+	return m.newBuilds[downloadID(build)]
+}
+
+// handleToggleNewOnly shows only the builds marked NEW, or all builds again
+func (m *Model) handleToggleNewOnly() (tea.Model, tea.Cmd) {
+	if m.newOnly {
+		m.newOnly = false
+		m.builds = append(m.builds, m.hiddenOld...)
+		m.hiddenOld = nil
+		m.sortBuilds()
+		m.showNotice("Showing all builds")
+		return m, nil
+	}
+
+	if len(m.newBuilds) == 0 {
+		m.showNotice("No new builds since the last fetch")
+		return m, nil
+	}
+	m.newOnly = true
+	m.builds = m.applyNewFilter(m.builds)
+	m.cursor = 0
+	m.startIndex = 0
+	m.groupStart = 0
+	m.showNotice("Showing only new builds")
+	return m, nil
+}
+
+// applyNewFilter keeps only the builds marked NEW while the filter is on.
+// The others are kept aside to be listed again when it is turned off.
+func (m *Model) applyNewFilter(builds []model.BlenderBuild) []model.BlenderBuild {
+	if !m.newOnly {
+		return builds
+	}
+
+	filtered := make([]model.BlenderBuild, 0)
+	m.hiddenOld = nil
+	for _, build := range builds {
+		if m.isNew(build) {
+			filtered = append(filtered, build)
+		} else {
+			m.hiddenOld = append(m.hiddenOld, build)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// notesCharLimit keeps notes to a few paragraphs
+const notesCharLimit = 2000
+
+// notesEditor is the dialog for editing the notes of a local build
+type notesEditor struct {
+	version string
+	arch    string
+	area    textarea.Model
+}
+
+// SaveBuildNotes creates a command to save the notes of a local build
+func (c *Commands) SaveBuildNotes(version, arch, notes string) tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		dirPath, err := local.FindBuildDir(downloadDir, version, arch)
+		if err != nil {
+			return buildNotesSavedMsg{version: version, err: err}
+		}
+		if dirPath == "" {
+			return buildNotesSavedMsg{version: version, err: fmt.Errorf("build directory for Blender version %s not found", version)}
+		}
+		build, err := local.SetBuildNotes(dirPath, notes)
+		if err != nil {
+			return buildNotesSavedMsg{version: version, err: fmt.Errorf("failed to save notes: %w", err)}
+		}
+		return buildNotesSavedMsg{version: version, build: build}
+	}
+}
+
+// openNotesEditor shows the notes editor for the selected local build
+func (m *Model) openNotesEditor() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok || (build.Status != model.StateLocal && build.Status != model.StateUpdate) || m.rejectShared(build) {
+		return m, nil
+	}
+
+	area := textarea.New()
+	area.Placeholder = "e.g., crashes with OptiX, used for the final render"
+	area.CharLimit = notesCharLimit
+	area.ShowLineNumbers = false
+	area.SetWidth(60)
+	area.SetHeight(8)
+	area.SetValue(build.Notes)
+	area.Focus()
+
+	m.notesEditor = &notesEditor{version: build.Version, arch: build.Architecture, area: area}
+	return m, textarea.Blink
+}
+
+// updateNotesEditor handles key events while the notes editor is open
+func (m *Model) updateNotesEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.notesEditor = nil
+		return m, nil
+
+	case "ctrl+s":
+		editor := m.notesEditor
+		m.notesEditor = nil
+		return m, m.commands.SaveBuildNotes(editor.version, editor.arch, editor.area.Value())
+	}
+
+	var cmd tea.Cmd
+	m.notesEditor.area, cmd = m.notesEditor.area.Update(msg)
+	return m, cmd
+}
+
+// handleBuildNotesSaved shows the saved notes on every row of the build
+func (m *Model) handleBuildNotesSaved(msg buildNotesSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.err = nil
+	for i := range m.builds {
+		if !m.builds[i].Matches(msg.version, msg.build.Architecture) {
+			continue
+		}
+		m.builds[i].Notes = msg.build.Notes
+		if m.builds[i].Installed != nil {
+			m.builds[i].Installed.Notes = msg.build.Notes
+		}
+	}
+	m.showNotice(fmt.Sprintf("Saved notes for Blender %s", msg.version))
+	return m, nil
+}
+
+// renderNotesEditor renders the notes editor dialog
+func (m *Model) renderNotesEditor(availableHeight int) string {
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(titleStyle.Render("Notes for Blender "+m.notesEditor.version) + "\n\n" + m.notesEditor.area.View())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderNotesEditorFooter renders the key hints for the notes editor
+func (m *Model) renderNotesEditorFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Save", keyStyle.Render("ctrl+s")),
+		fmt.Sprintf("%s New line", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
+
+// renderNotesSection renders the notes of a build for the details view, wrapped to width
+func renderNotesSection(notes string, width int) string {
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	if width > 80 {
+		width = 80
+	}
+	return titleStyle.Render("Notes") + "\n" + lp.NewStyle().Width(width).Render(notes) + "\n"
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// FindExpiredOldBuilds creates a command to look for old builds past the retention period
+func (c *Commands) FindExpiredOldBuilds() tea.Cmd {
+	if c.cfg.OldBuildsRetentionDays <= 0 {
+		return nil
+	}
+	retention := time.Duration(c.cfg.OldBuildsRetentionDays) * 24 * time.Hour
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		builds, err := local.ExpiredOldBuilds(downloadDir, retention, time.Now())
+		return oldBuildsExpiredMsg{builds: builds, err: err}
+	}
+}
+
+// PurgeOldBuilds creates a command to move old builds to the trash, where they stay until the launcher exits
+func (c *Commands) PurgeOldBuilds(builds []local.OldBuild) tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		trashed, err := local.TrashOldBuilds(downloadDir, builds)
+		return oldBuildsPurgedMsg{trashed: trashed, freed: oldBuildsSize(builds[:len(trashed)]), err: err}
+	}
+}
+
+// CleanOldBuilds creates a command to move all old builds to the trash
+func (c *Commands) CleanOldBuilds() tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		builds, err := local.ListOldBuilds(downloadDir)
+		if err != nil {
+			return oldBuildsPurgedMsg{err: err}
+		}
+		for i := range builds {
+			builds[i].Size, _ = download.DirSize(builds[i].Path)
+		}
+		return c.PurgeOldBuilds(builds)()
+	}
+}
+
+// oldBuildsSize returns the total size of old builds
+func oldBuildsSize(builds []local.OldBuild) int64 {
+	var size int64
+	for _, build := range builds {
+		size += build.Size
+	}
+	return size
+}
+
+// handleOldBuildsExpired asks whether to purge the old builds past the retention period
+func (m *Model) handleOldBuildsExpired(msg oldBuildsExpiredMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	if len(msg.builds) > 0 {
+		m.purgeConfirm = msg.builds
+	}
+	return m, nil
+}
+
+// updatePurgeConfirm purges the expired old builds on y; n and esc keep them until the next start
+func (m *Model) updatePurgeConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	builds := m.purgeConfirm
+	m.purgeConfirm = nil
+	if msg.String() == "y" {
+		return m, m.commands.PurgeOldBuilds(builds)
+	}
+	return m, nil
+}
+
+// handleOldBuildsPurged reports how much space purging old builds frees; u moves them back from the trash
+func (m *Model) handleOldBuildsPurged(msg oldBuildsPurgedMsg) (tea.Model, tea.Cmd) {
+	if len(msg.trashed) > 0 {
+		action := fmt.Sprintf("purge of %d old build(s)", len(msg.trashed))
+		m.pushUndo(action, m.commands.RestoreTrashed(action, msg.trashed))
+	}
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	if len(msg.trashed) == 0 {
+		m.showNotice("No old builds to clean")
+		return m, nil
+	}
+	m.showNotice(fmt.Sprintf("Purged %d old build(s), %s freed on exit (u to undo)", len(msg.trashed), model.FormatByteSize(msg.freed)))
+	return m, nil
+}
+
+// renderPurgeConfirm renders the purge summary shown in place of the contextual commands
+func (m *Model) renderPurgeConfirm(keyStyle lp.Style, separator string) string {
+	warnStyle := lp.NewStyle().Foreground(lp.Color(orangeColor))
+	summary := fmt.Sprintf("Purge %d old build(s) archived more than %d day(s) ago (%s)?",
+		len(m.purgeConfirm), m.config.OldBuildsRetentionDays, model.FormatByteSize(oldBuildsSize(m.purgeConfirm)))
+	return warnStyle.Render(summary) + separator +
+		fmt.Sprintf("%s Purge", keyStyle.Render("y")) + separator +
+		fmt.Sprintf("%s Keep for now", keyStyle.Render("n"))
+}
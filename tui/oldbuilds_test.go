@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPurgeConfirm(t *testing.T) {
+	cfg := config.Config{DownloadDir: t.TempDir(), OldBuildsRetentionDays: 30}
+	expired := []local.OldBuild{{Name: "blender-4.2.0_20250101_120000"}}
+	m := &Model{config: cfg, commands: &Commands{cfg: cfg}, builds: []model.BlenderBuild{
+		{Version: "4.3.0", Status: model.StateLocal},
+		{Version: "4.2.0", Status: model.StateLocal},
+	}}
+
+	// List keys are no answer, they go to the list
+	m.purgeConfirm = expired
+	m.updateKey(tea.KeyMsg{Type: tea.KeyDown})
+	if m.purgeConfirm == nil {
+		t.Fatal("Expected the question to stay open")
+	}
+	if m.cursor != 1 {
+		t.Errorf("Expected the cursor to move down, got %d", m.cursor)
+	}
+
+	// n keeps the old builds
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}); cmd != nil || m.purgeConfirm != nil {
+		t.Errorf("Expected n to close the question without purging")
+	}
+
+	// y purges them
+	m.purgeConfirm = expired
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}); cmd == nil || m.purgeConfirm != nil {
+		t.Errorf("Expected y to purge the old builds")
+	}
+}
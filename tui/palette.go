@@ -0,0 +1,196 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// paletteMaxResults limits how many matches the quick-launch palette shows
+const paletteMaxResults = 10
+
+// palette is the quick-launch popup that fuzzy-matches local builds
+type palette struct {
+	input   textinput.Model
+	matches []model.BlenderBuild
+	cursor  int
+}
+
+// paletteLabel returns the text a build is matched against in the palette
+func paletteLabel(build model.BlenderBuild) string {
+	parts := []string{build.Version}
+	if build.Label != "" {
+		parts = append(parts, build.Label)
+	}
+	parts = append(parts, build.Branch, build.ReleaseCycle, build.Hash)
+	if build.Notes != "" {
+		parts = append(parts, build.Notes)
+	}
+	return strings.Join(parts, " ")
+}
+
+// fuzzyScore matches query as a case-insensitive subsequence of target.
+// Consecutive characters and matches at word starts score higher; ok is false if query doesn't match.
+func fuzzyScore(query, target string) (score int, ok bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(target))
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	qi := 0
+	prevMatch := -2
+	for ti, r := range t {
+		if qi == len(q) {
+			break
+		}
+		if r != q[qi] {
+			continue
+		}
+		score++
+		if ti == prevMatch+1 {
+			score += 3 // Consecutive characters
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 2 // Start of a word
+		}
+		prevMatch = ti
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// openPalette shows the quick-launch palette
+func (m *Model) openPalette() (tea.Model, tea.Cmd) {
+	input := textinput.New()
+	input.Placeholder = "version, branch or hash"
+	input.Prompt = "> "
+	input.CharLimit = 64
+	input.Width = 40
+	input.Focus()
+
+	m.palette = &palette{input: input}
+	m.updatePaletteMatches()
+	return m, textinput.Blink
+}
+
+// updatePaletteMatches recomputes the palette results for the current query
+func (m *Model) updatePaletteMatches() {
+	query := m.palette.input.Value()
+
+	type scored struct {
+		build model.BlenderBuild
+		score int
+	}
+	var results []scored
+	for _, build := range m.builds {
+		if build.Status != model.StateLocal && build.Status != model.StateUpdate {
+			continue
+		}
+		if score, ok := fuzzyScore(query, paletteLabel(build)); ok {
+			results = append(results, scored{build, score})
+		}
+	}
+
+	// Best matches first, newest versions first among equal scores
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		return model.VersionLess(results[j].build.Version, results[i].build.Version)
+	})
+
+	m.palette.matches = m.palette.matches[:0]
+	for i := 0; i < len(results) && i < paletteMaxResults; i++ {
+		m.palette.matches = append(m.palette.matches, results[i].build)
+	}
+	if m.palette.cursor >= len(m.palette.matches) {
+		m.palette.cursor = 0
+	}
+}
+
+// updatePalette handles key events while the palette is open
+func (m *Model) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+p":
+		m.palette = nil
+		return m, nil
+
+	case "up", "ctrl+k":
+		if len(m.palette.matches) > 0 {
+			m.palette.cursor = (m.palette.cursor - 1 + len(m.palette.matches)) % len(m.palette.matches)
+		}
+		return m, nil
+
+	case "down", "ctrl+j":
+		if len(m.palette.matches) > 0 {
+			m.palette.cursor = (m.palette.cursor + 1) % len(m.palette.matches)
+		}
+		return m, nil
+
+	case "enter":
+		if len(m.palette.matches) == 0 {
+			return m, nil
+		}
+		build := m.palette.matches[m.palette.cursor]
+		m.palette = nil
+		return m, m.startLaunch(launchRequest{build: build})
+	}
+
+	var cmd tea.Cmd
+	m.palette.input, cmd = m.palette.input.Update(msg)
+	m.updatePaletteMatches()
+	return m, cmd
+}
+
+// renderPalette renders the quick-launch palette popup
+func (m *Model) renderPalette(availableHeight int) string {
+	var b strings.Builder
+	b.WriteString(m.palette.input.View())
+	b.WriteString("\n\n")
+
+	if len(m.palette.matches) == 0 {
+		b.WriteString(lp.NewStyle().Italic(true).Render("No matching local builds"))
+	}
+	for i, build := range m.palette.matches {
+		line := fmt.Sprintf("%s %s %s %s", fillText(build.Version, 10), fillText(build.Branch, 20), fillText(build.ReleaseCycle, 10), build.Hash)
+		if i == m.palette.cursor {
+			b.WriteString(selectedRowStyle.Render(line))
+		} else {
+			b.WriteString(regularRowStyle.Render(line))
+		}
+		if i < len(m.palette.matches)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(b.String())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderPaletteFooter renders the key hints for the quick-launch palette
+func (m *Model) renderPaletteFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Launch", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Select", keyStyle.Render("↑/↓")),
+		fmt.Sprintf("%s Close", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
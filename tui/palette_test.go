@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"testing"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query, target string
+		ok            bool
+	}{
+		{"", "4.3.0 main", true},
+		{"43", "4.3.0 main", true},
+		{"MAIN", "4.3.0 main", true},
+		{"mian", "4.3.0 main", false},
+		{"4.5", "4.3.0 main", false},
+	}
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.query, tt.target); ok != tt.ok {
+			t.Errorf("fuzzyScore(%q, %q) matched = %v, want %v", tt.query, tt.target, ok, tt.ok)
+		}
+	}
+
+	// Consecutive characters and word starts rank higher
+	consecutive, _ := fuzzyScore("main", "4.3.0 main")
+	scattered, _ := fuzzyScore("main", "4.3.0 my_branch alpha in")
+	if consecutive <= scattered {
+		t.Errorf("Expected the consecutive match to score higher, got %d and %d", consecutive, scattered)
+	}
+}
+
+func TestPaletteMatches(t *testing.T) {
+	m := &Model{builds: []model.BlenderBuild{
+		{Version: "4.9.0", Branch: "main", Status: model.StateLocal},
+		{Version: "4.10.0", Branch: "main", Status: model.StateLocal},
+		{Version: "4.2.0", Branch: "main", Status: model.StateLocal},
+		{Version: "4.11.0", Branch: "main", Status: model.StateOnline},
+	}}
+	m.openPalette()
+
+	// Equal scores list the newest version first, compared by number rather than text
+	var versions []string
+	for _, build := range m.palette.matches {
+		versions = append(versions, build.Version)
+	}
+	if len(versions) != 3 || versions[0] != "4.10.0" || versions[1] != "4.9.0" || versions[2] != "4.2.0" {
+		t.Errorf("Expected the local builds newest first, got %v", versions)
+	}
+}
@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/api"
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/schedule"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// partialsPrompt holds orphaned partial downloads awaiting a decision
+type partialsPrompt struct {
+	partials  []local.PartialDownload
+	resumable []model.BlenderBuild // Builds of the partials found in the cached build lists
+}
+
+// FindOrphanedPartials creates a command to look for partial downloads left behind by a crash.
+// Partials of builds in the cached build lists can be resumed. Those of downloads in the queue file
+// aren't orphaned, they resume with the restored queue.
+func (c *Commands) FindOrphanedPartials() tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		found, err := local.OrphanedPartials(downloadDir, time.Now())
+		if err != nil || len(found) == 0 {
+			return partialsFoundMsg{err: err}
+		}
+		queued := make(map[string]bool)
+		if queue, err := schedule.LoadQueue(); err == nil {
+			for _, build := range queue.Builds {
+				queued[filepath.Base(build.DownloadURL)] = true
+			}
+		}
+		var partials []local.PartialDownload
+		for _, partial := range found {
+			if !queued[partial.Name] {
+				partials = append(partials, partial)
+			}
+		}
+		if len(partials) == 0 {
+			return partialsFoundMsg{}
+		}
+
+		byFile := make(map[string]model.BlenderBuild)
+		for _, buildType := range config.BuildTypes {
+			builds, _, err := api.LoadCachedBuilds("", buildType)
+			if err != nil {
+				continue
+			}
+			for _, build := range builds {
+				byFile[filepath.Base(build.DownloadURL)] = build
+			}
+		}
+
+		var resumable []model.BlenderBuild
+		for _, partial := range partials {
+			if build, ok := byFile[partial.Name]; ok {
+				resumable = append(resumable, build)
+			}
+		}
+		return partialsFoundMsg{partials: partials, resumable: resumable}
+	}
+}
+
+// DeletePartials creates a command to delete partial downloads
+func (c *Commands) DeletePartials(partials []local.PartialDownload) tea.Cmd {
+	return func() tea.Msg {
+		count, err := local.DeletePartials(partials)
+		var freed int64
+		for _, partial := range partials[:count] {
+			freed += partial.Size
+		}
+		return partialsDeletedMsg{count: count, freed: freed, err: err}
+	}
+}
+
+// handlePartialsFound asks what to do with orphaned partial downloads
+func (m *Model) handlePartialsFound(msg partialsFoundMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	if len(msg.partials) > 0 {
+		m.partialsPrompt = &partialsPrompt{partials: msg.partials, resumable: msg.resumable}
+	}
+	return m, nil
+}
+
+// partialsKey reports whether a key answers the partial downloads prompt, the list takes the others
+func partialsKey(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "r", "enter", "d", "y", "n", "esc":
+		return true
+	}
+	return false
+}
+
+// updatePartialsPrompt resumes the resumable partials on r or enter, deletes all of them on d or y,
+// and keeps them until the next start on n or esc
+func (m *Model) updatePartialsPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := m.partialsPrompt
+	switch msg.String() {
+	case "d", "y":
+		m.partialsPrompt = nil
+		return m, m.commands.DeletePartials(prompt.partials)
+	case "r", "enter":
+		if len(prompt.resumable) == 0 {
+			return m, nil
+		}
+		if m.offline {
+			m.err = fmt.Errorf("cannot resume downloads while offline")
+			return m, nil
+		}
+		m.partialsPrompt = nil
+		return m, m.resumeDownloads(prompt.resumable)
+	}
+	m.partialsPrompt = nil
+	return m, nil
+}
+
+// resumeDownloads starts downloads of builds whose archives are partially downloaded.
+// The downloader continues from the partial file when the server supports it.
+func (m *Model) resumeDownloads(builds []model.BlenderBuild) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, build := range builds {
+		// Builds from the cache may not be listed yet
+		listed := false
+		for _, b := range m.builds {
+			if b.Matches(build.Version, build.Architecture) && b.Hash == build.Hash {
+				listed = true
+				break
+			}
+		}
+		if !listed {
+			build.Status = model.StateOnline
+			m.builds = append(m.builds, build)
+		}
+
+		build := build
+		buildID := downloadID(build)
+		cmds = append(cmds, func() tea.Msg {
+			return startDownloadMsg{build: build, buildID: buildID}
+		})
+	}
+	m.sortBuilds()
+	return tea.Batch(cmds...)
+}
+
+// handlePartialsDeleted reports how much space deleting partial downloads freed
+func (m *Model) handlePartialsDeleted(msg partialsDeletedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.showNotice(fmt.Sprintf("Deleted %d partial download(s), freed %s", msg.count, model.FormatByteSize(msg.freed)))
+	return m, nil
+}
+
+// renderPartialsPrompt renders the orphaned partial downloads summary shown in place of the contextual commands
+func (m *Model) renderPartialsPrompt(keyStyle lp.Style, separator string) string {
+	warnStyle := lp.NewStyle().Foreground(lp.Color(orangeColor))
+	partials := m.partialsPrompt.partials
+
+	var size int64
+	oldest := time.Now()
+	for _, partial := range partials {
+		size += partial.Size
+		if partial.ModTime.Before(oldest) {
+			oldest = partial.ModTime
+		}
+	}
+	age := formatDuration(time.Since(oldest))
+
+	var summary string
+	if len(partials) == 1 {
+		summary = fmt.Sprintf("Partial download %s left in %s (%s, %s old)", partials[0].Name, filepath.Base(filepath.Dir(partials[0].Path)),
+			model.FormatByteSize(size), age)
+	} else {
+		summary = fmt.Sprintf("%d partial downloads left in %s (%s, oldest %s)", len(partials), filepath.Base(filepath.Dir(partials[0].Path)),
+			model.FormatByteSize(size), age)
+	}
+
+	line := warnStyle.Render(summary)
+	if n := len(m.partialsPrompt.resumable); n > 0 {
+		line += separator + fmt.Sprintf("%s Resume %d", keyStyle.Render("r"), n)
+	}
+	return line + separator +
+		fmt.Sprintf("%s Delete", keyStyle.Render("d")) + separator +
+		fmt.Sprintf("%s Keep for now", keyStyle.Render("n"))
+}
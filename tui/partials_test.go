@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPartialsPrompt(t *testing.T) {
+	cfg := config.Config{DownloadDir: t.TempDir()}
+	build := model.BlenderBuild{Version: "4.3.0", Hash: "a1b2c3d4e5f6", Status: model.StateOnline}
+	prompt := &partialsPrompt{
+		partials:  []local.PartialDownload{{Name: "blender-4.3.0.tar.xz"}},
+		resumable: []model.BlenderBuild{build},
+	}
+	m := &Model{config: cfg, commands: &Commands{cfg: cfg}, builds: []model.BlenderBuild{
+		build,
+		{Version: "4.2.0", Status: model.StateLocal},
+	}}
+
+	// List keys go to the list, the partials are kept
+	m.partialsPrompt = prompt
+	m.updateKey(tea.KeyMsg{Type: tea.KeyDown})
+	if m.partialsPrompt == nil {
+		t.Fatal("Expected the question to stay open")
+	}
+	if m.cursor != 1 {
+		t.Errorf("Expected the cursor to move down, got %d", m.cursor)
+	}
+
+	// Enter resumes
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || m.partialsPrompt != nil {
+		t.Error("Expected enter to resume the partial download")
+	}
+
+	// n keeps them
+	m.partialsPrompt = prompt
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}); cmd != nil || m.partialsPrompt != nil {
+		t.Error("Expected n to close the question and keep the partials")
+	}
+
+	// d deletes them
+	m.partialsPrompt = prompt
+	if _, cmd := m.updateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")}); cmd == nil || m.partialsPrompt != nil {
+		t.Error("Expected d to delete the partials")
+	}
+}
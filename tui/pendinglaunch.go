@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pendingLaunchIndex returns the index of a build in the builds to launch once downloaded, -1 if it isn't one
+func (m *Model) pendingLaunchIndex(build model.BlenderBuild) int {
+	return slices.IndexFunc(m.pendingLaunches, func(p model.BlenderBuild) bool {
+		return p.Matches(build.Version, build.Architecture)
+	})
+}
+
+// launchPending reports whether a build is launched as soon as its download finishes
+func (m *Model) launchPending(build model.BlenderBuild) bool {
+	return m.pendingLaunchIndex(build) >= 0
+}
+
+// dropPendingLaunch forgets the launch waiting for a build's download, reporting whether there was one
+func (m *Model) dropPendingLaunch(build model.BlenderBuild) bool {
+	i := m.pendingLaunchIndex(build)
+	if i < 0 {
+		return false
+	}
+	m.pendingLaunches = slices.Delete(m.pendingLaunches, i, i+1)
+	return true
+}
+
+// handleDownloadAndLaunch downloads the selected build and launches it as soon as it is installed.
+// A local build is launched right away, and pressing it again on a waiting build cancels the
+// launch while the download goes on.
+func (m *Model) handleDownloadAndLaunch() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok {
+		return m, nil
+	}
+	if m.dropPendingLaunch(build) {
+		m.showNotice(fmt.Sprintf("Blender %s won't be launched when ready", build.Version))
+		return m, nil
+	}
+
+	switch build.Status {
+	case model.StateLocal:
+		return m.handleLaunchBlender()
+	case model.StateDownloading, model.StateExtracting:
+		m.pendingLaunches = append(m.pendingLaunches, build)
+		m.showNotice(fmt.Sprintf("Blender %s will be launched when ready (e to cancel)", build.Version))
+		return m, nil
+	}
+
+	if m.offline {
+		m.err = errOfflineDownloads
+		return m, nil
+	}
+	newModel, cmd := m.handleStartDownload()
+	// Nothing is pending until the download started, e.g. while a metered download waits for confirmation
+	if build, ok := m.selectedBuild(); ok && build.Status == model.StateDownloading {
+		m.pendingLaunches = append(m.pendingLaunches, build)
+		m.showNotice(fmt.Sprintf("Blender %s will be launched when ready (e to cancel)", build.Version))
+	}
+	return newModel, cmd
+}
+
+// pendingBuildInstalled launches a build waiting for its download once it is installed.
+// A failed download or a build that doesn't start cancels the launch.
+func (m *Model) pendingBuildInstalled(finished model.BlenderBuild, installed bool) tea.Cmd {
+	i := m.pendingLaunchIndex(finished)
+	if i < 0 {
+		return nil
+	}
+	build := m.pendingLaunches[i]
+	m.pendingLaunches = slices.Delete(m.pendingLaunches, i, i+1)
+	if !installed {
+		return nil
+	}
+	m.selectBuild(build)
+	if selected, ok := m.selectedBuild(); !ok || !selected.Matches(build.Version, build.Architecture) {
+		return nil
+	}
+	_, cmd := m.handleLaunchBlender()
+	return cmd
+}
@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// projectPin is the Blender version pinned by a .blender-version file of the project the launcher was started in
+type projectPin struct {
+	path     string // The .blender-version file
+	version  string // Pinned series or full version, see model.BlenderBuild.MatchesPin
+	applied  bool   // The pinned build was selected after the first local scan
+	prompt   bool   // The pinned version isn't installed, waiting for a decision
+	download *model.BlenderBuild
+}
+
+// PinBuild makes the launcher select the build pinned by the .blender-version file at path
+// once the local builds are scanned, and warn when it isn't installed
+func (m *Model) PinBuild(path, version string) {
+	m.pin = &projectPin{path: path, version: version}
+}
+
+// applyProjectPin selects the pinned build after the first local scan, or asks to download it
+func (m *Model) applyProjectPin() {
+	if m.pin == nil || m.pin.applied {
+		return
+	}
+	m.pin.applied = true
+	pinned, found := model.PinnedBuild(m.builds, m.pin.version)
+	if !found {
+		m.pin.prompt = true
+		return
+	}
+	m.selectBuild(pinned)
+}
+
+// selectBuild moves the cursor to a build
+func (m *Model) selectBuild(build model.BlenderBuild) {
+	for i, b := range m.builds {
+		if b.Matches(build.Version, build.Architecture) && b.Hash == build.Hash {
+			m.cursor = i
+			break
+		}
+	}
+	visibleRowsCount := m.contentHeight() - 1
+	if visibleRowsCount < 1 {
+		visibleRowsCount = 1
+	}
+	if m.cursor < m.startIndex || m.cursor >= m.startIndex+visibleRowsCount {
+		m.startIndex = m.cursor
+	}
+}
+
+// pinDownloadCandidate returns the index of the newest listed online build matching the pin, -1 if none
+func (m *Model) pinDownloadCandidate() int {
+	candidate := -1
+	for i, build := range m.builds {
+		if build.Status != model.StateOnline || !build.MatchesPin(m.pin.version) {
+			continue
+		}
+		if candidate < 0 || build.BuildDate.Time().After(m.builds[candidate].BuildDate.Time()) {
+			candidate = i
+		}
+	}
+	return candidate
+}
+
+// updatePinPrompt downloads the pinned version on d, fetches the online builds on f and dismisses the warning on esc
+func (m *Model) updatePinPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "d":
+		i := m.pinDownloadCandidate()
+		if i < 0 {
+			return m, nil
+		}
+		if m.offline {
+			m.err = errOfflineDownloads
+			return m, nil
+		}
+		build := m.builds[i]
+		m.pin.prompt = false
+		m.pin.download = &build
+		m.cursor = i
+		return m.handleStartDownload()
+
+	case "f":
+		return m, m.commands.FetchBuilds()
+
+	case "esc":
+		m.pin.prompt = false
+	}
+	return m, nil
+}
+
+// pinnedBuildInstalled selects the pinned build once the download started from the prompt finished
+func (m *Model) pinnedBuildInstalled(finished model.BlenderBuild, installed bool) {
+	pin := m.pin
+	if pin == nil || pin.download == nil || !pin.download.Matches(finished.Version, finished.Architecture) {
+		return
+	}
+	build := *pin.download
+	pin.download = nil
+	if !installed {
+		return
+	}
+	m.selectBuild(build)
+	m.showNotice(fmt.Sprintf("Installed Blender %s pinned by %s", build.Version, pinLocation(pin.path)))
+}
+
+// covers reports whether a file belongs to the project pinning the version
+func (p *projectPin) covers(path string) bool {
+	rel, err := filepath.Rel(filepath.Dir(p.path), path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// pinLocation shortens the path of a .blender-version file to its project directory, e.g. "shot010/.blender-version"
+func pinLocation(path string) string {
+	return filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path))
+}
+
+// renderPinPrompt renders the missing pinned version warning in place of the contextual commands
+func (m *Model) renderPinPrompt(keyStyle lp.Style, separator string) string {
+	warnStyle := lp.NewStyle().Foreground(lp.Color(orangeColor))
+
+	line := warnStyle.Render(fmt.Sprintf("%s pins Blender %s, which isn't installed", pinLocation(m.pin.path), m.pin.version))
+	if i := m.pinDownloadCandidate(); i >= 0 {
+		line += separator + fmt.Sprintf("%s Download %s", keyStyle.Render("d"), m.builds[i].Version)
+	} else {
+		line += separator + fmt.Sprintf("%s Fetch builds", keyStyle.Render("f"))
+	}
+	return line + separator + fmt.Sprintf("%s Dismiss", keyStyle.Render("esc"))
+}
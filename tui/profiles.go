@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// profileMenu lets the user pick the launch profile for one launch of a local build,
+// or make it the build's own profile
+type profileMenu struct {
+	build    model.BlenderBuild
+	profiles []string // "" for the global launch_profile, config.NoLaunchProfile, then the profile names
+	cursor   int
+}
+
+// withLaunchProfile adds the arguments and environment variables of the launch profile to a launch.
+// Profile arguments come before the extra arguments, so a .blend file stays last.
+func withLaunchProfile(cfg config.Config, execInfo model.BlenderExecMsg) model.BlenderExecMsg {
+	_, profile, ok := cfg.ResolveLaunchProfile(execInfo.Profile)
+	if !ok {
+		return execInfo
+	}
+	execInfo.Args = append(slices.Clone(profile.Args), execInfo.Args...)
+	execInfo.Env = append(slices.Clone(execInfo.Env), profile.Environ()...)
+	return execInfo
+}
+
+// launchWithProfile replaces the profile a launch command would use
+func launchWithProfile(launchCmd tea.Cmd, profile string) tea.Cmd {
+	return func() tea.Msg {
+		msg := launchCmd()
+		if execMsg, ok := msg.(model.BlenderExecMsg); ok {
+			execMsg.Profile = profile
+			return execMsg
+		}
+		return msg
+	}
+}
+
+// launchProfileLabel describes the profile a build is launched with, empty when there is none
+func (m *Model) launchProfileLabel(build model.BlenderBuild) string {
+	name, _, ok := m.config.ResolveLaunchProfile(build.LaunchProfile)
+	switch {
+	case ok && build.LaunchProfile != "":
+		return name + " (this build)"
+	case ok:
+		return name + " (global)"
+	case build.LaunchProfile == config.NoLaunchProfile && m.config.LaunchProfile != "":
+		return "none (this build)"
+	}
+	return ""
+}
+
+// SetLaunchProfile creates a command to save the launch profile of a local build, empty for the global one
+func (c *Commands) SetLaunchProfile(version, arch, profile string) tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		dirPath, err := local.FindBuildDir(downloadDir, version, arch)
+		if err != nil {
+			return launchProfileSetMsg{version: version, err: err}
+		}
+		if dirPath == "" {
+			return launchProfileSetMsg{version: version, err: fmt.Errorf("build directory for Blender version %s not found", version)}
+		}
+		build, err := local.SetBuildLaunchProfile(dirPath, profile)
+		if err != nil {
+			return launchProfileSetMsg{version: version, err: fmt.Errorf("failed to save launch profile: %w", err)}
+		}
+		return launchProfileSetMsg{version: version, build: build}
+	}
+}
+
+// openProfileMenu shows the launch profiles for the selected local build
+func (m *Model) openProfileMenu() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok || (build.Status != model.StateLocal && build.Status != model.StateUpdate) {
+		return m, nil
+	}
+	if len(m.config.LaunchProfiles) == 0 {
+		m.showNotice("No launch profiles configured, add them to [launch_profiles] in config.toml")
+		return m, nil
+	}
+	menu := &profileMenu{
+		build:    build,
+		profiles: append([]string{"", config.NoLaunchProfile}, m.config.LaunchProfileNames()...),
+	}
+	// Start on the profile the build is launched with now
+	if current := installedBuild(build).LaunchProfile; current != "" {
+		menu.cursor = max(slices.Index(menu.profiles, current), 0)
+	}
+	m.profileMenu = menu
+	return m, nil
+}
+
+// updateProfileMenu handles key events while the launch profile menu is open
+func (m *Model) updateProfileMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := m.profileMenu
+	switch msg.String() {
+	case "esc", "p", "q":
+		m.profileMenu = nil
+
+	case "up", "k":
+		menu.cursor = (menu.cursor - 1 + len(menu.profiles)) % len(menu.profiles)
+
+	case "down", "j":
+		menu.cursor = (menu.cursor + 1) % len(menu.profiles)
+
+	case "enter":
+		// Launch once with the profile, the build keeps its own
+		m.profileMenu = nil
+		profile := menu.profiles[menu.cursor]
+		if profile == "" {
+			profile = m.config.LaunchProfile
+		}
+		if profile == "" {
+			profile = config.NoLaunchProfile
+		}
+		return m.launchSelected(profile)
+
+	case "b":
+		// Launch the build with the profile from now on
+		if m.rejectShared(menu.build) {
+			return m, nil
+		}
+		m.profileMenu = nil
+		return m, m.commands.SetLaunchProfile(menu.build.Version, menu.build.Architecture, menu.profiles[menu.cursor])
+	}
+	return m, nil
+}
+
+// handleLaunchProfileSet shows the saved launch profile on every row of the build
+func (m *Model) handleLaunchProfileSet(msg launchProfileSetMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.err = nil
+	for i := range m.builds {
+		if !m.builds[i].Matches(msg.version, msg.build.Architecture) {
+			continue
+		}
+		m.builds[i].LaunchProfile = msg.build.LaunchProfile
+		if m.builds[i].Installed != nil {
+			m.builds[i].Installed.LaunchProfile = msg.build.LaunchProfile
+		}
+	}
+	if msg.build.LaunchProfile == "" {
+		m.showNotice(fmt.Sprintf("Blender %s uses the global launch profile again", msg.version))
+	} else {
+		m.showNotice(fmt.Sprintf("Blender %s launches with profile %q", msg.version, msg.build.LaunchProfile))
+	}
+	return m, nil
+}
+
+// profileMenuEntry returns the name and summary shown for an entry of the launch profile menu
+func (m *Model) profileMenuEntry(name string) (string, string) {
+	switch name {
+	case "":
+		if m.config.LaunchProfile == "" {
+			return "Global default", "no profile"
+		}
+		return "Global default", m.config.LaunchProfile
+	case config.NoLaunchProfile:
+		return "No profile", ""
+	}
+	profile := m.config.LaunchProfiles[name]
+	summary := append(slices.Clone(profile.Args), profile.Environ()...)
+	return name, strings.Join(summary, " ")
+}
+
+// renderProfileMenu renders the launch profile menu popup
+func (m *Model) renderProfileMenu(availableHeight int) string {
+	menu := m.profileMenu
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+	summaryStyle := lp.NewStyle().Faint(true)
+	current := installedBuild(menu.build).LaunchProfile
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Launch Blender " + versionCell(menu.build) + " with"))
+	b.WriteString("\n\n")
+	for i, profile := range menu.profiles {
+		name, summary := m.profileMenuEntry(profile)
+		if profile == current {
+			name += " ★"
+		}
+		line := fillText(name, 30)
+		if i == menu.cursor {
+			b.WriteString(selectedRowStyle.Render(line))
+		} else {
+			b.WriteString(regularRowStyle.Render(line))
+		}
+		if summary != "" {
+			b.WriteString(" " + summaryStyle.Render(truncateMiddle(summary, max(m.terminalWidth-40, 10))))
+		}
+		if i < len(menu.profiles)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(b.String())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderProfileMenuFooter renders the key hints for the launch profile menu
+func (m *Model) renderProfileMenuFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Launch", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Use for this build", keyStyle.Render("b")),
+		fmt.Sprintf("%s Select", keyStyle.Render("↑/↓")),
+		fmt.Sprintf("%s Close", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/model"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWithLaunchProfile(t *testing.T) {
+	cfg := config.Config{
+		LaunchProfile: "cpu",
+		LaunchProfiles: map[string]config.LaunchProfile{
+			"cpu":  {Args: []string{"--debug-cycles"}, Env: map[string]string{"CYCLES_DEVICE": "CPU"}},
+			"cuda": {Args: []string{"--background"}, Env: map[string]string{"CYCLES_DEVICE": "CUDA"}},
+		},
+	}
+	isolated := []string{"BLENDER_USER_CONFIG=/builds/4.3/isolated-config"}
+	execInfo := model.BlenderExecMsg{Env: isolated, Args: []string{"scene.blend"}}
+
+	got := withLaunchProfile(cfg, execInfo)
+	if !slices.Equal(got.Args, []string{"--debug-cycles", "scene.blend"}) {
+		t.Errorf("Expected the profile arguments before the .blend file, got %v", got.Args)
+	}
+	if !slices.Equal(got.Env, append(isolated, "CYCLES_DEVICE=CPU")) || len(execInfo.Env) != 1 {
+		t.Errorf("Expected the profile environment after the isolated config, got %v", got.Env)
+	}
+
+	// A profile picked for the launch wins over the global one, none drops it
+	picked := launchWithProfile(func() tea.Msg { return execInfo }, "cuda")().(model.BlenderExecMsg)
+	if got := withLaunchProfile(cfg, picked); got.Args[0] != "--background" {
+		t.Errorf("Expected the picked profile, got %v", got.Args)
+	}
+	execInfo.Profile = config.NoLaunchProfile
+	if got := withLaunchProfile(cfg, execInfo); len(got.Args) != 1 || len(got.Env) != 1 {
+		t.Errorf("Expected no profile, got %v %v", got.Args, got.Env)
+	}
+}
+
+func TestProfileMenu(t *testing.T) {
+	downloadDir := t.TempDir()
+	build := model.BlenderBuild{Version: "4.3.0", Architecture: "x64", Status: model.StateLocal}
+	installDir := filepath.Join(downloadDir, "blender-4.3.0-linux-x64")
+	if err := os.Mkdir(installDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(build)
+	if err := os.WriteFile(filepath.Join(installDir, "version.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{
+		DownloadDir:    downloadDir,
+		LaunchProfiles: map[string]config.LaunchProfile{"cuda": {Args: []string{"--background"}}},
+	}
+	m := &Model{config: cfg, commands: &Commands{cfg: cfg}, builds: []model.BlenderBuild{build}}
+	m.openProfileMenu()
+	if m.profileMenu == nil || len(m.profileMenu.profiles) != 3 {
+		t.Fatalf("Expected the global default, none and cuda entries, got %+v", m.profileMenu)
+	}
+
+	// b makes the selected profile the build's own
+	m.updateProfileMenu(tea.KeyMsg{Type: tea.KeyDown})
+	m.updateProfileMenu(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.updateProfileMenu(keyMsgFor("b"))
+	if m.profileMenu != nil || cmd == nil {
+		t.Fatal("Expected the menu to close and the profile to be saved")
+	}
+	m.Update(cmd())
+	if m.builds[0].LaunchProfile != "cuda" {
+		t.Errorf("Expected the build to launch with cuda, got %q", m.builds[0].LaunchProfile)
+	}
+	if label := m.launchProfileLabel(m.builds[0]); label != "cuda (this build)" {
+		t.Errorf("Unexpected launch profile label %q", label)
+	}
+	data, _ = os.ReadFile(filepath.Join(installDir, "version.json"))
+	var saved model.BlenderBuild
+	if err := json.Unmarshal(data, &saved); err != nil || saved.LaunchProfile != "cuda" {
+		t.Errorf("Expected the profile in version.json, got %q (%v)", saved.LaunchProfile, err)
+	}
+
+	// The menu opens on the build's profile next time
+	m.openProfileMenu()
+	if m.profileMenu.cursor != 2 {
+		t.Errorf("Expected the cursor on cuda, got %d", m.profileMenu.cursor)
+	}
+}
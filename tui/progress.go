@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// Partial block characters in eighths, for the blocks progress style
+var progressEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// Frames of the braille progress spinner
+var brailleFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// renderProgress renders download or extraction progress (0 to 1) in width cells using the
+// configured progress style: "bar" (default), "percentage", "blocks" or "braille". Low bandwidth mode
+// uses "text", the whole percent without colors, see progressStyle.
+func renderProgress(style string, progress float64, width int) string {
+	if progress < 0 {
+		progress = 0
+	}
+	if progress > 1 {
+		progress = 1
+	}
+	if width <= 0 {
+		return ""
+	}
+	percent := fmt.Sprintf("%.1f%%", progress*100)
+
+	switch style {
+	case "percentage":
+		// The bar with the percentage centered on it
+		label := []rune(percent)
+		if len(label) > width {
+			label = label[:width]
+		}
+		left := (width - len(label)) / 2
+		text := []rune(strings.Repeat(" ", left) + string(label) + strings.Repeat(" ", width-left-len(label)))
+		completed := int(float64(width) * progress)
+		return lp.NewStyle().Background(lp.Color(highlightColor)).Foreground(lp.Color(textColor)).Render(string(text[:completed])) +
+			lp.NewStyle().Background(lp.Color(backgroundColor)).Foreground(lp.Color(textColor)).Render(string(text[completed:]))
+
+	case "blocks":
+		// Full blocks with an eighth-step partial block, followed by the percentage
+		barWidth := width - len(percent) - 1
+		if barWidth < 1 {
+			return lp.NewStyle().Width(width).Render(percent)
+		}
+		eighths := int(float64(barWidth*8) * progress)
+		bar := strings.Repeat("█", eighths/8) + progressEighths[eighths%8]
+		filled := len([]rune(bar))
+		bar = lp.NewStyle().Foreground(lp.Color(highlightColor)).Render(bar) + strings.Repeat("░", barWidth-filled)
+		return bar + " " + percent
+
+	case "text":
+		return fillText(fmt.Sprintf("%.0f%%", progress*100), width)
+
+	case "braille":
+		// A spinner and the percentage, for narrow layouts
+		frame := brailleFrames[time.Now().UnixMilli()/100%int64(len(brailleFrames))]
+		text := lp.NewStyle().Foreground(lp.Color(highlightColor)).Render(frame) + " " + percent
+		return lp.NewStyle().Width(width).Render(text)
+	}
+
+	// Solid fill
+	completed := int(float64(width) * progress)
+	var bar string
+	if completed > 0 {
+		bar += lp.NewStyle().
+			Background(lp.Color(highlightColor)).
+			Foreground(lp.Color(textColor)).
+			Width(completed).
+			Render("")
+	}
+	if remaining := width - completed; remaining > 0 {
+		bar += lp.NewStyle().
+			Background(lp.Color(backgroundColor)).
+			Width(remaining).
+			Render("")
+	}
+	return bar
+}
+
+// phaseLabel names the running phase of an install, or its build state before the first phase
+func phaseLabel(state model.DownloadState) string {
+	if state.Phase != "" {
+		return state.Phase.Label()
+	}
+	return state.BuildState.String()
+}
+
+// renderDownloadSummary renders a one-line indicator of the running downloads for views without
+// the build table, empty when nothing is downloading
+func (m *Model) renderDownloadSummary() string {
+	var ids []string
+	for id, state := range m.downloadStates {
+		if state.BuildState == model.StateDownloading || state.BuildState == model.StateExtracting {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	sort.Strings(ids)
+
+	state := m.downloadStates[ids[0]]
+	name := ids[0]
+	for _, build := range m.builds {
+		if downloadID(build) == ids[0] {
+			name = "Blender " + versionCell(build)
+			break
+		}
+	}
+	verb := phaseLabel(*state)
+
+	line := fmt.Sprintf("%s %s %s %.0f%%", verb, name, renderProgress(m.progressStyle(), state.Progress, 12), state.Progress*100)
+	if m.lowBandwidth {
+		// The plain text progress is the percentage already
+		line = fmt.Sprintf("%s %s %.0f%%", verb, name, state.Progress*100)
+	}
+	if len(ids) > 1 {
+		line += fmt.Sprintf(" (+%d more)", len(ids)-1)
+	}
+	return line
+}
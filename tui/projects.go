@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/projects"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// recentPanel is the popup listing the .blend files recently opened through the launcher
+type recentPanel struct {
+	cursor int
+}
+
+// recordProject remembers a .blend file and the build it is opened with for the recent projects panel
+func (m *Model) recordProject(path string, build model.BlenderBuild) {
+	if m.recent == nil {
+		return
+	}
+	m.recent.Record(path, installedBuild(build), time.Now())
+	if err := m.recent.Save(); err != nil {
+		m.err = fmt.Errorf("failed to save recent projects: %w", err)
+	}
+}
+
+// openRecentPanel shows the recent projects panel
+func (m *Model) openRecentPanel() (tea.Model, tea.Cmd) {
+	if m.recent == nil || len(m.recent.Projects) == 0 {
+		m.showNotice("No recent projects, open a .blend file with the launcher first")
+		return m, nil
+	}
+	m.recentPanel = &recentPanel{}
+	return m, nil
+}
+
+// updateRecentPanel handles key events while the recent projects panel is open
+func (m *Model) updateRecentPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	panel := m.recentPanel
+	count := len(m.recent.Projects)
+	switch msg.String() {
+	case "esc", "R":
+		m.recentPanel = nil
+
+	case "up", "k":
+		panel.cursor = (panel.cursor - 1 + count) % count
+
+	case "down", "j":
+		panel.cursor = (panel.cursor + 1) % count
+
+	case "x":
+		// Forget the project, e.g. one that was moved or deleted
+		m.recent.Remove(m.recent.Projects[panel.cursor].Path)
+		if err := m.recent.Save(); err != nil {
+			m.err = fmt.Errorf("failed to save recent projects: %w", err)
+		}
+		if len(m.recent.Projects) == 0 {
+			m.recentPanel = nil
+		} else if panel.cursor >= len(m.recent.Projects) {
+			panel.cursor = len(m.recent.Projects) - 1
+		}
+
+	case "enter":
+		project := m.recent.Projects[panel.cursor]
+		if _, err := os.Stat(project.Path); err != nil {
+			m.showNotice(fmt.Sprintf("%s no longer exists, press x to remove it", project.Name()))
+			return m, nil
+		}
+		m.recentPanel = nil
+		return m, m.openRecentProject(project)
+	}
+	return m, nil
+}
+
+// openRecentProject launches a project with the build pinned by its .blender-version file,
+// the build it was last opened with, or else a build of the version that saved it
+func (m *Model) openRecentProject(project projects.Project) tea.Cmd {
+	if _, version, err := local.FindPinFile(filepath.Dir(project.Path)); err != nil {
+		m.err = err
+	} else if version != "" {
+		if pinned, found := model.PinnedBuild(m.builds, version); found {
+			return m.launchBlendFile(pinned, project.Path)
+		}
+	}
+
+	for _, build := range m.builds {
+		if build.Status != model.StateLocal && build.Status != model.StateUpdate {
+			continue
+		}
+		if installedBuild(build).Matches(project.Version, project.Architecture) {
+			return m.launchBlendFile(build, project.Path)
+		}
+	}
+
+	// The last build was removed, pick one from the file header like for a file given on the command line
+	m.blendLaunch = &blendLaunch{path: project.Path, scanned: true}
+	return readBlendVersionCmd(project.Path)
+}
+
+// renderRecentPanel renders the recent projects popup
+func (m *Model) renderRecentPanel(availableHeight int) string {
+	dimStyle := lp.NewStyle().Faint(true)
+	warnStyle := lp.NewStyle().Foreground(lp.Color(orangeColor))
+	width := m.terminalWidth - 4 // Border and padding
+
+	var b strings.Builder
+	b.WriteString(lp.NewStyle().Bold(true).Render("Recent projects"))
+	b.WriteString("\n\n")
+
+	// Keep the selected project visible in short terminals
+	visible := availableHeight - 4
+	if visible < 1 {
+		visible = 1
+	}
+	start := 0
+	if m.recentPanel.cursor >= visible {
+		start = m.recentPanel.cursor - visible + 1
+	}
+
+	for i := start; i < len(m.recent.Projects) && i < start+visible; i++ {
+		project := m.recent.Projects[i]
+		build := versionCell(model.BlenderBuild{Version: project.Version, Architecture: project.Architecture})
+		line := fmt.Sprintf("%s %s %s", padText(project.Name(), 28, lp.Left), padText(build, 18, lp.Left), project.OpenedAt.Format("2006-01-02 15:04"))
+		dir, dirStyle := "  "+filepath.Dir(project.Path), dimStyle
+		if _, err := os.Stat(project.Path); err != nil {
+			dir, dirStyle = "  missing: "+filepath.Dir(project.Path), warnStyle
+		}
+
+		if i == m.recentPanel.cursor {
+			b.WriteString(selectedRowStyle.Render(line))
+		} else {
+			b.WriteString(regularRowStyle.Render(line))
+		}
+		b.WriteString(dirStyle.Render(truncateText(dir, width-textWidth(line))))
+		if i < len(m.recent.Projects)-1 && i < start+visible-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(b.String())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderRecentPanelFooter renders the key hints for the recent projects panel
+func (m *Model) renderRecentPanelFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Open", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Select", keyStyle.Render("↑/↓")),
+		fmt.Sprintf("%s Remove from list", keyStyle.Render("x")),
+		fmt.Sprintf("%s Close", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
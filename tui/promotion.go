@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PromoteBuild creates a command to save the promotion state of a local build.
+// Admins may promote shared builds too, they are written in the shared directory.
+func (c *Commands) PromoteBuild(build model.BlenderBuild, promotion string) tea.Cmd {
+	buildsDir := c.cfg.DownloadDir
+	if build.Shared {
+		buildsDir = c.cfg.SharedDir
+	}
+	version, arch := build.Version, build.Architecture
+	return func() tea.Msg {
+		dirPath, err := local.FindBuildDir(buildsDir, version, arch)
+		if err != nil {
+			return buildPromotedMsg{version: version, err: err}
+		}
+		if dirPath == "" {
+			return buildPromotedMsg{version: version, err: fmt.Errorf("build directory for Blender version %s not found", version)}
+		}
+		saved, err := local.SetBuildPromotion(dirPath, promotion)
+		if err != nil {
+			return buildPromotedMsg{version: version, err: fmt.Errorf("failed to save promotion: %w", err)}
+		}
+		return buildPromotedMsg{version: version, build: saved}
+	}
+}
+
+// handleCyclePromotion moves the selected local build to the next promotion state
+func (m *Model) handleCyclePromotion() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok || (build.Status != model.StateLocal && build.Status != model.StateUpdate) {
+		return m, nil
+	}
+	if !m.config.PromotionAdmin {
+		m.showNotice("Only admins can change the promotion of a build (promotion_admin)")
+		return m, nil
+	}
+	return m, m.commands.PromoteBuild(build, installedBuild(build).NextPromotion())
+}
+
+// handleBuildPromoted shows the saved promotion on every row of the build
+func (m *Model) handleBuildPromoted(msg buildPromotedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.err = nil
+	for i := range m.builds {
+		if !m.builds[i].Matches(msg.version, msg.build.Architecture) {
+			continue
+		}
+		if m.builds[i].Installed != nil {
+			m.builds[i].Installed.Promotion = msg.build.Promotion
+		} else {
+			m.builds[i].Promotion = msg.build.Promotion
+		}
+	}
+	m.showNotice(fmt.Sprintf("Blender %s is now %s", msg.version, msg.build.PromotionState()))
+	return m, nil
+}
+
+// launchAllowed reports whether a build may be launched, showing a notice when approved_only forbids it
+func (m *Model) launchAllowed(build model.BlenderBuild) bool {
+	promotion := installedBuild(build).PromotionState()
+	if !m.config.ApprovedOnly || promotion == model.PromotionApproved {
+		return true
+	}
+	m.showNotice(fmt.Sprintf("Blender %s is %s, only approved builds can be launched", build.Version, promotion))
+	return false
+}
+
+// promotionBadge renders the promotion state of an installed build
+func promotionBadge(build model.BlenderBuild) string {
+	if build.Status != model.StateLocal && build.Status != model.StateUpdate {
+		return ""
+	}
+	switch installedBuild(build).PromotionState() {
+	case model.PromotionApproved:
+		return "✓ approved"
+	case model.PromotionBlocked:
+		return "✗ blocked"
+	default:
+		return "testing"
+	}
+}
+
+// installedBuild returns the build on disk: the installed one for updates, the build itself otherwise
+func installedBuild(build model.BlenderBuild) model.BlenderBuild {
+	if build.Status == model.StateUpdate && build.Installed != nil {
+		return *build.Installed
+	}
+	return build
+}
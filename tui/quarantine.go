@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// unquarantineCmd clears the quarantine attribute of a build and hands the launch back to handleBlenderExec
+func unquarantineCmd(execInfo model.BlenderExecMsg) tea.Cmd {
+	return func() tea.Msg {
+		if err := local.ClearQuarantine(execInfo.InstallDir); err != nil {
+			return errMsg{err}
+		}
+		execInfo.Quarantine = false
+		return execInfo
+	}
+}
+
+// updateQuarantinePrompt handles key events while the quarantine prompt is shown
+func (m *Model) updateQuarantinePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	execInfo := *m.quarantinePrompt
+	switch msg.String() {
+	case "c":
+		m.quarantinePrompt = nil
+		return m, unquarantineCmd(execInfo)
+
+	case "a":
+		// Remember the consent for all future builds
+		m.quarantinePrompt = nil
+		m.config.Unquarantine = true
+		if err := config.SaveConfig(m.config); err != nil {
+			m.err = fmt.Errorf("failed to save config: %w", err)
+			return m, nil
+		}
+		m.commands.SetConfig(m.config)
+		return m, unquarantineCmd(execInfo)
+
+	case "s":
+		// Approve the build in the system settings instead
+		m.quarantinePrompt = nil
+		if err := local.OpenSecuritySettings(); err != nil {
+			m.err = fmt.Errorf("failed to open the security settings: %w", err)
+			return m, nil
+		}
+		m.showNotice("Click \"Open Anyway\" for Blender in Privacy & Security after its first launch attempt")
+		return m, nil
+
+	case "enter":
+		// Launch as it is and let Gatekeeper ask
+		m.quarantinePrompt = nil
+		execInfo.Quarantine = false
+		return m.handleBlenderExec(execInfo)
+
+	case "esc", "q":
+		m.quarantinePrompt = nil
+	}
+	return m, nil
+}
+
+// renderQuarantinePrompt renders the Gatekeeper warning dialog
+func (m *Model) renderQuarantinePrompt(availableHeight int) string {
+	titleStyle := lp.NewStyle().Foreground(lp.Color(orangeColor)).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("⚠ Blocked by Gatekeeper"))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("Blender %s is marked as downloaded from the internet and\n", m.quarantinePrompt.Version))
+	b.WriteString("macOS will refuse to open it from a terminal. Clear the\n")
+	b.WriteString("quarantine attribute of:\n")
+	b.WriteString("  " + m.quarantinePrompt.InstallDir + "\n\n")
+	b.WriteString("or allow it in System Settings › Privacy & Security.")
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(1, 2).
+		Render(b.String())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Center, box)
+}
+
+// renderQuarantinePromptFooter renders the choices for the Gatekeeper warning dialog
+func (m *Model) renderQuarantinePromptFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Clear and launch", keyStyle.Render("c")),
+		fmt.Sprintf("%s Always clear", keyStyle.Render("a")),
+		fmt.Sprintf("%s Open security settings", keyStyle.Render("s")),
+		fmt.Sprintf("%s Launch anyway", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/stats"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// restoreQueue returns the command to queue the downloads left unfinished when the launcher quit, in their order,
+// once the builder answered a fetch. Like scheduled downloads they wait on a metered connection or past the download budget.
+func (m *Model) restoreQueue(msg buildsFetchedMsg) tea.Cmd {
+	if len(m.restoredQueue) == 0 || msg.offline || msg.err != nil {
+		return nil
+	}
+
+	// Builds installed meanwhile are dropped
+	var builds []model.BlenderBuild
+	var size int64
+	for _, build := range m.restoredQueue {
+		if !m.isInstalled(build) {
+			builds = append(builds, build)
+			size += build.Size
+		}
+	}
+	if m.config.Metered {
+		m.err = fmt.Errorf("%d queued downloads are on hold: metered connection mode is enabled", len(builds))
+		return nil
+	}
+	if m.budgetState(size) == stats.BudgetExceeded {
+		m.err = fmt.Errorf("%d queued downloads are on hold: they would exceed the download budget (%s used this month)", len(builds), m.budgetUsage())
+		return nil
+	}
+	m.restoredQueue = nil
+	if len(builds) == 0 {
+		return nil
+	}
+
+	// In sequence, so they are queued in their order
+	cmds := make([]tea.Cmd, 0, len(builds))
+	for _, build := range builds {
+		cmds = append(cmds, m.commands.DoDownload(build))
+	}
+	m.showNotice(fmt.Sprintf("Restored %d queued downloads", len(builds)))
+	return tea.Batch(tea.Sequence(cmds...), m.scheduleTick(10*time.Millisecond))
+}
+
+// isInstalled reports whether a local build has the version, architecture and hash of build
+func (m *Model) isInstalled(build model.BlenderBuild) bool {
+	for _, b := range append(m.builds, m.hiddenOld...) {
+		if b.Status == model.StateLocal && b.Matches(build.Version, build.Architecture) && b.Hash == build.Hash {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+	version "github.com/hashicorp/go-version"
+)
+
+// applyListFilters saves the changed build type / version filter and refetches the build list
+func (m *Model) applyListFilters() (tea.Model, tea.Cmd) {
+	if err := config.SaveConfig(m.config); err != nil {
+		m.err = fmt.Errorf("failed to save config: %w", err)
+		return m, nil
+	}
+	m.err = nil
+
+	// Keep the running download manager; only the fetch parameters change
+	m.commands.SetConfig(m.config)
+	return m, m.commands.FetchBuilds()
+}
+
+// handleCycleBuildType switches to the next build type and refetches
+func (m *Model) handleCycleBuildType() (tea.Model, tea.Cmd) {
+	m.buildTypeIndex = (m.buildTypeIndex + 1) % len(m.buildTypeOptions)
+	m.buildType = m.buildTypeOptions[m.buildTypeIndex]
+	m.config.BuildType = m.buildType
+	return m.applyListFilters()
+}
+
+// openFilterPrompt shows the inline version filter prompt for the selected build type
+func (m *Model) openFilterPrompt() (tea.Model, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = fmt.Sprintf("Version filter (%s): ", m.config.BuildType)
+	input.Placeholder = "e.g., 4.0, 3.6 (empty for none)"
+	input.CharLimit = 10
+	input.Width = 30
+	input.SetValue(m.versionFilter())
+	input.CursorEnd()
+	input.Focus()
+
+	m.filterPrompt = &input
+	return m, textinput.Blink
+}
+
+// updateFilterPrompt handles key events while the inline version filter prompt is open
+func (m *Model) updateFilterPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.filterPrompt = nil
+		return m, nil
+
+	case "enter":
+		value := strings.TrimSpace(m.filterPrompt.Value())
+		if value != "" {
+			if _, err := version.NewVersion(value); err != nil {
+				m.err = fmt.Errorf("invalid version filter format '%s'", value)
+				return m, nil
+			}
+		}
+		m.filterPrompt = nil
+		m.config.SetVersionFilterFor(m.config.BuildType, value)
+		return m.applyListFilters()
+	}
+
+	var cmd tea.Cmd
+	*m.filterPrompt, cmd = m.filterPrompt.Update(msg)
+	return m, cmd
+}
+
+// renderFilterPromptFooter renders the inline version filter prompt in place of the footer
+func (m *Model) renderFilterPromptFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	hints := strings.Join([]string{
+		fmt.Sprintf("%s Apply and fetch", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+	}, separator)
+
+	return footerStyle.Width(m.terminalWidth).Render(m.filterPrompt.View() + newlineStyle + hints)
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MeasureDownloading creates a command to measure the size of .downloading
+func (c *Commands) MeasureDownloading() tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		size, err := local.DownloadingSize(downloadDir)
+		return downloadingMeasuredMsg{size: size, err: err}
+	}
+}
+
+// EnforceDownloadingQuota creates a command to delete the least recently written leftovers in .downloading
+// while it is above downloading_quota_gb. Partial files of paused and queued downloads are kept;
+// while a download or extraction runs, .downloading is only measured.
+func (c *Commands) EnforceDownloadingQuota() tea.Cmd {
+	quota := c.cfg.DownloadingQuota()
+	if quota <= 0 || c.downloads == nil {
+		return nil
+	}
+	held, running := c.downloads.heldPaths()
+	if running {
+		return c.MeasureDownloading()
+	}
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		evicted, size, err := local.EvictDownloading(downloadDir, quota, held, time.Now())
+		return downloadingMeasuredMsg{size: size, evicted: evicted, err: err}
+	}
+}
+
+// handleDownloadingMeasured keeps the size of .downloading for the settings and reports deleted leftovers
+func (m *Model) handleDownloadingMeasured(msg downloadingMeasuredMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+	}
+	m.downloadingSize = msg.size
+	if len(msg.evicted) > 0 {
+		var freed int64
+		for _, item := range msg.evicted {
+			freed += item.Size
+		}
+		m.showNotice(fmt.Sprintf("%s quota reached: deleted %d old leftover(s), freed %s",
+			download.DownloadingDir, len(msg.evicted), model.FormatByteSize(freed)))
+	}
+	return m, nil
+}
+
+// downloadingUsage describes the size of .downloading against its quota, e.g. "1.2 GB of 5.0 GB"
+func (m *Model) downloadingUsage() string {
+	if m.downloadingSize < 0 {
+		return "measuring..."
+	}
+	usage := model.FormatByteSize(m.downloadingSize)
+	if quota := m.config.DownloadingQuota(); quota > 0 {
+		return fmt.Sprintf("%s of %s", usage, model.FormatByteSize(quota))
+	}
+	return usage + ", no quota"
+}
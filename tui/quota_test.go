@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/model"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEnforceDownloadingQuota(t *testing.T) {
+	downloadDir := t.TempDir()
+	downloadingDir := filepath.Join(downloadDir, download.DownloadingDir)
+	if err := os.Mkdir(downloadingDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Four leftovers of 1 MiB, the oldest first; the paused download is the oldest of all
+	now := time.Now()
+	names := []string{"paused.tar.xz", "oldest.tar.xz", "older.zip", "recent.tar.xz"}
+	ages := []time.Duration{72 * time.Hour, 48 * time.Hour, 24 * time.Hour, time.Minute}
+	for i, name := range names {
+		path := filepath.Join(downloadingDir, name)
+		if err := os.WriteFile(path, make([]byte, 1<<20), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := now.Add(-ages[i])
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A quota of 1.5 MiB: the paused partial and the file still being written stay
+	cfg := config.Config{DownloadDir: downloadDir, DownloadingQuotaGB: 1.5 / 1024}
+	c := &Commands{cfg: cfg, downloads: NewDownloadManager(cfg, nil)}
+	c.downloads.states["4.3.0"] = &model.DownloadState{BuildState: model.StateDownloading, Paused: true}
+	c.downloads.transfers["4.3.0"] = &transfer{path: filepath.Join(downloadingDir, "paused.tar.xz")}
+
+	msg, ok := c.EnforceDownloadingQuota()().(downloadingMeasuredMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("Expected .downloading to be measured, got %+v", msg)
+	}
+	if len(msg.evicted) != 2 || msg.evicted[0].Name != "oldest.tar.xz" || msg.evicted[1].Name != "older.zip" {
+		t.Errorf("Expected the two oldest leftovers to be deleted, got %+v", msg.evicted)
+	}
+	if msg.size != 2<<20 {
+		t.Errorf("Expected 2 MiB left, got %d", msg.size)
+	}
+	for _, name := range []string{"paused.tar.xz", "recent.tar.xz"} {
+		if _, err := os.Stat(filepath.Join(downloadingDir, name)); err != nil {
+			t.Errorf("Expected %s to be kept: %v", name, err)
+		}
+	}
+
+	// Nothing is deleted while a download runs
+	c.downloads.states["4.3.0"].Paused = false
+	if msg := c.EnforceDownloadingQuota()().(downloadingMeasuredMsg); len(msg.evicted) != 0 || msg.size != 2<<20 {
+		t.Errorf("Expected only a measurement while downloading, got %+v", msg)
+	}
+
+	m := &Model{config: cfg}
+	m.handleDownloadingMeasured(msg)
+	if got := m.downloadingUsage(); got != "2.0MB of 1.5MB" {
+		t.Errorf("Unexpected usage %q", got)
+	}
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/download"
+	"TUI-Blender-Launcher/local"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RecoverInterrupted creates a command to resolve installs interrupted by a crash,
+// so the following scan sees a consistent download directory. Builds deleted before
+// a crash are deleted for good, undoing only works within a session.
+func (c *Commands) RecoverInterrupted() tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		_ = local.EmptyTrash(downloadDir)
+		recovered, err := download.RecoverJournal(downloadDir)
+		return installsRecoveredMsg{recovered: recovered, err: err}
+	}
+}
+
+// handleInstallsRecovered reports installs that were rolled back or completed after a crash
+func (m *Model) handleInstallsRecovered(msg installsRecoveredMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	switch len(msg.recovered) {
+	case 0:
+	case 1:
+		r := msg.recovered[0]
+		if r.RolledBack {
+			m.showNotice(fmt.Sprintf("Interrupted install of %s was rolled back", r.Operation.Version))
+		} else {
+			m.showNotice(fmt.Sprintf("Interrupted install of %s was completed", r.Operation.Version))
+		}
+	default:
+		m.showNotice(fmt.Sprintf("Recovered %d interrupted installs", len(msg.recovered)))
+	}
+	return m, nil
+}
@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/stats"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// buildRepair tracks clean re-downloads of builds that failed their smoke test or verification
+type buildRepair struct {
+	prompt   *model.BlenderBuild // Build waiting for a decision to download it again, nil when none
+	reported map[string]bool     // Failed smoke tests already handled, a download's completion is seen by its message and the ticks
+	auto     map[string]bool     // Builds re-downloaded without asking this session; when they fail again, the user is asked
+}
+
+// firstReport reports whether the failed smoke test of a finished download is handled for the first time
+func (r *buildRepair) firstReport(build model.BlenderBuild) bool {
+	key := downloadID(build)
+	if build.SmokeTest != nil {
+		key += fmt.Sprintf("@%d", time.Time(build.SmokeTest.TestedAt).UnixNano())
+	}
+	if r.reported[key] {
+		return false
+	}
+	if r.reported == nil {
+		r.reported = make(map[string]bool)
+	}
+	r.reported[key] = true
+	return true
+}
+
+// repairReason describes what is wrong with a build that needs a repair
+func repairReason(build model.BlenderBuild) string {
+	if build.Broken() {
+		return fmt.Sprintf("Blender %s is broken: %s", build.Version, build.SmokeTest.Error)
+	}
+	return fmt.Sprintf("installed files of Blender %s changed since installation", build.Version)
+}
+
+// canRepair returns why a build can't be downloaded again, nil if it can
+func (m *Model) canRepair(build model.BlenderBuild) error {
+	switch {
+	case build.Shared:
+		return fmt.Errorf("Blender %s is a shared build, ask its admin to reinstall it", build.Version)
+	case build.DownloadURL == "":
+		return fmt.Errorf("Blender %s was not downloaded by the launcher and can't be downloaded again", build.Version)
+	case m.offline:
+		return errOfflineDownloads
+	}
+	return nil
+}
+
+// repairRow returns the index of the listed local build a repair downloads again, -1 if it isn't listed.
+// An installed build with an update available is replaced by downloading the update instead.
+func (m *Model) repairRow(build model.BlenderBuild) int {
+	id := downloadID(build)
+	for i := range m.builds {
+		if m.builds[i].Status == model.StateLocal && downloadID(m.builds[i]) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// offerRepair downloads a build that needs a repair again, right away with auto_repair
+// or after asking. A build is only repaired automatically once per session, so one that
+// keeps failing doesn't download in a loop, and never on a metered connection or past the download budget.
+func (m *Model) offerRepair(build model.BlenderBuild) tea.Cmd {
+	if m.repairRow(build) < 0 || m.canRepair(build) != nil {
+		return nil
+	}
+	id := downloadID(build)
+	if m.config.AutoRepair && !m.config.Metered && m.budgetState(build.Size) != stats.BudgetExceeded && !m.repair.auto[id] {
+		if m.repair.auto == nil {
+			m.repair.auto = make(map[string]bool)
+		}
+		m.repair.auto[id] = true
+		return m.startRepair(build)
+	}
+	m.repair.prompt = &build
+	return nil
+}
+
+// startRepair downloads a build again. The download moves the broken directory to the old builds
+// and restores the label, notes, tags, promotion and isolated user config of the build from it.
+func (m *Model) startRepair(build model.BlenderBuild) tea.Cmd {
+	i := m.repairRow(build)
+	if i < 0 {
+		return nil
+	}
+
+	// Results of the broken install don't apply to the new one
+	build = m.builds[i]
+	build.SmokeTest = nil
+	build.Introspection = nil
+	build.GPUProbe = nil
+	build.SHA256 = ""
+	build.TreeSHA256 = ""
+	build.Verification = ""
+	build.Status = model.StateDownloading
+	m.builds[i] = build
+
+	m.repair.prompt = nil
+	m.activeDownloadID = downloadID(build)
+	m.err = nil
+	m.showNotice(fmt.Sprintf("Downloading Blender %s again to repair it", build.Version))
+	return m.commands.DoDownload(build)
+}
+
+// updateRepairPrompt downloads the build again on r, reinstalls it from the archive cache on I
+// and dismisses the prompt on esc
+func (m *Model) updateRepairPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r":
+		build := *m.repair.prompt
+		if err := m.canRepair(build); err != nil {
+			m.err = err
+			return m, nil
+		}
+		return m, m.startRepair(build)
+	case "I":
+		// The prompt stays until the archive is found
+		return m, m.commands.FindCachedArchive(*m.repair.prompt)
+	}
+	m.repair.prompt = nil
+	return m, nil
+}
+
+// renderRepairPrompt renders the broken build warning in place of the contextual commands.
+// Other keys than r and esc work as usual while it's shown.
+func (m *Model) renderRepairPrompt(keyStyle lp.Style, separator string) string {
+	warnStyle := lp.NewStyle().Foreground(lp.Color(redColor))
+	prompt := warnStyle.Render(repairReason(*m.repair.prompt)) + separator +
+		fmt.Sprintf("%s Download again", keyStyle.Render("r")) + separator
+	if m.config.KeepArchives {
+		prompt += fmt.Sprintf("%s Reinstall from cache", keyStyle.Render("I")) + separator
+	}
+	return prompt + fmt.Sprintf("%s Dismiss", keyStyle.Render("esc"))
+}
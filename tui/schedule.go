@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"TUI-Blender-Launcher/schedule"
+	"TUI-Blender-Launcher/stats"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// scheduledAt returns when a download of the build is scheduled, or the zero time
+func (m *Model) scheduledAt(build model.BlenderBuild) time.Time {
+	if m.schedule == nil {
+		return time.Time{}
+	}
+	at, _ := m.schedule.Find(build.Version, build.Architecture)
+	return at
+}
+
+// handleScheduleDownload opens the schedule prompt for the selected build,
+// or removes its schedule if it is already scheduled
+func (m *Model) handleScheduleDownload() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok || m.schedule == nil {
+		return m, nil
+	}
+
+	if m.schedule.Remove(build.Version, build.Architecture) {
+		if err := m.schedule.Save(); err != nil {
+			m.err = fmt.Errorf("failed to save schedule: %w", err)
+		}
+		return m, nil
+	}
+
+	// Only builds that could be downloaded right now can be scheduled
+	if build.Status != model.StateOnline && build.Status != model.StateUpdate &&
+		build.Status != model.StateFailed && build.Status != model.StateCancelled {
+		return m, nil
+	}
+
+	input := textinput.New()
+	input.Prompt = fmt.Sprintf("Download %s at: ", build.Version)
+	input.Placeholder = "HH:MM, YYYY-MM-DD HH:MM or +2h"
+	input.CharLimit = 16
+	input.Width = 30
+	input.Focus()
+
+	m.schedulePrompt = &input
+	return m, textinput.Blink
+}
+
+// updateSchedulePrompt handles key events while the schedule prompt is open
+func (m *Model) updateSchedulePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.schedulePrompt = nil
+		return m, nil
+
+	case "enter":
+		at, err := schedule.ParseTime(m.schedulePrompt.Value(), time.Now())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.schedulePrompt = nil
+
+		build, ok := m.selectedBuild()
+		if !ok {
+			return m, nil
+		}
+		m.schedule.Add(build, at)
+		if err := m.schedule.Save(); err != nil {
+			m.err = fmt.Errorf("failed to save schedule: %w", err)
+			return m, nil
+		}
+		m.err = nil
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	*m.schedulePrompt, cmd = m.schedulePrompt.Update(msg)
+	return m, cmd
+}
+
+// startDueDownloads starts every scheduled download whose time has come
+func (m *Model) startDueDownloads() tea.Cmd {
+	if m.schedule == nil {
+		return nil
+	}
+
+	// On a metered connection nothing is downloaded without the user asking for it
+	if m.config.Metered {
+		if m.schedule.HasDue(time.Now()) {
+			m.err = fmt.Errorf("scheduled downloads are on hold: metered connection mode is enabled")
+		}
+		return nil
+	}
+	// Past the download budget, downloads wait for the next month or a manual start
+	var dueBytes int64
+	for _, entry := range m.schedule.Entries {
+		if !entry.At.After(time.Now()) {
+			dueBytes += entry.Build.Size
+		}
+	}
+	if dueBytes > 0 && m.budgetState(dueBytes) == stats.BudgetExceeded {
+		m.err = fmt.Errorf("scheduled downloads are on hold: they would exceed the download budget (%s used this month)", m.budgetUsage())
+		return nil
+	}
+	// Keep due entries until the builder is reachable again
+	if m.offline {
+		return nil
+	}
+
+	due := m.schedule.TakeDue(time.Now())
+	if len(due) == 0 {
+		return nil
+	}
+	if err := m.schedule.Save(); err != nil {
+		m.err = fmt.Errorf("failed to save schedule: %w", err)
+	}
+
+	var cmds []tea.Cmd
+	for _, entry := range due {
+		build := entry.Build
+		buildID := downloadID(build)
+		cmds = append(cmds, func() tea.Msg {
+			return startDownloadMsg{build: build, buildID: buildID}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderSchedulePromptFooter renders the schedule prompt in place of the footer
+func (m *Model) renderSchedulePromptFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	hints := strings.Join([]string{
+		fmt.Sprintf("%s Schedule", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+	}, separator)
+
+	return footerStyle.Width(m.terminalWidth).Render(m.schedulePrompt.View() + newlineStyle + hints)
+}
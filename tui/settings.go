@@ -1,11 +1,40 @@
 package tui
 
 import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/download"
+	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	lp "github.com/charmbracelet/lipgloss"
 )
 
+// newSettingsInputs creates the text inputs of the settings form filled from cfg:
+// the download directory, then the version filter of each build type
+func newSettingsInputs(cfg config.Config, buildTypes []string) []textinput.Model {
+	inputs := make([]textinput.Model, 0, len(buildTypes)+1)
+
+	// Download Dir input
+	t := textinput.New()
+	t.Placeholder = cfg.DownloadDir // Show default as placeholder
+	t.SetValue(cfg.DownloadDir)
+	t.CharLimit = 256
+	t.Width = 50
+	inputs = append(inputs, t)
+
+	// One version filter input per build type
+	for _, buildType := range buildTypes {
+		t = textinput.New()
+		t.Placeholder = "e.g., 4.0, 3.6 (leave empty for none)"
+		t.SetValue(cfg.VersionFilterFor(buildType))
+		t.CharLimit = 10
+		t.Width = 50
+		inputs = append(inputs, t)
+	}
+	return inputs
+}
+
 // renderSettingsContent renders the settings page content with a cleaner structure.
 func (m *Model) renderSettingsContent(availableHeight int) string {
 	var b strings.Builder
@@ -70,6 +99,30 @@ func (m *Model) renderSettingsContent(availableHeight int) string {
 		return sectionStyle.Render(sb.String())
 	}
 
+	// Helper to render the version filters, a text input per build type under a shared label
+	renderFilterSettings := func(label, description string) string {
+		var sb strings.Builder
+		sb.WriteString(labelStyle.Render(label))
+		sb.WriteString("\n")
+		for i, buildType := range m.buildTypeOptions {
+			index := i + 1
+			name := fmt.Sprintf("  %-13s", buildType)
+			if m.focusIndex == index {
+				sb.WriteString(labelStyleFocused.Render(name))
+				sb.WriteString(inputStyleFocused.Render(m.settingsInputs[index].View()))
+			} else {
+				sb.WriteString(labelStyle.Render(name))
+				sb.WriteString(inputStyle.Render(m.settingsInputs[index].View()))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString(descStyle.Render(description))
+		sb.WriteString("\n")
+		// Add a divider line
+		sb.WriteString("\n")
+		return sectionStyle.Render(sb.String())
+	}
+
 	// Helper to render the build type (horizontal selector) setting
 	renderBuildTypeSetting := func(label, description string) string {
 		var sb strings.Builder
@@ -95,6 +148,40 @@ func (m *Model) renderSettingsContent(availableHeight int) string {
 		sb.WriteString("\n")
 		sb.WriteString(descStyle.Render(description))
 		sb.WriteString("\n")
+		// Add a divider line
+		sb.WriteString("\n")
+		return sectionStyle.Render(sb.String())
+	}
+
+	// Helper to render the download ID (on/off selector plus the ID) setting
+	renderDownloadIDSetting := func(label, description string) string {
+		var sb strings.Builder
+		isFocused := (m.focusIndex == len(m.settingsInputs)+1)
+		if isFocused {
+			sb.WriteString(labelStyleFocused.Render(label))
+		} else {
+			sb.WriteString(labelStyle.Render(label))
+		}
+		sb.WriteString(" ")
+
+		var horizontalOptions strings.Builder
+		for _, enabled := range []bool{true, false} {
+			option := "off"
+			if enabled {
+				option = "on"
+			}
+			if enabled == m.sendDownloadID {
+				horizontalOptions.WriteString(selectedOptionStyle.Render(option))
+			} else {
+				horizontalOptions.WriteString(optionStyle.Render(option))
+			}
+		}
+		horizontalOptions.WriteString(" ")
+		horizontalOptions.WriteString(m.downloadID)
+		sb.WriteString(inputStyle.Render(horizontalOptions.String()))
+		sb.WriteString("\n")
+		sb.WriteString(descStyle.Render(description))
+		sb.WriteString("\n")
 		// No divider for the last setting
 		return sectionStyle.Render(sb.String())
 	}
@@ -107,16 +194,39 @@ func (m *Model) renderSettingsContent(availableHeight int) string {
 		"Where Blender builds will be downloaded and installed"))
 	b.WriteString("\n")
 
-	// Version Filter setting (text input)
-	b.WriteString(renderTextSetting(1,
-		"Version Filter:",
-		"Only show versions matching this filter (e.g., '4.0' or '3.6')"))
+	// Version filter settings (text inputs), one line per build type
+	b.WriteString(renderFilterSettings(
+		"Version Filters:",
+		"Only show builds of a type from this version on (e.g., '4.0' or '3.6'), empty for all"))
 	b.WriteString("\n")
 
 	// Build Type setting (horizontal selector)
 	b.WriteString(renderBuildTypeSetting(
 		"Build Type:",
 		"Select which build type to fetch (daily, patch, experimental) <- to select ->"))
+	b.WriteString("\n")
+
+	// Download ID setting (on/off selector)
+	b.WriteString(renderDownloadIDSetting(
+		"Download ID:",
+		"Identifier sent with each download and build list fetch <- on/off -> · r to regenerate"))
+	b.WriteString("\n")
+
+	// Size of .downloading (read-only)
+	b.WriteString(labelStyle.Render("Partial Downloads:"))
+	b.WriteString(" ")
+	b.WriteString(inputStyle.Render(m.downloadingUsage()))
+	b.WriteString("\n")
+	b.WriteString(descStyle.Render("Size of " + download.DownloadingDir + "; past downloading_quota_gb in config.toml the oldest leftovers are deleted"))
+	b.WriteString("\n\n")
+
+	// Extraction tuning (read-only)
+	b.WriteString(labelStyle.Render("Extraction:"))
+	b.WriteString(" ")
+	b.WriteString(inputStyle.Render(download.NewExtractTuning(&m.config).String()))
+	b.WriteString("\n")
+	b.WriteString(descStyle.Render("Files written in parallel and buffer size; set extract_workers and extract_buffer_mb in config.toml to override"))
+	b.WriteString("\n")
 
 	return lp.Place(m.terminalWidth, availableHeight, lp.Left, lp.Top, b.String())
 }
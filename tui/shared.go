@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// launchBuildCmd launches a local build from the download directory or the shared one
+func (m *Model) launchBuildCmd(build model.BlenderBuild) tea.Cmd {
+	if build.Shared {
+		return local.LaunchSharedBlenderCmd(m.config.SharedDir, build.Version, build.Architecture)
+	}
+	return local.LaunchBlenderCmd(m.config.DownloadDir, build.Version, build.Architecture)
+}
+
+// buildDir returns the installation directory of a local build
+func (m *Model) buildDir(build model.BlenderBuild) (string, error) {
+	if build.Shared {
+		return local.FindBuildDir(m.config.SharedDir, build.Version, build.Architecture)
+	}
+	return local.FindBuildDir(m.config.DownloadDir, build.Version, build.Architecture)
+}
+
+// rejectShared shows a notice and returns true when the build comes from the read-only shared directory
+func (m *Model) rejectShared(build model.BlenderBuild) bool {
+	if !build.Shared {
+		return false
+	}
+	m.showNotice(fmt.Sprintf("Blender %s is a shared build and can't be changed", build.Version))
+	return true
+}
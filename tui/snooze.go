@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// defaultSnoozeDays is offered when snoozing the updates of a build for the first time
+const defaultSnoozeDays = 7
+
+// SnoozeUpdates creates a command to hold back the updates of a local build for a number of days, 0 ends the snooze
+func (c *Commands) SnoozeUpdates(version, arch string, days int) tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		dirPath, err := local.FindBuildDir(downloadDir, version, arch)
+		if err != nil {
+			return updatesSnoozedMsg{version: version, err: err}
+		}
+		if dirPath == "" {
+			return updatesSnoozedMsg{version: version, err: fmt.Errorf("build directory for Blender version %s not found", version)}
+		}
+		var until *model.Timestamp
+		if days > 0 {
+			ts := model.Timestamp(time.Now().AddDate(0, 0, days))
+			until = &ts
+		}
+		build, err := local.SetBuildSnooze(dirPath, until)
+		if err != nil {
+			return updatesSnoozedMsg{version: version, err: fmt.Errorf("failed to save snooze: %w", err)}
+		}
+		return updatesSnoozedMsg{version: version, build: build}
+	}
+}
+
+// openSnoozePrompt asks for how many days the updates of the selected local build are held back
+func (m *Model) openSnoozePrompt() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok || (build.Status != model.StateLocal && build.Status != model.StateUpdate) || m.rejectShared(build) {
+		return m, nil
+	}
+
+	days := installedBuild(build).SnoozeDaysLeft(time.Now())
+	if days == 0 {
+		days = defaultSnoozeDays
+	}
+	input := textinput.New()
+	input.Prompt = fmt.Sprintf("Snooze updates of %s for days: ", build.Version)
+	input.Placeholder = "0 to end the snooze"
+	input.CharLimit = 4
+	input.Width = 20
+	input.SetValue(strconv.Itoa(days))
+	input.CursorEnd()
+	input.Focus()
+
+	m.snoozePrompt = &input
+	return m, textinput.Blink
+}
+
+// updateSnoozePrompt handles key events while the snooze prompt is open
+func (m *Model) updateSnoozePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.snoozePrompt = nil
+		return m, nil
+
+	case "enter":
+		value := strings.TrimSpace(m.snoozePrompt.Value())
+		days := 0
+		if value != "" {
+			var err error
+			if days, err = strconv.Atoi(value); err != nil || days < 0 {
+				m.err = fmt.Errorf("invalid number of days %q", value)
+				return m, nil
+			}
+		}
+		m.snoozePrompt = nil
+		build, ok := m.selectedBuild()
+		if !ok {
+			return m, nil
+		}
+		return m, m.commands.SnoozeUpdates(build.Version, build.Architecture, days)
+	}
+
+	var cmd tea.Cmd
+	*m.snoozePrompt, cmd = m.snoozePrompt.Update(msg)
+	return m, cmd
+}
+
+// handleUpdatesSnoozed shows the saved snooze and matches the build with the fetched listing again,
+// so a snoozed update is held back and an unsnoozed one shows up
+func (m *Model) handleUpdatesSnoozed(msg updatesSnoozedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.err = nil
+	for i := range m.builds {
+		if !m.builds[i].Matches(msg.version, msg.build.Architecture) {
+			continue
+		}
+		if m.builds[i].Installed != nil {
+			m.builds[i].Installed.SnoozedUntil = msg.build.SnoozedUntil
+		} else {
+			m.builds[i].SnoozedUntil = msg.build.SnoozedUntil
+		}
+	}
+	if days := msg.build.SnoozeDaysLeft(time.Now()); days > 0 {
+		m.showNotice(fmt.Sprintf("Updates of Blender %s snoozed for %d days", msg.version, days))
+	} else {
+		m.showNotice(fmt.Sprintf("Updates of Blender %s are shown again", msg.version))
+	}
+	if len(m.fetched) == 0 {
+		return m, nil
+	}
+	return m, m.commands.UpdateBuildStatus(m.fetched)
+}
+
+// renderSnoozePromptFooter renders the snooze prompt in place of the footer
+func (m *Model) renderSnoozePromptFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	hints := strings.Join([]string{
+		fmt.Sprintf("%s Snooze", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+	}, separator)
+
+	return footerStyle.Width(m.terminalWidth).Render(m.snoozePrompt.View() + newlineStyle + hints)
+}
@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/download"
+	"fmt"
+	"path"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// speedTestMenu shows how fast the builder and the mirrors answer, and picks the source downloads start from
+type speedTestMenu struct {
+	sample  string // File requested from every source
+	running bool
+	results []download.SourceSpeed
+	cursor  int
+}
+
+// SpeedTest creates a command to measure the builder and the configured mirrors with a sample file
+func (c *Commands) SpeedTest(sampleURL string) tea.Cmd {
+	mirrors := c.cfg.Mirrors
+	return func() tea.Msg {
+		return speedTestMsg{results: download.SpeedTest(sampleURL, mirrors)}
+	}
+}
+
+// openSpeedTest starts a speed test of the download sources with the file of the selected build,
+// or of the first listed build that can be downloaded
+func (m *Model) openSpeedTest() (tea.Model, tea.Cmd) {
+	if m.offline {
+		m.err = fmt.Errorf("offline: the speed test needs the builder to be reachable (press f to retry)")
+		return m, nil
+	}
+	sample := ""
+	if build, ok := m.selectedBuild(); ok && build.DownloadURL != "" && !build.Shared {
+		sample = build.DownloadURL
+	}
+	for i := 0; sample == "" && i < len(m.builds); i++ {
+		if !m.builds[i].Shared {
+			sample = m.builds[i].DownloadURL
+		}
+	}
+	if sample == "" {
+		m.err = fmt.Errorf("no build to test the download sources with, fetch the build list first (f)")
+		return m, nil
+	}
+
+	m.err = nil
+	m.speedTest = &speedTestMenu{sample: sample, running: true}
+	return m, m.commands.SpeedTest(sample)
+}
+
+// handleSpeedTestDone shows the ranked sources, unless the menu was closed meanwhile
+func (m *Model) handleSpeedTestDone(msg speedTestMsg) (tea.Model, tea.Cmd) {
+	if m.speedTest == nil {
+		return m, nil
+	}
+	m.speedTest.running = false
+	m.speedTest.results = msg.results
+	m.speedTest.cursor = 0
+	return m, nil
+}
+
+// updateSpeedTest handles key events while the speed test menu is open
+func (m *Model) updateSpeedTest(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := m.speedTest
+	switch msg.String() {
+	case "esc", "S", "q":
+		m.speedTest = nil
+
+	case "up", "k":
+		if len(menu.results) > 0 {
+			menu.cursor = (menu.cursor - 1 + len(menu.results)) % len(menu.results)
+		}
+
+	case "down", "j":
+		if len(menu.results) > 0 {
+			menu.cursor = (menu.cursor + 1) % len(menu.results)
+		}
+
+	case "enter":
+		if menu.running || len(menu.results) == 0 {
+			return m, nil
+		}
+		result := menu.results[menu.cursor]
+		if result.Err != nil {
+			m.err = fmt.Errorf("%s failed the speed test: %w", result.Host, result.Err)
+			return m, nil
+		}
+		m.speedTest = nil
+		return m.setDownloadSource(result)
+	}
+	return m, nil
+}
+
+// setDownloadSource makes downloads start from the mirror of a speed test result, or the builder
+func (m *Model) setDownloadSource(result download.SourceSpeed) (tea.Model, tea.Cmd) {
+	m.config.DownloadSource = result.Mirror
+	if err := config.SaveConfig(m.config); err != nil {
+		m.err = fmt.Errorf("failed to save config: %w", err)
+		return m, nil
+	}
+	config.SetConfigInstance(m.config)
+	m.commands.SetConfig(m.config)
+	m.err = nil
+	m.showNotice(fmt.Sprintf("Downloads now start from %s", result.Host))
+	return m, nil
+}
+
+// speedTestLine describes the measurement of a source in the speed test menu
+func (m *Model) speedTestLine(result download.SourceSpeed) string {
+	source := result.Host
+	if result.Mirror == "" {
+		source += " (builder)"
+	}
+	if result.Mirror == m.config.DownloadSource {
+		source += " *"
+	}
+	if result.Err != nil {
+		return fmt.Sprintf("%-40s failed: %v", source, result.Err)
+	}
+	return fmt.Sprintf("%-40s %6d ms %8.1f MB/s", source, result.Latency.Milliseconds(), result.Throughput/1024/1024)
+}
+
+// renderSpeedTest renders the speed test popup
+func (m *Model) renderSpeedTest(availableHeight int) string {
+	menu := m.speedTest
+	titleStyle := lp.NewStyle().Foreground(lp.Color(highlightColor)).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Download sources"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("Downloading the start of %s, * marks the source downloads start from", path.Base(menu.sample)))
+	b.WriteString("\n\n")
+	if menu.running {
+		b.WriteString(fmt.Sprintf("Testing the builder and %d mirror(s)...", len(m.config.Mirrors)))
+	}
+	for i, result := range menu.results {
+		line := m.speedTestLine(result)
+		if i == menu.cursor {
+			b.WriteString(selectedRowStyle.Render(line))
+		} else {
+			b.WriteString(regularRowStyle.Render(line))
+		}
+		if i < len(menu.results)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	box := lp.NewStyle().
+		Border(lp.RoundedBorder()).
+		BorderForeground(lp.Color(highlightColor)).
+		Padding(0, 1).
+		Render(b.String())
+
+	return lp.Place(m.terminalWidth, availableHeight, lp.Center, lp.Top, box)
+}
+
+// renderSpeedTestFooter renders the key hints for the speed test menu
+func (m *Model) renderSpeedTestFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	commands := []string{
+		fmt.Sprintf("%s Start downloads from this source", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Select", keyStyle.Render("↑/↓")),
+		fmt.Sprintf("%s Close", keyStyle.Render("esc")),
+	}
+
+	footerContent := newlineStyle + strings.Join(commands, separator)
+	return footerStyle.Width(m.terminalWidth).Render(footerContent)
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// abbreviatePath shortens a path for display by replacing the home directory with ~
+// and cutting the middle if it is still wider than maxWidth.
+func abbreviatePath(path string, maxWidth int) string {
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		if rel, err := filepath.Rel(home, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = filepath.Join("~", rel)
+		}
+	}
+
+	if maxWidth < 5 {
+		return path
+	}
+	return truncateMiddle(path, maxWidth)
+}
+
+// buildCounts returns how many builds are installed locally and how many come from the online listing
+func (m *Model) buildCounts() (localCount, onlineCount int) {
+	for _, build := range m.builds {
+		switch build.Status {
+		case model.StateLocal:
+			localCount++
+		case model.StateUpdate:
+			localCount++
+			onlineCount++
+		default:
+			onlineCount++
+		}
+	}
+	return localCount, onlineCount
+}
+
+// renderStatusBar renders the one-line summary of the configuration driving the build list.
+// The last error, if any, takes its place until it is cleared; a notice does so for a few seconds.
+func (m *Model) renderStatusBar() string {
+	barStyle := lp.NewStyle().Width(m.terminalWidth).MaxWidth(m.terminalWidth).Foreground(lp.Color(highlightColor))
+
+	if m.err != nil {
+		return barStyle.Foreground(lp.Color(redColor)).Render(truncateText(m.err.Error(), m.terminalWidth))
+	}
+	if m.notice != "" {
+		return barStyle.Foreground(lp.Color(greenColor)).Render(truncateText(m.notice, m.terminalWidth))
+	}
+
+	filter := m.versionFilter()
+	if filter == "" {
+		filter = "none"
+	}
+	localCount, onlineCount := m.buildCounts()
+
+	parts := []string{
+		"Type: " + m.config.BuildType,
+		"Filter: " + filter,
+		fmt.Sprintf("%d local / %d online", localCount, onlineCount),
+	}
+	if m.config.TagFilter != "" {
+		parts = append(parts, "Tag: "+m.config.TagFilter)
+	}
+	if m.newOnly {
+		parts = append(parts, "New only")
+	}
+	if m.scanProgress != nil {
+		parts = append(parts, fmt.Sprintf("Scanning %d/%d", m.scanProgress.Scanned, m.scanProgress.Total))
+	}
+	if m.config.Metered {
+		parts = append(parts, "Metered")
+	}
+	if m.lowBandwidth {
+		parts = append(parts, "Low bandwidth")
+	}
+	if m.config.DownloadBudget() > 0 {
+		parts = append(parts, "Budget: "+m.budgetUsage())
+	}
+	if m.plaintextSecrets {
+		parts = append(parts, "No keyring: secrets stored in plain text")
+	}
+
+	// Give the download directory whatever width the other parts leave
+	fixed := textWidth(strings.Join(parts, " · ")) + textWidth(" · Dir: ")
+	parts = append([]string{"Dir: " + abbreviatePath(m.config.DownloadDir, m.terminalWidth-fixed)}, parts...)
+
+	return barStyle.Render(strings.Join(parts, " · "))
+}
@@ -1,18 +1,28 @@
 package tui
 
 import (
+	"TUI-Blender-Launcher/api"
+	"TUI-Blender-Launcher/config"
 	"TUI-Blender-Launcher/model"
 	"fmt"
 	"strings"
+	"time"
 
 	lp "github.com/charmbracelet/lipgloss"
 )
 
 // Row represents a single row in the builds table
 type Row struct {
-	Build      model.BlenderBuild
-	IsSelected bool
-	Status     *model.DownloadState
+	Build         model.BlenderBuild
+	IsSelected    bool
+	Status        *model.DownloadState
+	ScheduledAt   time.Time // Time of a scheduled download, zero if none
+	ProgressStyle string    // How download progress is drawn, see renderProgress
+	New           bool      // Appeared since the previous fetch, see Model.newBuilds
+	LaunchPending bool      // Launched as soon as its download finishes, see Model.pendingLaunches
+	RequiredGlibc string    // glibc version the build needs when this machine's is older, see Model.systemGlibc
+	Highlight     highlight // The status of the build changed moments ago, see Model.statusHighlight
+	Custom        []string  // Values of the custom columns, see Model.customColumns
 }
 
 // NewRow creates a new row instance from a build
@@ -42,8 +52,18 @@ var (
 		"Hash":       {width: 0, priority: 6, flex: 1.0},
 		"Size":       {width: 0, priority: 7, flex: 1.0},
 		"Build Date": {width: 0, priority: 3, flex: 1.0},
+		"Source":     {width: 0, priority: 8, flex: 1.0},
+		"PR":         {width: 0, priority: 9, flex: 1.0},
+		"Verified":   {width: 0, priority: 10, flex: 1.0},
+		"Label":      {width: 0, priority: 11, flex: 1.0},
+		"Tags":       {width: 0, priority: 12, flex: 1.0},
+		"Promotion":  {width: 0, priority: 13, flex: 1.0},
+		"GPU":        {width: 0, priority: 14, flex: 1.0},
 	}
 
+	// Custom columns come after the built-in ones and aren't shown in compact mode
+	customColumnConfig = columnConfig{width: 0, priority: 15, flex: 1.0}
+
 	selectedHeaderCellStyle = lp.NewStyle().
 				Background(lp.Color(backgroundColor)).
 				Foreground(lp.Color(textColor)).
@@ -51,6 +71,14 @@ var (
 				Align(lp.Center)
 )
 
+// versionCell shows the version of a build, with the architecture when it isn't the native one
+func versionCell(build model.BlenderBuild) string {
+	if build.Architecture != "" && build.Architecture != api.NativeArch() {
+		return build.Version + " (" + build.Architecture + ")"
+	}
+	return build.Version
+}
+
 // Render renders a single row with the given column configuration
 func (r Row) Render(columns []ColumnConfig) string {
 	var cells []string
@@ -71,12 +99,17 @@ func (r Row) Render(columns []ColumnConfig) string {
 
 			switch col.Key {
 			case "Version":
-				cellContent = r.Build.Version
+				cellContent = versionCell(r.Build)
+				if r.LaunchPending {
+					cellContent += " LAUNCH"
+				}
 			case "Status":
-				if isDownloading {
-					cellContent = model.StateDownloading.String()
-				} else if isExtracting {
-					cellContent = model.StateExtracting.String()
+				if isDownloading && r.Status.Paused {
+					cellContent = "Paused"
+				} else if isDownloading && r.Status.Queued {
+					cellContent = "Queued"
+				} else {
+					cellContent = phaseLabel(*r.Status)
 				}
 			case "Branch":
 				// Show download speed in Branch column when downloading
@@ -91,11 +124,14 @@ func (r Row) Render(columns []ColumnConfig) string {
 						// For very high speeds, don't show decimal places
 						cellContent = fmt.Sprintf("%6.0f MB/s", speedMBps)
 					}
-				} else if isExtracting {
+				} else if isExtracting && r.Status.Total > 0 {
 					// Show percentage in Branch column for extraction with consistent formatting
 					cellContent = fmt.Sprintf("%6.1f%%", r.Status.Progress*100)
+				} else if isExtracting && !r.Status.PhaseStart.IsZero() {
+					// Phases without progress, like the backup, show how long they run
+					cellContent = fmt.Sprintf("%6.0fs", time.Since(r.Status.PhaseStart).Seconds())
 				}
-			case "Type", "Hash", "Size", "Build Date":
+			case "Type", "Hash", "Size", "Build Date", "Source", "PR", "Verified", "Label", "Tags", "Promotion", "GPU":
 				// These columns will be replaced by progress bar
 				cellContent = ""
 			}
@@ -108,9 +144,27 @@ func (r Row) Render(columns []ColumnConfig) string {
 			var cellContent string
 			switch col.Key {
 			case "Version":
-				cellContent = r.Build.Version
+				cellContent = versionCell(r.Build)
+				if r.New {
+					cellContent += " NEW"
+				}
 			case "Status":
 				cellContent = r.Build.Status.String()
+				if r.Build.Shared {
+					cellContent = "Shared"
+				}
+				if days := r.Build.SnoozeDaysLeft(time.Now()); days > 0 && r.Build.Status == model.StateLocal {
+					cellContent = fmt.Sprintf("Snoozed %dd", days)
+				}
+				if r.RequiredGlibc != "" && (isOnline || r.Build.Status == model.StateLocal) {
+					cellContent = "⚠ glibc " + r.RequiredGlibc
+				}
+				if r.Build.Status == model.StateLocal && r.Build.Broken() {
+					cellContent = "Broken"
+				}
+				if !r.ScheduledAt.IsZero() {
+					cellContent = "Scheduled " + formatScheduleTime(r.ScheduledAt)
+				}
 			case "Branch":
 				cellContent = r.Build.Branch
 			case "Type":
@@ -121,6 +175,27 @@ func (r Row) Render(columns []ColumnConfig) string {
 				cellContent = model.FormatByteSize(r.Build.Size)
 			case "Build Date":
 				cellContent = model.FormatBuildDate(r.Build.BuildDate)
+			case "Source":
+				cellContent = r.Build.SourceLabel()
+			case "PR":
+				if pr := r.Build.PullRequest(); pr != "" {
+					cellContent = "#" + pr
+				}
+			case "Verified":
+				cellContent = verificationBadge(r.Build)
+			case "Label":
+				cellContent = r.Build.Label
+			case "Tags":
+				cellContent = tagChips(r.Build.Tags)
+			case "Promotion":
+				cellContent = promotionBadge(r.Build)
+			case "GPU":
+				backends, _ := r.Build.SupportedGPUBackends()
+				cellContent = strings.Join(backends, " ")
+			default:
+				if i := col.Index - firstCustomColumn; i >= 0 && i < len(r.Custom) {
+					cellContent = r.Custom[i]
+				}
 			}
 			cells = append(cells, col.Style(cellContent))
 		}
@@ -153,58 +228,42 @@ func (r Row) Render(columns []ColumnConfig) string {
 				progressBarWidth += columns[i].Width
 			}
 
-			// Create a progress bar
-			progress := r.Status.Progress
-			if progress < 0 {
-				progress = 0
-			}
-			if progress > 1 {
-				progress = 1
-			}
-
-			// Create progress bar visual
-			completedWidth := int(float64(progressBarWidth) * progress)
-			if completedWidth > progressBarWidth {
-				completedWidth = progressBarWidth
-			}
-
-			remainingWidth := progressBarWidth - completedWidth
-
-			// Create the progress bar with orange color for the completed portion
-			progressBar := ""
-			if completedWidth > 0 {
-				progressBar += lp.NewStyle().
-					Background(lp.Color(highlightColor)).
-					Foreground(lp.Color(textColor)).
-					Width(completedWidth).
-					Render("")
-			}
-
-			if remainingWidth > 0 {
-				progressBar += lp.NewStyle().
-					Background(lp.Color(backgroundColor)).
-					Width(remainingWidth).
-					Render("")
-			}
+			progressBar := renderProgress(r.ProgressStyle, r.Status.Progress, progressBarWidth)
 
 			// Create a new row string with the progress bar inserted at the Type column
-			if typePosition < len(rowString) {
+			if typePosition < textWidth(rowString) {
 				// Replace from Type column onward with progress bar
-				rowString = rowString[:typePosition] + progressBar
+				rowString = cutText(rowString, typePosition) + progressBar
 			}
 		}
 	}
 
+	// A row whose status just changed blinks reversed, also when selected, then stays underlined
+	if r.Highlight == highlightFlash {
+		return lp.NewStyle().Reverse(true).Width(sumColumnWidths(columns)).Render(rowString)
+	}
+	underline := r.Highlight == highlightRecent
+
 	// Apply appropriate style consistently across the entire row
 	if r.IsSelected {
 		// Use selected style with explicit width to ensure alignment
-		return selectedRowStyle.Width(sumColumnWidths(columns)).Render(rowString)
+		return selectedRowStyle.Underline(underline).Width(sumColumnWidths(columns)).Render(rowString)
 	}
 
-	// Apply red text style for failed downloads
-	if isFailed || isCancelled {
+	// Apply red text style for failed downloads and builds that don't start
+	if isFailed || isCancelled || (r.Build.Status == model.StateLocal && r.Build.Broken()) {
 		return lp.NewStyle().
 			Foreground(lp.Color(redColor)).
+			Underline(underline).
+			Width(sumColumnWidths(columns)).
+			Render(rowString)
+	}
+
+	// Apply cyan text style for builds new since the last fetch
+	if isOnline && r.New {
+		return lp.NewStyle().
+			Foreground(lp.Color(newColor)).
+			Underline(underline).
 			Width(sumColumnWidths(columns)).
 			Render(rowString)
 	}
@@ -213,6 +272,7 @@ func (r Row) Render(columns []ColumnConfig) string {
 	if isOnline {
 		return lp.NewStyle().
 			Foreground(lp.Color(orangeColor)).
+			Underline(underline).
 			Width(sumColumnWidths(columns)).
 			Render(rowString)
 	}
@@ -221,12 +281,42 @@ func (r Row) Render(columns []ColumnConfig) string {
 	if isUpdate {
 		return lp.NewStyle().
 			Foreground(lp.Color(greenColor)).
+			Underline(underline).
 			Width(sumColumnWidths(columns)).
 			Render(rowString)
 	}
 
 	// Use regular style with explicit width to ensure alignment
-	return regularRowStyle.Width(sumColumnWidths(columns)).Render(rowString)
+	return regularRowStyle.Underline(underline).Width(sumColumnWidths(columns)).Render(rowString)
+}
+
+// verificationBadge shows the verification state of an installed build
+func verificationBadge(build model.BlenderBuild) string {
+	if build.Status == model.StateUpdate && build.Installed != nil {
+		build = *build.Installed
+	} else if build.Status != model.StateLocal {
+		return ""
+	}
+	switch build.Verification {
+	case model.VerificationVerified:
+		return "✓"
+	case model.VerificationNoChecksum:
+		return "no checksum"
+	case model.VerificationFailed:
+		return "✗ changed"
+	default:
+		// Installed before verification existed
+		return "⚠ unverified"
+	}
+}
+
+// formatScheduleTime shows the clock time for today and the date as well otherwise
+func formatScheduleTime(t time.Time) string {
+	now := time.Now()
+	if t.Year() == now.Year() && t.YearDay() == now.YearDay() {
+		return t.Format("15:04")
+	}
+	return t.Format("01-02 15:04")
 }
 
 // Helper function to calculate the sum of all column widths
@@ -247,44 +337,105 @@ type ColumnConfig struct {
 	Style func(string) string
 }
 
-// Updated GetBuildColumns to accept terminalWidth and compute widths
-func GetBuildColumns(terminalWidth int) []ColumnConfig {
-	var cellStyleCenter = lp.NewStyle().Align(lp.Center)
-	columns := []ColumnConfig{
-		{Name: "Version", Key: "Version", Index: 0},
-		{Name: "Status", Key: "Status", Index: 1},
-		{Name: "Branch", Key: "Branch", Index: 2},
-		{Name: "Type", Key: "Type", Index: 3},
-		{Name: "Hash", Key: "Hash", Index: 4},
-		{Name: "Size", Key: "Size", Index: 5},
-		{Name: "Build Date", Key: "Build Date", Index: 6},
+// builtinColumns are the columns of the build list in order, their index is their sort column
+var builtinColumns = [...]ColumnConfig{
+	{Name: "Version", Key: "Version", Index: 0},
+	{Name: "Status", Key: "Status", Index: 1},
+	{Name: "Branch", Key: "Branch", Index: 2},
+	{Name: "Type", Key: "Type", Index: 3},
+	{Name: "Hash", Key: "Hash", Index: 4},
+	{Name: "Size", Key: "Size", Index: 5},
+	{Name: "Build Date", Key: "Build Date", Index: 6},
+	{Name: "Source", Key: "Source", Index: 7},
+	{Name: "PR", Key: "PR", Index: 8},
+	{Name: "Verified", Key: "Verified", Index: 9},
+	{Name: "Label", Key: "Label", Index: 10},
+	{Name: "Tags", Key: "Tags", Index: 11},
+	{Name: "Promotion", Key: "Promotion", Index: 12},
+	{Name: "GPU", Key: "GPU", Index: 13},
+}
+
+// compactMaxPriority is the lowest column priority still shown in compact mode
+const compactMaxPriority = 6
+
+// columnConfigOf returns the configuration of a built-in or custom column
+func columnConfigOf(col ColumnConfig) columnConfig {
+	if col.Index >= firstCustomColumn {
+		return customColumnConfig
+	}
+	return columnConfigs[col.Key]
+}
+
+// Updated GetBuildColumns to accept terminalWidth and compute widths.
+// The custom columns follow the built-in ones. In compact mode only columns up to compactMaxPriority are kept.
+func GetBuildColumns(terminalWidth int, compact bool, custom []config.CustomColumn) []ColumnConfig {
+	columns := append([]ColumnConfig(nil), builtinColumns[:]...)
+	for i, column := range custom {
+		columns = append(columns, ColumnConfig{Name: column.Name, Index: firstCustomColumn + i})
+	}
+	if compact {
+		kept := columns[:0]
+		for _, col := range columns {
+			if columnConfigOf(col).priority <= compactMaxPriority {
+				kept = append(kept, col)
+			}
+		}
+		columns = kept
 	}
 	// Compute total flex for all columns
 	totalFlex := 0.0
 	for i := range columns {
-		totalFlex += columnConfigs[columns[i].Key].flex
+		totalFlex += columnConfigOf(columns[i]).flex
 	}
 	// Assign each column a width proportional to its flex value
 	for i := range columns {
-		flex := columnConfigs[columns[i].Key].flex
+		flex := columnConfigOf(columns[i]).flex
 		colWidth := int((float64(terminalWidth) * flex) / totalFlex)
 		columns[i].Width = colWidth
 		columns[i].Style = func(width int) func(string) string {
 			return func(s string) string {
-				return cellStyleCenter.Width(width).Render(s)
+				// Cut long values instead of wrapping them onto a second line
+				return padText(s, width, lp.Center)
 			}
 		}(colWidth)
 	}
 	return columns
 }
 
+// downloadStateFor returns the download state to display for a build, if any
+func (m *Model) downloadStateFor(build model.BlenderBuild) *model.DownloadState {
+	buildID := downloadID(build)
+
+	// Check if this is a downloading or extracting build
+	if build.Status == model.StateDownloading || build.Status == model.StateExtracting {
+		// Check in current model's download states
+		if state, exists := m.downloadStates[buildID]; exists {
+			// Always update last render state for downloads - but don't check for changes
+			// to avoid skipping download renderings
+			m.lastRenderState[buildID] = state.Progress
+			return state
+		}
+		return nil
+	}
+
+	// Fallback to checking in commands downloads manager
+	if m.commands != nil && m.commands.downloads != nil {
+		return m.commands.downloads.GetState(buildID)
+	}
+	return nil
+}
+
 // Update RenderRows to pass terminalWidth and respect visibleRowsCount
 func RenderRows(m *Model, visibleRowsCount int) string {
+	if m.grouped {
+		return renderGroupedRows(m, visibleRowsCount)
+	}
+
 	var output strings.Builder
 	newlineStyle := lp.NewStyle().Render("\n")
 
 	// Get column configuration with computed widths
-	columns := GetBuildColumns(m.terminalWidth)
+	columns := GetBuildColumns(m.terminalWidth, m.compact(), m.config.CustomColumns)
 
 	// Calculate visible range
 	endIndex := m.startIndex + visibleRowsCount
@@ -294,43 +445,31 @@ func RenderRows(m *Model, visibleRowsCount int) string {
 
 	// Map to track which build IDs we've processed in this render pass
 	processedBuilds := make(map[string]bool)
+	now := time.Now()
 
 	// Only render rows in the visible range
 	for i := m.startIndex; i < endIndex; i++ {
 		build := m.builds[i]
 
 		// Create a buildID to check for download state
-		buildID := build.Version
-		if build.Hash != "" {
-			buildID = build.Version + "-" + build.Hash[:8]
-		}
+		buildID := downloadID(build)
 
 		// Track that we're processing this build
 		processedBuilds[buildID] = true
 
 		// Get download state if exists
-		var downloadState *model.DownloadState = nil
-
-		// Check if this is a downloading or extracting build
-		if build.Status == model.StateDownloading || build.Status == model.StateExtracting {
-			// Check in current model's download states
-			if state, exists := m.downloadStates[buildID]; exists {
-				downloadState = state
-
-				// Always update last render state for downloads - but don't check for changes
-				// to avoid skipping download renderings
-				m.lastRenderState[buildID] = state.Progress
-			}
-		} else {
-			// Fallback to checking in commands downloads manager
-			if m.commands != nil && m.commands.downloads != nil {
-				downloadState = m.commands.downloads.GetState(buildID)
-			}
-		}
+		downloadState := m.downloadStateFor(build)
 
 		// Always render downloading/extracting rows, never skip them
 		// Create and render row; highlight if this is the current row
 		row := NewRow(build, i == m.cursor, downloadState)
+		row.ScheduledAt = m.scheduledAt(build)
+		row.ProgressStyle = m.progressStyle()
+		row.New = m.isNew(build)
+		row.LaunchPending = m.launchPending(build)
+		row.RequiredGlibc = build.GlibcRequirement(m.systemGlibc)
+		row.Highlight = m.statusHighlight(build, now)
+		row.Custom = m.customColumnValues(build)
 		rowText := row.Render(columns)
 
 		// Ensure each row has proper width
@@ -358,6 +497,9 @@ func (m *Model) renderBuildContent(availableHeight int) string {
 	if len(m.builds) == 0 {
 		// No builds to display
 		var msg string = "No Blender builds found locally or online."
+		if m.scanProgress != nil {
+			msg = fmt.Sprintf("Scanning local builds… %d/%d", m.scanProgress.Scanned, m.scanProgress.Total)
+		}
 
 		return lp.Place(
 			m.terminalWidth,
@@ -369,7 +511,7 @@ func (m *Model) renderBuildContent(availableHeight int) string {
 	}
 
 	// Get column configuration with computed widths
-	columns := GetBuildColumns(m.terminalWidth)
+	columns := GetBuildColumns(m.terminalWidth, m.compact(), m.config.CustomColumns)
 
 	// Build table header row first (without styling yet)
 	var headerCells []string
@@ -382,6 +524,7 @@ func (m *Model) renderBuildContent(availableHeight int) string {
 				headerText += " ↑"
 			}
 		}
+		headerText = truncateText(headerText, col.Width)
 		if col.Index == m.sortColumn {
 			headerCells = append(headerCells, selectedHeaderCellStyle.Width(col.Width).Render(headerText))
 		} else {
@@ -419,7 +562,7 @@ func (m *Model) renderBuildContent(availableHeight int) string {
 
 // updateSortColumn handles lateral key events for sorting columns.
 // It updates the Model's sortColumn value based on the key pressed.
-// Allowed values range from 0 (Version) to 6 (Build Date).
+// Allowed values range from 0 (Version) to the last custom column.
 func (m *Model) updateSortColumn(key string) {
 	switch key {
 	case "left":
@@ -427,8 +570,8 @@ func (m *Model) updateSortColumn(key string) {
 			m.sortColumn--
 		}
 	case "right":
-		// Use columnConfigs map to determine total column count
-		if m.sortColumn < len(columnConfigs)-1 {
+		// Use columnConfigs map and the custom columns to determine total column count
+		if m.sortColumn < len(columnConfigs)-1+len(m.config.CustomColumns) {
 			m.sortColumn++
 		}
 	}
@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/config"
+	"TUI-Blender-Launcher/local"
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+// tagColors are the chip colors; a tag always gets the same one
+var tagColors = []string{"39", "42", "170", "208", "214", "99", "203", "37"}
+
+// tagChip renders a tag as a colored chip
+func tagChip(tag string) string {
+	h := fnv.New32a()
+	h.Write([]byte(tag))
+	color := tagColors[h.Sum32()%uint32(len(tagColors))]
+	return lp.NewStyle().Foreground(lp.Color("0")).Background(lp.Color(color)).Render(tag)
+}
+
+// tagChips renders the tags of a build as chips separated by spaces
+func tagChips(tags []string) string {
+	chips := make([]string, len(tags))
+	for i, tag := range tags {
+		chips[i] = tagChip(tag)
+	}
+	return strings.Join(chips, " ")
+}
+
+// knownTags returns every tag used by a build in the list, sorted
+func (m *Model) knownTags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, build := range m.builds {
+		for _, tag := range build.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// TagBuild creates a command to save the tags of a local build
+func (c *Commands) TagBuild(version, arch string, tags []string) tea.Cmd {
+	downloadDir := c.cfg.DownloadDir
+	return func() tea.Msg {
+		dirPath, err := local.FindBuildDir(downloadDir, version, arch)
+		if err != nil {
+			return buildTaggedMsg{version: version, err: err}
+		}
+		if dirPath == "" {
+			return buildTaggedMsg{version: version, err: fmt.Errorf("build directory for Blender version %s not found", version)}
+		}
+		build, err := local.SetBuildTags(dirPath, tags)
+		if err != nil {
+			return buildTaggedMsg{version: version, err: fmt.Errorf("failed to save tags: %w", err)}
+		}
+		return buildTaggedMsg{version: version, build: build}
+	}
+}
+
+// openTagPrompt shows the inline tag editor for the selected local build
+func (m *Model) openTagPrompt() (tea.Model, tea.Cmd) {
+	build, ok := m.selectedBuild()
+	if !ok || (build.Status != model.StateLocal && build.Status != model.StateUpdate) || m.rejectShared(build) {
+		return m, nil
+	}
+
+	input := textinput.New()
+	input.Prompt = fmt.Sprintf("Tags for %s: ", build.Version)
+	input.Placeholder = "comma-separated, e.g. production, gpu-bug"
+	input.CharLimit = 120
+	input.Width = 40
+	input.SetValue(strings.Join(build.Tags, ", "))
+	input.CursorEnd()
+	input.Focus()
+
+	m.tagPrompt = &input
+	return m, textinput.Blink
+}
+
+// updateTagPrompt handles key events while the tag editor is open
+func (m *Model) updateTagPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.tagPrompt = nil
+		return m, nil
+
+	case "enter":
+		tags := model.ParseTags(m.tagPrompt.Value())
+		m.tagPrompt = nil
+		build, ok := m.selectedBuild()
+		if !ok {
+			return m, nil
+		}
+		if !slices.Equal(tags, build.Tags) {
+			m.pushUndo(fmt.Sprintf("tags of Blender %s", build.Version), m.commands.TagBuild(build.Version, build.Architecture, build.Tags))
+		}
+		return m, m.commands.TagBuild(build.Version, build.Architecture, tags)
+	}
+
+	var cmd tea.Cmd
+	*m.tagPrompt, cmd = m.tagPrompt.Update(msg)
+	return m, cmd
+}
+
+// handleBuildTagged shows the saved tags on every row of the build
+func (m *Model) handleBuildTagged(msg buildTaggedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.err = nil
+	for i := range m.builds {
+		if !m.builds[i].Matches(msg.version, msg.build.Architecture) {
+			continue
+		}
+		m.builds[i].Tags = msg.build.Tags
+		if m.builds[i].Installed != nil {
+			m.builds[i].Installed.Tags = msg.build.Tags
+		}
+	}
+	if len(msg.build.Tags) == 0 {
+		m.showNotice(fmt.Sprintf("Removed the tags of Blender %s", msg.version))
+	} else {
+		m.showNotice(fmt.Sprintf("Tagged Blender %s: %s", msg.version, strings.Join(msg.build.Tags, ", ")))
+	}
+	return m, nil
+}
+
+// openTagFilterPrompt shows the inline tag filter prompt
+func (m *Model) openTagFilterPrompt() (tea.Model, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = "Tag filter: "
+	input.Placeholder = "tag (empty for none)"
+	input.CharLimit = 40
+	input.Width = 30
+	input.SetValue(m.config.TagFilter)
+	input.CursorEnd()
+	input.Focus()
+
+	m.tagFilterPrompt = &input
+	return m, textinput.Blink
+}
+
+// updateTagFilterPrompt handles key events while the tag filter prompt is open
+func (m *Model) updateTagFilterPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.tagFilterPrompt = nil
+		return m, nil
+
+	case "enter":
+		var value string
+		if tags := model.ParseTags(m.tagFilterPrompt.Value()); len(tags) > 0 {
+			value = tags[0]
+		}
+		m.tagFilterPrompt = nil
+		m.config.TagFilter = value
+		if err := config.SaveConfig(m.config); err != nil {
+			m.err = fmt.Errorf("failed to save config: %w", err)
+			return m, nil
+		}
+		m.err = nil
+
+		// Builds hidden by the previous filter come back with the refetch of the config change
+		return m, m.applyConfig(m.config)
+	}
+
+	var cmd tea.Cmd
+	*m.tagFilterPrompt, cmd = m.tagFilterPrompt.Update(msg)
+	return m, cmd
+}
+
+// applyTagFilter keeps only builds carrying the tag of the tag filter
+func (m *Model) applyTagFilter(builds []model.BlenderBuild) []model.BlenderBuild {
+	if m.config.TagFilter == "" {
+		return builds
+	}
+
+	filtered := make([]model.BlenderBuild, 0)
+	for _, build := range builds {
+		if build.HasTag(m.config.TagFilter) {
+			filtered = append(filtered, build)
+		}
+	}
+	return filtered
+}
+
+// renderTagPromptFooter renders the tag editor in place of the footer, with the tags already in use
+func (m *Model) renderTagPromptFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	hints := []string{
+		fmt.Sprintf("%s Save tags", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+	}
+	if known := m.knownTags(); len(known) > 0 {
+		hints = append(hints, "In use: "+tagChips(known))
+	}
+
+	return footerStyle.Width(m.terminalWidth).Render(m.tagPrompt.View() + newlineStyle + strings.Join(hints, separator))
+}
+
+// renderTagFilterPromptFooter renders the tag filter prompt in place of the footer
+func (m *Model) renderTagFilterPromptFooter() string {
+	keyStyle := lp.NewStyle().Foreground(lp.Color(highlightColor))
+	separator := lp.NewStyle().Render(" · ")
+	newlineStyle := lp.NewStyle().Render("\n")
+
+	hints := []string{
+		fmt.Sprintf("%s Apply", keyStyle.Render("enter")),
+		fmt.Sprintf("%s Cancel", keyStyle.Render("esc")),
+	}
+	if known := m.knownTags(); len(known) > 0 {
+		hints = append(hints, "In use: "+tagChips(known))
+	}
+
+	return footerStyle.Width(m.terminalWidth).Render(m.tagFilterPrompt.View() + newlineStyle + strings.Join(hints, separator))
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"strings"
+
+	lp "github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// The text helpers measure in terminal cells instead of bytes or runes: CJK characters and most
+// emoji take two cells, and ANSI styling takes none. Every cell, label and path that is cut or
+// padded to a width goes through them, so wide characters don't break the column alignment.
+
+// ellipsis marks text that was cut
+const ellipsis = "…"
+
+// textWidth returns the number of terminal cells s takes
+func textWidth(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// truncateText cuts s to at most width cells, ending it with an ellipsis when something was cut.
+// A wide character that would straddle the limit is dropped as a whole.
+func truncateText(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return ansi.Truncate(s, width, ellipsis)
+}
+
+// truncateMiddle cuts the middle of s to fit in width cells, keeping both ends, e.g. of a path
+func truncateMiddle(s string, width int) string {
+	total := textWidth(s)
+	if total <= width {
+		return s
+	}
+	if width < 5 {
+		return truncateText(s, width)
+	}
+	keep := (width - 1) / 2
+	return ansi.Cut(s, 0, keep) + ellipsis + ansi.TruncateLeft(s, total-(width-1-keep), "")
+}
+
+// padText cuts s to width cells and fills it up with spaces to exactly width cells,
+// aligned left, right or centered
+func padText(s string, width int, align lp.Position) string {
+	s = truncateText(s, width)
+	gap := width - textWidth(s)
+	if gap <= 0 {
+		return s
+	}
+	left := 0
+	switch align {
+	case lp.Center:
+		left = gap / 2
+	case lp.Right:
+		left = gap
+	}
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", gap-left)
+}
+
+// fillText pads s with spaces to at least width cells, like %-*s does for ASCII text.
+// Wider text is kept whole.
+func fillText(s string, width int) string {
+	if gap := width - textWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// cutText returns the first width cells of s, keeping its styling, without an ellipsis
+func cutText(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return ansi.Truncate(s, width, "")
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"testing"
+
+	lp "github.com/charmbracelet/lipgloss"
+)
+
+func TestTextWidth(t *testing.T) {
+	tests := map[string]int{
+		"4.3.0":                5,
+		"日本語":                  6,
+		"✓":                    1,
+		"🚀":                    2,
+		"👩‍💻":                  2, // Joined into a single emoji
+		"\x1b[31mrouge\x1b[0m": 5,
+	}
+	for s, want := range tests {
+		if got := textWidth(s); got != want {
+			t.Errorf("textWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"daily", 10, "daily"},
+		{"experimental", 6, "exper…"},
+		{"テスト版ラベル", 7, "テスト…"},
+		{"テスト版ラベル", 6, "テス…"}, // テ, ス and … take 5 cells, the next character doesn't fit
+		{"🚀 launch", 4, "🚀 …"},
+		{"anything", 0, ""},
+	}
+	for _, tt := range tests {
+		got := truncateText(tt.s, tt.width)
+		if got != tt.want {
+			t.Errorf("truncateText(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+		if textWidth(got) > tt.width {
+			t.Errorf("truncateText(%q, %d) takes %d cells", tt.s, tt.width, textWidth(got))
+		}
+	}
+}
+
+func TestPadText(t *testing.T) {
+	for _, s := range []string{"4.3.0", "ラベル", "承認済み🚀", "a very long label that is cut", "✓"} {
+		for _, align := range []lp.Position{lp.Left, lp.Center, lp.Right} {
+			for _, width := range []int{1, 4, 9, 12} {
+				if got := padText(s, width, align); textWidth(got) != width {
+					t.Errorf("padText(%q, %d, %v) = %q takes %d cells", s, width, align, got, textWidth(got))
+				}
+			}
+		}
+	}
+	if got := padText("日本", 8, lp.Center); got != "  日本  " {
+		t.Errorf("Expected centered text, got %q", got)
+	}
+}
+
+func TestTruncateMiddle(t *testing.T) {
+	if got := truncateMiddle("~/blender/builds", 40); got != "~/blender/builds" {
+		t.Errorf("Expected a short path to be kept, got %q", got)
+	}
+	got := truncateMiddle("~/ブレンダー/ビルド/毎日", 12)
+	if textWidth(got) > 12 {
+		t.Errorf("truncateMiddle takes %d cells: %q", textWidth(got), got)
+	}
+	if got[:2] != "~/" || got[len(got)-len("毎日"):] != "毎日" {
+		t.Errorf("Expected both ends to be kept, got %q", got)
+	}
+}
+
+func TestRowRenderWideCells(t *testing.T) {
+	columns := GetBuildColumns(140, false, nil)
+	for _, label := range []string{"", "テスト版", "🎬 final render", "承認済みビルドのラベル"} {
+		build := model.BlenderBuild{Version: "4.3.0", Branch: "ブランチ", Status: model.StateLocal, Label: label}
+		if w := textWidth(NewRow(build, false, nil).Render(columns)); w != sumColumnWidths(columns) {
+			t.Errorf("Row labelled %q takes %d cells, want %d", label, w, sumColumnWidths(columns))
+		}
+	}
+
+	// The progress bar replaces the cells after the branch, which may hold wide characters
+	build := model.BlenderBuild{Version: "4.3.0", Branch: "ブランチ", Status: model.StateDownloading}
+	row := NewRow(build, false, &model.DownloadState{Progress: 0.5})
+	if w := textWidth(row.Render(columns)); w != sumColumnWidths(columns) {
+		t.Errorf("Downloading row takes %d cells, want %d", w, sumColumnWidths(columns))
+	}
+}
+
+func TestFillText(t *testing.T) {
+	if got := fillText("日本", 6); got != "日本  " {
+		t.Errorf("Expected padding by cells, got %q", got)
+	}
+	if got := fillText("a long branch name", 6); got != "a long branch name" {
+		t.Errorf("Expected wider text to be kept, got %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// activeTickInterval refreshes the list while downloads or extractions run
+	activeTickInterval = 250 * time.Millisecond
+	// idleTickInterval is the longest wait between ticks while nothing runs, to notice config file changes
+	idleTickInterval = 5 * time.Second
+)
+
+// scheduleTick returns a command delivering a tick after d, nil when a tick is already due by then.
+// Ticks aren't repeated on their own: each one schedules the next, see nextTick, so a single chain runs.
+func (m *Model) scheduleTick(d time.Duration) tea.Cmd {
+	at := time.Now().Add(d)
+	if !m.tickAt.IsZero() && !m.tickAt.After(at) {
+		return nil
+	}
+	m.tickAt = at
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// takeTick reports whether a tick is the one scheduled last. Ticks replaced by an earlier one
+// still arrive and are dropped.
+func (m *Model) takeTick(msg tickMsg) bool {
+	if time.Time(msg).Before(m.tickAt) {
+		return false
+	}
+	m.tickAt = time.Time{}
+	return true
+}
+
+// transfersRunning reports whether a download or extraction is in progress. Paused downloads wait for a key press.
+func (m *Model) transfersRunning() bool {
+	for _, state := range m.downloadStates {
+		if state.BuildState == model.StateExtracting || (state.BuildState == model.StateDownloading && !state.Paused) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextTick schedules the tick following the current one: after activeInterval while transfers run, otherwise when
+// the notice expires, a highlighted row changes, the next scheduled download is due, or after idleTickInterval
+func (m *Model) nextTick() tea.Cmd {
+	if m.transfersRunning() {
+		return m.scheduleTick(m.activeInterval())
+	}
+
+	now := time.Now()
+	wake := now.Add(idleTickInterval)
+	if m.notice != "" && m.noticeUntil.Before(wake) {
+		wake = m.noticeUntil
+	}
+	if m.schedule != nil {
+		for _, entry := range m.schedule.Entries {
+			if entry.At.Before(wake) {
+				wake = entry.At
+			}
+		}
+	}
+	if at, ok := m.nextHighlightChange(now); ok && at.Before(wake) {
+		wake = at
+	}
+	d := wake.Sub(now)
+	if d < activeTickInterval {
+		d = activeTickInterval
+	}
+	return m.scheduleTick(d)
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"testing"
+	"time"
+)
+
+func TestScheduleTick(t *testing.T) {
+	m := &Model{}
+	if m.scheduleTick(idleTickInterval) == nil {
+		t.Fatal("Expected a tick to be scheduled")
+	}
+	if m.scheduleTick(2*idleTickInterval) != nil {
+		t.Error("Expected no second tick after the one already due")
+	}
+	if m.scheduleTick(10*time.Millisecond) == nil {
+		t.Fatal("Expected an earlier tick to replace the scheduled one")
+	}
+
+	// The replaced tick arrives first only if the clock jumped; it is dropped all the same
+	if m.takeTick(tickMsg(time.Now())) {
+		t.Error("Expected a tick before the scheduled time to be dropped")
+	}
+	if !m.takeTick(tickMsg(time.Now().Add(time.Second))) || !m.tickAt.IsZero() {
+		t.Error("Expected the scheduled tick to be taken")
+	}
+}
+
+func TestNextTick(t *testing.T) {
+	m := &Model{downloadStates: map[string]*model.DownloadState{}}
+	m.nextTick()
+	if d := time.Until(m.tickAt); d < idleTickInterval-time.Second {
+		t.Errorf("Expected an idle tick in about %v, got %v", idleTickInterval, d)
+	}
+
+	// A notice that expires earlier wakes the list to clear it
+	m.tickAt = time.Time{}
+	m.showNotice("Copied")
+	m.nextTick()
+	if d := m.tickAt.Sub(m.noticeUntil); d < 0 || d > 10*time.Millisecond {
+		t.Errorf("Expected a tick when the notice expires, got %v", time.Until(m.tickAt))
+	}
+
+	// A paused download doesn't need refreshing, a running one does
+	m.tickAt, m.notice = time.Time{}, ""
+	m.downloadStates["4.3.0"] = &model.DownloadState{BuildState: model.StateDownloading, Paused: true}
+	m.nextTick()
+	if time.Until(m.tickAt) < time.Second {
+		t.Error("Expected a paused download to leave the list idle")
+	}
+	m.tickAt = time.Time{}
+	m.downloadStates["4.3.0"].Paused = false
+	m.nextTick()
+	if d := time.Until(m.tickAt); d > activeTickInterval {
+		t.Errorf("Expected a tick within %v while downloading, got %v", activeTickInterval, d)
+	}
+}
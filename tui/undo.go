@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/local"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// undoLimit is how many actions can be undone, older ones are forgotten
+const undoLimit = 20
+
+// undoAction is an action of the session that can be undone with u
+type undoAction struct {
+	description string  // What is undone, e.g. "delete of Blender 4.3.0"
+	undo        tea.Cmd // Reverts the action
+}
+
+// pushUndo records an action so u can revert it
+func (m *Model) pushUndo(description string, undo tea.Cmd) {
+	m.undoStack = append(m.undoStack, undoAction{description: description, undo: undo})
+	if len(m.undoStack) > undoLimit {
+		m.undoStack = m.undoStack[len(m.undoStack)-undoLimit:]
+	}
+}
+
+// handleUndo reverts the last action of the session that wasn't undone yet
+func (m *Model) handleUndo() (tea.Model, tea.Cmd) {
+	if len(m.undoStack) == 0 {
+		m.showNotice("Nothing to undo")
+		return m, nil
+	}
+	action := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+	return m, action.undo
+}
+
+// RestoreTrashed creates a command to move directories deleted by an action back where they were
+func (c *Commands) RestoreTrashed(action string, dirs []local.TrashedDir) tea.Cmd {
+	return func() tea.Msg {
+		for _, dir := range dirs {
+			if err := dir.Restore(); err != nil {
+				return undoneMsg{action: action, err: fmt.Errorf("could not undo %s: %w", action, err)}
+			}
+		}
+		return undoneMsg{action: action}
+	}
+}
+
+// EmptyTrash deletes the directories deleted during the session, which can't be undone afterwards
+func (c *Commands) EmptyTrash() error {
+	return local.EmptyTrash(c.cfg.DownloadDir)
+}
+
+// handleUndone reports an undone action and lists the restored builds again
+func (m *Model) handleUndone(msg undoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, nil
+	}
+	m.err = nil
+	m.showNotice(fmt.Sprintf("Undid %s", msg.action))
+	return m, m.commands.ScanLocalBuilds()
+}
@@ -1,45 +1,91 @@
 package tui
 
 import (
-	"TUI-Blender-Launcher/local"
 	"TUI-Blender-Launcher/model"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
 )
 
+// errOfflineDownloads is reported when a download is started while the builder is unreachable
+var errOfflineDownloads = errors.New("offline: downloads are disabled until the builder is reachable (press f to retry)")
+
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
+	// Add a program message listener to receive messages from background goroutines, and start the ticks
+	// that refresh download progress, they slow down while nothing runs
+	cmds := []tea.Cmd{m.commands.ProgramMsgListener(), m.scheduleTick(0)}
+
+	// Nothing to scan until the config file is usable, see leaveConfigError
+	if m.currentView != viewConfigError {
+		cmds = append(cmds, m.startupCmds())
+	}
+	return tea.Batch(cmds...)
+}
+
+// startupCmds returns the work done once the config is usable: scanning the local builds, checking the connection
+// and looking for leftovers of the last session
+func (m *Model) startupCmds() tea.Cmd {
 	var cmds []tea.Cmd
 
-	// Create a Commands instance
-	cmdManager := NewCommands(m.config)
+	// Start with local build scan to get builds already on disk, after cleaning up installs interrupted by a crash
+	cmds = append(cmds, tea.Sequence(m.commands.RecoverInterrupted(), m.commands.ScanLocalBuilds()))
 
-	// Start with local build scan to get builds already on disk
-	cmds = append(cmds, cmdManager.ScanLocalBuilds())
+	// Find out early whether we are offline
+	cmds = append(cmds, m.commands.CheckConnectivity())
 
-	// Add a program message listener to receive messages from background goroutines
-	cmds = append(cmds, cmdManager.ProgramMsgListener())
+	// Warn about a download directory on a network share
+	cmds = append(cmds, m.commands.CheckDownloadFilesystem())
 
-	// Start a ticker for continuous UI updates to show download progress
-	cmds = append(cmds, cmdManager.StartTicker())
+	// The digest needs the current listing
+	if !m.digestSince.IsZero() {
+		cmds = append(cmds, m.commands.FetchBuilds())
+	}
+
+	// Offer to purge old builds past the retention period
+	cmds = append(cmds, m.commands.FindExpiredOldBuilds())
+
+	// Offer to resume or delete partial downloads left behind by a crash
+	cmds = append(cmds, m.commands.FindOrphanedPartials())
+
+	// Find out which version saved the .blend file to open
+	if m.blendLaunch != nil {
+		cmds = append(cmds, readBlendVersionCmd(m.blendLaunch.path))
+	}
 
 	return tea.Batch(cmds...)
 }
 
-// Update updates the model based on messages
+// Update updates the model based on messages. Builds whose status changed meanwhile are highlighted,
+// ticking on while their rows blink.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// Handle key messages first, routing based on the current view
+	next, cmd := m.update(msg)
+	if m.trackStatusChanges(time.Now()) {
+		cmd = tea.Batch(cmd, m.scheduleTick(statusFlashInterval))
+	}
+	return next, cmd
+}
+
+// update handles a message, see Update
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Ticks only redraw the page when the transfers moved, see frameFingerprint.
+	// The progress bar model animates frames but isn't drawn.
+	switch msg.(type) {
+	case tickMsg, progress.FrameMsg:
+	default:
+		m.markDirty()
+	}
+
+	// Handle key messages first, routing based on the current view.
+	// A key may start, resume or cancel a download, tick soon so the list follows.
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		switch m.currentView {
-		case viewSettings, viewInitialSetup:
-			return m.updateSettingsView(keyMsg)
-		default:
-			return m.updateListView(keyMsg)
-		}
+		next, cmd := m.updateKey(keyMsg)
+		return next, tea.Batch(cmd, m.scheduleTick(activeTickInterval))
 	}
 
 	// Handle non-key messages
@@ -60,46 +106,147 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		return m, nil
 
+	case localScanProgressMsg:
+		return m.handleLocalScanProgress(msg)
+
 	case localBuildsScannedMsg:
 		return m.handleLocalBuildsScanned(msg)
 
 	case buildsFetchedMsg:
 		return m.handleBuildsFetched(msg)
 
+	case connectivityMsg:
+		m.offline = msg.err != nil
+		return m, nil
+
 	case buildsUpdatedMsg:
 		return m.handleBuildsUpdated(msg)
 
 	case model.BlenderExecMsg:
 		return m.handleBlenderExec(msg)
 
+	case installsRecoveredMsg:
+		return m.handleInstallsRecovered(msg)
+
+	case partialsFoundMsg:
+		return m.handlePartialsFound(msg)
+
+	case conflictMsg:
+		return m.handleConflict(msg)
+
+	case buildDirsFoundMsg:
+		return m.handleBuildDirsFound(msg)
+
+	case launchProfileSetMsg:
+		return m.handleLaunchProfileSet(msg)
+
+	case partialsDeletedMsg:
+		return m.handlePartialsDeleted(msg)
+
+	case archivesListedMsg:
+		return m.handleArchivesListed(msg)
+
+	case cachedArchiveFoundMsg:
+		return m.handleCachedArchiveFound(msg)
+
+	case archiveDeletedMsg:
+		return m.handleArchiveDeleted(msg)
+
+	case archiveExportedMsg:
+		return m.handleArchiveExported(msg)
+
+	case downloadingMeasuredMsg:
+		return m.handleDownloadingMeasured(msg)
+
+	case blendVersionReadMsg:
+		return m.handleBlendVersionRead(msg)
+
+	case oldBuildsExpiredMsg:
+		return m.handleOldBuildsExpired(msg)
+
+	case oldBuildsPurgedMsg:
+		return m.handleOldBuildsPurged(msg)
+
+	case buildVerifiedMsg:
+		return m.handleBuildVerified(msg)
+
+	case buildDeletedMsg:
+		return m.handleBuildDeleted(msg)
+
+	case undoneMsg:
+		return m.handleUndone(msg)
+
+	case buildLabelledMsg:
+		return m.handleBuildLabelled(msg)
+
+	case buildPromotedMsg:
+		return m.handleBuildPromoted(msg)
+
+	case updatesSnoozedMsg:
+		return m.handleUpdatesSnoozed(msg)
+
+	case buildNotesSavedMsg:
+		return m.handleBuildNotesSaved(msg)
+
+	case buildTaggedMsg:
+		return m.handleBuildTagged(msg)
+
+	case artifactDownloadedMsg:
+		return m.handleArtifactDownloaded(msg)
+
+	case speedTestMsg:
+		return m.handleSpeedTestDone(msg)
+
+	case metadataDiffMsg:
+		return m.handleMetadataDiff(msg)
+
+	case buildProbedMsg:
+		return m.handleBuildProbed(msg)
+
+	case blendAssociatedMsg:
+		return m.handleBlendAssociated(msg)
+
+	case commitsFetchedMsg:
+		return m.handleCommitsFetched(msg)
+
+	case configChangedMsg:
+		return m.handleConfigChanged(msg)
+
+	case filesystemCheckedMsg:
+		if msg.warning != "" {
+			m.showNotice("⚠ " + msg.warning)
+		}
+		return m, nil
+
+	case customColumnsMsg:
+		return m.handleCustomColumns(msg)
+
 	case startDownloadMsg:
 		m.activeDownloadID = msg.buildID
 		var cmds []tea.Cmd
 
 		// Update the build status immediately to show downloading
 		for i := range m.builds {
-			if m.builds[i].Version == msg.build.Version {
+			if m.builds[i].Matches(msg.build.Version, msg.build.Architecture) {
 				m.builds[i].Status = model.StateDownloading
 				break
 			}
 		}
 
-		// Create a Commands instance and call DoDownload directly
-		cmdManager := NewCommands(m.config)
-		cmds = append(cmds, cmdManager.DoDownload(msg.build))
+		// Start the download through the shared download manager so its progress is tracked
+		cmds = append(cmds, m.commands.DoDownload(msg.build))
 
-		// Make sure the ticker is running with a faster initial tick for responsiveness
-		cmds = append(cmds, tea.Tick(time.Millisecond*10, func(t time.Time) tea.Msg {
-			return tickMsg(t)
-		}))
+		// Tick right away so the download shows up, following ticks come while it runs
+		cmds = append(cmds, m.scheduleTick(10*time.Millisecond))
 
 		return m, tea.Batch(cmds...)
 
 	case downloadCompleteMsg:
 		// Handle completion of download
+		var broken *model.BlenderBuild
 		for i := range m.builds {
 			// Find the build by version and update its status
-			if m.builds[i].Version == msg.buildVersion {
+			if m.builds[i].Matches(msg.buildVersion, msg.buildArch) {
 				if msg.err != nil {
 					// Handle download error
 					m.builds[i].Status = model.StateFailed
@@ -107,45 +254,50 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					// Update to local state on success
 					m.builds[i].Status = model.StateLocal
+					if msg.transfer != nil {
+						m.recordTransfer(*msg.transfer)
+					}
+					if !msg.fromCache {
+						m.recordDownload(m.builds[i].Size)
+					}
+					if msg.smokeTest != nil {
+						m.builds[i].SmokeTest = msg.smokeTest
+					}
 
-					// Clear any error message
+					// Clear any error message, or report a build that doesn't start
 					m.err = nil
+					if m.builds[i].Broken() {
+						m.err = fmt.Errorf("Blender %s is broken: %s", msg.buildVersion, msg.smokeTest.Error)
+						build := m.builds[i]
+						broken = &build
+					}
 				}
 				break
 			}
 		}
 
 		// Re-sort the builds since status has changed
-		m.builds = model.SortBuilds(m.builds, m.sortColumn, m.sortReversed)
+		m.sortBuilds()
 
 		// Start listening for more program messages
-		cmdManager := NewCommands(m.config)
-		return m, cmdManager.ProgramMsgListener()
+		finished := model.BlenderBuild{Version: msg.buildVersion, Architecture: msg.buildArch}
+		installed := msg.err == nil && (msg.smokeTest == nil || msg.smokeTest.Passed)
+		m.pinnedBuildInstalled(finished, installed)
+		launchCmd := m.pendingBuildInstalled(finished, installed)
+		var repairCmd tea.Cmd
+		if broken != nil && m.repair.firstReport(*broken) {
+			repairCmd = m.offerRepair(*broken)
+		}
+		return m, tea.Batch(m.commands.ProgramMsgListener(), m.blendBuildInstalled(finished, installed), repairCmd, launchCmd, m.commands.EnforceDownloadingQuota(), m.scheduleTick(activeTickInterval))
 
 	case tickMsg:
+		if !m.takeTick(msg) {
+			return m, nil
+		}
 		// Process tick messages for both views
 		// Sync download states before handling the tick
 		m.SyncDownloadStates()
-
-		// Create a command for the next tick - use 500ms default but faster if downloading
-		var nextTickTime time.Duration = time.Millisecond * 500
-
-		// Check if we have active downloads and use faster refresh if needed
-		activeDownloads := 0
-		for _, state := range m.downloadStates {
-			if state.BuildState == model.StateDownloading || state.BuildState == model.StateExtracting {
-				activeDownloads++
-			}
-		}
-
-		// Use faster refresh rate during downloads/extractions
-		if activeDownloads > 0 {
-			nextTickTime = time.Millisecond * 250
-		}
-
-		cmd := tea.Tick(nextTickTime, func(t time.Time) tea.Msg {
-			return tickMsg(t)
-		})
+		m.expireNotice()
 
 		// Process the current tick based on view
 		var modelCmd tea.Cmd
@@ -156,17 +308,142 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			newModel, modelCmd = m.updateListView(msg)
 		}
 
-		// Return both the new tick command and any model commands
-		return newModel, tea.Batch(cmd, modelCmd)
+		// Return any model commands, starting due scheduled downloads, and the next tick
+		dueCmd, reloadCmd := m.startDueDownloads(), m.reloadConfigIfChanged()
+		if dueCmd != nil || reloadCmd != nil {
+			m.markDirty()
+		}
+		return newModel, tea.Batch(modelCmd, dueCmd, reloadCmd, m.nextTick())
 	}
 
 	return m, nil
 }
 
+// updateKey routes a key press to the open prompt or panel, otherwise to the current view
+func (m *Model) updateKey(keyMsg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// A pending launch prompt takes all keys until it is answered
+	if m.configPrompt != nil {
+		return m.updateConfigPrompt(keyMsg)
+	}
+	if m.quarantinePrompt != nil {
+		return m.updateQuarantinePrompt(keyMsg)
+	}
+	if m.palette != nil {
+		return m.updatePalette(keyMsg)
+	}
+	if m.recentPanel != nil {
+		return m.updateRecentPanel(keyMsg)
+	}
+	if m.dirPicker != nil {
+		return m.updateDirPicker(keyMsg)
+	}
+	if m.filterPrompt != nil {
+		return m.updateFilterPrompt(keyMsg)
+	}
+	if m.schedulePrompt != nil {
+		return m.updateSchedulePrompt(keyMsg)
+	}
+	if m.labelPrompt != nil {
+		return m.updateLabelPrompt(keyMsg)
+	}
+	if m.snoozePrompt != nil {
+		return m.updateSnoozePrompt(keyMsg)
+	}
+	if m.notesEditor != nil {
+		return m.updateNotesEditor(keyMsg)
+	}
+	if m.tagPrompt != nil {
+		return m.updateTagPrompt(keyMsg)
+	}
+	if m.tagFilterPrompt != nil {
+		return m.updateTagFilterPrompt(keyMsg)
+	}
+	if m.artifactMenu != nil {
+		return m.updateArtifactMenu(keyMsg)
+	}
+	if m.speedTest != nil {
+		return m.updateSpeedTest(keyMsg)
+	}
+	if m.metadataDiff != nil {
+		return m.updateMetadataDiff(keyMsg)
+	}
+	if m.digest != nil {
+		return m.updateDigest(keyMsg)
+	}
+	if m.actionMenu != nil {
+		return m.updateActionMenu(keyMsg)
+	}
+	if m.dirMenu != nil {
+		return m.updateDirMenu(keyMsg)
+	}
+	if m.profileMenu != nil {
+		return m.updateProfileMenu(keyMsg)
+	}
+	if m.downloadsPanel != nil {
+		return m.updateDownloadsPanel(keyMsg)
+	}
+	if m.archivePanel != nil {
+		return m.updateArchivePanel(keyMsg)
+	}
+	if m.yankPending {
+		return m.updateYank(keyMsg)
+	}
+	// The purge question only takes its own keys, the list stays usable
+	if m.purgeConfirm != nil && (keyMsg.String() == "y" || keyMsg.String() == "n" || keyMsg.String() == "esc") {
+		return m.updatePurgeConfirm(keyMsg)
+	}
+	if m.partialsPrompt != nil && partialsKey(keyMsg) {
+		return m.updatePartialsPrompt(keyMsg)
+	}
+	// An install waiting for a decision takes all keys of the list until it is answered
+	if len(m.conflicts.pending) > 0 && m.currentView == viewList {
+		return m.updateConflictPrompt(keyMsg)
+	}
+	// The broken build warning only takes its own keys, the list stays usable
+	if m.repair.prompt != nil && (keyMsg.String() == "r" || keyMsg.String() == "esc" || keyMsg.String() == "I") {
+		return m.updateRepairPrompt(keyMsg)
+	}
+	if m.blendLaunch != nil && m.blendLaunch.prompt {
+		return m.updateBlendPrompt(keyMsg)
+	}
+	if m.pin != nil && m.pin.prompt {
+		return m.updatePinPrompt(keyMsg)
+	}
+	// A launch warning is confirmed with enter, any other key calls the launch off and goes on to the list
+	if m.launchWarning != nil {
+		pending := m.launchWarning
+		m.launchWarning = nil
+		if keyMsg.String() == "enter" {
+			return m, m.launch(pending.launchRequest)
+		}
+	}
+	// Any key other than a second download press aborts the metered download confirmation
+	if m.downloadConfirm != "" && !key.Matches(keyMsg, GetKeyBinding(CmdDownloadBuild)) && !key.Matches(keyMsg, GetKeyBinding(CmdDownloadLaunch)) {
+		m.downloadConfirm = ""
+		return m, nil
+	}
+	// Any key other than a second cancel-all press aborts the confirmation
+	if m.confirmCancelAll && !key.Matches(keyMsg, GetKeyBinding(CmdCancelAll)) {
+		m.confirmCancelAll = false
+		return m, nil
+	}
+	switch m.currentView {
+	case viewSettings, viewInitialSetup:
+		return m.updateSettingsView(keyMsg)
+	case viewDetails:
+		return m.updateDetailsView(keyMsg)
+	case viewConfigError:
+		return m.updateConfigErrorView(keyMsg)
+	default:
+		return m.updateListView(keyMsg)
+	}
+}
+
 // updateSettingsView handles key events in the settings view
 func (m *Model) updateSettingsView(msg tea.Msg) (tea.Model, tea.Cmd) {
-	// Calculate total number of settable items (text inputs + dropdown)
-	totalItems := len(m.settingsInputs) + 1 // +1 for dropdown
+	// Calculate total number of settable items (text inputs + dropdown + download ID)
+	totalItems := len(m.settingsInputs) + 2
+	downloadIDIndex := len(m.settingsInputs) + 1
 
 	// Handle different message types
 	switch msg := msg.(type) {
@@ -214,17 +491,8 @@ func (m *Model) updateSettingsView(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				case CmdCleanOldBuilds:
 					if !m.editMode {
-						// Clean old builds from .oldbuilds directory
-						return m, func() tea.Msg {
-							count, err := local.CleanOldBuilds(m.config.DownloadDir)
-							if err != nil {
-								return errMsg{err}
-							}
-							if count == 0 {
-								return errMsg{fmt.Errorf("no old builds to clean")}
-							}
-							return errMsg{fmt.Errorf("successfully cleaned %d old build(s)", count)}
-						}
+						// Move the old builds from the .oldbuilds directory to the trash
+						return m, m.commands.CleanOldBuilds()
 					}
 
 				case CmdMoveUp:
@@ -253,6 +521,8 @@ func (m *Model) updateSettingsView(msg tea.Msg) (tea.Model, tea.Cmd) {
 							newIndex := (m.buildTypeIndex - 1 + len(m.buildTypeOptions)) % len(m.buildTypeOptions)
 							m.buildTypeIndex = newIndex
 							m.buildType = m.buildTypeOptions[newIndex]
+						} else if m.focusIndex == downloadIDIndex {
+							m.sendDownloadID = !m.sendDownloadID
 						}
 						return m, nil
 					}
@@ -265,9 +535,23 @@ func (m *Model) updateSettingsView(msg tea.Msg) (tea.Model, tea.Cmd) {
 							newIndex := (m.buildTypeIndex + 1) % len(m.buildTypeOptions)
 							m.buildTypeIndex = newIndex
 							m.buildType = m.buildTypeOptions[newIndex]
+						} else if m.focusIndex == downloadIDIndex {
+							m.sendDownloadID = !m.sendDownloadID
 						}
 						return m, nil
 					}
+
+				case CmdBrowseDir:
+					if !m.editMode && m.focusIndex == 0 {
+						m.dirPicker = newDirPicker(m.settingsInputs[0].Value())
+						return m, nil
+					}
+
+				case CmdRegenerateID:
+					if !m.editMode && m.focusIndex == downloadIDIndex {
+						m.downloadID = uuid.New().String()
+						return m, nil
+					}
 				}
 			}
 		}
@@ -331,7 +615,7 @@ func (m *Model) updateListView(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		// Calculate visible rows count for all navigation commands
-		visibleRowsCount := m.terminalHeight - 7 // Approximate height for header, footer, separators
+		visibleRowsCount := m.contentHeight() - 1 // Rows below the table header
 		if visibleRowsCount < 1 {
 			visibleRowsCount = 1
 		}
@@ -339,6 +623,10 @@ func (m *Model) updateListView(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Use centralized command handling
 		for _, cmd := range GetCommandsForView(viewList) {
 			if key.Matches(msg, GetKeyBinding(cmd.Type)) {
+				// A collapsed series header selects no build, only the commands on the whole list apply
+				if m.onCollapsedHeader() && !listWideCommands[cmd.Type] {
+					return m, nil
+				}
 				switch cmd.Type {
 				case CmdQuit:
 					// Quit application
@@ -348,10 +636,19 @@ func (m *Model) updateListView(msg tea.Msg) (tea.Model, tea.Cmd) {
 					// Switch to settings view
 					return m.handleShowSettings()
 
+				case CmdShowDetails:
+					// Switch to details view for the selected build
+					if len(m.builds) > 0 && m.cursor < len(m.builds) {
+						m.currentView = viewDetails
+						// Updates show what changed since the installed build
+						return m, m.requestCommitLog(m.builds[m.cursor])
+					}
+					return m, nil
+
 				case CmdToggleSortOrder:
 					// Toggle sort direction
 					m.sortReversed = !m.sortReversed
-					m.builds = model.SortBuilds(m.builds, m.sortColumn, m.sortReversed)
+					m.sortBuilds()
 					m.ensureCursorVisible(visibleRowsCount)
 					return m, nil
 
@@ -364,16 +661,25 @@ func (m *Model) updateListView(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 
 				case CmdMoveLeft:
+					// In the grouped view left/right collapse and expand the current series
+					if m.grouped {
+						m.setSeriesCollapsed(true)
+						return m, nil
+					}
 					// Move sort column left
 					m.updateSortColumn("left")
-					m.builds = model.SortBuilds(m.builds, m.sortColumn, m.sortReversed)
+					m.sortBuilds()
 					m.ensureCursorVisible(visibleRowsCount)
 					return m, nil
 
 				case CmdMoveRight:
+					if m.grouped {
+						m.setSeriesCollapsed(false)
+						return m, nil
+					}
 					// Move sort column right
 					m.updateSortColumn("right")
-					m.builds = model.SortBuilds(m.builds, m.sortColumn, m.sortReversed)
+					m.sortBuilds()
 					m.ensureCursorVisible(visibleRowsCount)
 					return m, nil
 
@@ -397,6 +703,11 @@ func (m *Model) updateListView(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, m.commands.FetchBuilds()
 
 				case CmdDownloadBuild:
+					// Downloads are disabled while offline
+					if m.offline {
+						m.err = errOfflineDownloads
+						return m, nil
+					}
 					// Start download for selected build
 					return m.handleStartDownload()
 
@@ -408,6 +719,128 @@ func (m *Model) updateListView(msg tea.Msg) (tea.Model, tea.Cmd) {
 					// Open the directory for the selected build
 					return m.handleOpenBuildDir()
 
+				case CmdLaunchProfile:
+					// Pick the launch profile for the selected build
+					return m.openProfileMenu()
+
+				case CmdOpenPR:
+					// Open the pull request of a patch build in the browser
+					return m.handleOpenPullRequest()
+
+				case CmdVerifyBuild:
+					// Check the installed files against the install-time checksum
+					if build, ok := m.selectedBuild(); ok && (build.Status == model.StateLocal || build.Status == model.StateUpdate) && !m.rejectShared(build) {
+						m.showNotice(fmt.Sprintf("Verifying Blender %s...", build.Version))
+						return m, m.commands.VerifyBuild(build.Version, build.Architecture)
+					}
+					return m, nil
+
+				case CmdLabelBuild:
+					// Edit the custom label of the selected build inline
+					return m.openLabelPrompt()
+
+				case CmdEditNotes:
+					// Open the notes editor for the selected build
+					return m.openNotesEditor()
+
+				case CmdEditTags:
+					// Edit the tags of the selected build inline
+					return m.openTagPrompt()
+
+				case CmdFilterTag:
+					// Edit the tag filter inline
+					return m.openTagFilterPrompt()
+
+				case CmdToggleDensity:
+					// Switch between the comfortable and compact layout
+					return m.handleToggleDensity()
+
+				case CmdShowArtifacts:
+					// List debug symbols, checksums and other files of the build
+					return m.openArtifactMenu()
+
+				case CmdPromoteBuild:
+					// Move the selected build to the next review state
+					return m.handleCyclePromotion()
+
+				case CmdSnoozeUpdates:
+					// Hold back the updates of the selected build for some days
+					return m.openSnoozePrompt()
+
+				case CmdActionMenu:
+					// List the actions valid for the selected build
+					return m.openActionMenu()
+
+				case CmdReinstallCache:
+					// Extract the kept archive of the selected build again, without downloading it
+					return m.handleReinstallFromCache()
+
+				case CmdArchiveCache:
+					// Browse the archives kept after their install
+					return m.openArchivePanel()
+
+				case CmdDownloadsPanel:
+					// List all downloads, whichever row is selected
+					return m.toggleDownloadsPanel()
+
+				case CmdYank:
+					// Wait for what to copy: hash, URL or path
+					if len(m.builds) > 0 && m.cursor < len(m.builds) {
+						m.yankPending = true
+					}
+					return m, nil
+
+				case CmdOpenSource:
+					// Open the commit or branch of the build in the browser
+					return m.handleOpenSourcePage()
+
+				case CmdAssociateBlend:
+					// Make the selected build open .blend files
+					return m.handleAssociateBlend()
+
+				case CmdQuickLaunch:
+					// Open the fuzzy finder over local builds
+					return m.openPalette()
+
+				case CmdRecentProjects:
+					// List the .blend files opened through the launcher
+					return m.openRecentPanel()
+
+				case CmdToggleNewOnly:
+					// Filter the list down to the builds marked NEW and back
+					return m.handleToggleNewOnly()
+
+				case CmdUndo:
+					// Revert the last delete, cleanup or label/tag edit
+					return m.handleUndo()
+
+				case CmdDownloadLaunch:
+					// Chain the download and the launch of the selected build
+					return m.handleDownloadAndLaunch()
+
+				case CmdSpeedTest:
+					// Measure the builder and the mirrors
+					return m.openSpeedTest()
+
+				case CmdCycleBuildType:
+					return m.handleCycleBuildType()
+
+				case CmdEditFilter:
+					return m.openFilterPrompt()
+
+				case CmdCancelAll:
+					return m.handleCancelAllDownloads()
+
+				case CmdScheduleBuild:
+					return m.handleScheduleDownload()
+
+				case CmdToggleGrouping:
+					m.grouped = !m.grouped
+					m.sortBuilds()
+					m.groupStart = 0
+					m.ensureCursorVisible(visibleRowsCount)
+					return m, nil
+
 				case CmdDeleteBuild:
 					build := m.builds[m.cursor]
 					if build.Status == model.StateLocal || build.Status == model.StateUpdate {
@@ -434,6 +867,11 @@ func (m *Model) updateCursor(direction string, visibleRowsCount int) {
 		return
 	}
 
+	if m.grouped {
+		m.moveGroupedCursor(direction, visibleRowsCount)
+		return
+	}
+
 	switch direction {
 	case "up":
 		m.cursor--
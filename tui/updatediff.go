@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"TUI-Blender-Launcher/model"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxCommitLines limits how many commits are listed in the update delta
+const maxCommitLines = 15
+
+// commitLog caches the commits between an installed build and its update
+type commitLog struct {
+	loading bool
+	commits []model.Commit
+	err     error
+}
+
+// commitLogKey identifies the commit range between two build hashes
+func commitLogKey(base, head string) string {
+	return base + "..." + head
+}
+
+// requestCommitLog starts fetching the commits for an update build unless they are cached
+func (m *Model) requestCommitLog(build model.BlenderBuild) tea.Cmd {
+	if build.Status != model.StateUpdate || build.Installed == nil {
+		return nil
+	}
+	base, head := build.Installed.Hash, build.Hash
+	if base == "" || head == "" {
+		return nil
+	}
+	key := commitLogKey(base, head)
+	if _, ok := m.commitLogs[key]; ok {
+		return nil
+	}
+	m.commitLogs[key] = &commitLog{loading: true}
+	return m.commands.FetchCommits(base, head)
+}
+
+// handleCommitsFetched stores a fetched commit list
+func (m *Model) handleCommitsFetched(msg commitsFetchedMsg) (tea.Model, tea.Cmd) {
+	m.commitLogs[commitLogKey(msg.base, msg.head)] = &commitLog{commits: msg.commits, err: msg.err}
+	return m, nil
+}
+
+// formatDuration renders a duration between two builds in days and hours
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+	return fmt.Sprintf("%dh %dm", hours, int(d.Minutes())%60)
+}
+
+// updateDeltaFields compares the installed build with the available update
+func updateDeltaFields(installed, update model.BlenderBuild) []detailField {
+	sizeDiff := update.Size - installed.Size
+	sign := "+"
+	if sizeDiff < 0 {
+		sign = "-"
+		sizeDiff = -sizeDiff
+	}
+
+	return []detailField{
+		{"Hash", fmt.Sprintf("%s → %s", installed.Hash, update.Hash)},
+		{"Build Date", fmt.Sprintf("%s → %s", model.FormatBuildDate(installed.BuildDate), model.FormatBuildDate(update.BuildDate))},
+		{"Newer by", formatDuration(update.BuildDate.Time().Sub(installed.BuildDate.Time()))},
+		{"Size", fmt.Sprintf("%s → %s (%s%s)", model.FormatByteSize(installed.Size), model.FormatByteSize(update.Size), sign, model.FormatByteSize(sizeDiff))},
+	}
+}
+
+// renderCommitLog renders the commit section of the update delta
+func (m *Model) renderCommitLog(installed, update model.BlenderBuild) string {
+	log, ok := m.commitLogs[commitLogKey(installed.Hash, update.Hash)]
+	switch {
+	case !ok:
+		return renderDetailSection("Commits", []detailField{{"Unavailable", "build hashes are unknown"}})
+	case log.loading:
+		return renderDetailSection("Commits", []detailField{{"Loading", "fetching commit list…"}})
+	case log.err != nil:
+		return renderDetailSection("Commits", []detailField{{"Unavailable", log.err.Error()}})
+	}
+
+	var fields []detailField
+	for i, c := range log.commits {
+		if i == maxCommitLines {
+			fields = append(fields, detailField{"", fmt.Sprintf("… and %d more", len(log.commits)-maxCommitLines)})
+			break
+		}
+		short := c.Hash
+		if len(short) > 10 {
+			short = short[:10]
+		}
+		fields = append(fields, detailField{short, strings.TrimSpace(c.Title)})
+	}
+	title := fmt.Sprintf("Commits (%d)", len(log.commits))
+	return renderDetailSection(title, fields)
+}
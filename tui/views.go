@@ -1,17 +1,54 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
 	lp "github.com/charmbracelet/lipgloss"
 )
 
-func (m *Model) renderPageForView() string {
+// terminalTooSmall reports whether the terminal is known to be smaller than the layout needs
+func (m *Model) terminalTooSmall() bool {
+	if m.terminalWidth == 0 && m.terminalHeight == 0 {
+		return false // Size not reported yet
+	}
+	return m.terminalWidth < minTerminalWidth || m.terminalHeight < minTerminalHeight
+}
+
+// renderTooSmall renders the notice shown instead of the layout in an undersized terminal
+func (m *Model) renderTooSmall() string {
+	warnStyle := lp.NewStyle().Foreground(lp.Color(orangeColor)).Bold(true)
+	lines := []string{
+		warnStyle.Render("Terminal too small"),
+		fmt.Sprintf("need %dx%d, have %dx%d", minTerminalWidth, minTerminalHeight, m.terminalWidth, m.terminalHeight),
+		"Resize the window or press q to quit",
+	}
+	if len(lines) > m.terminalHeight {
+		lines = lines[:m.terminalHeight]
+	}
+	for i, line := range lines {
+		lines[i] = truncateText(line, m.terminalWidth)
+	}
+	notice := lp.JoinVertical(lp.Center, lines...)
+	return lp.Place(m.terminalWidth, m.terminalHeight, lp.Center, lp.Center, notice)
+}
+
+// showsHeader reports whether the title and separator are shown; compact mode drops them
+// unless the offline banner needs the space
+func (m *Model) showsHeader() bool {
+	return !m.compact() || m.offline
+}
+
+// contentHeight returns the lines left for the page content between header and footer
+func (m *Model) contentHeight() int {
 	// Define fixed heights
 	headerHeight := 2
 	footerHeight := 2
+	if !m.showsHeader() {
+		headerHeight = 0
+	}
 
-	// Fixed items: header, footer, 2 separator lines
+	// Fixed items: header, footer, separator line and status bar
 	fixedHeightItems := headerHeight + footerHeight + 2
 
 	// Calculate content height
@@ -19,9 +56,23 @@ func (m *Model) renderPageForView() string {
 	if contentHeight < 1 {
 		contentHeight = 1
 	}
+	return contentHeight
+}
+
+func (m *Model) renderPageForView() string {
+	if m.terminalTooSmall() {
+		return m.renderTooSmall()
+	}
+
+	contentHeight := m.contentHeight()
 
 	// Generate app components
 	header := renderHeader(m.terminalWidth)
+	if m.offline {
+		header = renderOfflineBanner(m.terminalWidth, m.cachedAt)
+	} else if m.staleList != nil {
+		header = renderStaleBanner(m.terminalWidth, m.staleList)
+	}
 
 	// Create slim horizontal separators
 	separatorStyle := lp.NewStyle()
@@ -31,12 +82,76 @@ func (m *Model) renderPageForView() string {
 	var content string
 	var footer string
 
-	if m.currentView == viewInitialSetup || m.currentView == viewSettings {
+	if m.configPrompt != nil {
+		content = m.renderConfigPrompt(contentHeight)
+		footer = m.renderConfigPromptFooter()
+	} else if m.quarantinePrompt != nil {
+		content = m.renderQuarantinePrompt(contentHeight)
+		footer = m.renderQuarantinePromptFooter()
+	} else if m.palette != nil {
+		content = m.renderPalette(contentHeight)
+		footer = m.renderPaletteFooter()
+	} else if m.recentPanel != nil {
+		content = m.renderRecentPanel(contentHeight)
+		footer = m.renderRecentPanelFooter()
+	} else if m.artifactMenu != nil {
+		content = m.renderArtifactMenu(contentHeight)
+		footer = m.renderArtifactMenuFooter()
+	} else if m.speedTest != nil {
+		content = m.renderSpeedTest(contentHeight)
+		footer = m.renderSpeedTestFooter()
+	} else if m.metadataDiff != nil {
+		content = m.renderMetadataDiff(contentHeight)
+		footer = m.renderMetadataDiffFooter()
+	} else if m.digest != nil {
+		content = m.renderDigest(contentHeight)
+		footer = m.renderDigestFooter()
+	} else if m.actionMenu != nil {
+		content = m.renderActionMenu(contentHeight)
+		footer = m.renderActionMenuFooter()
+	} else if m.dirMenu != nil {
+		content = m.renderDirMenu(contentHeight)
+		footer = m.renderDirMenuFooter()
+	} else if m.profileMenu != nil {
+		content = m.renderProfileMenu(contentHeight)
+		footer = m.renderProfileMenuFooter()
+	} else if m.downloadsPanel != nil {
+		content = m.renderDownloadsPanel(contentHeight)
+		footer = m.renderDownloadsPanelFooter()
+	} else if m.archivePanel != nil {
+		content = m.renderArchivePanel(contentHeight)
+		footer = m.renderArchivePanelFooter()
+	} else if m.notesEditor != nil {
+		content = m.renderNotesEditor(contentHeight)
+		footer = m.renderNotesEditorFooter()
+	} else if m.dirPicker != nil {
+		content = m.dirPicker.view(m.terminalWidth, contentHeight)
+		footer = m.dirPicker.footer(m.terminalWidth)
+	} else if m.currentView == viewInitialSetup || m.currentView == viewSettings {
 		content = m.renderSettingsContent(contentHeight)
 		footer = m.renderSettingsFooter()
+	} else if m.currentView == viewConfigError {
+		content = m.renderConfigErrorContent(contentHeight)
+		footer = m.renderConfigErrorFooter()
+	} else if m.currentView == viewDetails {
+		content = m.renderDetailsContent(contentHeight)
+		footer = m.renderDetailsFooter()
 	} else {
 		content = m.renderBuildContent(contentHeight)
 		footer = m.renderBuildFooter()
+		if m.filterPrompt != nil {
+			footer = m.renderFilterPromptFooter()
+		} else if m.schedulePrompt != nil {
+			footer = m.renderSchedulePromptFooter()
+		} else if m.labelPrompt != nil {
+			footer = m.renderLabelPromptFooter()
+		} else if m.snoozePrompt != nil {
+			footer = m.renderSnoozePromptFooter()
+		} else if m.tagPrompt != nil {
+			footer = m.renderTagPromptFooter()
+		} else if m.tagFilterPrompt != nil {
+			footer = m.renderTagFilterPromptFooter()
+		}
 	}
 
 	// Calculate padding needed to push footer to bottom
@@ -55,14 +170,16 @@ func (m *Model) renderPageForView() string {
 
 	// Build the final view with all components properly styled
 	var view strings.Builder
-	view.WriteString(header)
-	view.WriteString(newlineStyle)
-	view.WriteString(separator)
-	view.WriteString(newlineStyle)
+	if m.showsHeader() {
+		view.WriteString(header)
+		view.WriteString(newlineStyle)
+		view.WriteString(separator)
+		view.WriteString(newlineStyle)
+	}
 	view.WriteString(content)
 	view.WriteString(padding)
 	view.WriteString(newlineStyle)
-	view.WriteString(separator)
+	view.WriteString(m.renderStatusBar())
 	view.WriteString(newlineStyle)
 	view.WriteString(footer)
 